@@ -0,0 +1,138 @@
+package main
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/niklas-heer/tdx/internal/markdown"
+)
+
+func TestCLI_AddTop(t *testing.T) {
+	file := writeSortFixture(t, "# Todos\n\n- [ ] First\n- [ ] Second\n")
+
+	output := runCLI(t, file, "add", "--top", "New first")
+	if !strings.Contains(output, "Added: New first") {
+		t.Errorf("Expected confirmation, got: %s", output)
+	}
+
+	assertTodoOrder(t, getTodos(t, file), "New first", "First", "Second")
+}
+
+func TestCLI_AddTopEmptyFile(t *testing.T) {
+	file := tempTestFile(t)
+
+	runCLI(t, file, "add", "--top", "Only")
+
+	assertTodoOrder(t, getTodos(t, file), "Only")
+}
+
+func TestCLI_AddAfter(t *testing.T) {
+	file := writeSortFixture(t, "# Todos\n\n- [ ] First\n- [ ] Second\n- [ ] Third\n")
+
+	runCLI(t, file, "add", "--after", "2", "Inserted")
+
+	assertTodoOrder(t, getTodos(t, file), "First", "Second", "Inserted", "Third")
+}
+
+func TestCLI_AddAfterFlagAfterText(t *testing.T) {
+	file := writeSortFixture(t, "# Todos\n\n- [ ] First\n- [ ] Second\n")
+
+	runCLI(t, file, "add", "Inserted", "--after", "1")
+
+	assertTodoOrder(t, getTodos(t, file), "First", "Inserted", "Second")
+}
+
+func TestCLI_AddAfterInvalidIndex(t *testing.T) {
+	file := writeSortFixture(t, "# Todos\n\n- [ ] First\n")
+
+	output := runCLI(t, file, "add", "--after", "5", "Nope")
+	if !strings.Contains(output, "invalid index 5") {
+		t.Errorf("Expected invalid index error, got: %s", output)
+	}
+	assertTodoOrder(t, getTodos(t, file), "First")
+
+	output = runCLI(t, file, "add", "--after", "x", "Nope")
+	if !strings.Contains(output, "invalid index") {
+		t.Errorf("Expected invalid index error, got: %s", output)
+	}
+}
+
+func TestCLI_AddTopAndAfterConflict(t *testing.T) {
+	file := writeSortFixture(t, "# Todos\n\n- [ ] First\n")
+
+	output := runCLI(t, file, "add", "--top", "--after", "1", "Nope")
+	if !strings.Contains(output, "can't be combined") {
+		t.Errorf("Expected conflict error, got: %s", output)
+	}
+}
+
+func TestCLI_AddKeepsPriorityAndTags(t *testing.T) {
+	file := tempTestFile(t)
+
+	runCLI(t, file, "add", `"Fix login !p1 #backend"`)
+
+	fm, err := markdown.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fm.Todos) != 1 {
+		t.Fatalf("Expected 1 todo, got %d", len(fm.Todos))
+	}
+	todo := fm.Todos[0]
+	if todo.Text != "Fix login !p1 #backend" {
+		t.Errorf("Text = %q, quotes should be stripped and markers kept", todo.Text)
+	}
+	if todo.Priority != 1 {
+		t.Errorf("Priority = %d, want 1", todo.Priority)
+	}
+	if len(todo.Tags) != 1 || todo.Tags[0] != "backend" {
+		t.Errorf("Tags = %v, want [backend]", todo.Tags)
+	}
+}
+
+// runCLIWithStdin runs the binary with input piped to stdin
+func runCLIWithStdin(t *testing.T, file string, input string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command(testBinary, append([]string{file}, args...)...)
+	cmd.Stdin = strings.NewReader(input)
+	out, _ := cmd.CombinedOutput()
+	return strings.TrimSpace(string(out))
+}
+
+func TestCLI_AddFromStdin(t *testing.T) {
+	file := writeSortFixture(t, "# Todos\n\n- [ ] Existing\n")
+
+	output := runCLIWithStdin(t, file, "  buy milk  \n\ncall mom\n", "add", "-")
+	if !strings.Contains(output, "Added: buy milk") || !strings.Contains(output, "Added: call mom") {
+		t.Errorf("Expected confirmations, got: %s", output)
+	}
+
+	assertTodoOrder(t, getTodos(t, file), "Existing", "buy milk", "call mom")
+}
+
+func TestCLI_AddFromPipedStdinWithoutText(t *testing.T) {
+	file := writeSortFixture(t, "# Todos\n\n- [ ] Existing\n")
+
+	runCLIWithStdin(t, file, "first\nsecond", "add")
+
+	assertTodoOrder(t, getTodos(t, file), "Existing", "first", "second")
+}
+
+func TestCLI_AddFromStdinAtTop(t *testing.T) {
+	file := writeSortFixture(t, "# Todos\n\n- [ ] Existing\n")
+
+	runCLIWithStdin(t, file, "first\nsecond\n", "add", "--top", "-")
+
+	assertTodoOrder(t, getTodos(t, file), "first", "second", "Existing")
+}
+
+func TestCLI_AddFromEmptyStdin(t *testing.T) {
+	file := writeSortFixture(t, "# Todos\n\n- [ ] Existing\n")
+
+	output := runCLIWithStdin(t, file, "\n  \n", "add", "-")
+	if !strings.Contains(output, "no todos on stdin") {
+		t.Errorf("Expected empty stdin error, got: %s", output)
+	}
+	assertTodoOrder(t, getTodos(t, file), "Existing")
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// withBackground makes the terminal report a dark or light background for the test
+func withBackground(t *testing.T, dark bool) {
+	t.Helper()
+	old := hasDarkBackground
+	hasDarkBackground = func() bool { return dark }
+	t.Cleanup(func() { hasDarkBackground = old })
+}
+
+func TestResolveTheme(t *testing.T) {
+	tests := []struct {
+		name  string
+		theme ThemeConfig
+		dark  bool
+		want  string
+	}{
+		{"auto on dark", ThemeConfig{Name: "auto"}, true, "tokyo-night"},
+		{"auto on light", ThemeConfig{Name: "auto"}, false, "tokyo-night-day"},
+		{"dark theme on dark", ThemeConfig{Name: "auto", Dark: "gruvbox-dark"}, true, "gruvbox-dark"},
+		{"light variant of the dark theme", ThemeConfig{Name: "auto", Dark: "gruvbox-dark"}, false, "gruvbox-light"},
+		{"dark variant of the light theme", ThemeConfig{Name: "auto", Light: "solarized-light"}, true, "solarized-dark"},
+		{"first dark variant", ThemeConfig{Name: "auto", Light: "catppuccin-latte"}, true, "catppuccin-mocha"},
+		{"dark theme without a variant", ThemeConfig{Name: "auto", Dark: "dracula"}, false, "tokyo-night-day"},
+		{"both set", ThemeConfig{Name: "auto", Dark: "nord", Light: "github-light"}, false, "github-light"},
+		{"named theme ignores the background", ThemeConfig{Name: "dracula"}, false, "dracula"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			withBackground(t, tt.dark)
+			if got := resolveTheme(tt.theme, tt.theme.Name); got != tt.want {
+				t.Errorf("resolveTheme = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestThemePairs_AreBuiltin(t *testing.T) {
+	for _, name := range []string{defaultDarkTheme, defaultLightTheme} {
+		if _, ok := builtinThemes[name]; !ok {
+			t.Errorf("Default theme %q isn't builtin", name)
+		}
+	}
+	for _, pair := range themePairs {
+		for _, name := range []string{pair.dark, pair.light} {
+			if _, ok := builtinThemes[name]; !ok {
+				t.Errorf("Paired theme %q isn't builtin", name)
+			}
+		}
+	}
+}
+
+func TestLoadConfig_AutoTheme(t *testing.T) {
+	origXDG := os.Getenv("XDG_CONFIG_HOME")
+	defer func() { _ = os.Setenv("XDG_CONFIG_HOME", origXDG) }()
+
+	tmpDir := t.TempDir()
+	_ = os.Setenv("XDG_CONFIG_HOME", tmpDir)
+
+	configDir := filepath.Join(tmpDir, "tdx")
+	_ = os.MkdirAll(configDir, 0755)
+	configPath := filepath.Join(configDir, "config.toml")
+	_ = os.WriteFile(configPath, []byte("[theme]\nname = \"auto\"\n"), 0644)
+
+	withBackground(t, true)
+	config := LoadConfig()
+	if config.Theme.Name != "auto" {
+		t.Errorf("Theme.Name = %q, want auto to be kept", config.Theme.Name)
+	}
+	if config.Colors != builtinThemes["tokyo-night"] {
+		t.Errorf("Expected the dark tokyo-night colors on a dark background, got %+v", config.Colors)
+	}
+
+	withBackground(t, false)
+	if LoadConfig().Colors != builtinThemes["tokyo-night-day"] {
+		t.Error("Expected the tokyo-night-day colors on a light background")
+	}
+
+	_ = os.WriteFile(configPath, []byte("[theme]\nname = \"auto\"\ndark = \"rose-pine\"\n"), 0644)
+	if LoadConfig().Colors != builtinThemes["rose-pine-dawn"] {
+		t.Error("Expected the light variant of the configured dark theme")
+	}
+
+	// Saving a theme from the picker keeps the pair
+	if err := SaveTheme("auto"); err != nil {
+		t.Fatal(err)
+	}
+	if config := LoadConfig(); config.Theme.Name != "auto" || config.Theme.Dark != "rose-pine" {
+		t.Errorf("Theme = %+v after saving", config.Theme)
+	}
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+const messyTodoFile = "# Todos\n* [ ] Buy milk\n+ [x] Call mom\n\n\n\n## Later\n- [ ] Fix bug"
+
+func TestCLI_Clean(t *testing.T) {
+	file := writeSortFixture(t, messyTodoFile)
+
+	output := runCLI(t, file, "clean")
+	if !strings.Contains(output, "Cleaned") {
+		t.Errorf("Expected confirmation, got: %s", output)
+	}
+
+	want := "# Todos\n\n- [ ] Buy milk\n- [x] Call mom\n\n## Later\n\n- [ ] Fix bug\n"
+	if got := readTestFile(t, file); got != want {
+		t.Errorf("File =\n%q\nwant\n%q", got, want)
+	}
+
+	// A second run leaves the file alone
+	output = runCLI(t, file, "clean")
+	if !strings.Contains(output, "already clean") {
+		t.Errorf("Expected already clean, got: %s", output)
+	}
+	if got := readTestFile(t, file); got != want {
+		t.Errorf("Second clean changed the file:\n%q", got)
+	}
+}
+
+func TestCLI_CleanCheck(t *testing.T) {
+	file := writeSortFixture(t, messyTodoFile)
+
+	output, code := runCLIExitCode(t, file, "clean", "--check")
+	if code != 1 {
+		t.Errorf("Exit code = %d, want 1 for a messy file", code)
+	}
+	if output != file {
+		t.Errorf("Expected the file path, got: %s", output)
+	}
+	if got := readTestFile(t, file); got != messyTodoFile {
+		t.Error("--check must not write the file")
+	}
+
+	runCLI(t, file, "clean")
+
+	output, code = runCLIExitCode(t, file, "clean", "--check")
+	if code != 0 || output != "" {
+		t.Errorf("Clean file: exit code = %d, output = %q, want 0 and no output", code, output)
+	}
+}
+
+func TestCLI_CleanDryRun(t *testing.T) {
+	file := writeSortFixture(t, messyTodoFile)
+
+	output := runCLI(t, file, "clean", "--dry-run")
+	if !strings.Contains(output, "- [ ] Buy milk") || strings.Contains(output, "Cleaned") {
+		t.Errorf("Expected the cleaned file only, got: %s", output)
+	}
+	if got := readTestFile(t, file); got != messyTodoFile {
+		t.Error("--dry-run must not write the file")
+	}
+}
+
+func TestCLI_CleanUnknownArgument(t *testing.T) {
+	file := writeSortFixture(t, messyTodoFile)
+
+	output, code := runCLIExitCode(t, file, "clean", "--fix")
+	if code != 1 || !strings.Contains(output, "unknown clean argument") {
+		t.Errorf("Exit code = %d, output = %s", code, output)
+	}
+}
@@ -41,12 +41,15 @@ func TestMain(m *testing.M) {
 		Yellow:         func(s string) string { return styles.Warning.Render(s) },
 		Code:           func(s string) string { return styles.Code.Render(s) },
 		Tag:            func(s string) string { return styles.Tag.Render(s) },
+		Context:        func(s string) string { return styles.Context.Render(s) },
+		Project:        func(s string) string { return styles.Project.Render(s) },
 		PriorityHigh:   func(s string) string { return styles.PriorityHigh.Render(s) },
 		PriorityMedium: func(s string) string { return styles.PriorityMedium.Render(s) },
 		PriorityLow:    func(s string) string { return styles.PriorityLow.Render(s) },
 		DueUrgent:      func(s string) string { return styles.DueUrgent.Render(s) },
 		DueSoon:        func(s string) string { return styles.DueSoon.Render(s) },
 		DueFuture:      func(s string) string { return styles.DueFuture.Render(s) },
+		Time:           func(s string) string { return styles.Time.Render(s) },
 	}
 	tui.Version = Version
 
@@ -68,12 +71,15 @@ func TestMain(m *testing.M) {
 			Yellow:         func(s string) string { return newStyles.Warning.Render(s) },
 			Code:           func(s string) string { return newStyles.Code.Render(s) },
 			Tag:            func(s string) string { return newStyles.Tag.Render(s) },
+			Context:        func(s string) string { return newStyles.Context.Render(s) },
+			Project:        func(s string) string { return newStyles.Project.Render(s) },
 			PriorityHigh:   func(s string) string { return newStyles.PriorityHigh.Render(s) },
 			PriorityMedium: func(s string) string { return newStyles.PriorityMedium.Render(s) },
 			PriorityLow:    func(s string) string { return newStyles.PriorityLow.Render(s) },
 			DueUrgent:      func(s string) string { return newStyles.DueUrgent.Render(s) },
 			DueSoon:        func(s string) string { return newStyles.DueSoon.Render(s) },
 			DueFuture:      func(s string) string { return newStyles.DueFuture.Render(s) },
+			Time:           func(s string) string { return newStyles.Time.Render(s) },
 		}
 	}
 	tui.ThemeSaveFunc = func(themeName string) error {
@@ -198,6 +204,21 @@ func TestCLI_EditTodo(t *testing.T) {
 	}
 }
 
+func TestCLI_EditWithoutTextNeedsTerminal(t *testing.T) {
+	file := tempTestFile(t)
+
+	runCLI(t, file, "add", "First todo")
+	output := runCLI(t, file, "edit", "1")
+
+	// Output isn't a terminal here, so the headless command still requires text
+	if !strings.Contains(output, "edit requires index and text arguments") {
+		t.Errorf("Expected headless usage error, got: %s", output)
+	}
+	if todos := getTodos(t, file); len(todos) != 1 || todos[0] != "- [ ] First todo" {
+		t.Errorf("Todo should be unchanged, got: %v", todos)
+	}
+}
+
 func TestCLI_DeleteTodo(t *testing.T) {
 	file := tempTestFile(t)
 
@@ -0,0 +1,297 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/niklas-heer/tdx/internal/markdown"
+)
+
+// completionCommands are the subcommands offered by shell completion
+var completionCommands = []string{"list", "add", "toggle", "edit", "delete", "move", "sort", "clean", "export", "import", "due", "log", "last", "recent", "open", "completion", "help"}
+
+// completionFlags are the global flags offered by shell completion
+var completionFlags = []string{"--read-only", "-r", "--show-headings", "--max-visible", "-m", "--file", "-f", "--tag", "--priority", "--done=false", "--dry-run", "--no-color", "--help", "--version"}
+
+// completionShells are the shells a completion script can be generated for
+var completionShells = []string{"bash", "zsh", "fish"}
+
+// completeIndicesCommand is the hidden command the scripts call to list todo indices
+const completeIndicesCommand = "__complete-indices"
+
+const bashCompletion = `# bash completion for tdx
+# Load with: source <(tdx completion bash)
+
+_tdx() {
+    local cur prev
+    cur="${COMP_WORDS[COMP_CWORD]}"
+    prev="${COMP_WORDS[COMP_CWORD-1]}"
+
+    local commands="__COMMANDS__"
+    local flags="__FLAGS__"
+
+    # Find the file argument and the subcommand typed so far
+    local file="" command="" cmdpos=0 i
+    for ((i = 1; i < COMP_CWORD; i++)); do
+        case "${COMP_WORDS[i]}" in
+            *.md) file="${COMP_WORDS[i]}" ;;
+            -f|--file) file="${COMP_WORDS[i+1]}"; ((i++)) ;;
+            -m|--max-visible|--tag|--priority) ((i++)) ;;
+            -*) ;;
+            *) if [[ -z "$command" ]]; then command="${COMP_WORDS[i]}"; cmdpos=$i; fi ;;
+        esac
+    done
+
+    case "$prev" in
+        -m|--max-visible|--tag|--priority) return 0 ;;
+        -f|--file) COMPREPLY=($(compgen -f -- "$cur")); return 0 ;;
+    esac
+
+    case "$command" in
+        toggle|edit|delete|move)
+            if ((COMP_CWORD == cmdpos + 1)); then
+                COMPREPLY=($(compgen -W "$(tdx ${file:+--file "$file"} __COMPLETE_INDICES__ 2>/dev/null | cut -f1)" -- "$cur"))
+            fi
+            return 0
+            ;;
+        sort)
+            COMPREPLY=($(compgen -W "__SORT_KEYS__ --dry-run" -- "$cur"))
+            return 0
+            ;;
+        clean)
+            COMPREPLY=($(compgen -W "--check --dry-run" -- "$cur"))
+            return 0
+            ;;
+        log)
+            COMPREPLY=($(compgen -W "--days --all" -- "$cur"))
+            return 0
+            ;;
+        import)
+            if ((COMP_CWORD == cmdpos + 1)); then
+                COMPREPLY=($(compgen -W "json" -- "$cur"))
+            else
+                COMPREPLY=($(compgen -f -W "--force" -- "$cur"))
+            fi
+            return 0
+            ;;
+        export)
+            if [[ "$prev" == "--out" ]]; then
+                COMPREPLY=($(compgen -f -- "$cur"))
+            else
+                COMPREPLY=($(compgen -W "html json --out" -- "$cur"))
+            fi
+            return 0
+            ;;
+        due)
+            COMPREPLY=($(compgen -W "--overdue" -- "$cur"))
+            return 0
+            ;;
+        completion)
+            COMPREPLY=($(compgen -W "__SHELLS__" -- "$cur"))
+            return 0
+            ;;
+        "") ;;
+        *) return 0 ;;
+    esac
+
+    if [[ "$cur" == -* ]]; then
+        COMPREPLY=($(compgen -W "$flags" -- "$cur"))
+    else
+        COMPREPLY=($(compgen -W "$commands" -- "$cur") $(compgen -f -X '!*.md' -- "$cur"))
+    fi
+}
+
+complete -o filenames -F _tdx tdx
+`
+
+const zshCompletion = `#compdef tdx
+# zsh completion for tdx
+# Load with: source <(tdx completion zsh)
+
+_tdx() {
+    local -a commands flags
+    commands=(__COMMANDS__)
+    flags=(__FLAGS__)
+
+    # Find the file argument and the subcommand typed so far
+    local file="" command="" cmdpos=0 i
+    for ((i = 2; i < CURRENT; i++)); do
+        case "${words[i]}" in
+            *.md) file="${words[i]}" ;;
+            -f|--file) file="${words[i+1]}"; ((i++)) ;;
+            -m|--max-visible|--tag|--priority) ((i++)) ;;
+            -*) ;;
+            *) if [[ -z "$command" ]]; then command="${words[i]}"; cmdpos=$i; fi ;;
+        esac
+    done
+
+    case "${words[CURRENT-1]}" in
+        -m|--max-visible|--tag|--priority) return 0 ;;
+        -f|--file) _files; return 0 ;;
+    esac
+
+    case "$command" in
+        toggle|edit|delete|move)
+            if ((CURRENT == cmdpos + 1)); then
+                local -a indices
+                indices=(${(f)"$(tdx ${file:+--file "$file"} __COMPLETE_INDICES__ 2>/dev/null)"})
+                indices=(${indices/$'\t'/:})
+                _describe 'todo' indices
+            fi
+            return 0
+            ;;
+        sort)
+            compadd -- __SORT_KEYS__ --dry-run
+            return 0
+            ;;
+        clean)
+            compadd -- --check --dry-run
+            return 0
+            ;;
+        log)
+            compadd -- --days --all
+            return 0
+            ;;
+        import)
+            if ((CURRENT == cmdpos + 1)); then
+                compadd -- json
+            else
+                compadd -- --force
+                _files -g '*.json'
+            fi
+            return 0
+            ;;
+        export)
+            if [[ "${words[CURRENT-1]}" == "--out" ]]; then
+                _files
+            else
+                compadd -- html json --out
+            fi
+            return 0
+            ;;
+        due)
+            compadd -- --overdue
+            return 0
+            ;;
+        completion)
+            compadd -- __SHELLS__
+            return 0
+            ;;
+        "") ;;
+        *) return 0 ;;
+    esac
+
+    if [[ "$PREFIX" == -* ]]; then
+        compadd -a flags
+    else
+        compadd -a commands
+        _files -g '*.md'
+    fi
+}
+
+if [[ "${funcstack[1]}" == "_tdx" ]]; then
+    _tdx "$@"
+else
+    compdef _tdx tdx
+fi
+`
+
+const fishCompletion = `# fish completion for tdx
+# Load with: tdx completion fish | source
+
+function __tdx_file
+    for arg in (commandline -opc)[2..-1]
+        if string match -q -- '*.md' $arg
+            echo $arg
+            return
+        end
+    end
+end
+
+function __tdx_needs_index
+    set -l tokens (commandline -opc)
+    contains -- $tokens[-1] toggle edit delete move
+end
+
+function __tdx_indices
+    tdx (__tdx_file) __COMPLETE_INDICES__ 2>/dev/null
+end
+
+set -l commands __COMMANDS__
+
+complete -c tdx -f
+complete -c tdx -n "not __fish_seen_subcommand_from $commands" -a "$commands"
+complete -c tdx -n "not __fish_seen_subcommand_from $commands" -a "(__fish_complete_suffix .md)"
+complete -c tdx -s r -l read-only -d "Don't save changes to disk"
+complete -c tdx -l show-headings -d "Display markdown headings between tasks"
+complete -c tdx -s m -l max-visible -x -d "Set max visible items"
+complete -c tdx -s f -l file -r -F -d "Todo file to use"
+complete -c tdx -n "not __fish_seen_subcommand_from $commands" -l tag -x -d "Open filtered by a tag"
+complete -c tdx -n "not __fish_seen_subcommand_from $commands" -l priority -x -d "Open filtered by a priority"
+complete -c tdx -n "not __fish_seen_subcommand_from $commands" -l done -x -a "true false" -d "Show or hide completed todos"
+complete -c tdx -l dry-run -d "Print the changed file instead of saving it"
+complete -c tdx -l no-color -d "Plain output without colors"
+complete -c tdx -s h -l help -d "Show help"
+complete -c tdx -l version -d "Show version"
+complete -c tdx -n "__tdx_needs_index" -a "(__tdx_indices)"
+complete -c tdx -n "__fish_seen_subcommand_from sort" -a "__SORT_KEYS__ --dry-run"
+complete -c tdx -n "__fish_seen_subcommand_from clean" -l check -d "Only report whether the file is clean"
+complete -c tdx -n "__fish_seen_subcommand_from log" -l days -x -d "Show completions of the last N days"
+complete -c tdx -n "__fish_seen_subcommand_from log" -l all -d "Show the whole completion log"
+complete -c tdx -n "__fish_seen_subcommand_from export; and not __fish_seen_subcommand_from html json" -a "html json"
+complete -c tdx -n "__fish_seen_subcommand_from import; and not __fish_seen_subcommand_from json" -a "json"
+complete -c tdx -n "__fish_seen_subcommand_from import; and __fish_seen_subcommand_from json" -F -l force -d "Replace an existing file"
+complete -c tdx -n "__fish_seen_subcommand_from export" -l out -r -F -d "Write the export to a file"
+complete -c tdx -n "__fish_seen_subcommand_from due" -l overdue -d "Only overdue todos, exit 1 if there are any"
+complete -c tdx -n "__fish_seen_subcommand_from completion" -a "__SHELLS__"
+`
+
+// completionScript returns the completion script for the given shell
+func completionScript(shell string) (string, error) {
+	var script string
+	switch shell {
+	case "bash":
+		script = bashCompletion
+	case "zsh":
+		script = zshCompletion
+	case "fish":
+		script = fishCompletion
+	default:
+		return "", fmt.Errorf("unsupported shell %q (use %s)", shell, strings.Join(completionShells, ", "))
+	}
+
+	replacer := strings.NewReplacer(
+		"__COMMANDS__", strings.Join(completionCommands, " "),
+		"__FLAGS__", strings.Join(completionFlags, " "),
+		"__SORT_KEYS__", strings.Join(markdown.SortKeys, " "),
+		"__SHELLS__", strings.Join(completionShells, " "),
+		"__COMPLETE_INDICES__", completeIndicesCommand,
+	)
+	return replacer.Replace(script), nil
+}
+
+func handleCompletionCommand(args []string) {
+	if len(args) == 0 {
+		fmt.Printf("Error: completion requires a shell (%s)\n", strings.Join(completionShells, ", "))
+		os.Exit(1)
+	}
+
+	script, err := completionScript(args[0])
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Print(script)
+}
+
+// printCompletionIndices prints "index<TAB>text" for every todo, used by the completion scripts
+func printCompletionIndices(filePath string) {
+	fm, err := markdown.ReadFile(filePath)
+	if err != nil {
+		return
+	}
+	for _, todo := range fm.Todos {
+		fmt.Printf("%d\t%s\n", todo.Index, todo.Text)
+	}
+}
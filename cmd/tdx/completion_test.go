@@ -0,0 +1,76 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+func TestCLI_CompletionBash(t *testing.T) {
+	output := runCLI(t, tempTestFile(t), "completion", "bash")
+
+	for _, want := range []string{"_tdx()", "complete -o filenames -F _tdx tdx", "toggle", "--read-only", "--max-visible", "*.md", "__complete-indices", "priority due alpha done"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected bash completion to contain %q", want)
+		}
+	}
+}
+
+func TestCLI_CompletionZsh(t *testing.T) {
+	output := runCLI(t, tempTestFile(t), "completion", "zsh")
+
+	for _, want := range []string{"#compdef tdx", "_tdx()", "compdef _tdx tdx", "_describe", "_files -g '*.md'", "--show-headings", "recent"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected zsh completion to contain %q", want)
+		}
+	}
+}
+
+func TestCLI_CompletionFish(t *testing.T) {
+	output := runCLI(t, tempTestFile(t), "completion", "fish")
+
+	for _, want := range []string{"complete -c tdx", "function __tdx_indices", "-l read-only", "-l max-visible", "__fish_complete_suffix .md", "last"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected fish completion to contain %q", want)
+		}
+	}
+}
+
+func TestCLI_CompletionBashIsValidSyntax(t *testing.T) {
+	bash, err := exec.LookPath("bash")
+	if err != nil {
+		t.Skip("bash not available")
+	}
+	script := runCLI(t, tempTestFile(t), "completion", "bash")
+
+	cmd := exec.Command(bash, "-n")
+	cmd.Stdin = strings.NewReader(script)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Errorf("bash -n rejected the script: %v\n%s", err, out)
+	}
+}
+
+func TestCLI_CompletionUnknownShell(t *testing.T) {
+	output := runCLI(t, tempTestFile(t), "completion", "powershell")
+	if !strings.Contains(output, "unsupported shell") {
+		t.Errorf("Expected unsupported shell error, got: %s", output)
+	}
+
+	output = runCLI(t, tempTestFile(t), "completion")
+	if !strings.Contains(output, "completion requires a shell") {
+		t.Errorf("Expected missing shell error, got: %s", output)
+	}
+}
+
+func TestCLI_CompletionIndices(t *testing.T) {
+	file := tempTestFile(t)
+	if err := os.WriteFile(file, []byte("# Todos\n\n- [ ] Buy milk\n- [x] Call mom\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	output := runCLI(t, file, "__complete-indices")
+	if output != "1\tBuy milk\n2\tCall mom" {
+		t.Errorf("Unexpected indices output: %q", output)
+	}
+}
@@ -6,4 +6,9 @@ package main
 var (
 	Version     = "dev"
 	Description = "dev build"
+
+	// Commit and BuildDate are optional; without them --version falls back to
+	// the VCS information Go embeds in the binary
+	Commit    = ""
+	BuildDate = ""
 )
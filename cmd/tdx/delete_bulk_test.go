@@ -0,0 +1,121 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/niklas-heer/tdx/internal/markdown"
+)
+
+const deleteBulkFixture = `---
+filter-done: false
+---
+# Work
+
+- [x] Ship release #work !p1
+- [ ] Write docs #work
+  - [x] Outline
+  - [ ] Examples
+- [ ] Review PR !p3
+
+## Home
+
+- [x] Groceries #home
+- [ ] Laundry #home !p3
+`
+
+// fileTodoTexts returns the text of every todo in the file, nested ones included
+func fileTodoTexts(t *testing.T, file string) []string {
+	t.Helper()
+	fm, err := markdown.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var texts []string
+	for _, todo := range fm.Todos {
+		texts = append(texts, todo.Text)
+	}
+	return texts
+}
+
+func TestCLI_DeleteDone(t *testing.T) {
+	file := writeSortFixture(t, deleteBulkFixture)
+
+	output := runCLI(t, file, "delete", "--done")
+	if !strings.Contains(output, "Deleted 3 todos") {
+		t.Errorf("Expected the count removed, got: %s", output)
+	}
+
+	want := `---
+filter-done: false
+---
+# Work
+
+- [ ] Write docs #work
+  - [ ] Examples
+- [ ] Review PR !p3
+
+## Home
+
+- [ ] Laundry #home !p3
+`
+	if got := readTestFile(t, file); got != want {
+		t.Errorf("File after delete --done:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestCLI_DeleteByTagAndPriority(t *testing.T) {
+	file := writeSortFixture(t, deleteBulkFixture)
+	output := runCLI(t, file, "delete", "--tag", "#home")
+	if !strings.Contains(output, "Deleted 2 todos") {
+		t.Errorf("Expected 2 #home todos deleted, got: %s", output)
+	}
+	assertTodoOrder(t, fileTodoTexts(t, file), "Ship release #work !p1", "Write docs #work", "Outline", "Examples", "Review PR !p3")
+
+	file = writeSortFixture(t, deleteBulkFixture)
+	runCLI(t, file, "delete", "--priority", "p3")
+	assertTodoOrder(t, fileTodoTexts(t, file), "Ship release #work !p1", "Write docs #work", "Outline", "Examples", "Groceries #home")
+
+	// Filters combine
+	file = writeSortFixture(t, deleteBulkFixture)
+	output = runCLI(t, file, "delete", "--done", "--tag", "work")
+	if !strings.Contains(output, "Deleted 1 todo") {
+		t.Errorf("Expected 1 todo deleted, got: %s", output)
+	}
+	assertTodoOrder(t, fileTodoTexts(t, file), "Write docs #work", "Outline", "Examples", "Review PR !p3", "Groceries #home", "Laundry #home !p3")
+}
+
+func TestCLI_DeleteNoMatch(t *testing.T) {
+	file := writeSortFixture(t, deleteBulkFixture)
+
+	output := runCLI(t, file, "delete", "--tag", "nope")
+	if !strings.Contains(output, "No todos match") {
+		t.Errorf("Expected a no-match message, got: %s", output)
+	}
+	if got := readTestFile(t, file); got != deleteBulkFixture {
+		t.Errorf("File should be unchanged:\n%s", got)
+	}
+}
+
+func TestCLI_DeleteArgErrors(t *testing.T) {
+	file := writeSortFixture(t, deleteBulkFixture)
+
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"delete", "2", "--done"}, "not both"},
+		{[]string{"delete", "--priority", "high"}, "invalid priority"},
+		{[]string{"delete", "--tag"}, "--tag requires a value"},
+		{[]string{"delete"}, "delete requires index argument"},
+	}
+	for _, tt := range tests {
+		output, code := runCLIExitCode(t, file, tt.args...)
+		if code != 1 || !strings.Contains(output, tt.want) {
+			t.Errorf("%v: got %q (exit %d), want %q and exit 1", tt.args, output, code, tt.want)
+		}
+	}
+	if got := readTestFile(t, file); got != deleteBulkFixture {
+		t.Errorf("File should be unchanged:\n%s", got)
+	}
+}
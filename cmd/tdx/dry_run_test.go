@@ -0,0 +1,104 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/niklas-heer/tdx/internal/cmd"
+)
+
+func TestCLI_DryRunLeavesFileUnchanged(t *testing.T) {
+	original := "# Todos\n\n- [ ] Write report\n- [ ] Call mom\n- [x] Buy milk\n"
+
+	tests := []struct {
+		name    string
+		args    []string
+		want    string
+		notWant string
+	}{
+		{"add", []string{"--dry-run", "add", "Walk dog"}, "- [ ] Walk dog", ""},
+		{"toggle", []string{"--dry-run", "toggle", "1"}, "- [x] Write report", "- [ ] Write report"},
+		{"edit", []string{"--dry-run", "edit", "2", "Call dad"}, "- [ ] Call dad", "Call mom"},
+		{"delete", []string{"--dry-run", "delete", "3"}, "- [ ] Call mom", "Buy milk"},
+		{"sort", []string{"--dry-run", "sort", "done"}, "- [ ] Call mom\n- [x] Buy milk", ""},
+		{"flag after command", []string{"toggle", "2", "--dry-run"}, "- [x] Call mom", "- [ ] Call mom"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := writeSortFixture(t, original)
+
+			output := runCLI(t, file, tt.args...)
+
+			if got := readTestFile(t, file); got != original {
+				t.Errorf("Dry run modified the file:\n%s", got)
+			}
+			if !strings.Contains(output, tt.want) {
+				t.Errorf("Expected %q in output:\n%s", tt.want, output)
+			}
+			if tt.notWant != "" && strings.Contains(output, tt.notWant) {
+				t.Errorf("Did not expect %q in output:\n%s", tt.notWant, output)
+			}
+			if strings.Contains(output, "✓") {
+				t.Errorf("Dry run output should only hold the file:\n%s", output)
+			}
+		})
+	}
+}
+
+func TestHandleCommand_DryRunFlagDoesNotLeak(t *testing.T) {
+	file := writeSortFixture(t, "# Todos\n\n- [ ] Write report\n")
+
+	cmd.HandleCommand("add", []string{"Walk dog", "--dry-run"}, file, "")
+	if cmd.DryRun {
+		t.Error("--dry-run after the command should only apply to that command")
+	}
+
+	cmd.HandleCommand("add", []string{"Call mom"}, file, "")
+	got := readTestFile(t, file)
+	if !strings.Contains(got, "- [ ] Call mom") || strings.Contains(got, "Walk dog") {
+		t.Errorf("Expected only the second add to be saved:\n%s", got)
+	}
+}
+
+func TestCLI_DryRunMove(t *testing.T) {
+	original := "# Todos\n\n- [ ] Write report\n- [ ] Call mom\n- [x] Buy milk\n"
+	file := writeSortFixture(t, original)
+
+	output := runCLI(t, file, "--dry-run", "move", "3", "1")
+
+	if got := readTestFile(t, file); got != original {
+		t.Errorf("Dry run modified the file:\n%s", got)
+	}
+	milk, report := strings.Index(output, "- [x] Buy milk"), strings.Index(output, "- [ ] Write report")
+	if milk == -1 || report == -1 || milk > report {
+		t.Errorf("Expected the moved todo first in the printed file:\n%s", output)
+	}
+}
+
+func TestCLI_DryRunErrorsStillReported(t *testing.T) {
+	file := writeSortFixture(t, "- [ ] Only one\n")
+
+	output := runCLI(t, file, "--dry-run", "delete", "5")
+	if !strings.Contains(output, "invalid index 5") {
+		t.Errorf("Expected invalid index error, got: %s", output)
+	}
+}
+
+func TestCLI_DryRunImport(t *testing.T) {
+	original := "# Todos\n\n- [ ] Keep me\n"
+	file := writeSortFixture(t, original)
+	input := filepath.Join(t.TempDir(), "todos.json")
+	_ = os.WriteFile(input, []byte(`{"version":1,"items":[{"type":"todo","text":"New"}]}`), 0644)
+
+	output := runCLI(t, file, "--dry-run", "import", "json", input, "--force")
+
+	if got := readTestFile(t, file); got != original {
+		t.Errorf("Dry run modified the file:\n%s", got)
+	}
+	if !strings.Contains(output, "- [ ] New") || strings.Contains(output, "Keep me") || strings.Contains(output, "✓") {
+		t.Errorf("Expected only the imported file to be printed, got:\n%s", output)
+	}
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// dueFixture returns a file with todos due long ago, today, tomorrow and far ahead
+func dueFixture(t *testing.T) string {
+	t.Helper()
+	today := time.Now().Format("2006-01-02")
+	tomorrow := time.Now().AddDate(0, 0, 1).Format("2006-01-02")
+	return writeSortFixture(t, `# Todos
+
+- [ ] Launch @due(2999-01-01)
+- [ ] Pay rent @due(2000-01-01)
+- [ ] Call bank @due(`+today+`)
+- [x] Filed taxes @due(2000-01-02)
+- [ ] Someday
+- [ ] Send report @due(`+tomorrow+`)
+`)
+}
+
+func TestCLI_DueGroupsTodos(t *testing.T) {
+	output, code := runCLIExitCode(t, dueFixture(t), "due")
+	if code != 0 {
+		t.Errorf("Exit code = %d, want 0", code)
+	}
+
+	// Each heading is followed by its todos, most urgent bucket first
+	order := []string{"Overdue", "2. [ ] Pay rent", "Today", "3. [ ] Call bank", "This Week", "6. [ ] Send report", "Later", "1. [ ] Launch"}
+	pos := -1
+	for _, want := range order {
+		i := strings.Index(output, want)
+		if i < pos {
+			t.Fatalf("Expected %q after the previous entries:\n%s", want, output)
+		}
+		pos = i
+	}
+	if strings.Contains(output, "Filed taxes") || strings.Contains(output, "Someday") {
+		t.Errorf("Expected done todos and todos without a due date to be left out:\n%s", output)
+	}
+}
+
+func TestCLI_DueOverdue(t *testing.T) {
+	output, code := runCLIExitCode(t, dueFixture(t), "due", "--overdue")
+	if code != 1 {
+		t.Errorf("Exit code = %d, want 1 with overdue todos", code)
+	}
+	if !strings.Contains(output, "Pay rent") || strings.Contains(output, "Call bank") || strings.Contains(output, "Launch") {
+		t.Errorf("Expected only the overdue todo:\n%s", output)
+	}
+
+	file := writeSortFixture(t, "# Todos\n\n- [ ] Launch @due(2999-01-01)\n")
+	output, code = runCLIExitCode(t, file, "due", "--overdue")
+	if code != 0 || !strings.Contains(output, "Nothing overdue") {
+		t.Errorf("Expected exit code 0 and Nothing overdue, got %d:\n%s", code, output)
+	}
+}
+
+func TestCLI_DueWithoutDates(t *testing.T) {
+	file := writeSortFixture(t, "# Todos\n\n- [ ] Someday\n")
+
+	output, code := runCLIExitCode(t, file, "due")
+	if code != 0 || !strings.Contains(output, "No open todos with due dates") {
+		t.Errorf("Got exit code %d:\n%s", code, output)
+	}
+
+	if output, _ := runCLIExitCode(t, file, "due", "--soon"); !strings.Contains(output, "unknown due argument") {
+		t.Errorf("Expected an error for an unknown flag, got: %s", output)
+	}
+}
@@ -299,3 +299,42 @@ func TestEdgeCase_TasksWithDifferentStatuses(t *testing.T) {
 		t.Error("Task 4 should be unchecked")
 	}
 }
+
+func TestEdgeCase_AlternativeListMarkers(t *testing.T) {
+	file := tempTestFile(t)
+
+	initial := "# Todos\n\n* [ ] Star task\n+ [ ] Plus task\n"
+	_ = os.WriteFile(file, []byte(initial), 0644)
+
+	output := runCLI(t, file, "list")
+	if !strings.Contains(output, "Star task") || !strings.Contains(output, "Plus task") {
+		t.Errorf("Expected both tasks listed, got: %s", output)
+	}
+
+	runCLI(t, file, "toggle", "1")
+
+	expected := "# Todos\n\n* [x] Star task\n+ [ ] Plus task\n"
+	if content := readTestFile(t, file); content != expected {
+		t.Errorf("Expected bullets preserved.\nExpected:\n%q\nGot:\n%q", expected, content)
+	}
+}
+
+func TestEdgeCase_OrderedTaskList(t *testing.T) {
+	file := tempTestFile(t)
+
+	initial := "# Todos\n\n1. [ ] First\n2. [ ] Second\n3. [ ] Third\n"
+	_ = os.WriteFile(file, []byte(initial), 0644)
+
+	output := runCLI(t, file, "list")
+	if !strings.Contains(output, "First") || !strings.Contains(output, "Third") {
+		t.Errorf("Expected the ordered tasks listed, got: %s", output)
+	}
+
+	runCLI(t, file, "delete", "2")
+	runCLI(t, file, "add", "Fourth")
+
+	expected := "# Todos\n\n1. [ ] First\n2. [ ] Third\n3. [ ] Fourth\n"
+	if content := readTestFile(t, file); content != expected {
+		t.Errorf("Expected the list renumbered.\nExpected:\n%q\nGot:\n%q", expected, content)
+	}
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// runCLIExitCode runs the binary and returns its output and exit code
+func runCLIExitCode(t *testing.T, file string, args ...string) (string, int) {
+	t.Helper()
+	cmd := exec.Command(testBinary, append([]string{file}, args...)...)
+	out, err := cmd.CombinedOutput()
+	code := 0
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		code = exitErr.ExitCode()
+	}
+	return strings.TrimSpace(string(out)), code
+}
+
+func TestCLI_EmptyFile(t *testing.T) {
+	commands := [][]string{
+		{"toggle", "1"},
+		{"edit", "1", "New text"},
+		{"delete", "1"},
+		{"move", "1", "2"},
+		{"sort", "alpha"},
+	}
+
+	files := map[string]string{
+		"empty":           "",
+		"whitespace only": "  \n\n\t\n",
+		"headings only":   "# Todos\n\nSome notes.\n",
+	}
+
+	for name, content := range files {
+		for _, args := range commands {
+			t.Run(name+"/"+args[0], func(t *testing.T) {
+				file := writeSortFixture(t, content)
+
+				output, code := runCLIExitCode(t, file, args...)
+
+				if code != 2 {
+					t.Errorf("exit code = %d, want 2 (output: %s)", code, output)
+				}
+				if !strings.Contains(output, "No todos in "+file) {
+					t.Errorf("Expected no todos message, got: %s", output)
+				}
+				if got := readTestFile(t, file); got != content {
+					t.Errorf("File was modified: %q", got)
+				}
+			})
+		}
+	}
+}
+
+func TestCLI_MissingFile(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "new.md")
+
+	for _, args := range [][]string{{"toggle", "1"}, {"delete", "1"}, {"sort", "done"}} {
+		output, code := runCLIExitCode(t, file, args...)
+		if code != 2 || !strings.Contains(output, "No todos in") {
+			t.Errorf("%s: exit code %d, output %q; want the no todos message with exit code 2", args[0], code, output)
+		}
+		if _, err := os.Stat(file); !os.IsNotExist(err) {
+			t.Fatalf("%s should not create the file", args[0])
+		}
+	}
+
+	// add still creates it
+	if _, code := runCLIExitCode(t, file, "add", "First"); code != 0 {
+		t.Errorf("add exit code = %d, want 0", code)
+	}
+	assertTodoOrder(t, getTodos(t, file), "First")
+}
+
+func TestCLI_InvalidIndexExitCode(t *testing.T) {
+	file := writeSortFixture(t, "- [ ] Only one\n")
+
+	output, code := runCLIExitCode(t, file, "toggle", "5")
+	if code != 1 || !strings.Contains(output, "invalid index 5") {
+		t.Errorf("exit code %d, output %q; want invalid index with exit code 1", code, output)
+	}
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCLI_ExportHTMLToStdout(t *testing.T) {
+	file := tempTestFile(t)
+	_ = os.WriteFile(file, []byte("# Todos\n\n- [x] Done #work\n- [ ] Open\n"), 0644)
+
+	output := runCLI(t, file, "export", "html")
+
+	if !strings.HasPrefix(output, "<!DOCTYPE html>") {
+		t.Fatalf("Expected an HTML page, got:\n%s", output)
+	}
+	if !strings.Contains(output, `<input checked="" disabled="" type="checkbox"> Done <span class="tag">#work</span>`) {
+		t.Errorf("Expected checked todo with styled tag:\n%s", output)
+	}
+	if !strings.Contains(readTestFile(t, file), "- [x] Done #work") {
+		t.Error("Export shouldn't modify the file")
+	}
+}
+
+func TestCLI_ExportHTMLToFile(t *testing.T) {
+	file := tempTestFile(t)
+	_ = os.WriteFile(file, []byte("# Todos\n\n- [ ] Open\n"), 0644)
+	out := filepath.Join(t.TempDir(), "todos.html")
+
+	output := runCLI(t, file, "export", "html", "--out", out)
+
+	if !strings.Contains(output, "Exported to "+out) {
+		t.Errorf("Expected confirmation, got: %s", output)
+	}
+	content, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(content), `<input disabled="" type="checkbox"> Open`) {
+		t.Errorf("Unexpected export:\n%s", content)
+	}
+}
+
+func TestCLI_ExportRequiresFormat(t *testing.T) {
+	file := tempTestFile(t)
+
+	output := runCLI(t, file, "export", "pdf")
+
+	if !strings.Contains(output, "export requires a format (html, json)") {
+		t.Errorf("Expected format error, got: %s", output)
+	}
+}
+
+func TestCLI_ExportImportJSON(t *testing.T) {
+	file := tempTestFile(t)
+	original := "# Todos\n\n- [ ] Ship !p1 #backend\n  a note\n  - [x] Tests\n"
+	_ = os.WriteFile(file, []byte(original), 0644)
+	backup := filepath.Join(t.TempDir(), "todos.json")
+
+	runCLI(t, file, "export", "json", "--out", backup)
+	data, err := os.ReadFile(backup)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"version": 1`) || !strings.Contains(string(data), `"tags": [`) {
+		t.Errorf("Unexpected JSON export:\n%s", data)
+	}
+
+	restored := filepath.Join(t.TempDir(), "restored.md")
+	output := runCLI(t, restored, "import", "json", backup)
+	if !strings.Contains(output, "Imported 2 todos") {
+		t.Errorf("Expected import confirmation, got: %s", output)
+	}
+	if got := readTestFile(t, restored); got != original {
+		t.Errorf("Restored file:\n%q\nwant:\n%q", got, original)
+	}
+}
+
+func TestCLI_ImportRefusesExistingFile(t *testing.T) {
+	file := tempTestFile(t)
+	_ = os.WriteFile(file, []byte("# Todos\n\n- [ ] Keep me\n"), 0644)
+	input := filepath.Join(t.TempDir(), "todos.json")
+	_ = os.WriteFile(input, []byte(`{"version":1,"items":[{"type":"todo","text":"New"}]}`), 0644)
+
+	output := runCLI(t, file, "import", "json", input)
+	if !strings.Contains(output, "already exists") {
+		t.Errorf("Expected refusal, got: %s", output)
+	}
+	if !strings.Contains(readTestFile(t, file), "Keep me") {
+		t.Error("Existing file should be untouched")
+	}
+
+	runCLI(t, file, "import", "json", input, "--force")
+	if got := getTodos(t, file); len(got) != 1 || got[0] != "- [ ] New" {
+		t.Errorf("Todos after --force = %v", got)
+	}
+}
+
+func TestCLI_ImportRejectsUnknownVersion(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "todo.md")
+	input := filepath.Join(t.TempDir(), "todos.json")
+	_ = os.WriteFile(input, []byte(`{"version":9,"items":[]}`), 0644)
+
+	output := runCLI(t, file, "import", "json", input)
+
+	if !strings.Contains(output, "unsupported version 9") {
+		t.Errorf("Expected version error, got: %s", output)
+	}
+	if _, err := os.Stat(file); err == nil {
+		t.Error("No file should be created")
+	}
+}
+
+func TestCLI_ImportKeepsTextJSONCantHold(t *testing.T) {
+	file := tempTestFile(t)
+	original := "# Todos\n\nMeeting notes for the week.\n\n- [ ] Keep me\n"
+	_ = os.WriteFile(file, []byte(original), 0644)
+	backup := filepath.Join(t.TempDir(), "todos.json")
+
+	output := runCLI(t, file, "export", "json", "--out", backup)
+	if !strings.Contains(output, "Warning") || !strings.Contains(output, "a paragraph") {
+		t.Errorf("Expected a warning about the left out paragraph, got: %s", output)
+	}
+
+	output = runCLI(t, file, "import", "json", backup, "--force")
+	if !strings.Contains(output, "can't keep") {
+		t.Errorf("Expected refusal, got: %s", output)
+	}
+	if got := readTestFile(t, file); got != original {
+		t.Errorf("File should be untouched, got:\n%s", got)
+	}
+}
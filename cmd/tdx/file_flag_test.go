@@ -0,0 +1,79 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestChooseFilePath_Precedence(t *testing.T) {
+	tests := []struct {
+		name                            string
+		flag, env, positional, fallback string
+		want                            string
+	}{
+		{"flag wins", "flag.md", "env.md", "pos.md", "config.md", "flag.md"},
+		{"env over positional", "", "env.md", "pos.md", "config.md", "env.md"},
+		{"positional over config", "", "", "pos.md", "config.md", "pos.md"},
+		{"config default", "", "", "", "config.md", "config.md"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := chooseFilePath(tt.flag, tt.env, tt.positional, tt.fallback); got != tt.want {
+				t.Errorf("chooseFilePath = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// runFileSelection runs the binary with a config whose default file is configFile
+func runFileSelection(t *testing.T, configFile, envFile string, args ...string) string {
+	t.Helper()
+	configHome := t.TempDir()
+	configDir := filepath.Join(configHome, "tdx")
+	_ = os.MkdirAll(configDir, 0755)
+	config := "[defaults]\nfile = \"" + configFile + "\"\n"
+	if err := os.WriteFile(filepath.Join(configDir, "config.toml"), []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := exec.Command(testBinary, args...)
+	cmd.Env = append(os.Environ(), "XDG_CONFIG_HOME="+configHome, fileEnvVar+"="+envFile)
+	out, _ := cmd.CombinedOutput()
+	return strings.TrimSpace(string(out))
+}
+
+func TestCLI_FileSelection(t *testing.T) {
+	flagFile := writeSortFixture(t, "# Todos\n\n- [ ] From flag\n")
+	envFile := writeSortFixture(t, "# Todos\n\n- [ ] From env\n")
+	configFile := writeSortFixture(t, "# Todos\n\n- [ ] From config\n")
+
+	tests := []struct {
+		name string
+		env  string
+		args []string
+		want string
+	}{
+		{"config default", "", []string{"list"}, "From config"},
+		{"env over config", envFile, []string{"list"}, "From env"},
+		{"flag over env", envFile, []string{"--file", flagFile, "list"}, "From flag"},
+		{"short flag", "", []string{"list", "-f", flagFile}, "From flag"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output := runFileSelection(t, configFile, tt.env, tt.args...)
+			if !strings.Contains(output, tt.want) {
+				t.Errorf("Expected %q, got:\n%s", tt.want, output)
+			}
+		})
+	}
+}
+
+func TestCLI_FileFlagMissingPath(t *testing.T) {
+	output := runFileSelection(t, "todo.md", "", "list", "--file")
+	if !strings.Contains(output, "--file requires a path") {
+		t.Errorf("Expected error for missing path, got: %s", output)
+	}
+}
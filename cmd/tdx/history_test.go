@@ -0,0 +1,113 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// runCLIWithConfig runs tdx on file with config.toml in a separate config directory
+func runCLIWithConfig(t *testing.T, configHome, config, file string, args ...string) string {
+	t.Helper()
+	configDir := filepath.Join(configHome, "tdx")
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(configDir, "config.toml"), []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cmd := exec.Command(testBinary, append([]string{file}, args...)...)
+	cmd.Env = append(os.Environ(), "XDG_CONFIG_HOME="+configHome)
+	out, _ := cmd.CombinedOutput()
+	return strings.TrimSpace(string(out))
+}
+
+func TestCLI_ToggleLogsCompletion(t *testing.T) {
+	configHome := t.TempDir()
+	file := writeSortFixture(t, "# Todos\n\n- [ ] Write report #work\n- [ ] Buy milk\n")
+
+	runCLIWithConfig(t, configHome, "[history]\nenabled = true\n", file, "toggle", "1")
+
+	content, err := os.ReadFile(filepath.Join(configHome, "tdx", "history.md"))
+	if err != nil {
+		t.Fatalf("Expected the completion log to be written: %v", err)
+	}
+	line := regexp.MustCompile(`(?m)^- \d{4}-\d{2}-\d{2} \d{2}:\d{2} \| (.+) \| (.+)$`).FindStringSubmatch(string(content))
+	if line == nil {
+		t.Fatalf("Expected a line with the time, file and text:\n%s", content)
+	}
+	want, _ := filepath.EvalSymlinks(file)
+	if line[1] != want || line[2] != "Write report #work" {
+		t.Errorf("Logged file %q and text %q, want %q and %q", line[1], line[2], want, "Write report #work")
+	}
+
+	// Unchecking isn't logged
+	runCLIWithConfig(t, configHome, "[history]\nenabled = true\n", file, "toggle", "1")
+	again, _ := os.ReadFile(filepath.Join(configHome, "tdx", "history.md"))
+	if string(again) != string(content) {
+		t.Errorf("Unchecking shouldn't change the log:\n%s", again)
+	}
+}
+
+func TestCLI_LogListsRecentCompletions(t *testing.T) {
+	configHome := t.TempDir()
+	file := writeSortFixture(t, "# Todos\n\n- [ ] Write report\n- [ ] Buy milk\n")
+	config := "[history]\nenabled = true\n"
+
+	runCLIWithConfig(t, configHome, config, file, "toggle", "2")
+	runCLIWithConfig(t, configHome, config, file, "toggle", "1")
+	// An old completion is only shown with --all
+	logPath := filepath.Join(configHome, "tdx", "history.md")
+	content, _ := os.ReadFile(logPath)
+	old := strings.Replace(string(content), "# Completed\n\n", "# Completed\n\n- 2001-02-03 10:00 | /old.md | Ancient task\n", 1)
+	_ = os.WriteFile(logPath, []byte(old), 0644)
+
+	output := runCLIWithConfig(t, configHome, config, file, "log")
+	report := strings.Index(output, "Write report")
+	milk := strings.Index(output, "Buy milk")
+	if report < 0 || milk < 0 || report > milk {
+		t.Errorf("Expected both completions, newest first:\n%s", output)
+	}
+	if strings.Contains(output, "Ancient task") {
+		t.Errorf("Expected only this week's completions:\n%s", output)
+	}
+
+	output = runCLIWithConfig(t, configHome, config, file, "log", "--all")
+	if !strings.Contains(output, "Ancient task") {
+		t.Errorf("Expected --all to show every completion:\n%s", output)
+	}
+
+	output = runCLIWithConfig(t, configHome, config, file, "log", "--days", "zero")
+	if !strings.Contains(output, "invalid number of days") {
+		t.Errorf("Expected an error for a bad --days, got: %s", output)
+	}
+}
+
+func TestCLI_LogDisabledByDefault(t *testing.T) {
+	configHome := t.TempDir()
+	file := writeSortFixture(t, "# Todos\n\n- [ ] Write report\n")
+
+	runCLIWithConfig(t, configHome, "[defaults]\nword_wrap = true\n", file, "toggle", "1")
+	if _, err := os.Stat(filepath.Join(configHome, "tdx", "history.md")); !os.IsNotExist(err) {
+		t.Error("Expected no completion log unless [history] enabled is set")
+	}
+
+	output := runCLIWithConfig(t, configHome, "", file, "log")
+	if !strings.Contains(output, "[history]") {
+		t.Errorf("Expected a hint on turning the log on, got: %s", output)
+	}
+}
+
+func TestCLI_DryRunDoesNotLog(t *testing.T) {
+	configHome := t.TempDir()
+	file := writeSortFixture(t, "# Todos\n\n- [ ] Write report\n")
+
+	runCLIWithConfig(t, configHome, "[history]\nenabled = true\n", file, "--dry-run", "toggle", "1")
+
+	if _, err := os.Stat(filepath.Join(configHome, "tdx", "history.md")); !os.IsNotExist(err) {
+		t.Error("A dry run shouldn't log completions")
+	}
+}
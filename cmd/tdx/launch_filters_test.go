@@ -0,0 +1,93 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+)
+
+// runTUIWithFlags launches the TUI on file with piped input, using a separate config directory
+func runTUIWithFlags(t *testing.T, file string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command(testBinary, append(args, file)...)
+	cmd.Env = append(os.Environ(), "XDG_CONFIG_HOME="+t.TempDir())
+	cmd.Stdin = strings.NewReader("")
+	out, _ := cmd.CombinedOutput()
+	return string(out)
+}
+
+const launchFiltersFixture = `# Todos
+
+- [ ] Write report #work !p1
+- [x] Call client #work !p2
+- [ ] Buy milk #home !p1
+`
+
+func TestCLI_LaunchWithTagFilter(t *testing.T) {
+	file := writeSortFixture(t, launchFiltersFixture)
+
+	output := runTUIWithFlags(t, file, "--tag", "work")
+
+	if !strings.Contains(output, "Write report") || !strings.Contains(output, "Call client") {
+		t.Errorf("Expected the #work todos:\n%s", output)
+	}
+	if strings.Contains(output, "Buy milk") {
+		t.Errorf("Expected the #home todo to be filtered out:\n%s", output)
+	}
+}
+
+func TestCLI_LaunchWithCombinedFilters(t *testing.T) {
+	file := writeSortFixture(t, launchFiltersFixture)
+
+	output := runTUIWithFlags(t, file, "--tag", "home", "--tag", "work", "--priority", "1", "--done=false")
+
+	if !strings.Contains(output, "Write report") || !strings.Contains(output, "Buy milk") {
+		t.Errorf("Expected the open p1 todos:\n%s", output)
+	}
+	if strings.Contains(output, "Call client") {
+		t.Errorf("Expected the completed p2 todo to be filtered out:\n%s", output)
+	}
+}
+
+func TestCLI_LaunchWithUnknownTag(t *testing.T) {
+	file := writeSortFixture(t, launchFiltersFixture)
+
+	output := runTUIWithFlags(t, file, "--tag", "nope")
+
+	if strings.Contains(output, "Error") {
+		t.Errorf("An unknown tag should be accepted:\n%s", output)
+	}
+	for _, text := range []string{"Write report", "Call client", "Buy milk"} {
+		if strings.Contains(output, text) {
+			t.Errorf("Expected no todos for an unknown tag, found %q:\n%s", text, output)
+		}
+	}
+}
+
+func TestCLI_LaunchFilterErrors(t *testing.T) {
+	file := writeSortFixture(t, launchFiltersFixture)
+
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{[]string{"--priority", "high"}, `invalid priority "high"`},
+		{[]string{"--done=maybe"}, "--done requires true or false"},
+	}
+	for _, tt := range tests {
+		if output := runTUIWithFlags(t, file, tt.args...); !strings.Contains(output, tt.want) {
+			t.Errorf("%v: expected %q, got:\n%s", tt.args, tt.want, output)
+		}
+	}
+}
+
+func TestCLI_CommandTagFlagNotTakenForLaunch(t *testing.T) {
+	file := writeSortFixture(t, launchFiltersFixture)
+
+	output := runCLI(t, file, "delete", "--tag", "home")
+
+	if !strings.Contains(output, "Deleted 1 todo") {
+		t.Errorf("Expected delete to get its own --tag, got:\n%s", output)
+	}
+}
@@ -1,20 +1,29 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
 	"github.com/niklas-heer/tdx/internal/cmd"
 	"github.com/niklas-heer/tdx/internal/config" // Still needed for recent files
+	"github.com/niklas-heer/tdx/internal/markdown"
 	"github.com/niklas-heer/tdx/internal/tui"
+	"github.com/niklas-heer/tdx/internal/util"
 )
 
 func main() {
 	// Load user config
 	appConfig := LoadConfig()
+	setupColor(appConfig, os.Args[1:], os.Getenv)
 	styles := NewStyles(appConfig)
 
 	// Inject config and styles into packages
@@ -24,68 +33,64 @@ func main() {
 
 	// Set recent files config
 	config.MaxRecentFiles = appConfig.Recent.MaxFiles
+	config.MaxSearchHistory = appConfig.Recent.MaxSearches
+	config.RecentSort = appConfig.Recent.Sort
+	config.PruneMissing = appConfig.Recent.PruneMissing
+
+	// Set markdown serialization config
+	markdown.PreserveCheckChar = appConfig.Display.PreserveCheckChar
+	markdown.IndentWidth = appConfig.Defaults.IndentWidth
+	markdown.ProgressChar = appConfig.Display.ProgressChar
+	markdown.DateFormat = appConfig.Display.DateFormat
+	markdown.CycleProgress = appConfig.Defaults.CycleProgress
+	markdown.NormalizeTokens = appConfig.Defaults.NormalizeTokens
+	markdown.NormalizeTagCase = appConfig.Tags.NormalizeCase
+	markdown.TagAliases = appConfig.Tags.Aliases
+
+	// Completion log, "" when [history] is off
+	historyPath := historyFilePath(appConfig.History)
 
 	// Setup TUI package globals
 	tui.Config = &tui.ConfigType{}
+	tui.Config.HistoryFile = historyPath
 	tui.Config.Display.CheckSymbol = appConfig.Display.CheckSymbol
 	tui.Config.Display.SelectMarker = appConfig.Display.SelectMarker
 	tui.Config.Display.MaxVisible = appConfig.Defaults.MaxVisible
+	tui.Config.Display.ShowProgress = appConfig.Display.ShowProgress
+	tui.Config.Display.ShowFilename = appConfig.Display.ShowFilename
+	tui.Config.Display.StatusHints = appConfig.Display.StatusHints
+	tui.Config.Display.DoneStyle = appConfig.Display.DoneStyle
+	tui.Config.Display.ScrollMargin = appConfig.Display.ScrollMargin
+	tui.Config.Display.SectionRule = appConfig.Display.SectionRule
+	tui.Hyperlinks = !appConfig.NoColor && hyperlinksEnabled(appConfig.Display.Hyperlinks)
 	tui.Config.Defaults.WordWrap = appConfig.Defaults.WordWrap
 	tui.Config.Defaults.FilterDone = appConfig.Defaults.FilterDone
 	tui.Config.Defaults.ShowHeadings = appConfig.Defaults.ShowHeadings
 	tui.Config.Defaults.ReadOnly = appConfig.Defaults.ReadOnly
+	tui.Config.Defaults.ConfirmDeleteParent = appConfig.Defaults.ConfirmDeleteParent
+	tui.Config.Defaults.ConfirmQuit = appConfig.Defaults.ConfirmQuit
 
-	tui.StyleFuncs = &tui.StyleFuncsType{
-		Magenta:        func(s string) string { return styles.Important.Render(s) },
-		Cyan:           func(s string) string { return styles.Accent.Render(s) },
-		Dim:            func(s string) string { return styles.Dim.Render(s) },
-		Green:          func(s string) string { return styles.Success.Render(s) },
-		Yellow:         func(s string) string { return styles.Warning.Render(s) },
-		Code:           func(s string) string { return styles.Code.Render(s) },
-		Tag:            func(s string) string { return styles.Tag.Render(s) },
-		PriorityHigh:   func(s string) string { return styles.PriorityHigh.Render(s) },
-		PriorityMedium: func(s string) string { return styles.PriorityMedium.Render(s) },
-		PriorityLow:    func(s string) string { return styles.PriorityLow.Render(s) },
-		DueUrgent:      func(s string) string { return styles.DueUrgent.Render(s) },
-		DueSoon:        func(s string) string { return styles.DueSoon.Render(s) },
-		DueFuture:      func(s string) string { return styles.DueFuture.Render(s) },
-	}
+	tui.StyleFuncs = tuiStyleFuncs(styles)
 	tui.Version = Version
 
 	// Setup theme picker support
-	tui.AvailableThemes = GetBuiltinThemeNames()
+	tui.AvailableThemes = append([]string{autoTheme}, GetBuiltinThemeNames()...)
 	tui.CurrentThemeName = appConfig.Theme.Name
 	tui.ThemeApplyFunc = func(themeName string) *tui.StyleFuncsType {
-		colors, ok := GetBuiltinTheme(themeName)
+		colors, ok := GetBuiltinTheme(resolveTheme(appConfig.Theme, themeName))
 		if !ok {
 			return nil
 		}
-		// Create a temporary config with the new theme colors
-		tempConfig := &UserConfig{Colors: colors}
-		newStyles := NewStyles(tempConfig)
-		return &tui.StyleFuncsType{
-			Magenta:        func(s string) string { return newStyles.Important.Render(s) },
-			Cyan:           func(s string) string { return newStyles.Accent.Render(s) },
-			Dim:            func(s string) string { return newStyles.Dim.Render(s) },
-			Green:          func(s string) string { return newStyles.Success.Render(s) },
-			Yellow:         func(s string) string { return newStyles.Warning.Render(s) },
-			Code:           func(s string) string { return newStyles.Code.Render(s) },
-			Tag:            func(s string) string { return newStyles.Tag.Render(s) },
-			PriorityHigh:   func(s string) string { return newStyles.PriorityHigh.Render(s) },
-			PriorityMedium: func(s string) string { return newStyles.PriorityMedium.Render(s) },
-			PriorityLow:    func(s string) string { return newStyles.PriorityLow.Render(s) },
-			DueUrgent:      func(s string) string { return newStyles.DueUrgent.Render(s) },
-			DueSoon:        func(s string) string { return newStyles.DueSoon.Render(s) },
-			DueFuture:      func(s string) string { return newStyles.DueFuture.Render(s) },
-		}
+		// Create a temporary config with the new theme colors, keeping the configured priority levels
+		tempConfig := &UserConfig{Colors: colors, Priority: appConfig.Priority, NoColor: appConfig.NoColor}
+		return tuiStyleFuncs(NewStyles(tempConfig))
 	}
 	tui.ThemeSaveFunc = SaveTheme
 
 	args := os.Args[1:]
 
 	// Determine file path, flags, and command
-	// Use config default file path (can be relative like "todo.md" or absolute like "~/todos.md")
-	filePath := appConfig.Defaults.File
+	var fileFlag, positionalFile string
 	var command string
 	var cmdArgs []string
 
@@ -96,13 +101,51 @@ func main() {
 
 	// Process arguments
 	var remainingArgs []string
+	var launchFilters tui.Filters
+	commandSeen := false // --tag, --priority and --done after a command are its own flags
 	for i := 0; i < len(args); i++ {
 		arg := args[i]
+		if !commandSeen {
+			switch {
+			case arg == "--tag" || arg == "--priority":
+				if i+1 >= len(args) {
+					fmt.Printf("Error: %s requires a value\n", arg)
+					os.Exit(1)
+				}
+				i++
+				if err := launchFilters.Add(arg, args[i]); err != nil {
+					fmt.Printf("Error: %v\n", err)
+					os.Exit(1)
+				}
+				continue
+			case strings.HasPrefix(arg, "--done="):
+				show, err := strconv.ParseBool(strings.TrimPrefix(arg, "--done="))
+				if err != nil {
+					fmt.Printf("Error: --done requires true or false\n")
+					os.Exit(1)
+				}
+				filterDone := !show
+				launchFilters.FilterDone = &filterDone
+				continue
+			}
+		}
 		switch arg {
 		case "--read-only", "-r":
 			readOnly = true
 		case "--show-headings":
 			showHeadings = true
+		case "--dry-run":
+			cmd.DryRun = true
+		case noColorFlag:
+			// Already applied by setupColor before the styles were made
+		case "--file", "-f":
+			if i+1 < len(args) {
+				i++
+				fileFlag = args[i]
+			} else {
+				fmt.Printf("Error: --file requires a path argument\n")
+				os.Exit(1)
+			}
 		case "--max-visible", "-m":
 			// Get the next argument as the number
 			if i+1 < len(args) {
@@ -118,15 +161,19 @@ func main() {
 				os.Exit(1)
 			}
 		default:
+			if !strings.HasPrefix(arg, "-") && !strings.HasSuffix(arg, ".md") {
+				commandSeen = true
+			}
 			remainingArgs = append(remainingArgs, arg)
 		}
 	}
 	args = remainingArgs
+	tui.LaunchFilters = launchFilters
 
 	if len(args) > 0 {
 		// Check if first arg is a .md file
 		if strings.HasSuffix(args[0], ".md") {
-			filePath = args[0]
+			positionalFile = args[0]
 			args = args[1:]
 		}
 
@@ -137,33 +184,75 @@ func main() {
 	}
 
 	// Resolve file path (expand ~ and make absolute)
-	filePath = resolveFilePath(filePath)
+	// The config default can be relative like "todo.md" or absolute like "~/todos.md"
+	filePath := resolveFilePath(chooseFilePath(fileFlag, os.Getenv(fileEnvVar), positionalFile, appConfig.Defaults.File))
+
+	cmd.ReadOnly = readOnly
 
 	// Handle commands
 	switch command {
 	case "help", "--help", "-h":
 		printHelp()
 	case "--version", "-v":
-		fmt.Printf("tdx v%s\n", Version)
+		fmt.Println(versionString())
 	case "--debug-config":
 		fmt.Printf("Theme: %s\n", appConfig.Theme.Name)
+		if appConfig.Theme.Name == autoTheme {
+			fmt.Printf("Theme.Resolved: %s\n", resolveTheme(appConfig.Theme, autoTheme))
+		}
 		fmt.Printf("Colors.Accent: %s\n", appConfig.Colors.Accent)
 		fmt.Printf("Colors.Success: %s\n", appConfig.Colors.Success)
+		fmt.Printf("NoColor: %v\n", appConfig.NoColor)
 		fmt.Printf("Display.CheckSymbol: %s\n", appConfig.Display.CheckSymbol)
 		fmt.Printf("Display.SelectMarker: %s\n", appConfig.Display.SelectMarker)
+		fmt.Printf("Display.ShowProgress: %v\n", appConfig.Display.ShowProgress)
+		fmt.Printf("Display.ShowFilename: %v\n", appConfig.Display.ShowFilename)
+		fmt.Printf("Display.Hyperlinks: %s\n", appConfig.Display.Hyperlinks)
+		fmt.Printf("Display.StatusHints: %s\n", appConfig.Display.StatusHints)
+		fmt.Printf("Display.DoneStyle: %s\n", appConfig.Display.DoneStyle)
+		fmt.Printf("Display.DateFormat: %s\n", appConfig.Display.DateFormat)
+		fmt.Printf("Display.SectionRule: %s\n", appConfig.Display.SectionRule)
+		fmt.Printf("Display.ScrollMargin: %d\n", appConfig.Display.ScrollMargin)
+		fmt.Printf("Display.ProgressChar: %s\n", appConfig.Display.ProgressChar)
 		fmt.Printf("Defaults.File: %s\n", appConfig.Defaults.File)
 		fmt.Printf("Defaults.MaxVisible: %d\n", appConfig.Defaults.MaxVisible)
 		fmt.Printf("Defaults.WordWrap: %v\n", appConfig.Defaults.WordWrap)
 		fmt.Printf("Defaults.ShowHeadings: %v\n", appConfig.Defaults.ShowHeadings)
 		fmt.Printf("Defaults.ReadOnly: %v\n", appConfig.Defaults.ReadOnly)
 		fmt.Printf("Defaults.FilterDone: %v\n", appConfig.Defaults.FilterDone)
+		fmt.Printf("Defaults.IndentWidth: %d\n", appConfig.Defaults.IndentWidth)
+		fmt.Printf("Defaults.ConfirmDeleteParent: %v\n", appConfig.Defaults.ConfirmDeleteParent)
+		fmt.Printf("Defaults.ConfirmQuit: %v\n", appConfig.Defaults.ConfirmQuit)
+		fmt.Printf("Defaults.CycleProgress: %v\n", appConfig.Defaults.CycleProgress)
+		fmt.Printf("Defaults.NormalizeTokens: %v\n", appConfig.Defaults.NormalizeTokens)
 		fmt.Printf("Recent.MaxFiles: %d\n", appConfig.Recent.MaxFiles)
-	case "list", "add", "toggle", "edit", "delete":
-		cmd.HandleCommand(command, cmdArgs, filePath)
+		fmt.Printf("Recent.MaxSearches: %d\n", appConfig.Recent.MaxSearches)
+		fmt.Printf("Recent.Sort: %s\n", appConfig.Recent.Sort)
+		fmt.Printf("Recent.PruneMissing: %v\n", appConfig.Recent.PruneMissing)
+		fmt.Printf("Tags.NormalizeCase: %v\n", appConfig.Tags.NormalizeCase)
+		fmt.Printf("Tags.Aliases: %v\n", appConfig.Tags.Aliases)
+		fmt.Printf("Priority.Colors: %v\n", appConfig.Priority.Colors)
+		fmt.Printf("History.Enabled: %v\n", appConfig.History.Enabled)
+		fmt.Printf("History.File: %s\n", historyPath)
+	case "edit":
+		// Without replacement text, open the todo in the TUI edit mode
+		if len(cmdArgs) == 1 && isTerminal(os.Stdout) {
+			handleInteractiveEdit(cmdArgs[0], filePath, readOnly, showHeadings, maxVisible)
+			return
+		}
+		cmd.HandleCommand(command, cmdArgs, filePath, historyPath)
+	case "list", "add", "toggle", "delete", "move", "sort", "clean", "export", "import", "due", "log":
+		cmd.HandleCommand(command, cmdArgs, filePath, historyPath)
+	case "completion":
+		handleCompletionCommand(cmdArgs)
+	case completeIndicesCommand:
+		printCompletionIndices(filePath)
 	case "last":
 		handleLastCommand(readOnly, showHeadings, maxVisible)
 	case "recent":
 		handleRecentCommand(cmdArgs, readOnly, showHeadings, maxVisible)
+	case "open":
+		handleOpenCommand(cmdArgs)
 	case "":
 		// Launch TUI
 		tui.Run(filePath, readOnly, showHeadings, maxVisible)
@@ -184,18 +273,58 @@ Options:
   -r, --read-only         Don't save changes to disk (read-only mode)
       --show-headings     Display markdown headings between tasks
   -m, --max-visible <N>   Set max visible items (0 = unlimited)
+  -f, --file <path>       Todo file to use (overrides $TDX_FILE and file.md)
+      --tag <name>        Open the TUI filtered by a tag (repeatable)
+      --priority <N>      Open the TUI filtered by a priority (repeatable)
+      --done=false        Open the TUI with completed todos hidden
+                          (--done=true shows them)
+      --dry-run           Print the changed file instead of saving it
+                          (add, toggle, edit, delete, move, sort, clean, import)
+      --no-color          Plain output without colors or escape sequences
+                          (also when NO_COLOR is set)
+  -v, --version           Print the version, commit, build date and Go version
 
 Commands:
   (none)              Launch interactive TUI
   list                List all todos
   add "text"          Add a new todo
-  toggle <index>      Toggle todo completion
+                      (--top inserts first, --after <index> after a todo)
+  add -               Add one todo per line read from stdin
+                      (also when no text is given and stdin is piped)
+  toggle <index>...   Toggle todo completion
+                      (several indices or ranges like 2-4)
+  toggle --text <s>   Toggle the first todo containing s (ignoring case)
+                      (--all toggles every matching todo)
   edit <index> "text" Edit todo text
+  edit <index>        Open the todo in the TUI edit mode
   delete <index>      Delete a todo
+  delete --done       Delete all completed todos in one write
+                      (--tag <tag> and --priority <n> narrow it down or
+                      work on their own; filters combine)
+  move <from> <to>    Move a todo to another position
+  sort <key>          Sort todos by priority, due, alpha, or done
+  clean               Normalize bullets, checkboxes, spacing and the trailing
+                      newline (--check lists the file and exits 1 if it isn't
+                      clean)
+  export html|json    Export the file as a standalone HTML page or JSON
+                      (--out <file> writes it to a file instead of stdout)
+  import json <file>  Create the file from a JSON export ("-" reads stdin,
+                      --force replaces an existing file)
+  due                 List open todos by due date: overdue, today, this week,
+                      later (--overdue lists only overdue ones and exits 1
+                      if there are any, for cron reminders)
+  log                 List todos completed in the last 7 days, newest first
+                      (--days <N> looks further back, --all shows everything;
+                      needs enabled = true under [history] in the config)
   last                Open the most recently used file
   recent              List recently opened files
   recent <number>     Open a recent file by number
+  recent <name>       Open the recent file best matching <name>
+  recent --json       List recent files as JSON
   recent clear        Clear recent files history
+  open <name>         Print the path of the recent file best matching <name>
+                      (e.g. cd "$(dirname "$(tdx open work)")")
+  completion <shell>  Print a completion script for bash, zsh, or fish
   help                Show this help
 
 TUI Controls:
@@ -213,6 +342,74 @@ TUI Controls:
 	fmt.Println(help)
 }
 
+// noColorFlag turns colors off like the NO_COLOR environment variable
+const noColorFlag = "--no-color"
+
+// setupColor turns colors off when NO_COLOR is set to anything but "" (see
+// no-color.org) or --no-color is passed, for plain output in logs, CI and
+// screen readers
+func setupColor(config *UserConfig, args []string, getenv func(string) string) {
+	config.NoColor = getenv("NO_COLOR") != "" || slices.Contains(args, noColorFlag)
+	tui.NoColor = config.NoColor
+	if config.NoColor {
+		// Also drops the attributes of styles the TUI makes itself, like reversed text
+		lipgloss.SetColorProfile(termenv.Ascii)
+	}
+}
+
+// tuiStyleFuncs returns the TUI render functions for styles
+func tuiStyleFuncs(styles *Styles) *tui.StyleFuncsType {
+	return &tui.StyleFuncsType{
+		Magenta:        func(s string) string { return styles.Important.Render(s) },
+		Cyan:           func(s string) string { return styles.Accent.Render(s) },
+		Dim:            func(s string) string { return styles.Dim.Render(s) },
+		Green:          func(s string) string { return styles.Success.Render(s) },
+		Yellow:         func(s string) string { return styles.Warning.Render(s) },
+		Code:           func(s string) string { return styles.Code.Render(s) },
+		Tag:            func(s string) string { return styles.Tag.Render(s) },
+		Context:        func(s string) string { return styles.Context.Render(s) },
+		Project:        func(s string) string { return styles.Project.Render(s) },
+		PriorityHigh:   func(s string) string { return styles.PriorityHigh.Render(s) },
+		PriorityMedium: func(s string) string { return styles.PriorityMedium.Render(s) },
+		PriorityLow:    func(s string) string { return styles.PriorityLow.Render(s) },
+		Priorities:     priorityLevelFuncs(styles.PriorityLevels),
+		DueUrgent:      func(s string) string { return styles.DueUrgent.Render(s) },
+		DueSoon:        func(s string) string { return styles.DueSoon.Render(s) },
+		DueFuture:      func(s string) string { return styles.DueFuture.Render(s) },
+		Time:           func(s string) string { return styles.Time.Render(s) },
+	}
+}
+
+// hyperlinksEnabled resolves the [display] hyperlinks setting for this terminal
+func hyperlinksEnabled(mode string) bool {
+	switch mode {
+	case "always":
+		return true
+	case "never":
+		return false
+	default:
+		return util.SupportsHyperlinks(os.Getenv)
+	}
+}
+
+// isTerminal reports whether f is attached to a terminal
+func isTerminal(f *os.File) bool {
+	stat, err := f.Stat()
+	return err == nil && stat.Mode()&os.ModeCharDevice != 0
+}
+
+func handleInteractiveEdit(arg string, filePath string, readOnly bool, showHeadings bool, maxVisible int) {
+	idx, err := strconv.Atoi(arg)
+	if err != nil || idx < 1 {
+		fmt.Println("Error: invalid index")
+		os.Exit(1)
+	}
+	if err := tui.RunEdit(filePath, idx-1, readOnly, showHeadings, maxVisible); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
 func handleLastCommand(readOnly bool, showHeadings bool, maxVisible int) {
 	// Load recent files
 	recentFiles, err := config.LoadRecentFiles()
@@ -250,24 +447,41 @@ func handleRecentCommand(args []string, readOnly bool, showHeadings bool, maxVis
 		os.Exit(1)
 	}
 
+	// Sort in the configured order (score, recent, alpha or frequency)
+	recentFiles.SortBy(config.RecentSort)
+
+	if len(args) > 0 && args[0] == "--json" {
+		if err := writeRecentJSON(os.Stdout, recentFiles.Files); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	if len(recentFiles.Files) == 0 {
 		fmt.Println("No recent files")
 		return
 	}
 
-	// Sort by score (recency * frequency)
-	recentFiles.SortByScore()
-
-	// If numeric argument, open that file
 	if len(args) > 0 {
-		index, err := strconv.Atoi(args[0])
-		if err != nil || index < 1 || index > len(recentFiles.Files) {
-			fmt.Printf("Error: invalid file number. Use 1-%d\n", len(recentFiles.Files))
-			os.Exit(1)
+		var filePath string
+		if index, err := strconv.Atoi(args[0]); err == nil {
+			// Numeric argument opens that file (1-indexed)
+			if index < 1 || index > len(recentFiles.Files) {
+				fmt.Printf("Error: invalid file number. Use 1-%d\n", len(recentFiles.Files))
+				os.Exit(1)
+			}
+			filePath = recentFiles.Files[index-1].Path
+		} else {
+			// Anything else is matched against the paths
+			file, ok := matchRecentFile(recentFiles.Files, args[0])
+			if !ok {
+				fmt.Printf("Error: no recent file matches %q\n", args[0])
+				os.Exit(1)
+			}
+			filePath = file.Path
 		}
 
-		// Open the selected file (1-indexed)
-		filePath := recentFiles.Files[index-1].Path
 		tui.Run(filePath, readOnly, showHeadings, maxVisible)
 		return
 	}
@@ -275,24 +489,126 @@ func handleRecentCommand(args []string, readOnly bool, showHeadings bool, maxVis
 	// No args - list all recent files
 	fmt.Println("Recent files:")
 	for i, file := range recentFiles.Files {
-		// Show relative path if in home directory
-		displayPath := file.Path
-		if home, err := os.UserHomeDir(); err == nil {
-			if rel, err := filepath.Rel(home, file.Path); err == nil && !strings.HasPrefix(rel, "..") {
-				displayPath = "~/" + rel
-			}
-		}
-
 		fmt.Printf("  %d. %s (accessed %d times, last: %s)\n",
 			i+1,
-			displayPath,
+			recentDisplayPath(file.Path),
 			file.AccessCount,
-			file.LastAccessed.Format("2006-01-02 15:04"))
+			formatAccessTime(file.LastAccessed, markdown.DateFormat))
+	}
+	fmt.Println("\nUse 'tdx recent <number>' or 'tdx recent <name>' to open a file")
+}
+
+// formatAccessTime formats when a recent file was last opened. Named date
+// formats other than relative get the time of day appended; a custom layout is
+// used as is.
+func formatAccessTime(t time.Time, format string) string {
+	if markdown.IsDatePreset(format) && format != markdown.DateFormatRelative {
+		return markdown.FormatDate(t, format) + t.Format(" 15:04")
+	}
+	return markdown.FormatDate(t, format)
+}
+
+// handleOpenCommand prints the absolute path of the recent file best matching
+// the query, without launching the TUI. Errors go to stderr so that only the
+// path ends up in command substitutions.
+func handleOpenCommand(args []string) {
+	if len(args) != 1 {
+		fmt.Fprintln(os.Stderr, "Usage: tdx open <name>")
+		os.Exit(1)
+	}
+
+	recentFiles, err := config.LoadRecentFiles()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading recent files: %v\n", err)
+		os.Exit(1)
+	}
+
+	file, ok := openRecentFile(recentFiles.Files, args[0])
+	if !ok {
+		fmt.Fprintf(os.Stderr, "Error: no recent file matches %q\n", args[0])
+		os.Exit(1)
+	}
+	fmt.Println(file.Path)
+}
+
+// openRecentFile returns the recent file best matching query, preferring the
+// more often opened file when several match equally well
+func openRecentFile(files []config.RecentFile, query string) (config.RecentFile, bool) {
+	byFrequency := &config.RecentFiles{Files: slices.Clone(files)}
+	byFrequency.SortBy(config.RecentSortFrequency)
+	return matchRecentFile(byFrequency.Files, query)
+}
+
+// recentJSONEntry is one file in the output of 'tdx recent --json'
+type recentJSONEntry struct {
+	Path         string    `json:"path"`
+	AccessCount  int       `json:"access_count"`
+	LastAccessed time.Time `json:"last_accessed"`
+}
+
+// writeRecentJSON writes the recent files as a JSON array, in the order given
+func writeRecentJSON(w io.Writer, files []config.RecentFile) error {
+	entries := make([]recentJSONEntry, 0, len(files))
+	for _, file := range files {
+		entries = append(entries, recentJSONEntry{
+			Path:         file.Path,
+			AccessCount:  file.AccessCount,
+			LastAccessed: file.LastAccessed,
+		})
+	}
+
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(w, string(data))
+	return err
+}
+
+// matchRecentFile returns the recent file whose path best matches query.
+// Paths are matched as displayed (relative to ~), so the home directory
+// doesn't match every query. Ties go to the earlier file in the list.
+func matchRecentFile(files []config.RecentFile, query string) (config.RecentFile, bool) {
+	query = util.FoldText(query)
+	best, bestScore := -1, 0
+	for i, file := range files {
+		if score := util.FuzzyScore(query, util.FoldText(recentDisplayPath(file.Path))); score > bestScore {
+			best, bestScore = i, score
+		}
+	}
+	if best < 0 {
+		return config.RecentFile{}, false
+	}
+	return files[best], true
+}
+
+// recentDisplayPath shows paths under the home directory relative to ~
+func recentDisplayPath(path string) string {
+	if home, err := os.UserHomeDir(); err == nil {
+		if rel, err := filepath.Rel(home, path); err == nil && !strings.HasPrefix(rel, "..") {
+			return "~/" + rel
+		}
+	}
+	return path
+}
+
+// fileEnvVar names the environment variable that selects the todo file
+const fileEnvVar = "TDX_FILE"
+
+// chooseFilePath picks the todo file by precedence: the --file flag, then
+// $TDX_FILE, then a positional .md argument, then the configured default
+func chooseFilePath(flag, env, positional, configDefault string) string {
+	for _, path := range []string{flag, env, positional} {
+		if path != "" {
+			return path
+		}
 	}
-	fmt.Println("\nUse 'tdx recent <number>' to open a file")
+	return configDefault
 }
 
-// resolveFilePath expands ~ to home directory and resolves relative paths to absolute
+// resolveFilePath expands ~ to home directory and resolves relative paths to absolute.
+// Symlinks are kept so the path shows as the user gave it; writes and recent
+// files resolve them.
 func resolveFilePath(filePath string) string {
 	// Expand ~ to home directory
 	if strings.HasPrefix(filePath, "~/") {
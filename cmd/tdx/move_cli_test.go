@@ -0,0 +1,84 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCLI_MoveUp(t *testing.T) {
+	file := writeSortFixture(t, "# Todos\n\n- [ ] First\n- [ ] Second\n- [ ] Third\n")
+
+	output := runCLI(t, file, "move", "3", "1")
+	if !strings.Contains(output, "Moved: Third (3 → 1)") {
+		t.Errorf("Expected confirmation, got: %s", output)
+	}
+	if !strings.Contains(output, "1. [ ] Third") || !strings.Contains(output, "3. [ ] Second") {
+		t.Errorf("Expected the new order in the output, got: %s", output)
+	}
+
+	assertTodoOrder(t, getTodos(t, file), "Third", "First", "Second")
+}
+
+func TestCLI_MoveDown(t *testing.T) {
+	file := writeSortFixture(t, "# Todos\n\n- [ ] First\n- [x] Second\n- [ ] Third\n")
+
+	runCLI(t, file, "move", "1", "2")
+
+	assertTodoOrder(t, getTodos(t, file), "Second", "First", "Third")
+	if !strings.Contains(readTestFile(t, file), "- [x] Second") {
+		t.Error("Expected the checked state to be kept")
+	}
+}
+
+func TestCLI_MoveSamePosition(t *testing.T) {
+	content := "# Todos\n\n- [ ] First\n- [ ] Second\n"
+	file := writeSortFixture(t, content)
+
+	output := runCLI(t, file, "move", "2", "2")
+	if !strings.Contains(output, "Already at 2") {
+		t.Errorf("Expected no-op message, got: %s", output)
+	}
+	if readTestFile(t, file) != content {
+		t.Errorf("File changed on a no-op move:\n%s", readTestFile(t, file))
+	}
+}
+
+func TestCLI_MoveInvalidIndex(t *testing.T) {
+	content := "# Todos\n\n- [ ] First\n- [ ] Second\n"
+	file := writeSortFixture(t, content)
+
+	for _, args := range [][]string{{"5", "1"}, {"1", "0"}, {"x", "1"}, {"1"}} {
+		output := runCLI(t, file, append([]string{"move"}, args...)...)
+		if !strings.Contains(output, "Error:") {
+			t.Errorf("move %v: expected an error, got: %s", args, output)
+		}
+		if readTestFile(t, file) != content {
+			t.Errorf("move %v changed the file:\n%s", args, readTestFile(t, file))
+		}
+	}
+}
+
+func TestCLI_MovePastNestedTodosReportsNewPosition(t *testing.T) {
+	file := writeSortFixture(t, "# Todos\n\n- [ ] A\n- [ ] B\n- [ ] C\n  - [ ] C1\n  - [ ] C2\n- [ ] D\n")
+
+	output := runCLI(t, file, "move", "1", "3")
+	if !strings.Contains(output, "Moved: A (1 → 5)") {
+		t.Errorf("Expected the position A landed at after C's nested todos, got: %s", output)
+	}
+	if !strings.Contains(output, "5. [ ] A") {
+		t.Errorf("Expected A listed at 5, got: %s", output)
+	}
+}
+
+func TestCLI_MoveIntoOwnNestedTodos(t *testing.T) {
+	content := "# Todos\n\n- [ ] A\n  - [ ] A1\n- [ ] B\n"
+	file := writeSortFixture(t, content)
+
+	output := runCLI(t, file, "move", "1", "2")
+	if !strings.Contains(output, "cannot move a todo into its own nested todos") {
+		t.Errorf("Expected an error, got: %s", output)
+	}
+	if got := readTestFile(t, file); got != content {
+		t.Errorf("File changed:\n%s", got)
+	}
+}
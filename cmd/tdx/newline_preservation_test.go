@@ -338,3 +338,32 @@ This has *italic* and **bold** text.
 		t.Error("Lost bold formatting")
 	}
 }
+
+// TestNewlinePreservation_ProseAndURLsVerbatim tests that prose, bare URLs and HTML survive a toggle byte for byte
+func TestNewlinePreservation_ProseAndURLsVerbatim(t *testing.T) {
+	file := tempTestFile(t)
+
+	initial := `# Todos
+
+- [ ] Review https://github.com/niklas-heer/tdx/pull/1
+
+Background: <https://example.com/spec> and <b>inline html</b>.
+Second line of the same paragraph.
+
+<details>
+<summary>More</summary>
+Hidden text
+</details>
+
+- [ ] Second task
+`
+
+	_ = os.WriteFile(file, []byte(initial), 0644)
+
+	runCLI(t, file, "toggle", "1")
+
+	expected := strings.Replace(initial, "- [ ] Review", "- [x] Review", 1)
+	if result := readTestFile(t, file); result != expected {
+		t.Errorf("Content not preserved verbatim.\nExpected:\n%s\nGot:\n%s", expected, result)
+	}
+}
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+	"github.com/niklas-heer/tdx/internal/tui"
+)
+
+const noColorFixture = `# Todos
+
+- [ ] Write **report** #work !p1 @due(2020-01-01) est:2h
+- [ ] Read [the docs](https://example.com)
+- [x] Buy milk
+`
+
+// withTerminalColors makes lipgloss render colors as on a terminal and restores
+// the color settings after the test
+func withTerminalColors(t *testing.T) {
+	t.Helper()
+	oldProfile := lipgloss.ColorProfile()
+	oldNoColor, oldHyperlinks, oldStyles := tui.NoColor, tui.Hyperlinks, tui.StyleFuncs
+	lipgloss.SetColorProfile(termenv.ANSI)
+	t.Cleanup(func() {
+		lipgloss.SetColorProfile(oldProfile)
+		tui.NoColor, tui.Hyperlinks, tui.StyleFuncs = oldNoColor, oldHyperlinks, oldStyles
+	})
+}
+
+// noColorEnv returns a getenv with NO_COLOR set to value
+func noColorEnv(value string) func(string) string {
+	return func(key string) string {
+		if key == "NO_COLOR" {
+			return value
+		}
+		return ""
+	}
+}
+
+// renderView sets up the TUI like main does and returns its view of content
+// after input
+func renderView(t *testing.T, args []string, getenv func(string) string, content, input string) string {
+	t.Helper()
+	config := DefaultConfig()
+	setupColor(config, args, getenv)
+	tui.StyleFuncs = tuiStyleFuncs(NewStyles(config))
+	tui.Hyperlinks = !config.NoColor && hyperlinksEnabled("always")
+	return runPiped(t, writeSortFixture(t, content), input)
+}
+
+func TestSetupColor(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		noColor string
+		want    bool
+	}{
+		{"default", nil, "", false},
+		{"NO_COLOR set", nil, "1", true},
+		{"NO_COLOR set to anything", []string{"list"}, "false", true},
+		{"flag", []string{"--no-color", "list"}, "", true},
+		{"flag after the command", []string{"list", "--no-color"}, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			withTerminalColors(t)
+			config := DefaultConfig()
+			setupColor(config, tt.args, noColorEnv(tt.noColor))
+			if config.NoColor != tt.want || tui.NoColor != tt.want {
+				t.Errorf("NoColor = %v (tui %v), want %v", config.NoColor, tui.NoColor, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewStyles_NoColor(t *testing.T) {
+	withTerminalColors(t)
+	config := DefaultConfig()
+	config.Priority.Colors = []string{"#ff0000", "#00ff00"}
+
+	colored := NewStyles(config)
+	if !strings.Contains(colored.Accent.Render("x"), "\x1b[") {
+		t.Fatal("Expected colors without NoColor")
+	}
+
+	config.NoColor = true
+	styles := NewStyles(config)
+	rendered := []string{
+		styles.Base.Render("x"), styles.Dim.Render("x"), styles.Accent.Render("x"),
+		styles.Success.Render("x"), styles.Warning.Render("x"), styles.Important.Render("x"),
+		styles.Error.Render("x"), styles.Code.Render("x"), styles.Tag.Render("x"),
+		styles.Context.Render("x"), styles.Project.Render("x"), styles.PriorityHigh.Render("x"),
+		styles.PriorityMedium.Render("x"), styles.PriorityLow.Render("x"), styles.DueUrgent.Render("x"),
+		styles.DueSoon.Render("x"), styles.DueFuture.Render("x"), styles.Time.Render("x"),
+	}
+	for _, level := range styles.PriorityLevels {
+		rendered = append(rendered, level.Render("x"))
+	}
+	for i, s := range rendered {
+		if s != "x" {
+			t.Errorf("Style %d rendered %q, want plain text", i, s)
+		}
+	}
+	if len(styles.PriorityLevels) != 2 {
+		t.Errorf("Expected a plain style per priority level, got %d", len(styles.PriorityLevels))
+	}
+}
+
+func TestNoColor_ViewHasNoEscapes(t *testing.T) {
+	withTerminalColors(t)
+
+	// On a terminal the view is colored and links are OSC 8 hyperlinks
+	colored := renderView(t, nil, noColorEnv(""), noColorFixture, "")
+	if !strings.Contains(colored, "\x1b[") || !strings.Contains(colored, "\x1b]8;;") {
+		t.Fatalf("Expected colors and hyperlinks without NO_COLOR:\n%q", colored)
+	}
+
+	for _, input := range []string{"", "/milk", ":sort", "?"} {
+		view := renderView(t, nil, noColorEnv("1"), noColorFixture, input)
+		if strings.Contains(view, "\x1b") {
+			t.Errorf("Expected no escape sequences after %q with NO_COLOR:\n%q", input, view)
+		}
+		if input == "" && !strings.Contains(view, "the docs (https://example.com)") {
+			t.Errorf("Expected the link as text (url):\n%s", view)
+		}
+	}
+
+	view := renderView(t, []string{"--no-color"}, noColorEnv(""), noColorFixture, ":sort")
+	if strings.Contains(view, "\x1b") {
+		t.Errorf("Expected no escape sequences with --no-color:\n%q", view)
+	}
+	// The input cursor stays visible without reversed text
+	if !strings.Contains(view, ":sort█") {
+		t.Errorf("Expected a block cursor in the command line:\n%s", view)
+	}
+}
+
+func TestCLI_NoColor(t *testing.T) {
+	run := func(env []string, args ...string) string {
+		t.Helper()
+		configHome := t.TempDir()
+		_ = os.MkdirAll(configHome+"/tdx", 0755)
+		_ = os.WriteFile(configHome+"/tdx/config.toml", []byte("[display]\nhyperlinks = \"always\"\n"), 0644)
+		cmd := exec.Command(testBinary, append(args, writeSortFixture(t, noColorFixture))...)
+		cmd.Env = append(os.Environ(), append(env, "XDG_CONFIG_HOME="+configHome)...)
+		cmd.Stdin = strings.NewReader("")
+		out, _ := cmd.CombinedOutput()
+		return string(out)
+	}
+
+	if output := run([]string{"NO_COLOR="}); !strings.Contains(output, "\x1b]8;;") {
+		t.Fatalf("Expected hyperlinks with hyperlinks = always:\n%q", output)
+	}
+	for _, output := range []string{run([]string{"NO_COLOR=1"}), run([]string{"NO_COLOR="}, "--no-color")} {
+		if strings.Contains(output, "\x1b") {
+			t.Errorf("Expected plain output:\n%q", output)
+		}
+		if !strings.Contains(output, "Write report") {
+			t.Errorf("Expected the todos:\n%s", output)
+		}
+	}
+}
@@ -465,8 +465,8 @@ func TestTUI_PriorityFilterMode(t *testing.T) {
 	runCLI(t, file, "add", "Task B !p2")
 	runCLI(t, file, "add", "Task C !p3")
 
-	// Press 'p' to enter priority filter mode
-	output := runPiped(t, file, "p")
+	// Press '!' to enter priority filter mode
+	output := runPiped(t, file, "!")
 
 	// Should show priority filter overlay with priority options
 	if !strings.Contains(output, "!p1") || !strings.Contains(output, "!p2") {
@@ -488,8 +488,8 @@ func TestTUI_PriorityFilterSelect(t *testing.T) {
 	runCLI(t, file, "add", "Task C !p3")
 	runCLI(t, file, "add", "Task D") // no priority
 
-	// Press 'p' to enter priority filter mode, space to select p1
-	output := runPiped(t, file, "p ")
+	// Press '!' to enter priority filter mode, space to select p1
+	output := runPiped(t, file, "! ")
 
 	// Output should show filtered indicator
 	if !strings.Contains(output, "p1") {
@@ -521,8 +521,8 @@ func TestTUI_PriorityFilterNavigate(t *testing.T) {
 	runCLI(t, file, "add", "Task B !p2")
 	runCLI(t, file, "add", "Task C !p3")
 
-	// Press 'p' to enter priority filter mode, j to move down, space to select p2
-	output := runPiped(t, file, "pj ")
+	// Press '!' to enter priority filter mode, j to move down, space to select p2
+	output := runPiped(t, file, "!j ")
 
 	// Only Task B (p2) should be visible
 	if !strings.Contains(output, "Task B") {
@@ -547,7 +547,7 @@ func TestTUI_PriorityFilterClear(t *testing.T) {
 	runCLI(t, file, "add", "Task C !p3")
 
 	// Select p1, then clear with 'c'
-	output := runPiped(t, file, "p pc")
+	output := runPiped(t, file, "! !c")
 
 	// After clearing, all tasks should be visible
 	if !strings.Contains(output, "Task A") {
@@ -569,8 +569,8 @@ func TestTUI_PriorityFilterNoPriorities(t *testing.T) {
 	runCLI(t, file, "add", "Task B")
 	runCLI(t, file, "add", "Task C")
 
-	// Press 'p' - should enter priority filter mode and show helpful message
-	output := runPiped(t, file, "p")
+	// Press '!' - should enter priority filter mode and show helpful message
+	output := runPiped(t, file, "!")
 
 	// Should show empty state message
 	if !strings.Contains(output, "No priorities available") {
@@ -644,7 +644,7 @@ func TestTUI_PriorityFilterMultipleSelect(t *testing.T) {
 	runCLI(t, file, "add", "Task C !p3")
 
 	// Select p1, then enter priority mode again and select p2
-	output := runPiped(t, file, "p pj ")
+	output := runPiped(t, file, "! !j ")
 
 	// Both p1 and p2 tasks should be visible
 	if !strings.Contains(output, "Task A") {
@@ -671,7 +671,7 @@ func TestTUI_PriorityFilterWithTagFilter(t *testing.T) {
 
 	// Select priority p1, then select tag #backend (first alphabetically)
 	// This should show only Task B (p1 + #backend)
-	output := runPiped(t, file, "p t ")
+	output := runPiped(t, file, "! t ")
 
 	// Task B should be visible (has both p1 and #backend - first tag alphabetically)
 	if !strings.Contains(output, "Task B") {
@@ -688,7 +688,7 @@ func TestTUI_PriorityFilterOverlayRender(t *testing.T) {
 	runCLI(t, file, "add", "Task C !p3")
 
 	// Enter priority filter mode and check overlay content
-	output := runPiped(t, file, "p")
+	output := runPiped(t, file, "!")
 
 	// Should show priority options in overlay
 	if !strings.Contains(output, "!p1") {
@@ -719,7 +719,7 @@ func TestTUI_EmptyFilterMessage(t *testing.T) {
 
 	// Select p2 priority filter and enable filter-done
 	// This should result in no visible todos (Task B is p2 but done, Task A is p1)
-	output := runPiped(t, file, "pj :filter-done\r")
+	output := runPiped(t, file, "!j :filter-done\r")
 
 	// Should show the empty filter message
 	if !strings.Contains(output, "No todos match current filters") {
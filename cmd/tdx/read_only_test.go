@@ -0,0 +1,72 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+const readOnlyFile = "---\nread-only: true\n---\n# Todos\n\n- [ ] Shared task\n- [ ] Other task\n"
+
+func TestCLI_ReadOnlyFrontmatterRefusesChanges(t *testing.T) {
+	commands := [][]string{
+		{"toggle", "1"},
+		{"edit", "1", "Changed"},
+		{"delete", "1"},
+		{"add", "New task"},
+		{"move", "2", "1"},
+		{"sort", "alpha"},
+		{"clean"},
+	}
+	for _, args := range commands {
+		t.Run(args[0], func(t *testing.T) {
+			file := writeSortFixture(t, readOnlyFile)
+
+			output, code := runCLIExitCode(t, file, args...)
+			if code != 3 {
+				t.Errorf("Exit code = %d, want 3", code)
+			}
+			if !strings.Contains(output, "is read-only") {
+				t.Errorf("Expected a read-only error, got: %s", output)
+			}
+			if got := readTestFile(t, file); got != readOnlyFile {
+				t.Errorf("File changed:\n%s", got)
+			}
+		})
+	}
+}
+
+func TestCLI_ReadOnlyFileCanBeRead(t *testing.T) {
+	file := writeSortFixture(t, readOnlyFile)
+
+	output, code := runCLIExitCode(t, file, "list")
+	if code != 0 || !strings.Contains(output, "Shared task") {
+		t.Errorf("list: exit code = %d, output = %s", code, output)
+	}
+
+	// A dry run doesn't write, so it is allowed
+	output, code = runCLIExitCode(t, file, "--dry-run", "toggle", "1")
+	if code != 0 || !strings.Contains(output, "- [x] Shared task") {
+		t.Errorf("dry run: exit code = %d, output = %s", code, output)
+	}
+	if got := readTestFile(t, file); got != readOnlyFile {
+		t.Errorf("Dry run changed the file:\n%s", got)
+	}
+}
+
+func TestCLI_ReadOnlyFlag(t *testing.T) {
+	file := writeSortFixture(t, "# Todos\n\n- [ ] Task\n")
+
+	output, code := runCLIExitCode(t, file, "--read-only", "toggle", "1")
+	if code != 3 || !strings.Contains(output, "read-only mode") {
+		t.Errorf("Exit code = %d, output = %s", code, output)
+	}
+	if got := readTestFile(t, file); got != "# Todos\n\n- [ ] Task\n" {
+		t.Errorf("File changed:\n%s", got)
+	}
+
+	// The file's own setting wins over the flag, as in the TUI
+	writable := writeSortFixture(t, "---\nread-only: false\n---\n# Todos\n\n- [ ] Task\n")
+	if output, code := runCLIExitCode(t, writable, "--read-only", "toggle", "1"); code != 0 {
+		t.Errorf("read-only: false: exit code = %d, output = %s", code, output)
+	}
+}
@@ -1,10 +1,14 @@
 package main
 
 import (
+	"bytes"
+	"encoding/json"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/niklas-heer/tdx/internal/config"
 )
@@ -236,3 +240,169 @@ func TestRecentFilesCursorResetOnChange(t *testing.T) {
 		t.Errorf("Expected cursor reset to first task after file change, got: %s", output)
 	}
 }
+
+func TestMatchRecentFile_PrefersBestMatch(t *testing.T) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		t.Skip("no home directory")
+	}
+	files := []config.RecentFile{
+		{Path: filepath.Join(home, "personal.md")},
+		{Path: filepath.Join(home, "work", "todo.md")},
+	}
+
+	file, ok := matchRecentFile(files, "wrk")
+	if !ok || file.Path != files[1].Path {
+		t.Errorf("matchRecentFile(wrk) = %q, %v; want %q", file.Path, ok, files[1].Path)
+	}
+
+	// Matching is case-insensitive
+	file, ok = matchRecentFile(files, "PERS")
+	if !ok || file.Path != files[0].Path {
+		t.Errorf("matchRecentFile(PERS) = %q, %v; want %q", file.Path, ok, files[0].Path)
+	}
+
+	if _, ok := matchRecentFile(files, "xyz"); ok {
+		t.Error("matchRecentFile(xyz) should not match")
+	}
+}
+
+func TestMatchRecentFile_TieKeepsListOrder(t *testing.T) {
+	files := []config.RecentFile{
+		{Path: "/tmp/a/todo.md"},
+		{Path: "/tmp/b/todo.md"},
+	}
+
+	file, ok := matchRecentFile(files, "todo")
+	if !ok || file.Path != "/tmp/a/todo.md" {
+		t.Errorf("matchRecentFile(todo) = %q, want the higher ranked file", file.Path)
+	}
+}
+
+func TestWriteRecentJSON(t *testing.T) {
+	accessed := time.Date(2025, 3, 4, 10, 30, 0, 0, time.UTC)
+	files := []config.RecentFile{
+		{Path: "/tmp/work.md", AccessCount: 3, LastAccessed: accessed, LastCursorPos: 5},
+	}
+
+	var buf bytes.Buffer
+	if err := writeRecentJSON(&buf, files); err != nil {
+		t.Fatal(err)
+	}
+
+	var entries []map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &entries); err != nil {
+		t.Fatalf("Invalid JSON: %v\n%s", err, buf.String())
+	}
+	if len(entries) != 1 {
+		t.Fatalf("Expected 1 entry, got %d", len(entries))
+	}
+	entry := entries[0]
+	if len(entry) != 3 {
+		t.Errorf("Expected only path, access_count and last_accessed, got %v", entry)
+	}
+	if entry["path"] != "/tmp/work.md" || entry["access_count"] != float64(3) || entry["last_accessed"] != "2025-03-04T10:30:00Z" {
+		t.Errorf("Unexpected entry: %v", entry)
+	}
+}
+
+func TestWriteRecentJSON_EmptyList(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeRecentJSON(&buf, nil); err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.TrimSpace(buf.String()); got != "[]" {
+		t.Errorf("Expected empty array, got %q", got)
+	}
+}
+
+func TestOpenRecentFile_TiePrefersFrequent(t *testing.T) {
+	now := time.Now()
+	files := []config.RecentFile{
+		{Path: "/tmp/a/todo.md", AccessCount: 1, LastAccessed: now},
+		{Path: "/tmp/b/todo.md", AccessCount: 5, LastAccessed: now.Add(-time.Hour)},
+	}
+
+	file, ok := openRecentFile(files, "todo")
+	if !ok || file.Path != "/tmp/b/todo.md" {
+		t.Errorf("openRecentFile(todo) = %q, want the more often opened file", file.Path)
+	}
+	if files[0].Path != "/tmp/a/todo.md" {
+		t.Error("openRecentFile should not reorder the given files")
+	}
+}
+
+// runOpen runs 'tdx open' with the recent files of configHome and returns stdout, stderr and the exit code
+func runOpen(t *testing.T, configHome string, args ...string) (string, string, int) {
+	t.Helper()
+	cmd := exec.Command(testBinary, append([]string{"open"}, args...)...)
+	cmd.Env = append(os.Environ(), "XDG_CONFIG_HOME="+configHome)
+	var stdout, stderr strings.Builder
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	err := cmd.Run()
+	code := 0
+	if exitErr, ok := err.(*exec.ExitError); ok {
+		code = exitErr.ExitCode()
+	} else if err != nil {
+		t.Fatal(err)
+	}
+	return stdout.String(), stderr.String(), code
+}
+
+func TestCLI_Open(t *testing.T) {
+	configHome := t.TempDir()
+	config.SetConfigDirForTesting(filepath.Join(configHome, "tdx"))
+	defer config.ResetConfigDirForTesting()
+
+	dir := t.TempDir()
+	work := filepath.Join(dir, "work", "todo.md")
+	home := filepath.Join(dir, "home", "todo.md")
+	for _, path := range []string{work, home} {
+		_ = os.MkdirAll(filepath.Dir(path), 0755)
+		if err := os.WriteFile(path, []byte("- [ ] Task\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// home is opened more often, work most recently
+	for _, path := range []string{home, home, home, work} {
+		if err := config.SaveRecentFile(path, 0); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	stdout, _, code := runOpen(t, configHome, "wrk")
+	if code != 0 || stdout != work+"\n" {
+		t.Errorf("open wrk = %q (exit %d), want %q", stdout, code, work)
+	}
+
+	// Both paths match "todo" equally well
+	stdout, _, code = runOpen(t, configHome, "todo")
+	if code != 0 || stdout != home+"\n" {
+		t.Errorf("open todo = %q (exit %d), want the more often opened %q", stdout, code, home)
+	}
+
+	stdout, stderr, code := runOpen(t, configHome, "xyz")
+	if code != 1 || stdout != "" || !strings.Contains(stderr, "no recent file matches") {
+		t.Errorf("open xyz = %q, %q (exit %d), want no output and exit 1", stdout, stderr, code)
+	}
+}
+
+func TestFormatAccessTime(t *testing.T) {
+	accessed := time.Date(2025, 1, 15, 14, 5, 0, 0, time.Local)
+
+	tests := map[string]string{
+		"iso":         "2025-01-15 14:05",
+		"us":          "01/15/2025 14:05",
+		"eu":          "15.01.2025 14:05",
+		"Jan 2 15:04": "Jan 15 14:05",
+	}
+	for format, want := range tests {
+		if got := formatAccessTime(accessed, format); got != want {
+			t.Errorf("formatAccessTime(%q) = %q, want %q", format, got, want)
+		}
+	}
+
+	if got := formatAccessTime(time.Now(), "relative"); got != "today" {
+		t.Errorf("formatAccessTime(relative) = %q, want today", got)
+	}
+}
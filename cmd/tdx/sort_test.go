@@ -0,0 +1,116 @@
+package main
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+// writeSortFixture writes content to a temp file for sort tests
+func writeSortFixture(t *testing.T, content string) string {
+	file := tempTestFile(t)
+	if err := os.WriteFile(file, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return file
+}
+
+// assertTodoOrder checks that todos appear in the expected order by text
+func assertTodoOrder(t *testing.T, todos []string, expected ...string) {
+	t.Helper()
+	if len(todos) != len(expected) {
+		t.Fatalf("Expected %d todos, got %d: %v", len(expected), len(todos), todos)
+	}
+	for i, want := range expected {
+		if !strings.Contains(todos[i], want) {
+			t.Errorf("Position %d: expected %q, got: %s", i, want, todos[i])
+		}
+	}
+}
+
+func TestCLI_SortPriority(t *testing.T) {
+	file := writeSortFixture(t, "- [ ] None one\n- [ ] Low !p3\n- [ ] High !p1\n- [ ] None two\n- [ ] Also high !p1\n")
+
+	output := runCLI(t, file, "sort", "priority")
+	if !strings.Contains(output, "Sorted by priority") {
+		t.Errorf("Expected confirmation, got: %s", output)
+	}
+
+	// Equal priorities keep their original relative order
+	assertTodoOrder(t, getTodos(t, file), "High", "Also high", "Low", "None one", "None two")
+}
+
+func TestCLI_SortDue(t *testing.T) {
+	file := writeSortFixture(t, "- [ ] No date\n- [ ] Later @due(2025-03-01)\n- [ ] Sooner @due(2025-01-15)\n- [ ] Also sooner @due(2025-01-15)\n")
+
+	runCLI(t, file, "sort", "due")
+
+	assertTodoOrder(t, getTodos(t, file), "Sooner", "Also sooner", "Later", "No date")
+}
+
+func TestCLI_SortAlpha(t *testing.T) {
+	file := writeSortFixture(t, "- [ ] cherry\n- [ ] Banana\n- [ ] apple\n- [ ] banana\n")
+
+	runCLI(t, file, "sort", "alpha")
+
+	// Case-insensitive; "Banana" stays ahead of "banana"
+	assertTodoOrder(t, getTodos(t, file), "apple", "Banana", "banana", "cherry")
+}
+
+func TestCLI_SortDone(t *testing.T) {
+	file := writeSortFixture(t, "- [x] Done A\n- [ ] Open A\n- [x] Done B\n- [ ] Open B\n")
+
+	runCLI(t, file, "sort", "done")
+
+	assertTodoOrder(t, getTodos(t, file), "Open A", "Open B", "Done A", "Done B")
+}
+
+func TestCLI_SortRespectsHeadings(t *testing.T) {
+	file := writeSortFixture(t, "# One\n\n- [ ] B\n- [ ] A\n\n# Two\n\n- [ ] D\n- [ ] C\n")
+
+	runCLI(t, file, "sort", "alpha")
+
+	content := readTestFile(t, file)
+	expected := "# One\n\n- [ ] A\n- [ ] B\n\n# Two\n\n- [ ] C\n- [ ] D\n"
+	if content != expected {
+		t.Errorf("Expected todos sorted within sections.\nExpected:\n%s\nGot:\n%s", expected, content)
+	}
+}
+
+func TestCLI_SortDryRun(t *testing.T) {
+	original := "- [ ] Low !p3\n- [ ] High !p1\n"
+	file := writeSortFixture(t, original)
+
+	output := runCLI(t, file, "sort", "priority", "--dry-run")
+
+	if readTestFile(t, file) != original {
+		t.Errorf("Dry run should not modify the file, got:\n%s", readTestFile(t, file))
+	}
+	highIdx := strings.Index(output, "High !p1")
+	lowIdx := strings.Index(output, "Low !p3")
+	if highIdx == -1 || lowIdx == -1 || highIdx > lowIdx {
+		t.Errorf("Expected sorted file on stdout, got:\n%s", output)
+	}
+}
+
+func TestCLI_SortInvalidKey(t *testing.T) {
+	original := "- [ ] B\n- [ ] A\n"
+	file := writeSortFixture(t, original)
+
+	output := runCLI(t, file, "sort", "size")
+	if !strings.Contains(output, "unknown sort key") {
+		t.Errorf("Expected unknown sort key error, got: %s", output)
+	}
+	if readTestFile(t, file) != original {
+		t.Errorf("Invalid key should not modify the file")
+	}
+}
+
+func TestCLI_SortMissingKey(t *testing.T) {
+	file := writeSortFixture(t, "- [ ] A\n")
+
+	output := runCLI(t, file, "sort")
+	if !strings.Contains(output, "sort requires a key") {
+		t.Errorf("Expected missing key error, got: %s", output)
+	}
+}
@@ -194,6 +194,10 @@ func TestTUI_SubtaskMoveWithNesting(t *testing.T) {
 	if !foundParentB {
 		t.Errorf("Parent B not found in result:\n%s", result)
 	}
+	// The moved todo keeps its nested todos
+	if !strings.Contains(result, "- [ ] Parent A\n  - [ ] Child A1\n  - [ ] Child A2\n- [ ] Parent C") {
+		t.Errorf("Expected Parent A with its children after Parent B, got:\n%s", result)
+	}
 }
 
 // TestTUI_SubtaskMoveNestedChild tests moving a nested child task
@@ -295,7 +299,7 @@ func TestTUI_SubtaskPriorityFilter(t *testing.T) {
 	_ = os.WriteFile(file, []byte(content), 0644)
 
 	// Filter by p1 priority
-	output := runPiped(t, file, "p ")
+	output := runPiped(t, file, "! ")
 
 	// Only p1 tasks should be visible
 	if !strings.Contains(output, "Parent !p1") {
@@ -402,7 +406,7 @@ func TestTUI_SubtaskCombinedPriorityAndTagFilter(t *testing.T) {
 	_ = os.WriteFile(file, []byte(content), 0644)
 
 	// Filter by p1 priority, then by backend tag
-	output := runPiped(t, file, "p t ")
+	output := runPiped(t, file, "! t ")
 
 	// Only tasks with both p1 AND backend should be visible
 	if !strings.Contains(output, "Task A !p1 #backend") {
@@ -475,7 +479,7 @@ func TestTUI_SubtaskNavigationWithFilters(t *testing.T) {
 	_ = os.WriteFile(file, []byte(content), 0644)
 
 	// Filter by p1, then navigate down
-	output := runPiped(t, file, "p jj")
+	output := runPiped(t, file, "! jj")
 
 	// Should be on Child 2a (third visible p1 task)
 	// Cursor indicator should show 0 for current position
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/niklas-heer/tdx/internal/config"
+	"github.com/niklas-heer/tdx/internal/tui"
+)
+
+// symlinkedTodoFile creates a todo file and a symlink to it in another directory
+func symlinkedTodoFile(t *testing.T) (realPath, linkPath string) {
+	t.Helper()
+	dir := t.TempDir()
+	realPath = filepath.Join(dir, "notes", "todo.md")
+	linkPath = filepath.Join(dir, "project", "todo.md")
+	_ = os.MkdirAll(filepath.Dir(realPath), 0755)
+	_ = os.MkdirAll(filepath.Dir(linkPath), 0755)
+	if err := os.WriteFile(realPath, []byte("# Todos\n\n- [ ] Task\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(realPath, linkPath); err != nil {
+		t.Skipf("Cannot create symlinks: %v", err)
+	}
+	// The temp dir itself may sit behind a symlink (e.g. /tmp on macOS)
+	realPath, err := filepath.EvalSymlinks(realPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return realPath, linkPath
+}
+
+func TestResolveFilePath_KeepsSymlink(t *testing.T) {
+	_, linkPath := symlinkedTodoFile(t)
+
+	if got := resolveFilePath(linkPath); got != linkPath {
+		t.Errorf("resolveFilePath(%q) = %q, want the link path kept for display", linkPath, got)
+	}
+}
+
+func TestCLI_SymlinkedFileShowsLinkPath(t *testing.T) {
+	realPath, linkPath := symlinkedTodoFile(t)
+
+	output := runCLI(t, linkPath, "clean")
+
+	if !strings.Contains(output, linkPath) || strings.Contains(output, realPath) {
+		t.Errorf("Expected messages to name %q as given, got: %s", linkPath, output)
+	}
+}
+
+func TestResolveFilePath_MissingFileKeepsPath(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "new.md")
+	if got := resolveFilePath(path); got != path {
+		t.Errorf("resolveFilePath(%q) = %q, want it unchanged", path, got)
+	}
+}
+
+func TestCLI_SymlinkedFileWritesRealFile(t *testing.T) {
+	realPath, linkPath := symlinkedTodoFile(t)
+
+	runCLI(t, linkPath, "add", "Through the link")
+
+	if content := readTestFile(t, realPath); !strings.Contains(content, "Through the link") {
+		t.Errorf("Expected the real file to be updated, got:\n%s", content)
+	}
+	info, err := os.Lstat(linkPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode()&os.ModeSymlink == 0 {
+		t.Error("Expected the symlink to survive the write, it was replaced by a regular file")
+	}
+}
+
+func TestTUI_SymlinkedFileRecordsCanonicalPath(t *testing.T) {
+	config.SetConfigDirForTesting(t.TempDir())
+	defer config.ResetConfigDirForTesting()
+
+	realPath, linkPath := symlinkedTodoFile(t)
+
+	tui.RunPiped(resolveFilePath(linkPath), []byte("j"), false)
+
+	files, err := config.GetRecentFilesList()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 1 || files[0].Path != realPath {
+		var paths []string
+		for _, f := range files {
+			paths = append(paths, f.Path)
+		}
+		t.Errorf("Recent files = %q, want only %q", paths, realPath)
+	}
+}
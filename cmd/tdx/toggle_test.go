@@ -0,0 +1,160 @@
+package main
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/niklas-heer/tdx/internal/markdown"
+)
+
+const toggleFixture = "# Todos\n\n- [ ] One\n- [ ] Two\n- [x] Three\n- [ ] Four\n- [ ] Five\n"
+
+func assertChecked(t *testing.T, file string, want ...bool) {
+	t.Helper()
+	fm, err := markdown.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	todos := fm.Todos
+	if len(todos) != len(want) {
+		t.Fatalf("Expected %d todos, got %d", len(want), len(todos))
+	}
+	for i, todo := range todos {
+		if todo.Checked != want[i] {
+			t.Errorf("Todo %d (%s) checked = %v, want %v", i+1, todo.Text, todo.Checked, want[i])
+		}
+	}
+}
+
+func TestCLI_ToggleList(t *testing.T) {
+	file := writeSortFixture(t, toggleFixture)
+
+	output := runCLI(t, file, "toggle", "1", "3", "5")
+
+	assertChecked(t, file, true, false, false, false, true)
+	for _, text := range []string{"One", "Three", "Five"} {
+		if !strings.Contains(output, "Toggled: ") || !strings.Contains(output, text) {
+			t.Errorf("Expected summary line for %s, got: %s", text, output)
+		}
+	}
+	if lines := strings.Count(output, "Toggled:"); lines != 3 {
+		t.Errorf("Expected 3 summary lines, got %d: %s", lines, output)
+	}
+}
+
+func TestCLI_ToggleRange(t *testing.T) {
+	file := writeSortFixture(t, toggleFixture)
+
+	runCLI(t, file, "toggle", "2-4")
+
+	assertChecked(t, file, false, true, false, true, false)
+}
+
+func TestCLI_ToggleOverlappingIndicesOnce(t *testing.T) {
+	file := writeSortFixture(t, toggleFixture)
+
+	runCLI(t, file, "toggle", "1-2", "2", "1")
+
+	assertChecked(t, file, true, true, true, false, false)
+}
+
+func TestCLI_ToggleInvalidIndexChangesNothing(t *testing.T) {
+	file := writeSortFixture(t, toggleFixture)
+
+	output := runCLI(t, file, "toggle", "1", "9")
+	if !strings.Contains(output, "invalid index 9") {
+		t.Errorf("Expected invalid index error, got: %s", output)
+	}
+
+	content, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(content) != toggleFixture {
+		t.Errorf("File should be unchanged, got:\n%s", content)
+	}
+}
+
+func TestCLI_ToggleInvalidRange(t *testing.T) {
+	file := writeSortFixture(t, toggleFixture)
+
+	for _, arg := range []string{"4-2", "x", "2-"} {
+		output := runCLI(t, file, "toggle", arg)
+		if !strings.Contains(output, "Error:") {
+			t.Errorf("toggle %s: expected error, got: %s", arg, output)
+		}
+	}
+	assertChecked(t, file, false, false, true, false, false)
+}
+
+func TestCLI_InProgressListAndToggle(t *testing.T) {
+	file := writeSortFixture(t, "# Todos\n\n- [-] Doing\n- [ ] Open\n")
+
+	if output := runCLI(t, file, "list"); !strings.Contains(output, "1. [-] Doing") {
+		t.Errorf("Expected the in-progress checkbox in the list:\n%s", output)
+	}
+
+	runCLI(t, file, "toggle", "1")
+	if content := readTestFile(t, file); !strings.Contains(content, "- [x] Doing\n") {
+		t.Errorf("Toggling an in-progress todo should mark it done:\n%s", content)
+	}
+}
+
+func TestCLI_ToggleByText(t *testing.T) {
+	file := writeSortFixture(t, "# Todos\n\n- [ ] Write report\n- [ ] Daily Standup notes\n- [ ] Review PR\n")
+
+	output := runCLI(t, file, "toggle", "--text", "daily standup")
+	if !strings.Contains(output, "Daily Standup notes") {
+		t.Errorf("Expected the toggled todo in the output:\n%s", output)
+	}
+	content := readTestFile(t, file)
+	if !strings.Contains(content, "- [x] Daily Standup notes\n") || strings.Contains(content, "- [x] Write report") {
+		t.Errorf("Expected only the matching todo to be toggled:\n%s", content)
+	}
+}
+
+func TestCLI_ToggleByTextFirstOrAll(t *testing.T) {
+	content := "# Todos\n\n- [ ] Standup Monday\n- [ ] Lunch\n- [ ] standup Tuesday\n"
+
+	file := writeSortFixture(t, content)
+	runCLI(t, file, "toggle", "--text", "STANDUP")
+	got := readTestFile(t, file)
+	if !strings.Contains(got, "- [x] Standup Monday\n") || !strings.Contains(got, "- [ ] standup Tuesday\n") {
+		t.Errorf("Expected only the first match to be toggled:\n%s", got)
+	}
+
+	file = writeSortFixture(t, content)
+	runCLI(t, file, "toggle", "--text", "standup", "--all")
+	got = readTestFile(t, file)
+	if !strings.Contains(got, "- [x] Standup Monday\n") || !strings.Contains(got, "- [x] standup Tuesday\n") || !strings.Contains(got, "- [ ] Lunch\n") {
+		t.Errorf("Expected every match to be toggled with --all:\n%s", got)
+	}
+}
+
+func TestCLI_ToggleByTextNoMatch(t *testing.T) {
+	content := "# Todos\n\n- [ ] Write report\n"
+	file := writeSortFixture(t, content)
+
+	output, code := runCLIExitCode(t, file, "toggle", "--text", "standup")
+	if code != 1 || !strings.Contains(output, `no todo contains "standup"`) {
+		t.Errorf("Expected an error and exit 1, got %q (exit %d)", output, code)
+	}
+	if got := readTestFile(t, file); got != content {
+		t.Errorf("File should be unchanged:\n%s", got)
+	}
+}
+
+func TestCLI_ToggleByTextInvalidArgs(t *testing.T) {
+	file := writeSortFixture(t, "# Todos\n\n- [ ] Task\n")
+
+	for _, args := range [][]string{
+		{"toggle", "--all"},
+		{"toggle", "1", "--text", "Task"},
+		{"toggle", "--text"},
+	} {
+		if output, code := runCLIExitCode(t, file, args...); code != 1 || !strings.Contains(output, "Error:") {
+			t.Errorf("%v: got %q (exit %d), want an error", args, output, code)
+		}
+	}
+}
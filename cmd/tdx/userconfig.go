@@ -4,6 +4,7 @@ import (
 	"embed"
 	"os"
 	"path/filepath"
+	"sync"
 
 	"github.com/BurntSushi/toml"
 	"github.com/charmbracelet/lipgloss"
@@ -19,11 +20,84 @@ type UserConfig struct {
 	Display  DisplayConfig  `toml:"display"`
 	Defaults DefaultsConfig `toml:"defaults"`
 	Recent   RecentConfig   `toml:"recent"`
+	Tags     TagsConfig     `toml:"tags"`
+	Priority PriorityConfig `toml:"priority"`
+	History  HistoryConfig  `toml:"history"`
+	NoColor  bool           `toml:"-"` // Set from NO_COLOR or --no-color, not from the config file
 }
 
 // ThemeConfig holds theme metadata
 type ThemeConfig struct {
-	Name string `toml:"name"`
+	Name  string `toml:"name"`  // theme to use, or "auto" to match the terminal background
+	Light string `toml:"light"` // theme for light terminals with "auto" (default: light variant of dark)
+	Dark  string `toml:"dark"`  // theme for dark terminals with "auto" (default: dark variant of light)
+}
+
+// autoTheme is the theme name that picks a light or dark theme to match the
+// terminal background
+const autoTheme = "auto"
+
+// Themes "auto" uses when [theme] has no light or dark setting with a variant
+const (
+	defaultDarkTheme  = "tokyo-night"
+	defaultLightTheme = "tokyo-night-day"
+)
+
+// themePairs lists builtin dark themes with their light variants. A light
+// theme with several dark variants goes back to the first one.
+var themePairs = []struct{ dark, light string }{
+	{"tokyo-night", "tokyo-night-day"},
+	{"catppuccin-mocha", "catppuccin-latte"},
+	{"catppuccin-macchiato", "catppuccin-latte"},
+	{"catppuccin-frappe", "catppuccin-latte"},
+	{"github-dark", "github-light"},
+	{"gruvbox-dark", "gruvbox-light"},
+	{"one-dark", "one-light"},
+	{"rose-pine", "rose-pine-dawn"},
+	{"solarized-dark", "solarized-light"},
+}
+
+// hasDarkBackground reports whether the terminal background is dark. lipgloss
+// asks the terminal (OSC 11), then reads COLORFGBG, and assumes dark when
+// neither answers. Asked at most once, and only for "auto".
+var hasDarkBackground = sync.OnceValue(lipgloss.HasDarkBackground)
+
+// resolveTheme returns the theme to use for name, turning "auto" into the
+// light or dark theme of the [theme] settings that matches the terminal
+func resolveTheme(theme ThemeConfig, name string) string {
+	if name != autoTheme {
+		return name
+	}
+	if hasDarkBackground() {
+		return darkTheme(theme)
+	}
+	return lightTheme(theme)
+}
+
+// darkTheme returns the theme "auto" uses on dark terminals
+func darkTheme(theme ThemeConfig) string {
+	if theme.Dark != "" {
+		return theme.Dark
+	}
+	for _, pair := range themePairs {
+		if pair.light == theme.Light {
+			return pair.dark
+		}
+	}
+	return defaultDarkTheme
+}
+
+// lightTheme returns the theme "auto" uses on light terminals
+func lightTheme(theme ThemeConfig) string {
+	if theme.Light != "" {
+		return theme.Light
+	}
+	for _, pair := range themePairs {
+		if pair.dark == theme.Dark {
+			return pair.light
+		}
+	}
+	return defaultLightTheme
 }
 
 // ColorsConfig holds all color definitions using hex codes
@@ -38,7 +112,9 @@ type ColorsConfig struct {
 	AlertError string `toml:"AlertError"` // errors
 
 	// Tag colors
-	Tag string `toml:"Tag"` // hashtag color (#tag)
+	Tag     string `toml:"Tag"`     // hashtag color (#tag)
+	Context string `toml:"Context"` // context color (@home)
+	Project string `toml:"Project"` // project color (+website)
 
 	// Priority colors (for !p1, !p2, !p3, !p4+)
 	PriorityHigh   string `toml:"PriorityHigh"`   // !p1 - critical
@@ -49,12 +125,25 @@ type ColorsConfig struct {
 	DueUrgent string `toml:"DueUrgent"` // overdue or due today
 	DueSoon   string `toml:"DueSoon"`   // due within 3 days
 	DueFuture string `toml:"DueFuture"` // due later
+
+	// Time tracking color (for est:2h and spent:45m)
+	Time string `toml:"Time"`
 }
 
 // DisplayConfig holds display settings
 type DisplayConfig struct {
-	CheckSymbol  string `toml:"check_symbol"`  // symbol for checked items (default: ✓)
-	SelectMarker string `toml:"select_marker"` // symbol for selected item (default: ➜)
+	CheckSymbol       string `toml:"check_symbol"`        // symbol for checked items (default: ✓)
+	SelectMarker      string `toml:"select_marker"`       // symbol for selected item (default: ➜)
+	PreserveCheckChar bool   `toml:"preserve_check_char"` // keep [X]/[✓] when saving instead of writing [x] (default: false)
+	ShowProgress      bool   `toml:"show_progress"`       // show a completion progress bar in the status bar (default: false)
+	ShowFilename      bool   `toml:"show_filename"`       // show the file path and todo count in the status bar (default: true)
+	Hyperlinks        string `toml:"hyperlinks"`          // "auto", "always" or "never": show links as OSC 8 hyperlinks (default: auto)
+	StatusHints       string `toml:"status_hints"`        // "full", "minimal" or "none": what the status bar shows in normal mode (default: full)
+	DoneStyle         string `toml:"done_style"`          // "magenta", "dim", "strikethrough" or "hidden-text": how completed todos look (default: magenta)
+	ScrollMargin      int    `toml:"scroll_margin"`       // rows kept between the cursor and the window edges (0 = cursor stays centered)
+	ProgressChar      string `toml:"progress_char"`       // "-" or "/": checkbox character written for in-progress todos (default: -)
+	DateFormat        string `toml:"date_format"`         // "iso", "us", "eu", "relative" or a Go time layout: how dates are shown (default: iso)
+	SectionRule       string `toml:"section_rule"`        // "none", "line" or "blank": separator before headings shown in the list (default: none)
 }
 
 // DefaultsConfig holds default behavior settings
@@ -65,11 +154,37 @@ type DefaultsConfig struct {
 	ShowHeadings bool   `toml:"show_headings"` // show headings between tasks (default: false)
 	ReadOnly     bool   `toml:"read_only"`     // open in read-only mode (default: false)
 	FilterDone   bool   `toml:"filter_done"`   // filter out completed tasks (default: false)
+	IndentWidth  int    `toml:"indent_width"`  // spaces per nesting level in files without nested todos (default: 2)
+
+	ConfirmDeleteParent bool `toml:"confirm_delete_parent"` // ask before deleting a todo with subtasks (default: false)
+	ConfirmQuit         bool `toml:"confirm_quit"`          // ask before quitting with esc (default: false)
+	CycleProgress       bool `toml:"cycle_progress"`        // toggling goes open, in progress, done (default: false)
+	NormalizeTokens     bool `toml:"normalize_tokens"`      // move tags, priority and due dates of edited todos to a fixed order (default: false)
 }
 
 // RecentConfig holds recent files settings
 type RecentConfig struct {
-	MaxFiles int `toml:"max_files"` // max recent files to track (default: 20)
+	MaxFiles     int    `toml:"max_files"`     // max recent files to track (default: 20)
+	MaxSearches  int    `toml:"max_searches"`  // max search queries to remember (default: 20)
+	Sort         string `toml:"sort"`          // "score", "recent", "alpha" or "frequency": order of the recent files list (default: score)
+	PruneMissing bool   `toml:"prune_missing"` // forget files that no longer exist (default: true)
+}
+
+// TagsConfig holds tag matching settings
+type TagsConfig struct {
+	NormalizeCase bool              `toml:"normalize_case"` // treat #Work and #work as the same tag (default: false)
+	Aliases       map[string]string `toml:"aliases"`        // alternative tag names mapped to the tag they stand for
+}
+
+// PriorityConfig holds priority level settings
+type PriorityConfig struct {
+	Colors []string `toml:"colors"` // color per level, starting with !p1; later levels use the last color (default: theme colors)
+}
+
+// HistoryConfig holds completion log settings
+type HistoryConfig struct {
+	Enabled bool   `toml:"enabled"` // append checked todos to a completion log (default: false)
+	File    string `toml:"file"`    // path of the log (default: history.md next to config.toml)
 }
 
 // loadBuiltinThemes loads themes from embedded TOML files
@@ -212,8 +327,15 @@ func DefaultConfig() *UserConfig {
 		},
 		Colors: builtinThemes["tokyo-night"],
 		Display: DisplayConfig{
-			CheckSymbol:  "✓", // default check symbol
-			SelectMarker: "➜", // default select marker
+			CheckSymbol:  "✓",       // default check symbol
+			SelectMarker: "➜",       // default select marker
+			ShowFilename: true,      // file path and count shown by default
+			Hyperlinks:   "auto",    // hyperlinks when the terminal supports them
+			StatusHints:  "full",    // indicators and key hints in the status bar
+			DoneStyle:    "magenta", // completed todos in the Important color
+			ProgressChar: "-",       // in-progress todos written as [-]
+			DateFormat:   "iso",     // dates shown as 2025-01-15
+			SectionRule:  "none",    // headings follow the todos directly
 		},
 		Defaults: DefaultsConfig{
 			File:         "todo.md", // default file name
@@ -222,9 +344,19 @@ func DefaultConfig() *UserConfig {
 			ShowHeadings: false,     // headings off by default
 			ReadOnly:     false,     // editing enabled by default
 			FilterDone:   false,     // show completed tasks by default
+			IndentWidth:  2,         // two-space nesting by default
+
+			ConfirmDeleteParent: false, // delete parents without asking by default
+			ConfirmQuit:         false, // esc quits without asking by default
+			CycleProgress:       false, // toggling goes straight from open to done
+			NormalizeTokens:     false, // tokens stay where they were typed
 		},
 		Recent: RecentConfig{
-			MaxFiles: 20, // default max recent files
+			MaxFiles:    20,      // default max recent files
+			MaxSearches: 20,      // default max search history
+			Sort:        "score", // recency and frequency combined
+
+			PruneMissing: true, // forget deleted files
 		},
 	}
 }
@@ -280,6 +412,35 @@ func LoadConfig() *UserConfig {
 	if config.Display.SelectMarker == "" {
 		config.Display.SelectMarker = defaults.Display.SelectMarker
 	}
+	switch config.Display.Hyperlinks {
+	case "auto", "always", "never":
+	default:
+		config.Display.Hyperlinks = defaults.Display.Hyperlinks
+	}
+	switch config.Display.StatusHints {
+	case "full", "minimal", "none":
+	default:
+		config.Display.StatusHints = defaults.Display.StatusHints
+	}
+	if config.Display.ProgressChar != "-" && config.Display.ProgressChar != "/" {
+		config.Display.ProgressChar = defaults.Display.ProgressChar
+	}
+	if config.Display.ScrollMargin < 0 {
+		config.Display.ScrollMargin = 0
+	}
+	switch config.Display.DoneStyle {
+	case "magenta", "dim", "strikethrough", "hidden-text":
+	default:
+		config.Display.DoneStyle = defaults.Display.DoneStyle
+	}
+	if config.Display.DateFormat == "" {
+		config.Display.DateFormat = defaults.Display.DateFormat
+	}
+	switch config.Display.SectionRule {
+	case "none", "line", "blank":
+	default:
+		config.Display.SectionRule = defaults.Display.SectionRule
+	}
 
 	// For Defaults section, we need to track which fields were explicitly set
 	// Since TOML doesn't distinguish between "not set" and "set to zero value",
@@ -289,6 +450,11 @@ func LoadConfig() *UserConfig {
 	// Re-parse to detect which keys are present (for defaults that use zero values)
 	rawConfig := make(map[string]interface{})
 	if _, err := toml.DecodeFile(configPath, &rawConfig); err == nil {
+		displayRaw, _ := rawConfig["display"].(map[string]interface{})
+		if _, set := displayRaw["show_filename"]; !set {
+			config.Display.ShowFilename = defaults.Display.ShowFilename
+		}
+
 		// Check if defaults section exists and apply only set values
 		if defaultsRaw, ok := rawConfig["defaults"].(map[string]interface{}); ok {
 			if _, set := defaultsRaw["file"]; set {
@@ -322,6 +488,21 @@ func LoadConfig() *UserConfig {
 			} else {
 				config.Defaults.FilterDone = defaults.Defaults.FilterDone
 			}
+			if _, set := defaultsRaw["confirm_delete_parent"]; !set {
+				config.Defaults.ConfirmDeleteParent = defaults.Defaults.ConfirmDeleteParent
+			}
+			if _, set := defaultsRaw["confirm_quit"]; !set {
+				config.Defaults.ConfirmQuit = defaults.Defaults.ConfirmQuit
+			}
+			if _, set := defaultsRaw["cycle_progress"]; !set {
+				config.Defaults.CycleProgress = defaults.Defaults.CycleProgress
+			}
+			if _, set := defaultsRaw["normalize_tokens"]; !set {
+				config.Defaults.NormalizeTokens = defaults.Defaults.NormalizeTokens
+			}
+			if config.Defaults.IndentWidth <= 0 {
+				config.Defaults.IndentWidth = defaults.Defaults.IndentWidth
+			}
 		} else {
 			// No defaults section - use all defaults
 			config.Defaults = defaults.Defaults
@@ -332,15 +513,29 @@ func LoadConfig() *UserConfig {
 			if _, set := recentRaw["max_files"]; !set {
 				config.Recent.MaxFiles = defaults.Recent.MaxFiles
 			}
+			if _, set := recentRaw["max_searches"]; !set {
+				config.Recent.MaxSearches = defaults.Recent.MaxSearches
+			}
+			if _, set := recentRaw["prune_missing"]; !set {
+				config.Recent.PruneMissing = defaults.Recent.PruneMissing
+			}
 		} else {
 			config.Recent = defaults.Recent
 		}
 	}
 
-	// Ensure MaxFiles has a sensible minimum
+	// Ensure MaxFiles and MaxSearches have a sensible minimum
 	if config.Recent.MaxFiles <= 0 {
 		config.Recent.MaxFiles = defaults.Recent.MaxFiles
 	}
+	if config.Recent.MaxSearches <= 0 {
+		config.Recent.MaxSearches = defaults.Recent.MaxSearches
+	}
+	switch config.Recent.Sort {
+	case "score", "recent", "alpha", "frequency":
+	default:
+		config.Recent.Sort = defaults.Recent.Sort
+	}
 
 	// Ensure File has a default value
 	if config.Defaults.File == "" {
@@ -349,7 +544,7 @@ func LoadConfig() *UserConfig {
 
 	// Apply colors from theme (user themes override builtin)
 	if config.Theme.Name != "" {
-		if theme, ok := GetBuiltinTheme(config.Theme.Name); ok {
+		if theme, ok := GetBuiltinTheme(resolveTheme(config.Theme, config.Theme.Name)); ok {
 			config.Colors = theme
 		}
 	}
@@ -368,22 +563,32 @@ type Styles struct {
 	Error     lipgloss.Style
 	Code      lipgloss.Style
 
-	// Tag style
-	Tag lipgloss.Style
+	// Tag, context and project styles
+	Tag     lipgloss.Style
+	Context lipgloss.Style
+	Project lipgloss.Style
 
 	// Priority styles
 	PriorityHigh   lipgloss.Style
 	PriorityMedium lipgloss.Style
 	PriorityLow    lipgloss.Style
+	PriorityLevels []lipgloss.Style // Configured styles for !p1, !p2, ... (replaces the three above when set)
 
 	// Due date styles
 	DueUrgent lipgloss.Style
 	DueSoon   lipgloss.Style
 	DueFuture lipgloss.Style
+
+	// Time tracking token style
+	Time lipgloss.Style
 }
 
 // NewStyles creates lipgloss styles from config colors
 func NewStyles(config *UserConfig) *Styles {
+	if config.NoColor {
+		return plainStyles(len(config.Priority.Colors))
+	}
+
 	// Helper to get color with fallback
 	colorOrFallback := func(color, fallback string) string {
 		if color != "" {
@@ -393,13 +598,22 @@ func NewStyles(config *UserConfig) *Styles {
 	}
 
 	// Fallback colors for new fields (Tokyo Night inspired defaults)
-	tagColor := colorOrFallback(config.Colors.Tag, config.Colors.Warning)        // Yellow/orange for tags
-	priorityHigh := colorOrFallback(config.Colors.PriorityHigh, "#f7768e")       // Red
-	priorityMedium := colorOrFallback(config.Colors.PriorityMedium, "#bb9af7")   // Purple/magenta
-	priorityLow := colorOrFallback(config.Colors.PriorityLow, config.Colors.Dim) // Dim
-	dueUrgent := colorOrFallback(config.Colors.DueUrgent, "#7dcfff")             // Cyan/blue
-	dueSoon := colorOrFallback(config.Colors.DueSoon, "#7aa2f7")                 // Blue
-	dueFuture := colorOrFallback(config.Colors.DueFuture, config.Colors.Dim)     // Dim
+	tagColor := colorOrFallback(config.Colors.Tag, config.Colors.Warning)         // Yellow/orange for tags
+	contextColor := colorOrFallback(config.Colors.Context, config.Colors.Success) // Green for @contexts
+	projectColor := colorOrFallback(config.Colors.Project, config.Colors.Accent)  // Blue for +projects
+	priorityHigh := colorOrFallback(config.Colors.PriorityHigh, "#f7768e")        // Red
+	priorityMedium := colorOrFallback(config.Colors.PriorityMedium, "#bb9af7")    // Purple/magenta
+	priorityLow := colorOrFallback(config.Colors.PriorityLow, config.Colors.Dim)  // Dim
+	dueUrgent := colorOrFallback(config.Colors.DueUrgent, "#7dcfff")              // Cyan/blue
+	dueSoon := colorOrFallback(config.Colors.DueSoon, "#7aa2f7")                  // Blue
+	dueFuture := colorOrFallback(config.Colors.DueFuture, config.Colors.Dim)      // Dim
+	timeColor := colorOrFallback(config.Colors.Time, "#73daca")                   // Teal
+
+	// Configured priority levels, the first one bold like PriorityHigh
+	var priorityLevels []lipgloss.Style
+	for i, color := range config.Priority.Colors {
+		priorityLevels = append(priorityLevels, lipgloss.NewStyle().Foreground(lipgloss.Color(color)).Bold(i == 0))
+	}
 
 	return &Styles{
 		Base:      lipgloss.NewStyle().Foreground(lipgloss.Color(config.Colors.Base)),
@@ -413,12 +627,46 @@ func NewStyles(config *UserConfig) *Styles {
 
 		// New styles for tags, priorities, and due dates
 		Tag:            lipgloss.NewStyle().Foreground(lipgloss.Color(tagColor)),
+		Context:        lipgloss.NewStyle().Foreground(lipgloss.Color(contextColor)),
+		Project:        lipgloss.NewStyle().Foreground(lipgloss.Color(projectColor)),
 		PriorityHigh:   lipgloss.NewStyle().Foreground(lipgloss.Color(priorityHigh)).Bold(true),
 		PriorityMedium: lipgloss.NewStyle().Foreground(lipgloss.Color(priorityMedium)),
 		PriorityLow:    lipgloss.NewStyle().Foreground(lipgloss.Color(priorityLow)),
+		PriorityLevels: priorityLevels,
 		DueUrgent:      lipgloss.NewStyle().Foreground(lipgloss.Color(dueUrgent)).Bold(true),
 		DueSoon:        lipgloss.NewStyle().Foreground(lipgloss.Color(dueSoon)),
 		DueFuture:      lipgloss.NewStyle().Foreground(lipgloss.Color(dueFuture)),
+		Time:           lipgloss.NewStyle().Foreground(lipgloss.Color(timeColor)),
+	}
+}
+
+// plainStyles returns styles that render text unchanged, for when colors are off
+func plainStyles(priorityLevels int) *Styles {
+	plain := lipgloss.NewStyle()
+	levels := make([]lipgloss.Style, priorityLevels)
+	for i := range levels {
+		levels[i] = plain
+	}
+	return &Styles{
+		Base:           plain,
+		Dim:            plain,
+		Accent:         plain,
+		Success:        plain,
+		Warning:        plain,
+		Important:      plain,
+		Error:          plain,
+		Code:           plain,
+		Tag:            plain,
+		Context:        plain,
+		Project:        plain,
+		PriorityHigh:   plain,
+		PriorityMedium: plain,
+		PriorityLow:    plain,
+		PriorityLevels: levels,
+		DueUrgent:      plain,
+		DueSoon:        plain,
+		DueFuture:      plain,
+		Time:           plain,
 	}
 }
 
@@ -434,13 +682,26 @@ func NewStyleFuncs(styles *Styles) *StyleFuncsType {
 
 		// New style functions for tags, priorities, and due dates
 		Tag:            func(s string) string { return styles.Tag.Render(s) },
+		Context:        func(s string) string { return styles.Context.Render(s) },
+		Project:        func(s string) string { return styles.Project.Render(s) },
 		PriorityHigh:   func(s string) string { return styles.PriorityHigh.Render(s) },
 		PriorityMedium: func(s string) string { return styles.PriorityMedium.Render(s) },
 		PriorityLow:    func(s string) string { return styles.PriorityLow.Render(s) },
+		Priorities:     priorityLevelFuncs(styles.PriorityLevels),
 		DueUrgent:      func(s string) string { return styles.DueUrgent.Render(s) },
 		DueSoon:        func(s string) string { return styles.DueSoon.Render(s) },
 		DueFuture:      func(s string) string { return styles.DueFuture.Render(s) },
+		Time:           func(s string) string { return styles.Time.Render(s) },
+	}
+}
+
+// priorityLevelFuncs turns configured priority level styles into render functions
+func priorityLevelFuncs(levels []lipgloss.Style) []func(string) string {
+	funcs := make([]func(string) string, len(levels))
+	for i, style := range levels {
+		funcs[i] = func(s string) string { return style.Render(s) }
 	}
+	return funcs
 }
 
 // StyleFuncsType holds style functions for rendering (duplicated here to avoid import cycle)
@@ -454,12 +715,31 @@ type StyleFuncsType struct {
 
 	// New style functions for tags, priorities, and due dates
 	Tag            func(string) string
+	Context        func(string) string
+	Project        func(string) string
 	PriorityHigh   func(string) string
 	PriorityMedium func(string) string
 	PriorityLow    func(string) string
+	Priorities     []func(string) string
 	DueUrgent      func(string) string
 	DueSoon        func(string) string
 	DueFuture      func(string) string
+	Time           func(string) string
+}
+
+// historyFilePath returns the completion log to write, or "" when it's off
+func historyFilePath(history HistoryConfig) string {
+	if !history.Enabled {
+		return ""
+	}
+	if history.File != "" {
+		return resolveFilePath(history.File)
+	}
+	configPath, err := getConfigPath()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(filepath.Dir(configPath), "history.md")
 }
 
 // getConfigPath returns the path to the TOML config file, creating directory if needed
@@ -489,11 +769,16 @@ func getConfigPath() (string, error) {
 // minimalSaveConfig is used for saving config without colors (colors come from theme)
 type minimalSaveConfig struct {
 	Theme struct {
-		Name string `toml:"name"`
+		Name  string `toml:"name"`
+		Light string `toml:"light,omitempty"`
+		Dark  string `toml:"dark,omitempty"`
 	} `toml:"theme"`
 	Display  *DisplayConfig  `toml:"display,omitempty"`
 	Defaults *DefaultsConfig `toml:"defaults,omitempty"`
 	Recent   *RecentConfig   `toml:"recent,omitempty"`
+	Tags     *TagsConfig     `toml:"tags,omitempty"`
+	Priority *PriorityConfig `toml:"priority,omitempty"`
+	History  *HistoryConfig  `toml:"history,omitempty"`
 }
 
 // SaveTheme saves the theme name to the config file
@@ -506,6 +791,7 @@ func SaveTheme(themeName string) error {
 
 	// Load existing config to preserve other settings
 	existingConfig := &UserConfig{}
+	existingConfig.Recent.PruneMissing = true // unset means the default, not false
 	if _, err := os.Stat(configPath); err == nil {
 		// File exists, load it to preserve settings
 		_, _ = toml.DecodeFile(configPath, existingConfig)
@@ -514,11 +800,23 @@ func SaveTheme(themeName string) error {
 	// Create config with theme name and preserve other settings
 	minConfig := &minimalSaveConfig{}
 	minConfig.Theme.Name = themeName
+	minConfig.Theme.Light = existingConfig.Theme.Light
+	minConfig.Theme.Dark = existingConfig.Theme.Dark
 
 	// Preserve display settings if they were customized
 	defaults := DefaultConfig()
 	if existingConfig.Display.CheckSymbol != "" ||
-		existingConfig.Display.SelectMarker != "" {
+		existingConfig.Display.SelectMarker != "" ||
+		existingConfig.Display.PreserveCheckChar ||
+		existingConfig.Display.ShowProgress ||
+		existingConfig.Display.ShowFilename != defaults.Display.ShowFilename ||
+		(existingConfig.Display.Hyperlinks != "" && existingConfig.Display.Hyperlinks != defaults.Display.Hyperlinks) ||
+		(existingConfig.Display.StatusHints != "" && existingConfig.Display.StatusHints != defaults.Display.StatusHints) ||
+		(existingConfig.Display.DoneStyle != "" && existingConfig.Display.DoneStyle != defaults.Display.DoneStyle) ||
+		existingConfig.Display.ScrollMargin != 0 ||
+		(existingConfig.Display.DateFormat != "" && existingConfig.Display.DateFormat != defaults.Display.DateFormat) ||
+		(existingConfig.Display.SectionRule != "" && existingConfig.Display.SectionRule != defaults.Display.SectionRule) ||
+		(existingConfig.Display.ProgressChar != "" && existingConfig.Display.ProgressChar != defaults.Display.ProgressChar) {
 		minConfig.Display = &existingConfig.Display
 	}
 
@@ -528,15 +826,38 @@ func SaveTheme(themeName string) error {
 		existingConfig.Defaults.WordWrap != defaults.Defaults.WordWrap ||
 		existingConfig.Defaults.ShowHeadings != defaults.Defaults.ShowHeadings ||
 		existingConfig.Defaults.ReadOnly != defaults.Defaults.ReadOnly ||
-		existingConfig.Defaults.FilterDone != defaults.Defaults.FilterDone {
+		existingConfig.Defaults.FilterDone != defaults.Defaults.FilterDone ||
+		existingConfig.Defaults.ConfirmDeleteParent != defaults.Defaults.ConfirmDeleteParent ||
+		existingConfig.Defaults.ConfirmQuit != defaults.Defaults.ConfirmQuit ||
+		existingConfig.Defaults.CycleProgress != defaults.Defaults.CycleProgress ||
+		existingConfig.Defaults.NormalizeTokens != defaults.Defaults.NormalizeTokens ||
+		(existingConfig.Defaults.IndentWidth != 0 && existingConfig.Defaults.IndentWidth != defaults.Defaults.IndentWidth) {
 		minConfig.Defaults = &existingConfig.Defaults
 	}
 
 	// Preserve recent settings if customized
-	if existingConfig.Recent.MaxFiles != 0 && existingConfig.Recent.MaxFiles != defaults.Recent.MaxFiles {
+	if (existingConfig.Recent.MaxFiles != 0 && existingConfig.Recent.MaxFiles != defaults.Recent.MaxFiles) ||
+		(existingConfig.Recent.MaxSearches != 0 && existingConfig.Recent.MaxSearches != defaults.Recent.MaxSearches) ||
+		(existingConfig.Recent.Sort != "" && existingConfig.Recent.Sort != defaults.Recent.Sort) ||
+		existingConfig.Recent.PruneMissing != defaults.Recent.PruneMissing {
 		minConfig.Recent = &existingConfig.Recent
 	}
 
+	// Preserve tag settings if any are set
+	if existingConfig.Tags.NormalizeCase || len(existingConfig.Tags.Aliases) > 0 {
+		minConfig.Tags = &existingConfig.Tags
+	}
+
+	// Preserve priority levels if configured
+	if len(existingConfig.Priority.Colors) > 0 {
+		minConfig.Priority = &existingConfig.Priority
+	}
+
+	// Preserve the completion log settings if set
+	if existingConfig.History.Enabled || existingConfig.History.File != "" {
+		minConfig.History = &existingConfig.History
+	}
+
 	// Write config to file
 	f, err := os.Create(configPath)
 	if err != nil {
@@ -405,6 +405,110 @@ filter_done = true
 	}
 }
 
+func TestLoadConfig_PreserveCheckChar(t *testing.T) {
+	// Save original env
+	origXDG := os.Getenv("XDG_CONFIG_HOME")
+	defer func() { _ = os.Setenv("XDG_CONFIG_HOME", origXDG) }()
+
+	tmpDir := t.TempDir()
+	_ = os.Setenv("XDG_CONFIG_HOME", tmpDir)
+
+	if LoadConfig().Display.PreserveCheckChar {
+		t.Error("PreserveCheckChar should default to false")
+	}
+
+	configDir := filepath.Join(tmpDir, "tdx")
+	_ = os.MkdirAll(configDir, 0755)
+	configPath := filepath.Join(configDir, "config.toml")
+
+	configContent := `[display]
+preserve_check_char = true
+`
+	_ = os.WriteFile(configPath, []byte(configContent), 0644)
+
+	config := LoadConfig()
+	if !config.Display.PreserveCheckChar {
+		t.Error("PreserveCheckChar should be true when set in config")
+	}
+	if config.Display.CheckSymbol != "✓" {
+		t.Errorf("CheckSymbol should keep its default, got %q", config.Display.CheckSymbol)
+	}
+
+	// Saving a theme must not drop the setting
+	if err := SaveTheme("nord"); err != nil {
+		t.Fatalf("SaveTheme failed: %v", err)
+	}
+	if !LoadConfig().Display.PreserveCheckChar {
+		t.Error("PreserveCheckChar should be preserved by SaveTheme")
+	}
+}
+
+func TestLoadConfig_IndentWidth(t *testing.T) {
+	origXDG := os.Getenv("XDG_CONFIG_HOME")
+	defer func() { _ = os.Setenv("XDG_CONFIG_HOME", origXDG) }()
+
+	tmpDir := t.TempDir()
+	_ = os.Setenv("XDG_CONFIG_HOME", tmpDir)
+
+	configDir := filepath.Join(tmpDir, "tdx")
+	_ = os.MkdirAll(configDir, 0755)
+	configPath := filepath.Join(configDir, "config.toml")
+
+	_ = os.WriteFile(configPath, []byte("[defaults]\nword_wrap = false\n"), 0644)
+	if got := LoadConfig().Defaults.IndentWidth; got != 2 {
+		t.Errorf("IndentWidth should default to 2, got %d", got)
+	}
+
+	_ = os.WriteFile(configPath, []byte("[defaults]\nindent_width = 4\n"), 0644)
+	if got := LoadConfig().Defaults.IndentWidth; got != 4 {
+		t.Errorf("IndentWidth = %d, want 4", got)
+	}
+}
+
+func TestLoadConfig_ConfirmDeleteParent(t *testing.T) {
+	origXDG := os.Getenv("XDG_CONFIG_HOME")
+	defer func() { _ = os.Setenv("XDG_CONFIG_HOME", origXDG) }()
+
+	tmpDir := t.TempDir()
+	_ = os.Setenv("XDG_CONFIG_HOME", tmpDir)
+
+	configDir := filepath.Join(tmpDir, "tdx")
+	_ = os.MkdirAll(configDir, 0755)
+	configPath := filepath.Join(configDir, "config.toml")
+
+	_ = os.WriteFile(configPath, []byte("[defaults]\nword_wrap = false\n"), 0644)
+	if LoadConfig().Defaults.ConfirmDeleteParent {
+		t.Error("ConfirmDeleteParent should default to false")
+	}
+
+	_ = os.WriteFile(configPath, []byte("[defaults]\nconfirm_delete_parent = true\n"), 0644)
+	if !LoadConfig().Defaults.ConfirmDeleteParent {
+		t.Error("ConfirmDeleteParent should be true when set")
+	}
+}
+
+func TestLoadConfig_ConfirmQuit(t *testing.T) {
+	origXDG := os.Getenv("XDG_CONFIG_HOME")
+	defer func() { _ = os.Setenv("XDG_CONFIG_HOME", origXDG) }()
+
+	tmpDir := t.TempDir()
+	_ = os.Setenv("XDG_CONFIG_HOME", tmpDir)
+
+	configDir := filepath.Join(tmpDir, "tdx")
+	_ = os.MkdirAll(configDir, 0755)
+	configPath := filepath.Join(configDir, "config.toml")
+
+	_ = os.WriteFile(configPath, []byte("[defaults]\nword_wrap = false\n"), 0644)
+	if LoadConfig().Defaults.ConfirmQuit {
+		t.Error("ConfirmQuit should default to false")
+	}
+
+	_ = os.WriteFile(configPath, []byte("[defaults]\nconfirm_quit = true\n"), 0644)
+	if !LoadConfig().Defaults.ConfirmQuit {
+		t.Error("ConfirmQuit should be true when set")
+	}
+}
+
 // Tests for new style functions (Tag, Priority, Due)
 
 func TestNewStyleFuncs_IncludesNewStyles(t *testing.T) {
@@ -522,6 +626,9 @@ func TestAllBuiltinThemes_HaveNewColors(t *testing.T) {
 			if theme.DueUrgent == "" {
 				t.Errorf("Theme %q missing DueUrgent color", name)
 			}
+			if theme.Time == "" {
+				t.Errorf("Theme %q missing Time color", name)
+			}
 		})
 	}
 }
@@ -599,3 +706,412 @@ func TestResolveFilePath_CustomFilename(t *testing.T) {
 		})
 	}
 }
+
+func TestLoadConfig_Tags(t *testing.T) {
+	origXDG := os.Getenv("XDG_CONFIG_HOME")
+	defer func() { _ = os.Setenv("XDG_CONFIG_HOME", origXDG) }()
+
+	tmpDir := t.TempDir()
+	_ = os.Setenv("XDG_CONFIG_HOME", tmpDir)
+
+	configDir := filepath.Join(tmpDir, "tdx")
+	_ = os.MkdirAll(configDir, 0755)
+	configPath := filepath.Join(configDir, "config.toml")
+
+	_ = os.WriteFile(configPath, []byte("[defaults]\nword_wrap = false\n"), 0644)
+	config := LoadConfig()
+	if config.Tags.NormalizeCase || len(config.Tags.Aliases) != 0 {
+		t.Errorf("Tags = %+v, want normalization off by default", config.Tags)
+	}
+
+	_ = os.WriteFile(configPath, []byte("[tags]\nnormalize_case = true\n\n[tags.aliases]\nwip = \"in-progress\"\n"), 0644)
+	config = LoadConfig()
+	if !config.Tags.NormalizeCase {
+		t.Error("NormalizeCase should be true when set")
+	}
+	if config.Tags.Aliases["wip"] != "in-progress" {
+		t.Errorf("Aliases = %v, want wip = in-progress", config.Tags.Aliases)
+	}
+
+	// Saving a theme keeps the tag settings
+	if err := SaveTheme("nord"); err != nil {
+		t.Fatal(err)
+	}
+	if got := LoadConfig().Tags.Aliases["wip"]; got != "in-progress" {
+		t.Errorf("After SaveTheme, alias = %q, want in-progress", got)
+	}
+}
+
+func TestLoadConfig_PriorityColors(t *testing.T) {
+	origXDG := os.Getenv("XDG_CONFIG_HOME")
+	defer func() { _ = os.Setenv("XDG_CONFIG_HOME", origXDG) }()
+
+	tmpDir := t.TempDir()
+	_ = os.Setenv("XDG_CONFIG_HOME", tmpDir)
+
+	configDir := filepath.Join(tmpDir, "tdx")
+	_ = os.MkdirAll(configDir, 0755)
+	configPath := filepath.Join(configDir, "config.toml")
+
+	_ = os.WriteFile(configPath, []byte("[defaults]\nword_wrap = false\n"), 0644)
+	if styles := NewStyles(LoadConfig()); len(styles.PriorityLevels) != 0 {
+		t.Errorf("PriorityLevels = %d, want none without [priority]", len(styles.PriorityLevels))
+	}
+
+	colors := "[priority]\ncolors = [\"#ff0000\", \"#ff8800\", \"#ffff00\", \"#00ff00\", \"#888888\"]\n"
+	_ = os.WriteFile(configPath, []byte(colors), 0644)
+	config := LoadConfig()
+	if len(config.Priority.Colors) != 5 || config.Priority.Colors[4] != "#888888" {
+		t.Errorf("Priority.Colors = %v, want 5 levels", config.Priority.Colors)
+	}
+	funcs := NewStyleFuncs(NewStyles(config))
+	if len(funcs.Priorities) != 5 {
+		t.Errorf("Priorities = %d funcs, want 5", len(funcs.Priorities))
+	}
+	if got := funcs.Priorities[4]("!p5"); !strings.Contains(got, "!p5") {
+		t.Errorf("Priorities[4](!p5) = %q", got)
+	}
+
+	// Saving a theme keeps the priority levels
+	if err := SaveTheme("nord"); err != nil {
+		t.Fatal(err)
+	}
+	if got := LoadConfig().Priority.Colors; len(got) != 5 {
+		t.Errorf("After SaveTheme, Priority.Colors = %v, want 5 levels", got)
+	}
+}
+
+func TestLoadConfig_Hyperlinks(t *testing.T) {
+	origXDG := os.Getenv("XDG_CONFIG_HOME")
+	defer func() { _ = os.Setenv("XDG_CONFIG_HOME", origXDG) }()
+
+	tmpDir := t.TempDir()
+	_ = os.Setenv("XDG_CONFIG_HOME", tmpDir)
+
+	configDir := filepath.Join(tmpDir, "tdx")
+	_ = os.MkdirAll(configDir, 0755)
+	configPath := filepath.Join(configDir, "config.toml")
+
+	tests := []struct {
+		config string
+		want   string
+	}{
+		{"[display]\ncheck_symbol = \"x\"\n", "auto"},
+		{"[display]\nhyperlinks = \"never\"\n", "never"},
+		{"[display]\nhyperlinks = \"always\"\n", "always"},
+		{"[display]\nhyperlinks = \"sometimes\"\n", "auto"},
+	}
+	for _, tt := range tests {
+		_ = os.WriteFile(configPath, []byte(tt.config), 0644)
+		if got := LoadConfig().Display.Hyperlinks; got != tt.want {
+			t.Errorf("config %q: Hyperlinks = %q, want %q", tt.config, got, tt.want)
+		}
+	}
+}
+
+func TestLoadConfig_StatusHints(t *testing.T) {
+	origXDG := os.Getenv("XDG_CONFIG_HOME")
+	defer func() { _ = os.Setenv("XDG_CONFIG_HOME", origXDG) }()
+
+	tmpDir := t.TempDir()
+	_ = os.Setenv("XDG_CONFIG_HOME", tmpDir)
+
+	configDir := filepath.Join(tmpDir, "tdx")
+	_ = os.MkdirAll(configDir, 0755)
+	configPath := filepath.Join(configDir, "config.toml")
+
+	tests := []struct {
+		config string
+		want   string
+	}{
+		{"[display]\ncheck_symbol = \"x\"\n", "full"},
+		{"[display]\nstatus_hints = \"minimal\"\n", "minimal"},
+		{"[display]\nstatus_hints = \"none\"\n", "none"},
+		{"[display]\nstatus_hints = \"loud\"\n", "full"},
+	}
+	for _, tt := range tests {
+		_ = os.WriteFile(configPath, []byte(tt.config), 0644)
+		if got := LoadConfig().Display.StatusHints; got != tt.want {
+			t.Errorf("config %q: StatusHints = %q, want %q", tt.config, got, tt.want)
+		}
+	}
+}
+
+func TestLoadConfig_RecentSort(t *testing.T) {
+	origXDG := os.Getenv("XDG_CONFIG_HOME")
+	defer func() { _ = os.Setenv("XDG_CONFIG_HOME", origXDG) }()
+
+	tmpDir := t.TempDir()
+	_ = os.Setenv("XDG_CONFIG_HOME", tmpDir)
+
+	configDir := filepath.Join(tmpDir, "tdx")
+	_ = os.MkdirAll(configDir, 0755)
+	configPath := filepath.Join(configDir, "config.toml")
+
+	tests := []struct {
+		config string
+		want   string
+	}{
+		{"[display]\ncheck_symbol = \"x\"\n", "score"},
+		{"[recent]\nmax_files = 5\n", "score"},
+		{"[recent]\nsort = \"recent\"\n", "recent"},
+		{"[recent]\nsort = \"alpha\"\n", "alpha"},
+		{"[recent]\nsort = \"frequency\"\n", "frequency"},
+		{"[recent]\nsort = \"random\"\n", "score"},
+	}
+	for _, tt := range tests {
+		_ = os.WriteFile(configPath, []byte(tt.config), 0644)
+		if got := LoadConfig().Recent.Sort; got != tt.want {
+			t.Errorf("config %q: Recent.Sort = %q, want %q", tt.config, got, tt.want)
+		}
+	}
+}
+
+func TestHyperlinksEnabled(t *testing.T) {
+	t.Setenv("TERM", "dumb")
+	t.Setenv("TERM_PROGRAM", "")
+	t.Setenv("WT_SESSION", "")
+	t.Setenv("KITTY_WINDOW_ID", "")
+	t.Setenv("VTE_VERSION", "")
+
+	if !hyperlinksEnabled("always") {
+		t.Error("always should enable hyperlinks")
+	}
+	if hyperlinksEnabled("never") {
+		t.Error("never should disable hyperlinks")
+	}
+	if hyperlinksEnabled("auto") {
+		t.Error("auto should disable hyperlinks on a dumb terminal")
+	}
+}
+
+func TestLoadConfig_DoneStyle(t *testing.T) {
+	origXDG := os.Getenv("XDG_CONFIG_HOME")
+	defer func() { _ = os.Setenv("XDG_CONFIG_HOME", origXDG) }()
+
+	tmpDir := t.TempDir()
+	_ = os.Setenv("XDG_CONFIG_HOME", tmpDir)
+
+	configDir := filepath.Join(tmpDir, "tdx")
+	_ = os.MkdirAll(configDir, 0755)
+	configPath := filepath.Join(configDir, "config.toml")
+
+	tests := []struct {
+		config string
+		want   string
+	}{
+		{"[display]\ncheck_symbol = \"x\"\n", "magenta"},
+		{"[display]\ndone_style = \"dim\"\n", "dim"},
+		{"[display]\ndone_style = \"strikethrough\"\n", "strikethrough"},
+		{"[display]\ndone_style = \"hidden-text\"\n", "hidden-text"},
+		{"[display]\ndone_style = \"blink\"\n", "magenta"},
+	}
+	for _, tt := range tests {
+		_ = os.WriteFile(configPath, []byte(tt.config), 0644)
+		if got := LoadConfig().Display.DoneStyle; got != tt.want {
+			t.Errorf("config %q: DoneStyle = %q, want %q", tt.config, got, tt.want)
+		}
+	}
+}
+
+func TestLoadConfig_ScrollMargin(t *testing.T) {
+	origXDG := os.Getenv("XDG_CONFIG_HOME")
+	defer func() { _ = os.Setenv("XDG_CONFIG_HOME", origXDG) }()
+
+	tmpDir := t.TempDir()
+	_ = os.Setenv("XDG_CONFIG_HOME", tmpDir)
+
+	configDir := filepath.Join(tmpDir, "tdx")
+	_ = os.MkdirAll(configDir, 0755)
+	configPath := filepath.Join(configDir, "config.toml")
+
+	tests := []struct {
+		config string
+		want   int
+	}{
+		{"[display]\ncheck_symbol = \"x\"\n", 0},
+		{"[display]\nscroll_margin = 3\n", 3},
+		{"[display]\nscroll_margin = -2\n", 0},
+	}
+	for _, tt := range tests {
+		_ = os.WriteFile(configPath, []byte(tt.config), 0644)
+		if got := LoadConfig().Display.ScrollMargin; got != tt.want {
+			t.Errorf("config %q: ScrollMargin = %d, want %d", tt.config, got, tt.want)
+		}
+	}
+}
+
+func TestLoadConfig_Progress(t *testing.T) {
+	origXDG := os.Getenv("XDG_CONFIG_HOME")
+	defer func() { _ = os.Setenv("XDG_CONFIG_HOME", origXDG) }()
+
+	tmpDir := t.TempDir()
+	_ = os.Setenv("XDG_CONFIG_HOME", tmpDir)
+
+	configDir := filepath.Join(tmpDir, "tdx")
+	_ = os.MkdirAll(configDir, 0755)
+	configPath := filepath.Join(configDir, "config.toml")
+
+	tests := []struct {
+		config    string
+		wantChar  string
+		wantCycle bool
+	}{
+		{"[display]\ncheck_symbol = \"x\"\n", "-", false},
+		{"[display]\nprogress_char = \"/\"\n[defaults]\ncycle_progress = true\n", "/", true},
+		{"[display]\nprogress_char = \"~\"\n[defaults]\nword_wrap = false\n", "-", false},
+	}
+	for _, tt := range tests {
+		_ = os.WriteFile(configPath, []byte(tt.config), 0644)
+		cfg := LoadConfig()
+		if cfg.Display.ProgressChar != tt.wantChar || cfg.Defaults.CycleProgress != tt.wantCycle {
+			t.Errorf("config %q: ProgressChar = %q, CycleProgress = %v; want %q, %v",
+				tt.config, cfg.Display.ProgressChar, cfg.Defaults.CycleProgress, tt.wantChar, tt.wantCycle)
+		}
+	}
+}
+
+func TestLoadConfig_PruneMissing(t *testing.T) {
+	origXDG := os.Getenv("XDG_CONFIG_HOME")
+	defer func() { _ = os.Setenv("XDG_CONFIG_HOME", origXDG) }()
+
+	tmpDir := t.TempDir()
+	_ = os.Setenv("XDG_CONFIG_HOME", tmpDir)
+
+	configDir := filepath.Join(tmpDir, "tdx")
+	_ = os.MkdirAll(configDir, 0755)
+	configPath := filepath.Join(configDir, "config.toml")
+
+	tests := []struct {
+		config string
+		want   bool
+	}{
+		{"[display]\ncheck_symbol = \"x\"\n", true},
+		{"[recent]\nmax_files = 5\n", true},
+		{"[recent]\nprune_missing = false\n", false},
+	}
+	for _, tt := range tests {
+		_ = os.WriteFile(configPath, []byte(tt.config), 0644)
+		if got := LoadConfig().Recent.PruneMissing; got != tt.want {
+			t.Errorf("config %q: PruneMissing = %v, want %v", tt.config, got, tt.want)
+		}
+	}
+
+	// Saving a theme keeps the setting
+	_ = os.WriteFile(configPath, []byte("[recent]\nprune_missing = false\n"), 0644)
+	if err := SaveTheme("nord"); err != nil {
+		t.Fatal(err)
+	}
+	if LoadConfig().Recent.PruneMissing {
+		t.Error("SaveTheme dropped prune_missing = false")
+	}
+}
+
+func TestLoadConfig_DateFormat(t *testing.T) {
+	origXDG := os.Getenv("XDG_CONFIG_HOME")
+	defer func() { _ = os.Setenv("XDG_CONFIG_HOME", origXDG) }()
+
+	tmpDir := t.TempDir()
+	_ = os.Setenv("XDG_CONFIG_HOME", tmpDir)
+
+	configDir := filepath.Join(tmpDir, "tdx")
+	_ = os.MkdirAll(configDir, 0755)
+	configPath := filepath.Join(configDir, "config.toml")
+
+	tests := map[string]string{
+		"[display]\ncheck_symbol = \"x\"\n":        "iso",
+		"[display]\ndate_format = \"relative\"\n":  "relative",
+		"[display]\ndate_format = \"Mon Jan 2\"\n": "Mon Jan 2",
+		"[display]\ndate_format = \"\"\n":          "iso",
+	}
+	for config, want := range tests {
+		_ = os.WriteFile(configPath, []byte(config), 0644)
+		if got := LoadConfig().Display.DateFormat; got != want {
+			t.Errorf("config %q: DateFormat = %q, want %q", config, got, want)
+		}
+	}
+}
+
+func TestLoadConfig_NormalizeTokens(t *testing.T) {
+	origXDG := os.Getenv("XDG_CONFIG_HOME")
+	defer func() { _ = os.Setenv("XDG_CONFIG_HOME", origXDG) }()
+
+	tmpDir := t.TempDir()
+	_ = os.Setenv("XDG_CONFIG_HOME", tmpDir)
+
+	configDir := filepath.Join(tmpDir, "tdx")
+	_ = os.MkdirAll(configDir, 0755)
+	configPath := filepath.Join(configDir, "config.toml")
+
+	tests := map[string]bool{
+		"[defaults]\nword_wrap = false\n":       false,
+		"[defaults]\nnormalize_tokens = true\n": true,
+	}
+	for config, want := range tests {
+		_ = os.WriteFile(configPath, []byte(config), 0644)
+		if got := LoadConfig().Defaults.NormalizeTokens; got != want {
+			t.Errorf("config %q: NormalizeTokens = %v, want %v", config, got, want)
+		}
+	}
+}
+
+func TestLoadConfig_SectionRule(t *testing.T) {
+	origXDG := os.Getenv("XDG_CONFIG_HOME")
+	defer func() { _ = os.Setenv("XDG_CONFIG_HOME", origXDG) }()
+
+	tmpDir := t.TempDir()
+	_ = os.Setenv("XDG_CONFIG_HOME", tmpDir)
+
+	configDir := filepath.Join(tmpDir, "tdx")
+	_ = os.MkdirAll(configDir, 0755)
+	configPath := filepath.Join(configDir, "config.toml")
+
+	tests := map[string]string{
+		"[display]\ncheck_symbol = \"x\"\n":     "none",
+		"[display]\nsection_rule = \"line\"\n":  "line",
+		"[display]\nsection_rule = \"blank\"\n": "blank",
+		"[display]\nsection_rule = \"thick\"\n": "none",
+	}
+	for config, want := range tests {
+		_ = os.WriteFile(configPath, []byte(config), 0644)
+		if got := LoadConfig().Display.SectionRule; got != want {
+			t.Errorf("config %q: SectionRule = %q, want %q", config, got, want)
+		}
+	}
+}
+
+func TestLoadConfig_History(t *testing.T) {
+	origXDG := os.Getenv("XDG_CONFIG_HOME")
+	defer func() { _ = os.Setenv("XDG_CONFIG_HOME", origXDG) }()
+
+	tmpDir := t.TempDir()
+	_ = os.Setenv("XDG_CONFIG_HOME", tmpDir)
+
+	configDir := filepath.Join(tmpDir, "tdx")
+	_ = os.MkdirAll(configDir, 0755)
+	configPath := filepath.Join(configDir, "config.toml")
+
+	_ = os.WriteFile(configPath, []byte("[defaults]\nword_wrap = false\n"), 0644)
+	if path := historyFilePath(LoadConfig().History); path != "" {
+		t.Errorf("The completion log should be off by default, got %q", path)
+	}
+
+	_ = os.WriteFile(configPath, []byte("[history]\nenabled = true\n"), 0644)
+	if path := historyFilePath(LoadConfig().History); path != filepath.Join(configDir, "history.md") {
+		t.Errorf("historyFilePath = %q, want history.md in the config directory", path)
+	}
+
+	logPath := filepath.Join(tmpDir, "done.md")
+	_ = os.WriteFile(configPath, []byte("[history]\nenabled = true\nfile = \""+logPath+"\"\n"), 0644)
+	if path := historyFilePath(LoadConfig().History); path != logPath {
+		t.Errorf("historyFilePath = %q, want %q", path, logPath)
+	}
+
+	// Saving a theme keeps the setting
+	if err := SaveTheme("nord"); err != nil {
+		t.Fatal(err)
+	}
+	if cfg := LoadConfig(); !cfg.History.Enabled || cfg.History.File != logPath {
+		t.Errorf("History = %+v after saving a theme", cfg.History)
+	}
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"fmt"
+	"runtime/debug"
+	"strings"
+)
+
+// versionString returns the --version output: the version followed by the
+// commit, the build date and the Go version, as far as they are known
+func versionString() string {
+	info, _ := debug.ReadBuildInfo()
+	return formatVersion(Version, Commit, BuildDate, info)
+}
+
+// formatVersion builds the version line. commit and date set via ldflags win
+// over the VCS settings in info, which may be nil.
+func formatVersion(version, commit, date string, info *debug.BuildInfo) string {
+	dateLabel := "built"
+	commitFromVCS := commit == ""
+	modified := false // Uncommitted changes, only known for the VCS commit
+	goVersion := ""
+	if info != nil {
+		goVersion = info.GoVersion
+		for _, setting := range info.Settings {
+			switch setting.Key {
+			case "vcs.revision":
+				if commit == "" {
+					commit = setting.Value
+				}
+			case "vcs.time":
+				if date == "" {
+					date, dateLabel = setting.Value, "committed"
+				}
+			case "vcs.modified":
+				modified = setting.Value == "true" && commitFromVCS
+			}
+		}
+	}
+
+	var details []string
+	if commit != "" {
+		if len(commit) > 12 {
+			commit = commit[:12]
+		}
+		if modified {
+			commit += "-dirty"
+		}
+		details = append(details, "commit "+commit)
+	}
+	if date != "" {
+		details = append(details, dateLabel+" "+date)
+	}
+	if goVersion != "" {
+		details = append(details, goVersion)
+	}
+
+	line := fmt.Sprintf("tdx v%s", version)
+	if len(details) > 0 {
+		line += " (" + strings.Join(details, ", ") + ")"
+	}
+	return line
+}
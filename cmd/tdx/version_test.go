@@ -0,0 +1,68 @@
+package main
+
+import (
+	"debug/buildinfo"
+	"os/exec"
+	"runtime/debug"
+	"strings"
+	"testing"
+)
+
+func TestFormatVersion(t *testing.T) {
+	vcs := &debug.BuildInfo{
+		GoVersion: "go1.25.4",
+		Settings: []debug.BuildSetting{
+			{Key: "vcs.revision", Value: "0934abf1c2d3e4f5a6b7c8d9e0f1a2b3c4d5e6f7"},
+			{Key: "vcs.time", Value: "2026-10-01T12:00:00Z"},
+			{Key: "vcs.modified", Value: "true"},
+		},
+	}
+
+	tests := []struct {
+		name         string
+		commit, date string
+		info         *debug.BuildInfo
+		want         string
+	}{
+		{"no build info", "", "", nil, "tdx v1.2.3"},
+		{"vcs info", "", "", vcs, "tdx v1.2.3 (commit 0934abf1c2d3-dirty, committed 2026-10-01T12:00:00Z, go1.25.4)"},
+		{"ldflags win", "abc1234", "2026-10-02", vcs, "tdx v1.2.3 (commit abc1234, built 2026-10-02, go1.25.4)"},
+		{"only go version", "", "", &debug.BuildInfo{GoVersion: "go1.25.4"}, "tdx v1.2.3 (go1.25.4)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatVersion("1.2.3", tt.commit, tt.date, tt.info); got != tt.want {
+				t.Errorf("formatVersion = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCLI_Version(t *testing.T) {
+	out, err := exec.Command(testBinary, "--version").CombinedOutput()
+	if err != nil {
+		t.Fatalf("tdx --version failed: %v\n%s", err, out)
+	}
+	output := string(out)
+
+	if !strings.HasPrefix(output, "tdx v"+Version) {
+		t.Errorf("Expected the version first, got %q", output)
+	}
+
+	info, err := buildinfo.ReadFile(testBinary)
+	if err != nil {
+		t.Fatalf("Reading the build info of the test binary: %v", err)
+	}
+	if !strings.Contains(output, info.GoVersion) {
+		t.Errorf("Expected the Go version %s in %q", info.GoVersion, output)
+	}
+	for _, setting := range info.Settings {
+		if setting.Key != "vcs.revision" {
+			continue
+		}
+		commit := setting.Value[:min(12, len(setting.Value))]
+		if !strings.Contains(output, "commit "+commit) {
+			t.Errorf("Expected commit %s in %q", commit, output)
+		}
+	}
+}
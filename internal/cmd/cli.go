@@ -2,7 +2,10 @@ package cmd
 
 import (
 	"fmt"
+	"io"
 	"os"
+	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -16,6 +19,72 @@ var (
 	CheckSymbol string
 )
 
+// DryRun makes mutating commands print the resulting file instead of saving it.
+// Set by main from the global --dry-run flag.
+var DryRun bool
+
+// ReadOnly refuses the commands that change the file, unless the file's
+// frontmatter sets read-only: false. Set by main from --read-only and
+// [defaults] read_only.
+var ReadOnly bool
+
+// writeFile saves a file for the mutating commands; HandleCommand swaps it for printFile on dry runs
+var writeFile = markdown.WriteFile
+
+// printFile writes the serialized file to stdout and leaves the disk alone
+func printFile(_ string, fm *markdown.FileModel) error {
+	fmt.Print(markdown.SerializeMarkdown(fm))
+	return nil
+}
+
+// exitNoTodos is the exit code when a command needs todos but the file has none
+// (or doesn't exist yet), so scripts can tell it apart from an invalid index (1)
+const exitNoTodos = 2
+
+// exitReadOnly is the exit code when a command would change a read-only file
+const exitReadOnly = 3
+
+// mutatingCommands are the commands that write the todo file
+var mutatingCommands = []string{"add", "toggle", "edit", "delete", "move", "sort", "clean", "import"}
+
+// requireWritable exits with exitReadOnly if the file is read-only, through its
+// frontmatter or ReadOnly. The file's read-only setting wins, as in the TUI.
+func requireWritable(filePath string) {
+	fm, err := markdown.ReadFile(filePath)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if fm.Metadata.ReadOnly != nil {
+		if *fm.Metadata.ReadOnly {
+			fmt.Printf("Error: %s is read-only (read-only: true in its frontmatter)\n", filePath)
+			os.Exit(exitReadOnly)
+		}
+		return
+	}
+	if ReadOnly {
+		fmt.Println("Error: read-only mode is on (--read-only or read_only in the config)")
+		os.Exit(exitReadOnly)
+	}
+}
+
+// requireTodos exits with exitNoTodos if the file has no todos to work on
+func requireTodos(filePath string, fm *markdown.FileModel) {
+	if len(fm.Todos) == 0 {
+		fmt.Printf("No todos in %s\n", filePath)
+		os.Exit(exitNoTodos)
+	}
+}
+
+// printDone prints a success message. Dry runs skip it so stdout only holds the file.
+func printDone(format string, a ...any) {
+	if DryRun {
+		return
+	}
+	fmt.Printf("%s %s\n", GreenStyle("✓"), fmt.Sprintf(format, a...))
+}
+
 // ListTodos lists all todos in a file
 func ListTodos(filePath string) {
 	fm, err := markdown.ReadFile(filePath)
@@ -29,65 +98,343 @@ func ListTodos(filePath string) {
 		return
 	}
 
-	for _, todo := range fm.Todos {
-		checkbox := "[ ]"
-		if todo.Checked {
-			checkbox = "[" + CheckSymbol + "]"
+	printTodos(fm.Todos)
+}
+
+// defaultLogDays is how far back "tdx log" looks without --days
+const defaultLogDays = 7
+
+// ShowLog prints the todos completed in the last days days from the log at
+// historyPath, newest first. days of 0 prints the whole completion log.
+func ShowLog(historyPath string, days int) {
+	if historyPath == "" {
+		fmt.Println("The completion log is off. Turn it on with enabled = true under [history] in the config.")
+		return
+	}
+	completions, err := markdown.ReadHistory(historyPath)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	completions = markdown.CompletedSince(completions, days)
+	for i := len(completions) - 1; i >= 0; i-- {
+		c := completions[i]
+		fmt.Printf("  %s  %s  %s\n", DimStyle(c.Time.Format("2006-01-02 15:04")), c.Text, DimStyle(homePath(c.File)))
+	}
+	if len(completions) == 0 {
+		if days > 0 {
+			fmt.Printf("Nothing completed in the last %d days\n", days)
+		} else {
+			fmt.Println("Nothing completed yet")
 		}
-		fmt.Printf("  %d. %s %s\n", todo.Index, checkbox, todo.Text)
 	}
 }
 
-// AddTodo adds a new todo to a file
-func AddTodo(filePath string, text string) {
+// parseLogArgs reads "[--days N | --all]" and returns how many days to show, 0 for all
+func parseLogArgs(args []string) (int, error) {
+	days := defaultLogDays
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--all":
+			days = 0
+		case "--days":
+			if i+1 >= len(args) {
+				return 0, fmt.Errorf("--days requires a number")
+			}
+			i++
+			n, err := strconv.Atoi(args[i])
+			if err != nil || n < 1 {
+				return 0, fmt.Errorf("invalid number of days: %s", args[i])
+			}
+			days = n
+		default:
+			return 0, fmt.Errorf("unknown log argument %q", args[i])
+		}
+	}
+	return days, nil
+}
+
+// homePath shortens a path in the home directory to start with ~
+func homePath(path string) string {
+	home, err := os.UserHomeDir()
+	if err != nil || home == "" {
+		return path
+	}
+	if rest, ok := strings.CutPrefix(path, home+string(filepath.Separator)); ok {
+		return filepath.Join("~", rest)
+	}
+	return path
+}
+
+// printTodos prints todos as a numbered list with their checkboxes
+func printTodos(todos []markdown.Todo) {
+	for _, todo := range todos {
+		fmt.Printf("  %d. %s %s\n", todo.Index, checkboxFor(todo), todo.Text)
+	}
+}
+
+// checkboxFor returns the checkbox shown for a todo in CLI output
+func checkboxFor(todo markdown.Todo) string {
+	switch {
+	case todo.Checked:
+		return "[" + CheckSymbol + "]"
+	case todo.InProgress:
+		return "[" + markdown.ProgressChar + "]"
+	default:
+		return "[ ]"
+	}
+}
+
+// ShowDue prints the open todos with a due date grouped into Overdue, Today,
+// This Week and Later. With overdueOnly only the overdue ones are printed, and
+// finding any exits with code 1, for reminders from cron.
+func ShowDue(filePath string, overdueOnly bool) {
+	fm, err := markdown.ReadFile(filePath)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	groups := markdown.GroupByDue(fm.Todos)
+	buckets := markdown.DueBuckets
+	if overdueOnly {
+		buckets = []string{markdown.DueOverdue}
+	}
+
+	shown := 0
+	for _, bucket := range buckets {
+		todos := groups[bucket]
+		if len(todos) == 0 {
+			continue
+		}
+		if shown > 0 {
+			fmt.Println()
+		}
+		fmt.Println(bucket)
+		for _, todo := range todos {
+			date := markdown.FormatDate(*todo.DueDate, markdown.DateFormat)
+			fmt.Printf("  %d. %s %s  %s\n", todo.Index, checkboxFor(todo), todo.Text, DimStyle(date))
+		}
+		shown += len(todos)
+	}
+
+	switch {
+	case overdueOnly && shown > 0:
+		os.Exit(1)
+	case overdueOnly:
+		fmt.Println("Nothing overdue")
+	case shown == 0:
+		fmt.Println("No open todos with due dates")
+	}
+}
+
+// Placements for AddTodo besides a 1-based index to insert after
+const (
+	AddAtEnd = -1 // Append at the end of the file
+	AddAtTop = 0  // Insert before the first todo
+)
+
+// AddTodo adds a new todo to a file at the given placement
+// Priority, tag and due date markers in the text are kept as-is, so the todo is categorized right away
+func AddTodo(filePath string, text string, after int) {
 	// Remove surrounding quotes if present
-	text = strings.Trim(text, "\"")
+	AddTodos(filePath, []string{strings.Trim(text, "\"")}, after)
+}
 
+// AddTodos adds several todos in order at the given placement in a single write
+func AddTodos(filePath string, texts []string, after int) {
 	fm, err := markdown.ReadFile(filePath)
 	if err != nil {
 		fmt.Printf("Error: %v\n", err)
 		os.Exit(1)
 	}
 
-	fm.AddTodoItem(text, false)
+	if after > len(fm.Todos) {
+		fmt.Printf("Error: invalid index %d\n", after)
+		os.Exit(1)
+	}
+
+	for i, text := range texts {
+		if after == AddAtEnd {
+			fm.AddTodoItem(text, false)
+		} else {
+			fm.InsertTodoItemAfter(after-1+i, text, false)
+		}
+	}
 
-	if err := markdown.WriteFile(filePath, fm); err != nil {
+	if err := writeFile(filePath, fm); err != nil {
 		fmt.Printf("Error: %v\n", err)
 		os.Exit(1)
 	}
 
-	fmt.Printf("%s Added: %s\n", GreenStyle("✓"), text)
+	for _, text := range texts {
+		printDone("Added: %s", text)
+	}
+}
+
+// readTodoLines returns the trimmed, non-blank lines of r, one todo each
+func readTodoLines(r io.Reader) ([]string, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	var texts []string
+	for _, line := range strings.Split(string(data), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			texts = append(texts, line)
+		}
+	}
+	return texts, nil
 }
 
-// ToggleTodo toggles the completion status of a todo
-func ToggleTodo(filePath string, index int) {
+// stdinIsPiped reports whether stdin is a pipe or file rather than a terminal
+func stdinIsPiped() bool {
+	stat, err := os.Stdin.Stat()
+	return err == nil && stat.Mode()&os.ModeCharDevice == 0
+}
+
+// ToggleTodos toggles the completion status of each todo in a single write.
+// All indices are validated first so nothing is written if any is out of range.
+// Checked todos are appended to the completion log at historyPath, if set.
+func ToggleTodos(filePath string, indices []int, historyPath string) {
 	fm, err := markdown.ReadFile(filePath)
 	if err != nil {
 		fmt.Printf("Error: %v\n", err)
 		os.Exit(1)
 	}
 
-	if index < 1 || index > len(fm.Todos) {
-		fmt.Printf("Error: invalid index %d\n", index)
-		os.Exit(1)
+	requireTodos(filePath, fm)
+
+	for _, index := range indices {
+		if index < 1 || index > len(fm.Todos) {
+			fmt.Printf("Error: invalid index %d\n", index)
+			os.Exit(1)
+		}
 	}
 
-	todo := fm.Todos[index-1]
-	if err := fm.UpdateTodoItem(index-1, todo.Text, !todo.Checked); err != nil {
+	toggleAndWrite(filePath, fm, indices, historyPath)
+}
+
+// ToggleMatching toggles the first todo whose text contains substr (ignoring
+// case), or every such todo when all is set
+func ToggleMatching(filePath string, substr string, all bool, historyPath string) {
+	fm, err := markdown.ReadFile(filePath)
+	if err != nil {
 		fmt.Printf("Error: %v\n", err)
 		os.Exit(1)
 	}
 
-	if err := markdown.WriteFile(filePath, fm); err != nil {
+	requireTodos(filePath, fm)
+
+	var indices []int
+	needle := strings.ToLower(substr)
+	for i, todo := range fm.Todos {
+		if strings.Contains(strings.ToLower(todo.Text), needle) {
+			indices = append(indices, i+1)
+			if !all {
+				break
+			}
+		}
+	}
+	if len(indices) == 0 {
+		fmt.Printf("Error: no todo contains %q\n", substr)
+		os.Exit(1)
+	}
+
+	toggleAndWrite(filePath, fm, indices, historyPath)
+}
+
+// toggleAndWrite toggles the todos at the given 1-based indices, saves the file,
+// logs the ones that are now checked and prints the toggled todos
+func toggleAndWrite(filePath string, fm *markdown.FileModel, indices []int, historyPath string) {
+	toggled := make([]markdown.Todo, 0, len(indices))
+	for _, index := range indices {
+		if err := fm.ToggleTodoItem(index - 1); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		toggled = append(toggled, fm.Todos[index-1])
+	}
+
+	if err := writeFile(filePath, fm); err != nil {
 		fmt.Printf("Error: %v\n", err)
 		os.Exit(1)
 	}
 
-	checkbox := "[ ]"
-	if !todo.Checked {
-		checkbox = "[" + CheckSymbol + "]"
+	if historyPath != "" && !DryRun {
+		completed := slices.DeleteFunc(slices.Clone(toggled), func(todo markdown.Todo) bool { return !todo.Checked })
+		if err := markdown.LogCompleted(historyPath, filePath, completed); err != nil {
+			// The todos are saved; a log that can't be written doesn't fail the toggle
+			fmt.Fprintf(os.Stderr, "Warning: could not write the completion log: %v\n", err)
+		}
+	}
+
+	for _, todo := range toggled {
+		printDone("Toggled: %s %s", checkboxFor(todo), todo.Text)
+	}
+}
+
+// parseToggleArgs reads the arguments of 'tdx toggle': indices, or --text with
+// an optional --all
+func parseToggleArgs(args []string) (indices []int, text string, all bool, err error) {
+	var rest []string
+	hasText := false
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--all":
+			all = true
+		case "--text":
+			if i+1 >= len(args) || args[i+1] == "" {
+				return nil, "", false, fmt.Errorf("--text requires a value")
+			}
+			i++
+			text, hasText = args[i], true
+		default:
+			rest = append(rest, args[i])
+		}
 	}
-	fmt.Printf("%s Toggled: %s %s\n", GreenStyle("✓"), checkbox, todo.Text)
+
+	switch {
+	case hasText && len(rest) > 0:
+		return nil, "", false, fmt.Errorf("toggle takes indices or --text, not both")
+	case all && !hasText:
+		return nil, "", false, fmt.Errorf("--all requires --text")
+	case hasText:
+		return nil, text, all, nil
+	case len(rest) == 0:
+		return nil, "", false, fmt.Errorf("toggle requires index argument")
+	}
+	indices, err = parseIndices(rest)
+	return indices, "", false, err
+}
+
+// parseIndices parses todo indices and ranges like "1 3 5" or "2-4".
+// Duplicates are dropped so every todo is handled once, in the order given.
+func parseIndices(args []string) ([]int, error) {
+	var indices []int
+	seen := make(map[int]bool)
+	for _, arg := range args {
+		first, last := arg, arg
+		if from, to, ok := strings.Cut(arg, "-"); ok && from != "" {
+			first, last = from, to
+		}
+		start, err := strconv.Atoi(first)
+		if err != nil {
+			return nil, fmt.Errorf("invalid index %q", arg)
+		}
+		end, err := strconv.Atoi(last)
+		if err != nil || end < start {
+			return nil, fmt.Errorf("invalid range %q", arg)
+		}
+		for i := start; i <= end; i++ {
+			if !seen[i] {
+				seen[i] = true
+				indices = append(indices, i)
+			}
+		}
+	}
+	return indices, nil
 }
 
 // EditTodo edits the text of a todo
@@ -100,6 +447,8 @@ func EditTodo(filePath string, index int, text string) {
 		os.Exit(1)
 	}
 
+	requireTodos(filePath, fm)
+
 	if index < 1 || index > len(fm.Todos) {
 		fmt.Printf("Error: invalid index %d\n", index)
 		os.Exit(1)
@@ -111,12 +460,12 @@ func EditTodo(filePath string, index int, text string) {
 		os.Exit(1)
 	}
 
-	if err := markdown.WriteFile(filePath, fm); err != nil {
+	if err := writeFile(filePath, fm); err != nil {
 		fmt.Printf("Error: %v\n", err)
 		os.Exit(1)
 	}
 
-	fmt.Printf("%s Edited: %s\n", GreenStyle("✓"), text)
+	printDone("Edited: %s", text)
 }
 
 // DeleteTodo deletes a todo by index
@@ -127,6 +476,8 @@ func DeleteTodo(filePath string, index int) {
 		os.Exit(1)
 	}
 
+	requireTodos(filePath, fm)
+
 	if index < 1 || index > len(fm.Todos) {
 		fmt.Printf("Error: invalid index %d\n", index)
 		os.Exit(1)
@@ -139,36 +490,420 @@ func DeleteTodo(filePath string, index int) {
 		os.Exit(1)
 	}
 
-	if err := markdown.WriteFile(filePath, fm); err != nil {
+	if err := writeFile(filePath, fm); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	printDone("Deleted: %s", todo.Text)
+}
+
+// DeleteFilter selects the todos removed by 'tdx delete --done', '--tag' and
+// '--priority'. A todo has to match every filter that is set.
+type DeleteFilter struct {
+	Done     bool   // Only completed todos
+	Tag      string // Only todos with this tag (without #)
+	Priority int    // Only todos with this priority (0 = any)
+}
+
+// IsSet reports whether any filter is set
+func (f DeleteFilter) IsSet() bool {
+	return f.Done || f.Tag != "" || f.Priority > 0
+}
+
+// Matches reports whether the todo passes every filter that is set
+func (f DeleteFilter) Matches(todo markdown.Todo) bool {
+	if f.Done && !todo.Checked {
+		return false
+	}
+	if f.Tag != "" && !todo.HasTag(f.Tag) {
+		return false
+	}
+	if f.Priority > 0 && todo.Priority != f.Priority {
+		return false
+	}
+	return true
+}
+
+// DeleteMatching deletes every todo matching the filter in a single write and
+// prints how many were removed. Nested todos of a deleted todo that don't
+// match move up a level, as when deleting a single todo.
+func DeleteMatching(filePath string, filter DeleteFilter) {
+	fm, err := markdown.ReadFile(filePath)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	requireTodos(filePath, fm)
+
+	var matches []int
+	for i, todo := range fm.Todos {
+		if filter.Matches(todo) {
+			matches = append(matches, i)
+		}
+	}
+	if len(matches) == 0 {
+		fmt.Println("No todos match")
+		return
+	}
+
+	// Delete from the end so earlier indices stay valid
+	for _, i := range slices.Backward(matches) {
+		if err := fm.DeleteTodoItem(i); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	if err := writeFile(filePath, fm); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if len(matches) == 1 {
+		printDone("Deleted 1 todo")
+	} else {
+		printDone("Deleted %d todos", len(matches))
+	}
+}
+
+// parseDeleteArgs reads the arguments of 'tdx delete': either an index or filter flags
+func parseDeleteArgs(args []string) (int, DeleteFilter, error) {
+	var filter DeleteFilter
+	index, hasIndex := 0, false
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--done":
+			filter.Done = true
+		case "--tag", "--priority":
+			if i+1 >= len(args) {
+				return 0, filter, fmt.Errorf("%s requires a value", args[i])
+			}
+			i++
+			if args[i-1] == "--tag" {
+				filter.Tag = strings.TrimPrefix(args[i], "#")
+				continue
+			}
+			p, err := strconv.Atoi(strings.TrimLeft(args[i], "!pP"))
+			if err != nil || p < 1 {
+				return 0, filter, fmt.Errorf("invalid priority %q", args[i])
+			}
+			filter.Priority = p
+		default:
+			idx, err := strconv.Atoi(args[i])
+			if err != nil || hasIndex {
+				return 0, filter, fmt.Errorf("invalid index")
+			}
+			index, hasIndex = idx, true
+		}
+	}
+
+	switch {
+	case hasIndex && filter.IsSet():
+		return 0, filter, fmt.Errorf("delete takes an index or filters, not both")
+	case !hasIndex && !filter.IsSet():
+		return 0, filter, fmt.Errorf("delete requires index argument")
+	}
+	return index, filter, nil
+}
+
+// MoveTodo moves the todo at from to position to (both 1-based) and prints the new order
+func MoveTodo(filePath string, from, to int) {
+	fm, err := markdown.ReadFile(filePath)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	requireTodos(filePath, fm)
+
+	for _, index := range []int{from, to} {
+		if index < 1 || index > len(fm.Todos) {
+			fmt.Printf("Error: invalid index %d\n", index)
+			os.Exit(1)
+		}
+	}
+
+	todo := fm.Todos[from-1]
+	if from == to {
+		fmt.Printf("%s Already at %d: %s\n", GreenStyle("✓"), to, todo.Text)
+		return
+	}
+
+	if err := fm.MoveTodoItem(from-1, to-1); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if err := writeFile(filePath, fm); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Nested todos move along with their parent, so the todo doesn't always land
+	// at to. Moving keeps its position in the source, which identifies it.
+	moved := to
+	for i, t := range fm.Todos {
+		if t.LineNo == todo.LineNo {
+			moved = i + 1
+			break
+		}
+	}
+
+	printDone("Moved: %s (%d → %d)", todo.Text, from, moved)
+	if !DryRun {
+		printTodos(fm.Todos)
+	}
+}
+
+// SortTodos reorders todos by key within each heading section
+func SortTodos(filePath string, key string) {
+	fm, err := markdown.ReadFile(filePath)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	requireTodos(filePath, fm)
+
+	if err := fm.SortTodos(key); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if err := writeFile(filePath, fm); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	printDone("Sorted by %s", key)
+}
+
+// CleanFile rewrites the file in its normalized layout (see markdown.CleanMarkdown).
+// With check nothing is written: the path is printed and the exit code is 1 if
+// the file isn't clean, like gofmt -l.
+func CleanFile(filePath string, check bool) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	cleaned := markdown.CleanMarkdown(string(data))
+	if check {
+		if cleaned != string(data) {
+			fmt.Println(filePath)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if DryRun {
+		fmt.Print(cleaned)
+		return
+	}
+	if cleaned == string(data) {
+		printDone("%s is already clean", filePath)
+		return
+	}
+	if err := markdown.WriteContent(filePath, cleaned); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	printDone("Cleaned %s", filePath)
+}
+
+// exportFormats are the formats supported by the export and import commands
+var exportFormats = []string{"html", "json"}
+
+// ExportFile renders a file as HTML or JSON, written to outPath or stdout if empty
+func ExportFile(filePath string, format string, outPath string) {
+	fm, err := markdown.ReadFile(filePath)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	var output string
+	switch format {
+	case "html":
+		output, err = markdown.RenderHTML(fm)
+	case "json":
+		output, err = markdown.ExportJSON(fm)
+		if lost := markdown.UnrepresentableInJSON(fm); lost != "" {
+			// On stderr, so the warning doesn't end up in the exported JSON
+			fmt.Fprintf(os.Stderr, "Warning: %s has %s, which the JSON export leaves out\n", filePath, lost)
+		}
+	}
+	if err != nil {
 		fmt.Printf("Error: %v\n", err)
 		os.Exit(1)
 	}
 
-	fmt.Printf("%s Deleted: %s\n", GreenStyle("✓"), todo.Text)
+	if outPath == "" {
+		fmt.Print(output)
+		return
+	}
+
+	if err := os.WriteFile(outPath, []byte(output), 0644); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("%s Exported to %s\n", GreenStyle("✓"), outPath)
 }
 
-// HandleCommand parses and executes CLI commands
-func HandleCommand(command string, cmdArgs []string, filePath string) {
+// ImportJSON reconstructs a markdown file from a JSON export ("-" reads stdin).
+// An existing file is only replaced with force, and never when it has content
+// the JSON form can't hold, since the import would lose it.
+func ImportJSON(filePath string, inPath string, force bool) {
+	if _, err := os.Stat(filePath); err == nil {
+		if !force {
+			fmt.Printf("Error: %s already exists (use --force to replace it)\n", filePath)
+			os.Exit(1)
+		}
+		existing, err := markdown.ReadFile(filePath)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		if lost := markdown.UnrepresentableInJSON(existing); lost != "" {
+			fmt.Printf("Error: %s has %s, which a JSON import can't keep (import into a new file instead)\n", filePath, lost)
+			os.Exit(1)
+		}
+	}
+
+	var data []byte
+	var err error
+	if inPath == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(inPath)
+	}
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	fm, err := markdown.ImportJSON(data)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if err := writeFile(filePath, fm); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	printDone("Imported %d todos to %s", len(fm.Todos), filePath)
+}
+
+// parseFormatArgs splits export/import arguments into the format, the file path
+// (given after valueFlag, or as the next plain argument) and whether --force was given
+func parseFormatArgs(cmdArgs []string, valueFlag string) (format, value string, force bool) {
+	for i := 0; i < len(cmdArgs); i++ {
+		switch {
+		case valueFlag != "" && cmdArgs[i] == valueFlag:
+			if i+1 >= len(cmdArgs) {
+				fmt.Printf("Error: %s requires a file path\n", valueFlag)
+				os.Exit(1)
+			}
+			i++
+			value = cmdArgs[i]
+		case cmdArgs[i] == "--force":
+			force = true
+		case format == "":
+			format = cmdArgs[i]
+		case value == "":
+			value = cmdArgs[i]
+		}
+	}
+	return format, value, force
+}
+
+// HandleCommand parses and executes CLI commands. historyPath is the completion
+// log, "" when it's off.
+func HandleCommand(command string, cmdArgs []string, filePath string, historyPath string) {
+	// --dry-run may also follow the command, as in "sort priority --dry-run"
+	if slices.Contains(cmdArgs, "--dry-run") {
+		defer func(dryRun bool) { DryRun = dryRun }(DryRun)
+		DryRun = true
+		cmdArgs = slices.DeleteFunc(slices.Clone(cmdArgs), func(arg string) bool { return arg == "--dry-run" })
+	}
+	if DryRun {
+		defer func(write func(string, *markdown.FileModel) error) { writeFile = write }(writeFile)
+		writeFile = printFile
+	} else if slices.Contains(mutatingCommands, command) {
+		requireWritable(filePath)
+	}
+
 	switch command {
 	case "list":
 		ListTodos(filePath)
 	case "add":
-		if len(cmdArgs) < 1 {
+		after := AddAtEnd
+		var words []string
+		for i := 0; i < len(cmdArgs); i++ {
+			switch cmdArgs[i] {
+			case "--top":
+				if after != AddAtEnd {
+					fmt.Println("Error: --top and --after can't be combined")
+					os.Exit(1)
+				}
+				after = AddAtTop
+			case "--after":
+				if after != AddAtEnd {
+					fmt.Println("Error: --top and --after can't be combined")
+					os.Exit(1)
+				}
+				if i+1 >= len(cmdArgs) {
+					fmt.Println("Error: --after requires an index")
+					os.Exit(1)
+				}
+				i++
+				idx, err := strconv.Atoi(cmdArgs[i])
+				if err != nil || idx < 1 {
+					fmt.Println("Error: invalid index")
+					os.Exit(1)
+				}
+				after = idx
+			default:
+				words = append(words, cmdArgs[i])
+			}
+		}
+		// "-" or no text with piped input reads one todo per line from stdin
+		if (len(words) == 1 && words[0] == "-") || (len(words) == 0 && stdinIsPiped()) {
+			texts, err := readTodoLines(os.Stdin)
+			if err != nil {
+				fmt.Printf("Error: %v\n", err)
+				os.Exit(1)
+			}
+			if len(texts) == 0 {
+				fmt.Println("Error: no todos on stdin")
+				os.Exit(1)
+			}
+			AddTodos(filePath, texts, after)
+			return
+		}
+		if len(words) < 1 {
 			fmt.Println("Error: add requires text argument")
 			os.Exit(1)
 		}
-		AddTodo(filePath, strings.Join(cmdArgs, " "))
+		AddTodo(filePath, strings.Join(words, " "), after)
 	case "toggle":
-		if len(cmdArgs) < 1 {
-			fmt.Println("Error: toggle requires index argument")
-			os.Exit(1)
-		}
-		idx, err := strconv.Atoi(cmdArgs[0])
+		indices, text, all, err := parseToggleArgs(cmdArgs)
 		if err != nil {
-			fmt.Println("Error: invalid index")
+			fmt.Printf("Error: %v\n", err)
 			os.Exit(1)
 		}
-		ToggleTodo(filePath, idx)
+		if text != "" {
+			ToggleMatching(filePath, text, all, historyPath)
+			return
+		}
+		ToggleTodos(filePath, indices, historyPath)
 	case "edit":
 		if len(cmdArgs) < 2 {
 			fmt.Println("Error: edit requires index and text arguments")
@@ -181,16 +916,79 @@ func HandleCommand(command string, cmdArgs []string, filePath string) {
 		}
 		EditTodo(filePath, idx, strings.Join(cmdArgs[1:], " "))
 	case "delete":
-		if len(cmdArgs) < 1 {
-			fmt.Println("Error: delete requires index argument")
+		idx, filter, err := parseDeleteArgs(cmdArgs)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
 			os.Exit(1)
 		}
-		idx, err := strconv.Atoi(cmdArgs[0])
+		if filter.IsSet() {
+			DeleteMatching(filePath, filter)
+			return
+		}
+		DeleteTodo(filePath, idx)
+	case "move":
+		if len(cmdArgs) < 2 {
+			fmt.Println("Error: move requires from and to index arguments")
+			os.Exit(1)
+		}
+		from, err := strconv.Atoi(cmdArgs[0])
 		if err != nil {
 			fmt.Println("Error: invalid index")
 			os.Exit(1)
 		}
-		DeleteTodo(filePath, idx)
+		to, err := strconv.Atoi(cmdArgs[1])
+		if err != nil {
+			fmt.Println("Error: invalid index")
+			os.Exit(1)
+		}
+		MoveTodo(filePath, from, to)
+	case "sort":
+		if len(cmdArgs) < 1 {
+			fmt.Printf("Error: sort requires a key (%s)\n", strings.Join(markdown.SortKeys, ", "))
+			os.Exit(1)
+		}
+		SortTodos(filePath, cmdArgs[0])
+	case "clean":
+		check := false
+		for _, arg := range cmdArgs {
+			if arg != "--check" {
+				fmt.Printf("Error: unknown clean argument %q\n", arg)
+				os.Exit(1)
+			}
+			check = true
+		}
+		CleanFile(filePath, check)
+	case "export":
+		format, outPath, _ := parseFormatArgs(cmdArgs, "--out")
+		if !slices.Contains(exportFormats, format) {
+			fmt.Printf("Error: export requires a format (%s)\n", strings.Join(exportFormats, ", "))
+			os.Exit(1)
+		}
+		ExportFile(filePath, format, outPath)
+	case "import":
+		format, inPath, force := parseFormatArgs(cmdArgs, "")
+		if format != "json" || inPath == "" {
+			fmt.Println("Error: import requires a format and an input file (json <file.json>)")
+			os.Exit(1)
+		}
+		ImportJSON(filePath, inPath, force)
+	case "due":
+		overdueOnly := false
+		for _, arg := range cmdArgs {
+			if arg != "--overdue" {
+				fmt.Printf("Error: unknown due argument %q\n", arg)
+				os.Exit(1)
+			}
+			overdueOnly = true
+		}
+		ShowDue(filePath, overdueOnly)
+	case "log":
+		days, err := parseLogArgs(cmdArgs)
+		if err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		ShowLog(historyPath, days)
 	default:
 		fmt.Printf("Unknown command: %s\n", command)
 		os.Exit(1)
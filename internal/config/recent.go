@@ -9,17 +9,28 @@ import (
 	"os"
 	"path/filepath"
 	"sort"
+	"strings"
 	"time"
+
+	"github.com/niklas-heer/tdx/internal/util"
 )
 
 // RecentFile represents a recently opened file with tracking metadata
 type RecentFile struct {
-	Path          string    `json:"path"`            // Absolute path to the file
-	LastAccessed  time.Time `json:"last_accessed"`   // Last time this file was opened
-	AccessCount   int       `json:"access_count"`    // Number of times opened
-	LastCursorPos int       `json:"last_cursor_pos"` // Last cursor position in the file
-	ContentHash   string    `json:"content_hash"`    // SHA256 hash of file content when cursor was saved
-	LastModified  time.Time `json:"last_modified"`   // Last modification time when accessed
+	Path          string     `json:"path"`                 // Absolute path to the file
+	LastAccessed  time.Time  `json:"last_accessed"`        // Last time this file was opened
+	AccessCount   int        `json:"access_count"`         // Number of times opened
+	LastCursorPos int        `json:"last_cursor_pos"`      // Last cursor position in the file
+	ContentHash   string     `json:"content_hash"`         // SHA256 hash of file content when cursor was saved
+	LastModified  time.Time  `json:"last_modified"`        // Last modification time when accessed
+	ViewState     *ViewState `json:"view_state,omitempty"` // Filters in use when the file was closed
+}
+
+// ViewState holds the filter settings of a file that are restored when it is reopened
+type ViewState struct {
+	FilteredTags       []string `json:"filtered_tags,omitempty"` // Active tag filters (without #)
+	FilterDone         bool     `json:"filter_done"`             // Whether completed todos are hidden
+	MaxVisibleOverride int      `json:"max_visible_override"`    // Max visible items (-1 = config default)
 }
 
 // RecentFiles manages the list of recently opened files
@@ -53,6 +64,21 @@ const DefaultMaxRecent = 20
 // This allows the config to be loaded from config.toml instead of config.yaml
 var MaxRecentFiles = DefaultMaxRecent
 
+// Orders for listing recent files, chosen with [recent] sort in config.toml
+const (
+	RecentSortScore     = "score"     // Recency and frequency combined
+	RecentSortRecent    = "recent"    // Most recently opened first
+	RecentSortAlpha     = "alpha"     // Alphabetical by path
+	RecentSortFrequency = "frequency" // Most often opened first
+)
+
+// RecentSort is the order used to list recent files, set by main.go from config.toml
+var RecentSort = RecentSortScore
+
+// PruneMissing drops files that no longer exist when the list is saved,
+// set by main.go from config.toml
+var PruneMissing = true
+
 // computeFileHash computes SHA256 hash of file content
 func computeFileHash(filePath string) (string, error) {
 	file, err := os.Open(filePath)
@@ -71,11 +97,19 @@ func computeFileHash(filePath string) (string, error) {
 
 // SaveRecentFile adds or updates a file in the recent files list
 func SaveRecentFile(filePath string, cursorPos int) error {
-	// Get absolute path
-	absPath, err := filepath.Abs(filePath)
-	if err != nil {
-		return err
-	}
+	return saveRecentFile(filePath, cursorPos, nil)
+}
+
+// SaveRecentFileWithState adds or updates a file in the recent files list,
+// also recording its view state
+func SaveRecentFileWithState(filePath string, cursorPos int, state ViewState) error {
+	return saveRecentFile(filePath, cursorPos, &state)
+}
+
+// saveRecentFile records a file access; a nil state keeps the previously saved one
+func saveRecentFile(filePath string, cursorPos int, state *ViewState) error {
+	// One entry per file, however it was opened
+	absPath := util.CanonicalPath(filePath)
 
 	// Check if file exists
 	fileInfo, err := os.Stat(absPath)
@@ -110,6 +144,9 @@ func SaveRecentFile(filePath string, cursorPos int) error {
 			recent.Files[i].LastCursorPos = cursorPos
 			recent.Files[i].ContentHash = contentHash
 			recent.Files[i].LastModified = fileInfo.ModTime()
+			if state != nil {
+				recent.Files[i].ViewState = state
+			}
 			found = true
 			break
 		}
@@ -124,13 +161,16 @@ func SaveRecentFile(filePath string, cursorPos int) error {
 			LastCursorPos: cursorPos,
 			ContentHash:   contentHash,
 			LastModified:  fileInfo.ModTime(),
+			ViewState:     state,
 		})
 	}
 
-	// Clean up non-existent files
-	recent.CleanupMissing()
+	if PruneMissing {
+		recent.CleanupMissing()
+	}
 
-	// Sort and trim to max size
+	// Keep the highest scored files; the configured limit wins over the stored one
+	recent.MaxRecent = getMaxRecentFromConfig()
 	recent.SortByScore()
 	if len(recent.Files) > recent.MaxRecent {
 		recent.Files = recent.Files[:recent.MaxRecent]
@@ -232,6 +272,32 @@ func (r *RecentFiles) SortByScore() {
 	})
 }
 
+// SortBy sorts files in the given order (one of the RecentSort* constants).
+// Unknown orders sort by score.
+func (r *RecentFiles) SortBy(order string) {
+	switch order {
+	case RecentSortRecent:
+		sort.SliceStable(r.Files, func(i, j int) bool {
+			return r.Files[i].LastAccessed.After(r.Files[j].LastAccessed)
+		})
+	case RecentSortAlpha:
+		sort.SliceStable(r.Files, func(i, j int) bool {
+			return strings.ToLower(r.Files[i].Path) < strings.ToLower(r.Files[j].Path)
+		})
+	case RecentSortFrequency:
+		sort.SliceStable(r.Files, func(i, j int) bool {
+			a, b := r.Files[i], r.Files[j]
+			if a.AccessCount != b.AccessCount {
+				return a.AccessCount > b.AccessCount
+			}
+			// Equally frequent files are listed most recent first
+			return a.LastAccessed.After(b.LastAccessed)
+		})
+	default:
+		r.SortByScore()
+	}
+}
+
 // calculateScore computes a score for a file based on recency and frequency
 // Score formula: accessCount * (1 / (hoursSinceAccess + 1))
 // This gives higher weight to recent files, with frequency as a multiplier
@@ -255,10 +321,7 @@ func (r *RecentFiles) calculateScore(file RecentFile, now time.Time) float64 {
 // GetCursorPosition returns the saved cursor position for a file
 // Returns -1 if file not found or content has changed
 func (r *RecentFiles) GetCursorPosition(filePath string) int {
-	absPath, err := filepath.Abs(filePath)
-	if err != nil {
-		return -1
-	}
+	absPath := util.CanonicalPath(filePath)
 
 	for _, file := range r.Files {
 		if file.Path == absPath {
@@ -277,7 +340,21 @@ func (r *RecentFiles) GetCursorPosition(filePath string) int {
 	return -1
 }
 
-// GetRecentFilesList returns a list of recent files sorted by score
+// GetViewState returns the saved view state for a file, or nil if none was saved.
+// Unlike the cursor position, it is kept when the file content changes.
+func (r *RecentFiles) GetViewState(filePath string) *ViewState {
+	absPath := util.CanonicalPath(filePath)
+
+	for _, file := range r.Files {
+		if file.Path == absPath {
+			return file.ViewState
+		}
+	}
+
+	return nil
+}
+
+// GetRecentFilesList returns a list of recent files in the configured order
 func GetRecentFilesList() ([]RecentFile, error) {
 	recent, err := LoadRecentFiles()
 	if err != nil {
@@ -285,7 +362,7 @@ func GetRecentFilesList() ([]RecentFile, error) {
 	}
 
 	recent.CleanupMissing()
-	recent.SortByScore()
+	recent.SortBy(RecentSort)
 
 	return recent.Files, nil
 }
@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"slices"
 	"testing"
 	"time"
 )
@@ -140,6 +141,42 @@ func TestRecentFilesSortByScore(t *testing.T) {
 	}
 }
 
+func TestRecentFilesSortBy(t *testing.T) {
+	now := time.Now()
+	records := []RecentFile{
+		{Path: "/notes/beta.md", LastAccessed: now.Add(-48 * time.Hour), AccessCount: 9},
+		{Path: "/notes/Alpha.md", LastAccessed: now, AccessCount: 1},
+		{Path: "/notes/gamma.md", LastAccessed: now.Add(-1 * time.Hour), AccessCount: 5},
+		{Path: "/notes/delta.md", LastAccessed: now.Add(-2 * time.Hour), AccessCount: 5},
+	}
+
+	tests := []struct {
+		order string
+		want  []string
+	}{
+		{RecentSortScore, []string{"/notes/gamma.md", "/notes/delta.md", "/notes/beta.md", "/notes/Alpha.md"}},
+		{RecentSortRecent, []string{"/notes/Alpha.md", "/notes/gamma.md", "/notes/delta.md", "/notes/beta.md"}},
+		{RecentSortAlpha, []string{"/notes/Alpha.md", "/notes/beta.md", "/notes/delta.md", "/notes/gamma.md"}},
+		{RecentSortFrequency, []string{"/notes/beta.md", "/notes/gamma.md", "/notes/delta.md", "/notes/Alpha.md"}},
+		{"bogus", []string{"/notes/gamma.md", "/notes/delta.md", "/notes/beta.md", "/notes/Alpha.md"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.order, func(t *testing.T) {
+			rf := &RecentFiles{Files: append([]RecentFile(nil), records...)}
+			rf.SortBy(tt.order)
+
+			var got []string
+			for _, f := range rf.Files {
+				got = append(got, f.Path)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("SortBy(%q) = %v, want %v", tt.order, got, tt.want)
+			}
+		})
+	}
+}
+
 func TestGetCursorPosition(t *testing.T) {
 	tmpDir := t.TempDir()
 	testFile := filepath.Join(tmpDir, "test.md")
@@ -184,6 +221,59 @@ func TestGetCursorPosition(t *testing.T) {
 	}
 }
 
+func TestViewStateSavedAndRestored(t *testing.T) {
+	tmpDir := t.TempDir()
+	testFile := filepath.Join(tmpDir, "test.md")
+
+	if err := os.WriteFile(testFile, []byte("original content"), 0644); err != nil {
+		t.Fatalf("Failed to create test file: %v", err)
+	}
+
+	oldGetConfigDir := getConfigDir
+	getConfigDir = func() (string, error) {
+		return tmpDir, nil
+	}
+	defer func() { getConfigDir = oldGetConfigDir }()
+
+	state := ViewState{FilteredTags: []string{"work"}, FilterDone: true, MaxVisibleOverride: 7}
+	if err := SaveRecentFileWithState(testFile, 2, state); err != nil {
+		t.Fatalf("SaveRecentFileWithState failed: %v", err)
+	}
+
+	// A plain save (e.g. from the CLI) keeps the saved state
+	if err := SaveRecentFile(testFile, 3); err != nil {
+		t.Fatalf("SaveRecentFile failed: %v", err)
+	}
+
+	// State survives content changes, unlike the cursor position
+	if err := os.WriteFile(testFile, []byte("modified content"), 0644); err != nil {
+		t.Fatalf("Failed to modify test file: %v", err)
+	}
+
+	recentFiles, err := LoadRecentFiles()
+	if err != nil {
+		t.Fatalf("LoadRecentFiles failed: %v", err)
+	}
+
+	got := recentFiles.GetViewState(testFile)
+	if got == nil {
+		t.Fatal("Expected saved view state, got nil")
+	}
+	if len(got.FilteredTags) != 1 || got.FilteredTags[0] != "work" {
+		t.Errorf("FilteredTags = %v, want [work]", got.FilteredTags)
+	}
+	if !got.FilterDone {
+		t.Error("Expected FilterDone to be restored")
+	}
+	if got.MaxVisibleOverride != 7 {
+		t.Errorf("MaxVisibleOverride = %d, want 7", got.MaxVisibleOverride)
+	}
+
+	if recentFiles.GetViewState(filepath.Join(tmpDir, "other.md")) != nil {
+		t.Error("Expected nil view state for unknown file")
+	}
+}
+
 func TestMaxRecentFiles(t *testing.T) {
 	tmpDir := t.TempDir()
 
@@ -193,21 +283,18 @@ func TestMaxRecentFiles(t *testing.T) {
 	}
 	defer func() { getConfigDir = oldGetConfigDir }()
 
-	// Create more files than the default max
+	// Create more files than the configured max
 	maxFiles := 5
+	oldMax := MaxRecentFiles
+	MaxRecentFiles = maxFiles
+	defer func() { MaxRecentFiles = oldMax }()
+
 	for i := 0; i < maxFiles+3; i++ {
 		testFile := filepath.Join(tmpDir, "test"+string(rune('0'+i))+".md")
 		if err := os.WriteFile(testFile, []byte("test"), 0644); err != nil {
 			t.Fatalf("Failed to create test file: %v", err)
 		}
 
-		// Override max for this test
-		rf, _ := LoadRecentFiles()
-		rf.MaxRecent = maxFiles
-		if err := rf.Save(); err != nil {
-			t.Fatalf("Save failed: %v", err)
-		}
-
 		if err := SaveRecentFile(testFile, 0); err != nil {
 			t.Fatalf("SaveRecentFile failed: %v", err)
 		}
@@ -224,6 +311,113 @@ func TestMaxRecentFiles(t *testing.T) {
 	}
 }
 
+func TestSaveRecentFile_TrimsPersistedList(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	oldGetConfigDir := getConfigDir
+	getConfigDir = func() (string, error) {
+		return tmpDir, nil
+	}
+	defer func() { getConfigDir = oldGetConfigDir }()
+
+	// A list saved with a larger limit is cut down to the configured one
+	var files []RecentFile
+	for i := range 6 {
+		path := filepath.Join(tmpDir, "old"+string(rune('0'+i))+".md")
+		if err := os.WriteFile(path, []byte("test"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		files = append(files, RecentFile{
+			Path:         path,
+			LastAccessed: time.Now().Add(-time.Duration(i+1) * time.Hour),
+			AccessCount:  1,
+		})
+	}
+	stored := &RecentFiles{Files: files, MaxRecent: 50}
+	if err := stored.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	oldMax := MaxRecentFiles
+	MaxRecentFiles = 3
+	defer func() { MaxRecentFiles = oldMax }()
+
+	newest := filepath.Join(tmpDir, "new.md")
+	if err := os.WriteFile(newest, []byte("test"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := SaveRecentFile(newest, 0); err != nil {
+		t.Fatalf("SaveRecentFile failed: %v", err)
+	}
+
+	rf, err := LoadRecentFiles()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, f := range rf.Files {
+		got = append(got, filepath.Base(f.Path))
+	}
+	want := []string{"new.md", "old0.md", "old1.md"}
+	if !slices.Equal(got, want) {
+		t.Errorf("Persisted files = %v, want %v", got, want)
+	}
+	if rf.MaxRecent != 3 {
+		t.Errorf("MaxRecent = %d, want 3", rf.MaxRecent)
+	}
+}
+
+func TestSaveRecentFile_PruneMissing(t *testing.T) {
+	for _, prune := range []bool{true, false} {
+		tmpDir := t.TempDir()
+
+		oldGetConfigDir := getConfigDir
+		getConfigDir = func() (string, error) {
+			return tmpDir, nil
+		}
+		oldPrune := PruneMissing
+		PruneMissing = prune
+
+		gone := filepath.Join(tmpDir, "gone.md")
+		kept := filepath.Join(tmpDir, "kept.md")
+		for _, path := range []string{gone, kept} {
+			if err := os.WriteFile(path, []byte("test"), 0644); err != nil {
+				t.Fatal(err)
+			}
+			if err := SaveRecentFile(path, 0); err != nil {
+				t.Fatalf("SaveRecentFile failed: %v", err)
+			}
+		}
+		if err := os.Remove(gone); err != nil {
+			t.Fatal(err)
+		}
+		if err := SaveRecentFile(kept, 0); err != nil {
+			t.Fatalf("SaveRecentFile failed: %v", err)
+		}
+
+		rf, err := LoadRecentFiles()
+		if err != nil {
+			t.Fatal(err)
+		}
+		stored := slices.ContainsFunc(rf.Files, func(f RecentFile) bool { return f.Path == gone })
+		if stored == prune {
+			t.Errorf("PruneMissing = %v: deleted file stored = %v", prune, stored)
+		}
+
+		// Missing files are never listed, pruned or not
+		list, err := GetRecentFilesList()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(list) != 1 || list[0].Path != kept {
+			t.Errorf("PruneMissing = %v: listed %v, want only %s", prune, list, kept)
+		}
+
+		getConfigDir = oldGetConfigDir
+		PruneMissing = oldPrune
+	}
+}
+
 func TestClearRecentFiles(t *testing.T) {
 	tmpDir := t.TempDir()
 	testFile := filepath.Join(tmpDir, "test.md")
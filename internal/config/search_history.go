@@ -0,0 +1,104 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// SearchHistory holds previous search queries, most recent first
+type SearchHistory struct {
+	Queries []string `json:"queries"`
+}
+
+// DefaultMaxSearchHistory is the default maximum number of search queries to keep
+const DefaultMaxSearchHistory = 20
+
+// MaxSearchHistory can be set by main.go from config.toml
+var MaxSearchHistory = DefaultMaxSearchHistory
+
+// LoadSearchHistory loads the search history from disk
+func LoadSearchHistory() (*SearchHistory, error) {
+	path, err := GetSearchHistoryPath()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &SearchHistory{Queries: []string{}}, nil
+		}
+		return nil, err
+	}
+
+	var history SearchHistory
+	if err := json.Unmarshal(data, &history); err != nil {
+		return nil, err
+	}
+	return &history, nil
+}
+
+// AddSearchQueries records queries in the order they were made and saves the history.
+// The history on disk is reloaded first so searches from other sessions are kept.
+func AddSearchQueries(queries ...string) error {
+	history, err := LoadSearchHistory()
+	if err != nil {
+		history = &SearchHistory{}
+	}
+	for _, query := range queries {
+		history.Add(query)
+	}
+	return history.Save()
+}
+
+// Add moves query to the front of the history, dropping duplicates and the oldest
+// entries beyond MaxSearchHistory. Blank queries are ignored.
+func (h *SearchHistory) Add(query string) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return
+	}
+
+	h.Queries = slices.DeleteFunc(h.Queries, func(q string) bool { return q == query })
+	h.Queries = slices.Insert(h.Queries, 0, query)
+
+	limit := MaxSearchHistory
+	if limit <= 0 {
+		limit = DefaultMaxSearchHistory
+	}
+	if len(h.Queries) > limit {
+		h.Queries = h.Queries[:limit]
+	}
+}
+
+// Save writes the search history to disk
+func (h *SearchHistory) Save() error {
+	path, err := GetSearchHistoryPath()
+	if err != nil {
+		return err
+	}
+
+	// Ensure config directory exists
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(h, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, data, 0644)
+}
+
+// GetSearchHistoryPath returns the path to the search history JSON file
+func GetSearchHistoryPath() (string, error) {
+	configDir, err := getConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "search_history.json"), nil
+}
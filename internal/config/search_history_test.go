@@ -0,0 +1,54 @@
+package config
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestAddSearchQueries_MostRecentFirst(t *testing.T) {
+	SetConfigDirForTesting(t.TempDir())
+	defer ResetConfigDirForTesting()
+
+	if err := AddSearchQueries("login", "#auth"); err != nil {
+		t.Fatalf("AddSearchQueries failed: %v", err)
+	}
+	// A later session searches "login" again
+	if err := AddSearchQueries("login", "  "); err != nil {
+		t.Fatalf("AddSearchQueries failed: %v", err)
+	}
+
+	history, err := LoadSearchHistory()
+	if err != nil {
+		t.Fatalf("LoadSearchHistory failed: %v", err)
+	}
+	if want := []string{"login", "#auth"}; !slices.Equal(history.Queries, want) {
+		t.Errorf("Queries = %v, want %v", history.Queries, want)
+	}
+}
+
+func TestSearchHistory_TrimsToMax(t *testing.T) {
+	old := MaxSearchHistory
+	MaxSearchHistory = 2
+	defer func() { MaxSearchHistory = old }()
+
+	var h SearchHistory
+	for _, q := range []string{"a", "b", "c"} {
+		h.Add(q)
+	}
+	if want := []string{"c", "b"}; !slices.Equal(h.Queries, want) {
+		t.Errorf("Queries = %v, want %v", h.Queries, want)
+	}
+}
+
+func TestLoadSearchHistory_Missing(t *testing.T) {
+	SetConfigDirForTesting(t.TempDir())
+	defer ResetConfigDirForTesting()
+
+	history, err := LoadSearchHistory()
+	if err != nil {
+		t.Fatalf("LoadSearchHistory failed: %v", err)
+	}
+	if len(history.Queries) != 0 {
+		t.Errorf("Expected empty history, got %v", history.Queries)
+	}
+}
@@ -3,6 +3,7 @@ package markdown
 import (
 	"bytes"
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/yuin/goldmark"
@@ -16,6 +17,15 @@ import (
 type ASTDocument struct {
 	Source []byte
 	AST    ast.Node
+
+	leading []byte                    // Source before the first top-level block
+	blocks  map[ast.Node]*blockSource // Original source of each top-level block
+	items   map[ast.Node]*itemLayout  // Original bullet, indentation and spacing of each list item
+
+	checkChars map[ast.Node]string   // Original character of checkboxes not written as [x]
+	progress   map[ast.Node]string   // Character of checkboxes marked in progress ([-] or [/])
+	notes      map[ast.Node][]string // Note lines under each task item, without their indentation
+	indentUnit string                // Indentation of one nesting level as written in the source
 }
 
 // TodoNode represents a todo item in the AST with its associated checkbox
@@ -37,12 +47,18 @@ func ParseAST(content string) (*ASTDocument, error) {
 		),
 	)
 
-	doc := md.Parser().Parse(text.NewReader(source))
+	doc := md.Parser().Parse(text.NewReader(normalizeCheckMarks(source)))
+	flattenRawInlines(doc, source)
 
-	return &ASTDocument{
+	astDoc := &ASTDocument{
 		Source: source,
 		AST:    doc,
-	}, nil
+	}
+	astDoc.recordBlockSources()
+	astDoc.recordListItems()
+	astDoc.recordCheckChars()
+	astDoc.recordNotes()
+	return astDoc, nil
 }
 
 // ExtractTodos walks the AST and extracts all task list items with nesting information
@@ -80,27 +96,10 @@ func (doc *ASTDocument) ExtractTodos() []Todo {
 		// If this is a task item, extract it
 		currentIdx := -1
 		if checkbox != nil {
-			text := doc.extractTodoText(listItem, checkbox)
-			tags := ExtractTags(text)
-			priority := ExtractPriority(text)
-
-			// Get line number from textBlock
-			lineNo := 0
-			if textBlock != nil && textBlock.Lines().Len() > 0 {
-				lineNo = textBlock.Lines().At(0).Start
-			}
-
-			todo := Todo{
-				Index:       todoIndex + 1,
-				Checked:     checkbox.IsChecked,
-				Text:        text,
-				LineNo:      lineNo,
-				Tags:        tags,
-				Priority:    priority,
-				Depth:       depth,
-				ParentIndex: parentIdx,
-				DueDate:     ExtractDueDate(text),
-			}
+			todo := doc.buildTodo(listItem, checkbox, textBlock)
+			todo.Index = todoIndex + 1
+			todo.Depth = depth
+			todo.ParentIndex = parentIdx
 			todos = append(todos, todo)
 			currentIdx = todoIndex
 			todoIndex++
@@ -141,6 +140,56 @@ func (doc *ASTDocument) ExtractTodos() []Todo {
 	return todos
 }
 
+// buildTodo reads a task list item into a Todo. The position in the tree
+// (Index, Depth and ParentIndex) is left for the caller to fill in.
+func (doc *ASTDocument) buildTodo(listItem *ast.ListItem, checkbox *extast.TaskCheckBox, textBlock ast.Node) Todo {
+	text := doc.extractTodoText(listItem, checkbox)
+
+	// Get line number from textBlock
+	lineNo := 0
+	if textBlock != nil && textBlock.Lines().Len() > 0 {
+		lineNo = textBlock.Lines().At(0).Start
+	}
+
+	return Todo{
+		Checked:    checkbox.IsChecked,
+		InProgress: doc.progressChar(checkbox) != "",
+		Text:       text,
+		LineNo:     lineNo,
+		Tags:       ExtractTags(text),
+		Contexts:   ExtractContexts(text),
+		Projects:   ExtractProjects(text),
+		Color:      ExtractColor(text),
+		Priority:   ExtractPriority(text),
+		DueDate:    ExtractDueDate(text),
+		Estimate:   ExtractEstimate(text),
+		Spent:      ExtractSpent(text),
+		Marker:     doc.itemMarker(listItem),
+		Ordered:    isOrderedItem(listItem),
+		Notes:      slices.Clone(doc.notes[listItem]),
+	}
+}
+
+// RefreshTodo re-reads the todo at todoIndex from the AST into todos, after
+// an edit that changed only that todo (its text or checkbox). The other todos
+// and the nesting are unchanged, so this avoids extracting every todo again.
+func (doc *ASTDocument) RefreshTodo(todos []Todo, todoIndex int) error {
+	if todoIndex < 0 || todoIndex >= len(todos) {
+		return fmt.Errorf("invalid todo index: %d", todoIndex)
+	}
+	node, err := doc.FindTodoNode(todoIndex)
+	if err != nil {
+		return err
+	}
+
+	todo := doc.buildTodo(node.ListItem, node.CheckBox, node.CheckBox.Parent())
+	todo.Index = todos[todoIndex].Index
+	todo.Depth = todos[todoIndex].Depth
+	todo.ParentIndex = todos[todoIndex].ParentIndex
+	todos[todoIndex] = todo
+	return nil
+}
+
 // Heading represents a markdown heading with its position
 type Heading struct {
 	Level           int // 1-6 for h1-h6
@@ -340,10 +389,31 @@ func (doc *ASTDocument) ToggleTodo(todoIndex int) error {
 		return err
 	}
 
-	// Toggle the checkbox state
+	// Toggle the checkbox state; in-progress todos become done
 	node.CheckBox.IsChecked = !node.CheckBox.IsChecked
 	node.Checked = node.CheckBox.IsChecked
+	delete(doc.progress, node.CheckBox)
+
+	return nil
+}
+
+// SetTodoInProgress marks an open todo as in progress, or back to open.
+// Done todos are unchecked first.
+func (doc *ASTDocument) SetTodoInProgress(todoIndex int, inProgress bool) error {
+	node, err := doc.FindTodoNode(todoIndex)
+	if err != nil {
+		return err
+	}
 
+	if !inProgress {
+		delete(doc.progress, node.CheckBox)
+		return nil
+	}
+	node.CheckBox.IsChecked = false
+	node.Checked = false
+	if _, ok := doc.progress[node.CheckBox]; !ok {
+		doc.progress[node.CheckBox] = ProgressChar
+	}
 	return nil
 }
 
@@ -389,6 +459,7 @@ func (doc *ASTDocument) UpdateTodoText(todoIndex int, newText string) error {
 		),
 	)
 	tempDoc := md.Parser().Parse(text.NewReader([]byte(tempMarkdown)))
+	flattenRawInlines(tempDoc, []byte(tempMarkdown))
 
 	// Find the parsed list item
 	var newListItem *ast.ListItem
@@ -428,6 +499,42 @@ func (doc *ASTDocument) UpdateTodoText(todoIndex int, newText string) error {
 	// Adjust all segments to point to our source
 	adjustNodeSegments(newListItem, sourceStart)
 
+	// Carry over the original layout, notes and check character
+	if layout, ok := doc.items[listItem]; ok {
+		doc.items[newListItem] = layout
+	}
+	if notes, ok := doc.notes[listItem]; ok {
+		doc.notes[newListItem] = notes
+	}
+	_, hasCheckChar := doc.checkChars[node.CheckBox]
+	_, hasProgress := doc.progress[node.CheckBox]
+	if hasCheckChar || hasProgress {
+		_ = ast.Walk(newListItem, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+			if entering && n.Kind() == extast.KindTaskCheckBox {
+				if c, ok := doc.checkChars[node.CheckBox]; ok {
+					doc.checkChars[n] = c
+				}
+				if c, ok := doc.progress[node.CheckBox]; ok {
+					doc.progress[n] = c
+				}
+				return ast.WalkStop, nil
+			}
+			return ast.WalkContinue, nil
+		})
+	}
+
+	// Keep nested todos under the edited item
+	var nested []ast.Node
+	for child := listItem.FirstChild(); child != nil; child = child.NextSibling() {
+		if child.Kind() == ast.KindList {
+			nested = append(nested, child)
+		}
+	}
+	for _, list := range nested {
+		listItem.RemoveChild(listItem, list)
+		newListItem.AppendChild(newListItem, list)
+	}
+
 	// Replace old list item with new one
 	parentList.RemoveChild(parentList, listItem)
 
@@ -689,6 +796,9 @@ func (doc *ASTDocument) MoveTodoToPosition(fromIndex, targetIndex int, insertAft
 	if err != nil {
 		return err
 	}
+	if isAncestor(nodeFrom.ListItem, nodeTarget.ListItem) {
+		return fmt.Errorf("cannot move a todo into its own nested todos")
+	}
 
 	// Remove from current parent
 	parentFrom := nodeFrom.ListItem.Parent()
@@ -722,6 +832,9 @@ func (doc *ASTDocument) MoveTodo(fromIndex, toIndex int) error {
 	if err != nil {
 		return err
 	}
+	if isAncestor(nodeFrom.ListItem, nodeTo.ListItem) {
+		return fmt.Errorf("cannot move a todo into its own nested todos")
+	}
 
 	// Get parents
 	parentFrom := nodeFrom.ListItem.Parent()
@@ -742,6 +855,16 @@ func (doc *ASTDocument) MoveTodo(fromIndex, toIndex int) error {
 	return nil
 }
 
+// isAncestor reports whether node is nested somewhere inside ancestor
+func isAncestor(ancestor, node ast.Node) bool {
+	for n := node.Parent(); n != nil; n = n.Parent() {
+		if n == ancestor {
+			return true
+		}
+	}
+	return false
+}
+
 // IndentTodo makes a todo a child of its previous sibling (increases depth)
 // Returns error if the todo cannot be indented (e.g., first item in list)
 func (doc *ASTDocument) IndentTodo(todoIndex int) error {
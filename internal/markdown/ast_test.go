@@ -250,3 +250,214 @@ func TestUpdateTodoText_SpecialCharacters(t *testing.T) {
 		}
 	}
 }
+
+func TestListMarkers_AsteriskPreservedOnToggle(t *testing.T) {
+	content := "# Todos\n\n* [ ] First\n* [ ] Second\n"
+
+	fm := ParseMarkdown(content)
+	if len(fm.Todos) != 2 {
+		t.Fatalf("Expected 2 todos, got %d", len(fm.Todos))
+	}
+	if fm.Todos[0].Marker != '*' {
+		t.Errorf("Expected marker '*', got %q", fm.Todos[0].Marker)
+	}
+
+	if err := fm.UpdateTodoItem(0, "First", true); err != nil {
+		t.Fatalf("UpdateTodoItem failed: %v", err)
+	}
+
+	expected := "# Todos\n\n* [x] First\n* [ ] Second\n"
+	if got := SerializeMarkdown(fm); got != expected {
+		t.Errorf("Expected:\n%q\nGot:\n%q", expected, got)
+	}
+}
+
+func TestListMarkers_PlusPreservedOnEdit(t *testing.T) {
+	fm := ParseMarkdown("# Todos\n\n+ [ ] Old text\n")
+
+	if err := fm.UpdateTodoItem(0, "New text", false); err != nil {
+		t.Fatalf("UpdateTodoItem failed: %v", err)
+	}
+
+	expected := "# Todos\n\n+ [ ] New text\n"
+	if got := SerializeMarkdown(fm); got != expected {
+		t.Errorf("Expected:\n%q\nGot:\n%q", expected, got)
+	}
+}
+
+func TestListMarkers_MixedMarkersRoundTrip(t *testing.T) {
+	content := "# Todos\n\n- [ ] Dash\n* [ ] Star\n+ [ ] Plus\n"
+
+	fm := ParseMarkdown(content)
+	if len(fm.Todos) != 3 {
+		t.Fatalf("Expected 3 todos, got %d", len(fm.Todos))
+	}
+
+	for i, want := range []byte{'-', '*', '+'} {
+		if fm.Todos[i].Marker != want {
+			t.Errorf("Todo %d: expected marker %q, got %q", i, want, fm.Todos[i].Marker)
+		}
+	}
+
+	_ = fm.UpdateTodoItem(1, "Star", true)
+
+	expected := "# Todos\n\n- [ ] Dash\n* [x] Star\n+ [ ] Plus\n"
+	if got := SerializeMarkdown(fm); got != expected {
+		t.Errorf("Expected:\n%q\nGot:\n%q", expected, got)
+	}
+}
+
+func TestListMarkers_KeptWhenMovedBetweenLists(t *testing.T) {
+	fm := ParseMarkdown("# Todos\n\n- [ ] Dash\n\n## Later\n\n* [ ] Star\n")
+
+	if err := fm.MoveTodoItem(1, 0); err != nil {
+		t.Fatalf("MoveTodoItem failed: %v", err)
+	}
+
+	if fm.Todos[0].Text != "Star" || fm.Todos[0].Marker != '*' {
+		t.Errorf("Expected moved todo 'Star' with marker '*', got %q with %q", fm.Todos[0].Text, fm.Todos[0].Marker)
+	}
+}
+
+func TestParseMarkdown_LooseCheckboxSpacing(t *testing.T) {
+	content := "# Todos\n\n-   [ ] extra spaces\n- [ ]no space\n- [ ] trailing   \n-  [x]  both sides\n"
+	fm := ParseMarkdown(content)
+
+	want := []struct {
+		text    string
+		checked bool
+	}{
+		{"extra spaces", false},
+		{"no space", false},
+		{"trailing", false},
+		{"both sides", true},
+	}
+	if len(fm.Todos) != len(want) {
+		t.Fatalf("Expected %d todos, got %d: %+v", len(want), len(fm.Todos), fm.Todos)
+	}
+	for i, w := range want {
+		if fm.Todos[i].Text != w.text || fm.Todos[i].Checked != w.checked {
+			t.Errorf("Todo %d = %q (checked=%v), want %q (checked=%v)", i, fm.Todos[i].Text, fm.Todos[i].Checked, w.text, w.checked)
+		}
+	}
+
+	// Writing normalizes the spacing
+	expected := "# Todos\n\n- [ ] extra spaces\n- [ ] no space\n- [ ] trailing\n- [x] both sides\n"
+	if got := SerializeMarkdown(fm); got != expected {
+		t.Errorf("Serialized:\n%q\nwant:\n%q", got, expected)
+	}
+}
+
+func TestParseMarkdown_LooseCheckboxSpacingNested(t *testing.T) {
+	fm := ParseMarkdown("# Todos\n\n- [ ] parent  \n  -  [ ]  child\n  - [x]kid\n")
+
+	if len(fm.Todos) != 3 {
+		t.Fatalf("Expected 3 todos, got %d", len(fm.Todos))
+	}
+	if fm.Todos[1].Text != "child" || fm.Todos[1].Depth != 1 {
+		t.Errorf("Todo 1 = %q at depth %d, want child at depth 1", fm.Todos[1].Text, fm.Todos[1].Depth)
+	}
+	if fm.Todos[2].Text != "kid" || !fm.Todos[2].Checked || fm.Todos[2].ParentIndex != 0 {
+		t.Errorf("Todo 2 = %+v, want checked kid under parent", fm.Todos[2])
+	}
+}
+
+func TestCountTodoLines(t *testing.T) {
+	content := "# Todos\n\n- [ ] One\n  * [x] Two\n1. [✓] Three\n- Not a todo\n+ [-] Four\n"
+	if got, want := CountTodoLines(content), len(ParseMarkdown(content).Todos); got != want {
+		t.Errorf("CountTodoLines = %d, want %d", got, want)
+	}
+}
+
+func TestOrderedList_ParsedAsTodos(t *testing.T) {
+	fm := ParseMarkdown("# Todos\n\n1. [ ] First\n2. [x] Second\n\n## Later\n\n1) [ ] Third\n")
+
+	if len(fm.Todos) != 3 {
+		t.Fatalf("Expected 3 todos, got %d", len(fm.Todos))
+	}
+	for i, want := range []byte{'.', '.', ')'} {
+		if !fm.Todos[i].Ordered || fm.Todos[i].Marker != want {
+			t.Errorf("Todo %d: expected an ordered item with %q, got ordered=%v marker %q", i, want, fm.Todos[i].Ordered, fm.Todos[i].Marker)
+		}
+	}
+	if !fm.Todos[1].Checked {
+		t.Error("Expected the second todo to be checked")
+	}
+
+	unordered := ParseMarkdown("# Todos\n\n- [ ] Dash\n")
+	if unordered.Todos[0].Ordered {
+		t.Error("A bulleted todo shouldn't be ordered")
+	}
+}
+
+func TestOrderedList_RoundTrip(t *testing.T) {
+	content := "# Todos\n\n1. [ ] First\n   A note\n2. [x] Second\n\n## Later\n\n5) [ ] Fifth\n6) [ ] Sixth\n"
+
+	fm := ParseMarkdown(content)
+	if got := SerializeMarkdown(fm); got != content {
+		t.Errorf("Expected:\n%q\nGot:\n%q", content, got)
+	}
+
+	_ = fm.ToggleTodoItem(0)
+	expected := "# Todos\n\n1. [x] First\n   A note\n2. [x] Second\n\n## Later\n\n5) [ ] Fifth\n6) [ ] Sixth\n"
+	if got := SerializeMarkdown(fm); got != expected {
+		t.Errorf("Expected:\n%q\nGot:\n%q", expected, got)
+	}
+}
+
+func TestOrderedList_RenumberedAfterInsert(t *testing.T) {
+	fm := ParseMarkdown("# Todos\n\n1. [ ] First\n2. [ ] Second\n3. [ ] Third\n")
+
+	if idx := fm.InsertTodoItemAfter(0, "Inserted", false); idx != 1 {
+		t.Fatalf("Expected the todo inserted at 1, got %d", idx)
+	}
+
+	expected := "# Todos\n\n1. [ ] First\n2. [ ] Inserted\n3. [ ] Second\n4. [ ] Third\n"
+	if got := SerializeMarkdown(fm); got != expected {
+		t.Errorf("Expected:\n%q\nGot:\n%q", expected, got)
+	}
+	if !fm.Todos[1].Ordered {
+		t.Error("Expected the inserted todo to be part of the ordered list")
+	}
+}
+
+func TestOrderedList_RenumberedAfterDelete(t *testing.T) {
+	fm := ParseMarkdown("# Todos\n\n1. [ ] First\n2. [ ] Second\n3. [ ] Third\n")
+
+	if err := fm.DeleteTodoItem(0); err != nil {
+		t.Fatalf("DeleteTodoItem failed: %v", err)
+	}
+
+	expected := "# Todos\n\n1. [ ] Second\n2. [ ] Third\n"
+	if got := SerializeMarkdown(fm); got != expected {
+		t.Errorf("Expected:\n%q\nGot:\n%q", expected, got)
+	}
+}
+
+func TestOrderedList_KeepsStartNumber(t *testing.T) {
+	fm := ParseMarkdown("# Todos\n\n3. [ ] Third\n4. [ ] Fourth\n")
+
+	fm.AddTodoItem("Fifth", false)
+
+	expected := "# Todos\n\n3. [ ] Third\n4. [ ] Fourth\n5. [ ] Fifth\n"
+	if got := SerializeMarkdown(fm); got != expected {
+		t.Errorf("Expected:\n%q\nGot:\n%q", expected, got)
+	}
+}
+
+func TestOrderedList_NestedPastNumber(t *testing.T) {
+	fm := ParseMarkdown("# Todos\n\n1. [ ] Parent\n2. [ ] Child\n")
+
+	if err := fm.IndentTodoItem(1); err != nil {
+		t.Fatalf("IndentTodoItem failed: %v", err)
+	}
+
+	content := SerializeMarkdown(fm)
+	expected := "# Todos\n\n1. [ ] Parent\n   - [ ] Child\n"
+	if content != expected {
+		t.Errorf("Expected:\n%q\nGot:\n%q", expected, content)
+	}
+	if reparsed := ParseMarkdown(content); len(reparsed.Todos) != 2 || reparsed.Todos[1].ParentIndex != 0 {
+		t.Errorf("Expected the child to stay nested after a reparse, got %+v", reparsed.Todos)
+	}
+}
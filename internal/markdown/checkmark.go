@@ -0,0 +1,91 @@
+package markdown
+
+import (
+	"bytes"
+	"regexp"
+	"unicode/utf8"
+
+	"github.com/yuin/goldmark/ast"
+	extast "github.com/yuin/goldmark/extension/ast"
+)
+
+// PreserveCheckChar keeps the original check character ([X], [✓]) of checked
+// todos when saving instead of normalizing it to [x]
+var PreserveCheckChar bool
+
+// checkMarkRegex matches task list items checked with a check mark, e.g. "- [✓] Done"
+// GFM only understands [x] and [X], so these are rewritten before parsing
+var checkMarkRegex = regexp.MustCompile(`(?m)^([ \t]*(?:[-*+]|\d+[.)])[ \t]+)\[✓\]`)
+
+// progressMarkRegex matches task list items marked in progress, e.g. "- [-] Doing" or "- [/] Doing"
+var progressMarkRegex = regexp.MustCompile(`(?m)^([ \t]*(?:[-*+]|\d+[.)])[ \t]+)\[[-/]\]`)
+
+// ProgressChar is written inside the checkbox of todos marked in progress
+// when they weren't read that way: "-" for [-] or "/" for [/]
+var ProgressChar = "-"
+
+// CycleProgress makes toggling an open todo mark it in progress first, so
+// toggling cycles open, in progress, done
+var CycleProgress bool
+
+// normalizeCheckMarks returns source with [✓] checkboxes replaced by [x] and
+// in-progress checkboxes by [ ], padded to the same byte length so AST segments
+// stay valid for the original source
+func normalizeCheckMarks(source []byte) []byte {
+	if bytes.Contains(source, []byte("✓")) {
+		source = checkMarkRegex.ReplaceAll(source, []byte("${1}[x]  "))
+	}
+	if bytes.Contains(source, []byte("[-]")) || bytes.Contains(source, []byte("[/]")) {
+		source = progressMarkRegex.ReplaceAll(source, []byte("${1}[ ]"))
+	}
+	return source
+}
+
+// recordCheckChars remembers checkboxes written with something other than a
+// lowercase x, and the character of checkboxes marked in progress
+func (doc *ASTDocument) recordCheckChars() {
+	doc.checkChars = make(map[ast.Node]string)
+	doc.progress = make(map[ast.Node]string)
+	_ = ast.Walk(doc.AST, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering || n.Kind() != extast.KindTaskCheckBox {
+			return ast.WalkContinue, nil
+		}
+		checkbox := n.(*extast.TaskCheckBox)
+		block := checkbox.Parent()
+		if block == nil || block.Lines().Len() == 0 {
+			return ast.WalkContinue, nil
+		}
+
+		// The block's first line starts at the opening bracket of the checkbox
+		start := block.Lines().At(0).Start
+		if start+1 >= len(doc.Source) || doc.Source[start] != '[' {
+			return ast.WalkContinue, nil
+		}
+		r, _ := utf8.DecodeRune(doc.Source[start+1:])
+		switch {
+		case !checkbox.IsChecked:
+			if r == '-' || r == '/' {
+				doc.progress[checkbox] = string(r)
+			}
+		case r != 'x' && r != utf8.RuneError:
+			doc.checkChars[checkbox] = string(r)
+		}
+		return ast.WalkContinue, nil
+	})
+}
+
+// checkChar returns the character to write inside a checked checkbox
+func (doc *ASTDocument) checkChar(checkbox ast.Node) string {
+	if PreserveCheckChar {
+		if c, ok := doc.checkChars[checkbox]; ok {
+			return c
+		}
+	}
+	return "x"
+}
+
+// progressChar returns the character to write inside the checkbox of a todo
+// marked in progress, or "" if it isn't
+func (doc *ASTDocument) progressChar(checkbox ast.Node) string {
+	return doc.progress[checkbox]
+}
@@ -0,0 +1,71 @@
+package markdown
+
+import "testing"
+
+func TestCheckMark_UppercaseXIsChecked(t *testing.T) {
+	fm := ParseMarkdown("# Todos\n\n- [X] Done\n- [ ] Open\n")
+
+	if len(fm.Todos) != 2 {
+		t.Fatalf("Expected 2 todos, got %d", len(fm.Todos))
+	}
+	if !fm.Todos[0].Checked {
+		t.Error("Expected '- [X] Done' to be checked")
+	}
+	if fm.Todos[0].Text != "Done" {
+		t.Errorf("Expected text 'Done', got %q", fm.Todos[0].Text)
+	}
+}
+
+func TestCheckMark_CheckSymbolIsChecked(t *testing.T) {
+	fm := ParseMarkdown("# Todos\n\n- [✓] Shipped\n* [✓] Also shipped\n- [ ] Open\n")
+
+	if len(fm.Todos) != 3 {
+		t.Fatalf("Expected 3 todos, got %d", len(fm.Todos))
+	}
+	for i := 0; i < 2; i++ {
+		if !fm.Todos[i].Checked {
+			t.Errorf("Todo %d should be checked", i)
+		}
+	}
+	if fm.Todos[0].Text != "Shipped" {
+		t.Errorf("Expected text 'Shipped', got %q", fm.Todos[0].Text)
+	}
+}
+
+func TestCheckMark_CheckSymbolInCodeBlockUntouched(t *testing.T) {
+	content := "# Todos\n\n- [ ] Task\n\n```\n- [✓] not a task\n```\n"
+	fm := ParseMarkdown(content)
+
+	if len(fm.Todos) != 1 {
+		t.Fatalf("Expected 1 todo, got %d", len(fm.Todos))
+	}
+	if got := SerializeMarkdown(fm); got != content {
+		t.Errorf("Expected code block unchanged.\nExpected:\n%q\nGot:\n%q", content, got)
+	}
+}
+
+func TestCheckMark_NormalizedOnSave(t *testing.T) {
+	PreserveCheckChar = false
+
+	fm := ParseMarkdown("# Todos\n\n- [X] Upper\n- [✓] Symbol\n- [ ] Open\n")
+	_ = fm.UpdateTodoItem(2, "Open", true)
+
+	expected := "# Todos\n\n- [x] Upper\n- [x] Symbol\n- [x] Open\n"
+	if got := SerializeMarkdown(fm); got != expected {
+		t.Errorf("Expected:\n%q\nGot:\n%q", expected, got)
+	}
+}
+
+func TestCheckMark_PreservedWhenConfigured(t *testing.T) {
+	PreserveCheckChar = true
+	defer func() { PreserveCheckChar = false }()
+
+	fm := ParseMarkdown("# Todos\n\n- [X] Upper\n- [✓] Symbol\n- [ ] Open\n")
+	_ = fm.UpdateTodoItem(1, "Symbol edited", true)
+	_ = fm.UpdateTodoItem(2, "Open", true)
+
+	expected := "# Todos\n\n- [X] Upper\n- [✓] Symbol edited\n- [x] Open\n"
+	if got := SerializeMarkdown(fm); got != expected {
+		t.Errorf("Expected:\n%q\nGot:\n%q", expected, got)
+	}
+}
@@ -0,0 +1,215 @@
+package markdown
+
+import (
+	"bytes"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/yuin/goldmark/ast"
+	extast "github.com/yuin/goldmark/extension/ast"
+)
+
+// atxHeadingRegex matches a "#" heading line
+var atxHeadingRegex = regexp.MustCompile(`^#{1,6}(\s|$)`)
+
+// cleanEdit replaces the source from start to stop with text
+type cleanEdit struct {
+	start, stop int
+	text        string
+}
+
+// CleanMarkdown normalizes the layout of a todo file: "-" as the bullet of
+// every list item, [x] for checked todos (unless PreserveCheckChar is set, as
+// when saving), one blank line around headings, no repeated blank lines and a
+// single trailing newline. Todo tokens are put in canonical order when
+// NormalizeTokens is set. Bullets and checkboxes are found in the parsed
+// document, so frontmatter, code blocks and HTML blocks are left alone.
+// Cleaning a cleaned file doesn't change it.
+func CleanMarkdown(content string) string {
+	frontmatter := ""
+	if loc := frontmatterRegex.FindStringIndex(content); loc != nil {
+		// The match may include blank lines after the closing "---"
+		frontmatter = strings.TrimRight(content[:loc[1]], " \t\n") + "\n"
+		content = content[loc[1]:]
+	}
+
+	doc, err := ParseAST(content)
+	if err != nil {
+		return frontmatter + content
+	}
+	verbatim := doc.verbatimLines()
+	content = applyCleanEdits(doc.Source, doc.cleanEdits())
+
+	var lines []string
+	blank := false        // a blank line is pending before the next content line
+	afterHeading := false // the last line written is a heading
+	for i, line := range strings.Split(content, "\n") {
+		if verbatim[i] && (verbatim[i-1] || strings.TrimSpace(line) == "") {
+			// Inside a code or HTML block every line is kept as-is
+			lines = append(lines, line)
+			continue
+		}
+
+		if strings.TrimSpace(line) == "" {
+			blank = len(lines) > 0
+			continue
+		}
+
+		heading := !verbatim[i] && atxHeadingRegex.MatchString(line)
+		if len(lines) > 0 && (blank || heading || afterHeading) {
+			lines = append(lines, "")
+		}
+		blank = false
+		afterHeading = heading
+		lines = append(lines, line)
+	}
+
+	// A code block at the end of the file doesn't keep trailing blank lines
+	for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	if len(lines) == 0 {
+		return frontmatter
+	}
+	return frontmatter + strings.Join(lines, "\n") + "\n"
+}
+
+// verbatimLines returns the numbers of the lines inside code and HTML blocks,
+// which cleaning passes through unchanged
+func (doc *ASTDocument) verbatimLines() map[int]bool {
+	var starts []int // Offset of the start of every line after the first
+	for i, b := range doc.Source {
+		if b == '\n' {
+			starts = append(starts, i+1)
+		}
+	}
+	lineOf := func(pos int) int {
+		return sort.SearchInts(starts, pos+1)
+	}
+
+	verbatim := make(map[int]bool)
+	_ = ast.Walk(doc.AST, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		switch n.Kind() {
+		case ast.KindCodeBlock, ast.KindFencedCodeBlock, ast.KindHTMLBlock:
+			lines := n.Lines()
+			for i := 0; i < lines.Len(); i++ {
+				verbatim[lineOf(lines.At(i).Start)] = true
+			}
+			if html, ok := n.(*ast.HTMLBlock); ok && html.HasClosure() {
+				verbatim[lineOf(html.ClosureLine.Start)] = true
+			}
+			return ast.WalkSkipChildren, nil
+		}
+		return ast.WalkContinue, nil
+	})
+	return verbatim
+}
+
+// cleanEdits returns the edits that give bullet list items the "-" bullet,
+// write [x] in checked checkboxes and normalize the tokens of todos
+func (doc *ASTDocument) cleanEdits() []cleanEdit {
+	var edits []cleanEdit
+	_ = ast.Walk(doc.AST, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		switch n := n.(type) {
+		case *ast.ListItem:
+			if list, ok := n.Parent().(*ast.List); ok && !list.IsOrdered() && list.Marker != '-' {
+				if pos, ok := doc.markerPos(n); ok {
+					edits = append(edits, cleanEdit{pos, pos + 1, "-"})
+				}
+			}
+		case *extast.TaskCheckBox:
+			edits = append(edits, doc.checkboxEdits(n)...)
+		}
+		return ast.WalkContinue, nil
+	})
+	return edits
+}
+
+// markerPos returns the offset of a bullet list item's marker
+func (doc *ASTDocument) markerPos(item ast.Node) (int, bool) {
+	var pos int
+	if nested, ok := item.FirstChild().(*ast.List); ok && nested.FirstChild() != nil {
+		// "* * Item" starts a nested list on the marker's line
+		p, ok := doc.markerPos(nested.FirstChild())
+		if !ok {
+			return 0, false
+		}
+		pos = p
+	} else {
+		start, _, ok := nodeSpan(item)
+		if !ok {
+			return 0, false
+		}
+		pos = start
+	}
+	for pos > 0 && (doc.Source[pos-1] == ' ' || doc.Source[pos-1] == '\t') {
+		pos--
+	}
+	list, ok := item.Parent().(*ast.List)
+	if !ok || pos == 0 || doc.Source[pos-1] != list.Marker {
+		return 0, false
+	}
+	return pos - 1, true
+}
+
+// checkboxEdits returns the edits that write [x] in a checked checkbox written
+// another way and normalize the tokens on the todo's first line
+func (doc *ASTDocument) checkboxEdits(checkbox *extast.TaskCheckBox) []cleanEdit {
+	block := checkbox.Parent()
+	if block == nil || block.Lines().Len() == 0 {
+		return nil
+	}
+	// The block's first line starts at the opening bracket of the checkbox
+	start := block.Lines().At(0).Start
+	if start+1 >= len(doc.Source) || doc.Source[start] != '[' {
+		return nil
+	}
+
+	var edits []cleanEdit
+	r, size := utf8.DecodeRune(doc.Source[start+1:])
+	if checkbox.IsChecked && r != 'x' && !PreserveCheckChar {
+		edits = append(edits, cleanEdit{start + 1, start + 1 + size, "x"})
+	}
+
+	textStart := start + 1 + size + 1 // Past the closing bracket
+	for textStart < len(doc.Source) && (doc.Source[textStart] == ' ' || doc.Source[textStart] == '\t') {
+		textStart++
+	}
+	textStop := lineEnd(doc.Source, textStart)
+	if textStop > textStart && doc.Source[textStop-1] == '\n' {
+		textStop--
+	}
+	if textStart < textStop {
+		text := string(doc.Source[textStart:textStop])
+		if normalized := normalizeText(text); normalized != text {
+			edits = append(edits, cleanEdit{textStart, textStop, normalized})
+		}
+	}
+	return edits
+}
+
+// applyCleanEdits returns source with the edits applied
+func applyCleanEdits(source []byte, edits []cleanEdit) string {
+	slices.SortFunc(edits, func(a, b cleanEdit) int { return a.start - b.start })
+	var b bytes.Buffer
+	pos := 0
+	for _, e := range edits {
+		if e.start < pos {
+			continue // Overlaps an earlier edit
+		}
+		b.Write(source[pos:e.start])
+		b.WriteString(e.text)
+		pos = e.stop
+	}
+	b.Write(source[pos:])
+	return b.String()
+}
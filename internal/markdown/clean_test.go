@@ -0,0 +1,106 @@
+package markdown
+
+import "testing"
+
+const messyFile = "---\nfilter-done: true\n---\n\n\n# Todos\n* [ ] Buy milk\n+ [x] Call mom   \n\n\n\n## Later\n\n\n\n- [ ] Fix bug\n    * [ ] Nested\n  \n```\n* not a list\n\n\n# not a heading\n```\n\n* * *\n# Notes\nSome text\n\n\n"
+
+const cleanFile = "---\nfilter-done: true\n---\n# Todos\n\n- [ ] Buy milk\n- [x] Call mom   \n\n## Later\n\n- [ ] Fix bug\n    - [ ] Nested\n\n```\n* not a list\n\n\n# not a heading\n```\n\n* * *\n\n# Notes\n\nSome text\n"
+
+func TestCleanMarkdown_MessyFile(t *testing.T) {
+	if got := CleanMarkdown(messyFile); got != cleanFile {
+		t.Errorf("CleanMarkdown =\n%q\nwant\n%q", got, cleanFile)
+	}
+}
+
+func TestCleanMarkdown_Idempotent(t *testing.T) {
+	once := CleanMarkdown(messyFile)
+	if twice := CleanMarkdown(once); twice != once {
+		t.Errorf("Cleaning twice changed the file:\n%q\n%q", once, twice)
+	}
+	for _, content := range []string{"", "\n\n", "# Todos", "```\n- x\n\n\n", "- [ ] Only\n"} {
+		once := CleanMarkdown(content)
+		if twice := CleanMarkdown(once); twice != once {
+			t.Errorf("CleanMarkdown(%q) isn't stable: %q then %q", content, once, twice)
+		}
+	}
+}
+
+func TestCleanMarkdown_TrailingNewline(t *testing.T) {
+	if got := CleanMarkdown("# Todos\n\n- [ ] Task"); got != "# Todos\n\n- [ ] Task\n" {
+		t.Errorf("CleanMarkdown = %q", got)
+	}
+}
+
+func TestCleanMarkdown_KeepsCleanFile(t *testing.T) {
+	content := "# Todos\n\n- [ ] Task #work !p1\n  A note\n- [x] Done\n\n[ref]: https://example.com\n"
+	if got := CleanMarkdown(content); got != content {
+		t.Errorf("CleanMarkdown changed a clean file:\n%q", got)
+	}
+}
+
+func TestCleanMarkdown_NormalizeTokens(t *testing.T) {
+	content := "# Todos\n\n- [ ] !p1 Task #work @due(2025-01-15) more\n"
+
+	if got := CleanMarkdown(content); got != content {
+		t.Errorf("Tokens moved without NormalizeTokens: %q", got)
+	}
+
+	NormalizeTokens = true
+	t.Cleanup(func() { NormalizeTokens = false })
+	want := "# Todos\n\n- [ ] Task more #work !p1 @due(2025-01-15)\n"
+	if got := CleanMarkdown(content); got != want {
+		t.Errorf("CleanMarkdown = %q, want %q", got, want)
+	}
+}
+
+func TestCleanMarkdown_CheckChars(t *testing.T) {
+	content := "# Todos\n\n- [X] Upper\n- [✓] Check mark\n1. [X] Ordered\n- [ ] Open\n- [-] Doing\n"
+	want := "# Todos\n\n- [x] Upper\n- [x] Check mark\n1. [x] Ordered\n- [ ] Open\n- [-] Doing\n"
+	if got := CleanMarkdown(content); got != want {
+		t.Errorf("CleanMarkdown = %q, want %q", got, want)
+	}
+
+	// Saving the toggled file writes the same checkbox
+	fm := ParseMarkdown(content)
+	_ = fm.ToggleTodoItem(3)
+	_ = fm.ToggleTodoItem(3)
+	if got := SerializeMarkdown(fm); got != want {
+		t.Errorf("Saving wrote %q, want %q like clean", got, want)
+	}
+
+	PreserveCheckChar = true
+	t.Cleanup(func() { PreserveCheckChar = false })
+	if got := CleanMarkdown(content); got != content {
+		t.Errorf("CleanMarkdown changed check characters with PreserveCheckChar: %q", got)
+	}
+}
+
+func TestCleanMarkdown_KeepsIndentedCode(t *testing.T) {
+	content := "# Todos\n\n- [ ] Task\n\nSome code:\n\n    * [X] literal in code\n\n\n    # not a heading\n\n- [ ] Other\n"
+	if got := CleanMarkdown(content); got != content {
+		t.Errorf("CleanMarkdown changed an indented code block:\n%q\nwant\n%q", got, content)
+	}
+}
+
+func TestCleanMarkdown_KeepsHTMLBlocks(t *testing.T) {
+	content := "# Todos\n\n<div>\n* keep\n# not a heading\n- [X] literal\n</div>\n\n* [X] Task\n"
+	want := "# Todos\n\n<div>\n* keep\n# not a heading\n- [X] literal\n</div>\n\n- [x] Task\n"
+	if got := CleanMarkdown(content); got != want {
+		t.Errorf("CleanMarkdown =\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestCleanMarkdown_KeepsFencedCode(t *testing.T) {
+	content := "# Todos\n\n```\n\n* [X] first line blank\n\n\n```\n\n  ~~~md\n  # in a list\n  ~~~\n"
+	if got := CleanMarkdown(content); got != content {
+		t.Errorf("CleanMarkdown changed a fenced code block:\n%q\nwant\n%q", got, content)
+	}
+}
+
+func TestCleanMarkdown_NestedAndQuotedBullets(t *testing.T) {
+	content := "# Todos\n\n* * [ ] Nested on one line\n> + [X] Quoted\n"
+	want := "# Todos\n\n- - [ ] Nested on one line\n> - [x] Quoted\n"
+	if got := CleanMarkdown(content); got != want {
+		t.Errorf("CleanMarkdown = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,53 @@
+package markdown
+
+import (
+	"slices"
+	"strings"
+)
+
+// LabelColors are the colors a todo can be labelled with, as in "@red"
+var LabelColors = []string{"red", "orange", "yellow", "green", "blue", "purple", "pink", "gray"}
+
+// IsLabelColor reports whether name (without the @) is one of the LabelColors
+func IsLabelColor(name string) bool {
+	return containsFold(LabelColors, name)
+}
+
+// ExtractColor returns the label color of a todo in lower case, or "" if it
+// has none. If there are several @color tokens the last one wins.
+func ExtractColor(text string) string {
+	color := ""
+	for _, token := range extractWordTokens(contextRegex, text) {
+		if IsLabelColor(token) {
+			color = strings.ToLower(token)
+		}
+	}
+	return color
+}
+
+// RemoveColor deletes the @color tokens from the text, along with the space
+// before them. Other contexts are kept.
+func RemoveColor(text string) string {
+	masked := maskInlineSpans(text)
+	var b strings.Builder
+	last := 0
+	for _, loc := range contextRegex.FindAllStringSubmatchIndex(masked, -1) {
+		name := text[loc[2]:loc[3]]
+		if !IsLabelColor(name) || (loc[3] < len(text) && text[loc[3]] == '(') {
+			continue
+		}
+		start := loc[2] - 1 // The @
+		if start > last && text[start-1] == ' ' {
+			start--
+		}
+		b.WriteString(text[last:start])
+		last = loc[3]
+	}
+	b.WriteString(text[last:])
+	return strings.TrimSpace(b.String())
+}
+
+// withoutColors drops the label colors from a list of contexts
+func withoutColors(contexts []string) []string {
+	return slices.DeleteFunc(contexts, IsLabelColor)
+}
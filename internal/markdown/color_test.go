@@ -0,0 +1,55 @@
+package markdown
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestExtractColor(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"Call mom @red", "red"},
+		{"@Blue first", "blue"},
+		{"Both @red and @green", "green"},
+		{"Meeting @work", ""},
+		{"Team @red-team", ""},
+		{"Email me@red.com", ""},
+		{"Code `@red` span", ""},
+	}
+	for _, tt := range tests {
+		if got := ExtractColor(tt.text); got != tt.want {
+			t.Errorf("ExtractColor(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestRemoveColor(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"Call mom @red", "Call mom"},
+		{"@red Call mom @home", "Call mom @home"},
+		{"Call @blue mom #family", "Call mom #family"},
+		{"Keep `@red` in code", "Keep `@red` in code"},
+	}
+	for _, tt := range tests {
+		if got := RemoveColor(tt.text); got != tt.want {
+			t.Errorf("RemoveColor(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestParseMarkdown_ColorIsNotAContext(t *testing.T) {
+	fm := ParseMarkdown("# Todos\n\n- [ ] Call mom @red @phone\n")
+
+	todo := fm.Todos[0]
+	if todo.Color != "red" {
+		t.Errorf("Color = %q, want red", todo.Color)
+	}
+	if !slices.Equal(todo.Contexts, []string{"phone"}) {
+		t.Errorf("Contexts = %v, want [phone]", todo.Contexts)
+	}
+}
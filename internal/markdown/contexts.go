@@ -0,0 +1,130 @@
+package markdown
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// contextRegex matches todo.txt style contexts like @home or @phone
+// The @ must start a word so email addresses are not picked up
+var contextRegex = regexp.MustCompile(`(?:^|\s)@([a-zA-Z0-9_-]+)`)
+
+// projectRegex matches todo.txt style projects like +website
+// The + must start a word so expressions like C++ or a+b are not picked up
+var projectRegex = regexp.MustCompile(`(?:^|\s)\+([a-zA-Z0-9_-]+)`)
+
+// ExtractContexts extracts all @contexts from todo text
+// @due(...) dates and @color labels are not contexts
+func ExtractContexts(text string) []string {
+	return withoutColors(extractWordTokens(contextRegex, text))
+}
+
+// ExtractProjects extracts all +projects from todo text
+func ExtractProjects(text string) []string {
+	return extractWordTokens(projectRegex, text)
+}
+
+// extractWordTokens returns the unique names matched by re, skipping function-like
+// tokens such as @due(2025-01-15) and anything inside code spans or link targets
+func extractWordTokens(re *regexp.Regexp, text string) []string {
+	text = maskInlineSpans(text)
+	matches := re.FindAllStringSubmatchIndex(text, -1)
+	tokens := make([]string, 0, len(matches))
+	seen := make(map[string]bool)
+
+	for _, match := range matches {
+		start, end := match[2], match[3]
+		if end < len(text) && text[end] == '(' {
+			continue
+		}
+		token := text[start:end]
+		if !seen[token] {
+			tokens = append(tokens, token)
+			seen[token] = true
+		}
+	}
+
+	return tokens
+}
+
+// HasContext checks if a todo has a specific context
+func (t *Todo) HasContext(context string) bool {
+	return containsFold(t.Contexts, context)
+}
+
+// HasAnyContext checks if a todo has any of the specified contexts
+func (t *Todo) HasAnyContext(contexts []string) bool {
+	if len(contexts) == 0 {
+		return true // No filter means match all
+	}
+	for _, context := range contexts {
+		if t.HasContext(context) {
+			return true
+		}
+	}
+	return false
+}
+
+// HasProject checks if a todo belongs to a specific project
+func (t *Todo) HasProject(project string) bool {
+	return containsFold(t.Projects, project)
+}
+
+// HasAnyProject checks if a todo belongs to any of the specified projects
+func (t *Todo) HasAnyProject(projects []string) bool {
+	if len(projects) == 0 {
+		return true // No filter means match all
+	}
+	for _, project := range projects {
+		if t.HasProject(project) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetAllContexts returns all unique contexts from a list of todos, sorted alphabetically
+func GetAllContexts(todos []Todo) []string {
+	var all [][]string
+	for _, todo := range todos {
+		all = append(all, todo.Contexts)
+	}
+	return sortedUnion(all)
+}
+
+// GetAllProjects returns all unique projects from a list of todos, sorted alphabetically
+func GetAllProjects(todos []Todo) []string {
+	var all [][]string
+	for _, todo := range todos {
+		all = append(all, todo.Projects)
+	}
+	return sortedUnion(all)
+}
+
+// containsFold reports whether values contains s, ignoring case
+func containsFold(values []string, s string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// sortedUnion merges string lists into a sorted list without duplicates
+func sortedUnion(lists [][]string) []string {
+	set := make(map[string]bool)
+	for _, list := range lists {
+		for _, v := range list {
+			set[v] = true
+		}
+	}
+
+	result := make([]string, 0, len(set))
+	for v := range set {
+		result = append(result, v)
+	}
+	sort.Strings(result)
+	return result
+}
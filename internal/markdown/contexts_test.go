@@ -0,0 +1,90 @@
+package markdown
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExtractContexts(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected []string
+	}{
+		{"single", "Call mom @phone", []string{"phone"}},
+		{"multiple", "@home Fix sink @weekend", []string{"home", "weekend"}},
+		{"duplicates", "Buy milk @store @home @store", []string{"store", "home"}},
+		{"due date is not a context", "Pay rent @due(2025-01-01) @home", []string{"home"}},
+		{"email is not a context", "Mail bob@example.com", []string{}},
+		{"none", "Plain task", []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ExtractContexts(tt.text)
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("ExtractContexts(%q) = %v, want %v", tt.text, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestExtractProjects(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		expected []string
+	}{
+		{"single", "Write copy +website", []string{"website"}},
+		{"multiple", "+garden Plant tomatoes +spring", []string{"garden", "spring"}},
+		{"duplicates", "+app fix +app login +api", []string{"app", "api"}},
+		{"operators are not projects", "Learn C++ and a+b", []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ExtractProjects(tt.text)
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("ExtractProjects(%q) = %v, want %v", tt.text, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestContextsAndProjects_ParsedIntoTodos(t *testing.T) {
+	fm := ParseMarkdown("# Todos\n\n- [ ] Call plumber @phone +house #urgent\n- [ ] Write docs @work +website\n")
+
+	if !reflect.DeepEqual(fm.Todos[0].Contexts, []string{"phone"}) {
+		t.Errorf("Expected contexts [phone], got %v", fm.Todos[0].Contexts)
+	}
+	if !reflect.DeepEqual(fm.Todos[0].Projects, []string{"house"}) {
+		t.Errorf("Expected projects [house], got %v", fm.Todos[0].Projects)
+	}
+	if !reflect.DeepEqual(fm.Todos[0].Tags, []string{"urgent"}) {
+		t.Errorf("Expected tags [urgent], got %v", fm.Todos[0].Tags)
+	}
+
+	if got := GetAllContexts(fm.Todos); !reflect.DeepEqual(got, []string{"phone", "work"}) {
+		t.Errorf("GetAllContexts = %v", got)
+	}
+	if got := GetAllProjects(fm.Todos); !reflect.DeepEqual(got, []string{"house", "website"}) {
+		t.Errorf("GetAllProjects = %v", got)
+	}
+}
+
+func TestHasAnyContextAndProject(t *testing.T) {
+	todo := Todo{Contexts: []string{"Home"}, Projects: []string{"website"}}
+
+	if !todo.HasAnyContext(nil) || !todo.HasAnyProject(nil) {
+		t.Error("Empty filter should match all todos")
+	}
+	if !todo.HasAnyContext([]string{"work", "home"}) {
+		t.Error("Expected case-insensitive context match")
+	}
+	if todo.HasAnyContext([]string{"work"}) {
+		t.Error("Did not expect @work to match")
+	}
+	if !todo.HasAnyProject([]string{"website"}) || todo.HasAnyProject([]string{"garden"}) {
+		t.Error("Project matching is wrong")
+	}
+}
@@ -982,6 +982,26 @@ func TestASTDocument_MoveTodoToPosition_SameIndex(t *testing.T) {
 	}
 }
 
+func TestASTDocument_MoveTodoIntoOwnChildren(t *testing.T) {
+	content := `# Todos
+
+- [ ] Parent
+  - [ ] Child
+- [ ] Other
+`
+	doc, _ := ParseAST(content)
+
+	if err := doc.MoveTodo(0, 1); err == nil {
+		t.Error("MoveTodo into its own child should fail")
+	}
+	if err := doc.MoveTodoToPosition(0, 1, true); err == nil {
+		t.Error("MoveTodoToPosition into its own child should fail")
+	}
+	if todos := doc.ExtractTodos(); len(todos) != 3 {
+		t.Errorf("Expected all 3 todos to be kept, got %d", len(todos))
+	}
+}
+
 func TestASTDocument_SwapTodos(t *testing.T) {
 	content := `# Todos
 
@@ -0,0 +1,34 @@
+package markdown
+
+import "strings"
+
+// dedupeKey normalizes todo text for duplicate detection
+func dedupeKey(text string) string {
+	return strings.ToLower(strings.TrimSpace(text))
+}
+
+// RemoveDuplicates deletes todos whose text matches an earlier todo, ignoring case
+// and surrounding whitespace. The first todo is kept, and checked if any of its
+// duplicates was. Subtasks of removed todos move up a level, as with any delete.
+// Returns the number of todos removed.
+func (fm *FileModel) RemoveDuplicates() int {
+	first := make(map[string]int)
+	var duplicates []int
+	for i, todo := range fm.Todos {
+		key := dedupeKey(todo.Text)
+		if keep, ok := first[key]; ok {
+			duplicates = append(duplicates, i)
+			if todo.Checked && !fm.Todos[keep].Checked {
+				_ = fm.UpdateTodoItem(keep, fm.Todos[keep].Text, true)
+			}
+			continue
+		}
+		first[key] = i
+	}
+
+	// Delete from the end so earlier indices stay valid
+	for i := len(duplicates) - 1; i >= 0; i-- {
+		_ = fm.DeleteTodoItem(duplicates[i])
+	}
+	return len(duplicates)
+}
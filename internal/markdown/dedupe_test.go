@@ -0,0 +1,45 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRemoveDuplicates_KeepsFirstAndCheckedState(t *testing.T) {
+	fm := ParseMarkdown("# Todos\n\n- [ ] Buy milk\n- [ ] Call mom\n- [x] buy milk \n- [ ] BUY MILK\n")
+
+	if removed := fm.RemoveDuplicates(); removed != 2 {
+		t.Errorf("removed = %d, want 2", removed)
+	}
+
+	want := "# Todos\n\n- [x] Buy milk\n- [ ] Call mom\n"
+	if got := SerializeMarkdown(fm); got != want {
+		t.Errorf("got:\n%q\nwant:\n%q", got, want)
+	}
+}
+
+func TestRemoveDuplicates_NoDuplicates(t *testing.T) {
+	content := "# Todos\n\n- [ ] A\n- [ ] B\n"
+	fm := ParseMarkdown(content)
+
+	if removed := fm.RemoveDuplicates(); removed != 0 {
+		t.Errorf("removed = %d, want 0", removed)
+	}
+	if got := SerializeMarkdown(fm); got != content {
+		t.Errorf("File changed:\n%q", got)
+	}
+}
+
+func TestRemoveDuplicates_PromotesSubtasksOfRemovedTodo(t *testing.T) {
+	fm := ParseMarkdown("# Todos\n\n## Work\n\n- [ ] Review\n\n## Home\n\n- [ ] review\n  - [ ] Garden plans\n")
+
+	fm.RemoveDuplicates()
+
+	got := SerializeMarkdown(fm)
+	if !strings.Contains(got, "## Home\n\n- [ ] Garden plans\n") {
+		t.Errorf("Expected subtask promoted under its heading:\n%s", got)
+	}
+	if strings.Count(strings.ToLower(got), "review") != 1 {
+		t.Errorf("Expected a single review todo:\n%s", got)
+	}
+}
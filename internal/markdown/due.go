@@ -1,8 +1,10 @@
 package markdown
 
 import (
+	"fmt"
 	"regexp"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -11,17 +13,71 @@ import (
 // Format: @due(YYYY-MM-DD)
 var dueRegex = regexp.MustCompile(`@due\((\d{4}-\d{2}-\d{2})\)`)
 
+// relativeDueRegex matches relative due date tokens like due:tomorrow, due:fri or due:+3d.
+// They are resolved against the current day whenever the text is parsed, so the
+// token itself stays in the text.
+var relativeDueRegex = regexp.MustCompile(`(?i)(?:^|\s)(due:(today|tomorrow|mon|tue|wed|thu|fri|sat|sun|\+\d+d))\b`)
+
+// weekdayTokens maps the short weekday names used in relative due tokens
+var weekdayTokens = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+// resolveRelativeDue turns the value of a relative due token (e.g. "tomorrow",
+// "mon" or "+3d") into a date relative to today. Weekdays resolve to their next
+// occurrence after today, so due:mon written on a Monday means a week later.
+func resolveRelativeDue(value string) (time.Time, bool) {
+	today := startOfDay(now())
+	value = strings.ToLower(value)
+
+	switch value {
+	case "today":
+		return today, true
+	case "tomorrow":
+		return today.AddDate(0, 0, 1), true
+	}
+
+	if wd, ok := weekdayTokens[value]; ok {
+		days := (int(wd)-int(today.Weekday())+6)%7 + 1
+		return today.AddDate(0, 0, days), true
+	}
+
+	if strings.HasPrefix(value, "+") && strings.HasSuffix(value, "d") {
+		days, err := strconv.Atoi(value[1 : len(value)-1])
+		if err != nil {
+			return time.Time{}, false
+		}
+		return today.AddDate(0, 0, days), true
+	}
+
+	return time.Time{}, false
+}
+
 // ExtractDueDate extracts the due date from todo text.
 // Returns nil if no due date is set or if the date is invalid.
 // If multiple due dates exist, returns the earliest one.
+// Relative tokens like due:tomorrow count as well and are resolved against today.
 // The returned time is in local timezone at midnight.
+// Markers inside code spans or link targets are ignored.
 func ExtractDueDate(text string) *time.Time {
-	matches := dueRegex.FindAllStringSubmatch(text, -1)
-	if len(matches) == 0 {
-		return nil
-	}
+	masked := maskInlineSpans(text)
+	matches := dueRegex.FindAllStringSubmatch(masked, -1)
 
 	var earliestDate *time.Time
+	for _, match := range relativeDueRegex.FindAllStringSubmatch(masked, -1) {
+		if date, ok := resolveRelativeDue(match[2]); ok {
+			if earliestDate == nil || date.Before(*earliestDate) {
+				earliestDate = &date
+			}
+		}
+	}
+
 	for _, match := range matches {
 		if len(match) > 1 {
 			dateStr := match[1]
@@ -38,22 +94,96 @@ func ExtractDueDate(text string) *time.Time {
 	return earliestDate
 }
 
-// HasDueDate checks if the text contains a due date marker
+// HasDueDate checks if the text contains a due date marker or relative due token
 func HasDueDate(text string) bool {
-	return dueRegex.MatchString(text)
+	masked := maskInlineSpans(text)
+	return dueRegex.MatchString(masked) || relativeDueRegex.MatchString(masked)
 }
 
 // RemoveDueDate removes all due date markers from the text
 // This is useful for display purposes if you want text without due date markers
 func RemoveDueDate(text string) string {
-	return strings.TrimSpace(dueRegex.ReplaceAllString(text, ""))
+	return removeOutsideSpans(relativeDueRegex, removeOutsideSpans(dueRegex, text))
 }
 
-// GetDueDateMarker returns the first due date marker found in the text (e.g., "@due(2025-11-24)")
+// GetDueDateMarker returns the first due date marker found in the text (e.g., "@due(2025-11-24)" or "due:tomorrow")
 // Returns empty string if no due date is set
 func GetDueDateMarker(text string) string {
-	match := dueRegex.FindString(text)
-	return match
+	masked := maskInlineSpans(text)
+	loc := dueRegex.FindStringIndex(masked)
+	if rel := relativeDueRegex.FindStringSubmatchIndex(masked); rel != nil && (loc == nil || rel[2] < loc[0]) {
+		loc = rel[2:4]
+	}
+	if loc == nil {
+		return ""
+	}
+	return text[loc[0]:loc[1]]
+}
+
+// now returns the current time used to classify due dates.
+// Tests replace it to get deterministic results.
+var now = time.Now
+
+// Named date formats for [display] date_format; any other value is used as a Go time layout
+const (
+	DateFormatISO      = "iso"      // 2025-01-15
+	DateFormatUS       = "us"       // 01/15/2025
+	DateFormatEU       = "eu"       // 15.01.2025
+	DateFormatRelative = "relative" // "in 3 days", "2 days ago"
+)
+
+// dateLayouts maps the named date formats to Go time layouts
+var dateLayouts = map[string]string{
+	DateFormatISO: "2006-01-02",
+	DateFormatUS:  "01/02/2006",
+	DateFormatEU:  "02.01.2006",
+}
+
+// DateFormat is how dates are displayed, set by main.go from config.toml.
+// Dates written to files and exports are always ISO.
+var DateFormat = DateFormatISO
+
+// IsDatePreset reports whether format is one of the named date formats
+func IsDatePreset(format string) bool {
+	_, ok := dateLayouts[format]
+	return ok || format == DateFormatRelative
+}
+
+// FormatDate formats the day of t for display in the given format: a named
+// format or a Go time layout. An empty format means ISO.
+func FormatDate(t time.Time, format string) string {
+	if format == DateFormatRelative {
+		return relativeDate(t)
+	}
+	if format == "" {
+		format = DateFormatISO
+	}
+	if layout, ok := dateLayouts[format]; ok {
+		return t.Format(layout)
+	}
+	return t.Format(format)
+}
+
+// relativeDate describes the day of t relative to today, e.g. "in 3 days"
+func relativeDate(t time.Time) string {
+	today := startOfDay(now())
+	y, m, d := t.Date()
+	day := time.Date(y, m, d, 0, 0, 0, 0, time.Local)
+	// Round, as a day can be 23 or 25 hours long around DST changes
+	days := int(day.Sub(today).Round(24*time.Hour).Hours() / 24)
+
+	switch {
+	case days == 0:
+		return "today"
+	case days == 1:
+		return "tomorrow"
+	case days == -1:
+		return "yesterday"
+	case days > 1:
+		return fmt.Sprintf("in %d days", days)
+	default:
+		return fmt.Sprintf("%d days ago", -days)
+	}
 }
 
 // startOfDay returns the start of day (midnight) for the given time in local timezone
@@ -67,7 +197,7 @@ func IsOverdue(dueDate *time.Time) bool {
 	if dueDate == nil {
 		return false
 	}
-	today := startOfDay(time.Now())
+	today := startOfDay(now())
 	dueDay := startOfDay(*dueDate)
 	return dueDay.Before(today)
 }
@@ -77,7 +207,7 @@ func IsDueToday(dueDate *time.Time) bool {
 	if dueDate == nil {
 		return false
 	}
-	today := startOfDay(time.Now())
+	today := startOfDay(now())
 	dueDay := startOfDay(*dueDate)
 	return dueDay.Equal(today)
 }
@@ -87,7 +217,7 @@ func IsDueSoon(dueDate *time.Time, days int) bool {
 	if dueDate == nil {
 		return false
 	}
-	today := startOfDay(time.Now())
+	today := startOfDay(now())
 	dueDay := startOfDay(*dueDate)
 
 	// Not overdue and not today
@@ -172,3 +302,50 @@ func (t *Todo) HasAnyDueDateFilter(filters []string) bool {
 	}
 	return false
 }
+
+// Due date buckets of "tdx due", matching the overdue, today and week due filters
+const (
+	DueOverdue  = "Overdue"
+	DueToday    = "Today"
+	DueThisWeek = "This Week"
+	DueLater    = "Later"
+)
+
+// DueBuckets lists the due date buckets from the most urgent
+var DueBuckets = []string{DueOverdue, DueToday, DueThisWeek, DueLater}
+
+// DueBucket returns the bucket of the todo's due date, or "" when it has none
+func (t *Todo) DueBucket() string {
+	switch {
+	case t.DueDate == nil:
+		return ""
+	case t.IsOverdue():
+		return DueOverdue
+	case t.IsDueToday():
+		return DueToday
+	case t.IsDueSoon(7):
+		return DueThisWeek
+	default:
+		return DueLater
+	}
+}
+
+// GroupByDue returns the open todos with a due date by bucket, earliest due first
+// within each bucket and in file order for the same day
+func GroupByDue(todos []Todo) map[string][]Todo {
+	groups := make(map[string][]Todo)
+	for _, todo := range todos {
+		if todo.Checked {
+			continue
+		}
+		if bucket := todo.DueBucket(); bucket != "" {
+			groups[bucket] = append(groups[bucket], todo)
+		}
+	}
+	for _, group := range groups {
+		sort.SliceStable(group, func(i, j int) bool {
+			return startOfDay(*group[i].DueDate).Before(startOfDay(*group[j].DueDate))
+		})
+	}
+	return groups
+}
@@ -1,6 +1,7 @@
 package markdown
 
 import (
+	"strings"
 	"testing"
 	"time"
 )
@@ -12,6 +13,14 @@ func testStartOfDay(t time.Time) time.Time {
 	return time.Date(y, m, d, 0, 0, 0, 0, time.Local)
 }
 
+// setNow pins the clock used for due date classification for the duration of the test
+func setNow(t *testing.T, fixed time.Time) {
+	t.Helper()
+	old := now
+	now = func() time.Time { return fixed }
+	t.Cleanup(func() { now = old })
+}
+
 func TestExtractDueDate(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -701,6 +710,133 @@ func TestTodo_HasAnyDueDateFilter(t *testing.T) {
 	}
 }
 
+func TestHasDueDateFilter_FixedClock(t *testing.T) {
+	// Wednesday afternoon, so "today" must not depend on the time of day
+	setNow(t, time.Date(2025, 6, 11, 15, 30, 0, 0, time.Local))
+
+	tests := []struct {
+		date    string
+		overdue bool
+		today   bool
+		week    bool
+	}{
+		{"2025-06-10", true, false, false},
+		{"2025-01-01", true, false, false},
+		{"2025-06-11", false, true, true},
+		{"2025-06-12", false, false, true},
+		{"2025-06-18", false, false, true},
+		{"2025-06-19", false, false, false},
+		{"2026-06-11", false, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.date, func(t *testing.T) {
+			todo := Todo{DueDate: ExtractDueDate("Task @due(" + tt.date + ")")}
+			if got := todo.HasDueDateFilter("overdue"); got != tt.overdue {
+				t.Errorf("overdue = %v, want %v", got, tt.overdue)
+			}
+			if got := todo.HasDueDateFilter("today"); got != tt.today {
+				t.Errorf("today = %v, want %v", got, tt.today)
+			}
+			if got := todo.HasDueDateFilter("week"); got != tt.week {
+				t.Errorf("week = %v, want %v", got, tt.week)
+			}
+		})
+	}
+}
+
+func TestIsDueToday_FixedClockAtMidnight(t *testing.T) {
+	due := ExtractDueDate("Task @due(2025-03-01)")
+
+	setNow(t, time.Date(2025, 2, 28, 23, 59, 59, 0, time.Local))
+	if IsDueToday(due) || !IsDueSoon(due, 1) {
+		t.Error("One second before midnight the task should be due tomorrow")
+	}
+
+	setNow(t, time.Date(2025, 3, 1, 0, 0, 0, 0, time.Local))
+	if !IsDueToday(due) || IsOverdue(due) {
+		t.Error("At midnight the task should be due today")
+	}
+}
+
+func TestExtractDueDate_RelativeTokens(t *testing.T) {
+	// Wednesday
+	setNow(t, time.Date(2025, 3, 5, 14, 30, 0, 0, time.Local))
+
+	tests := []struct {
+		text     string
+		expected string // YYYY-MM-DD or empty for nil
+	}{
+		{"Call mom due:today", "2025-03-05"},
+		{"Call mom due:tomorrow", "2025-03-06"},
+		{"Call mom due:Tomorrow", "2025-03-06"},
+		{"Send invoice due:fri", "2025-03-07"},
+		{"Weekly sync due:mon", "2025-03-10"},
+		{"Standup due:wed", "2025-03-12"}, // same weekday means next week
+		{"Renew passport due:+3d", "2025-03-08"},
+		{"due:+0d at start", "2025-03-05"},
+		{"Earliest wins due:+10d @due(2025-03-07)", "2025-03-07"},
+		{"Earliest wins @due(2025-04-01) due:tomorrow", "2025-03-06"},
+		{"Unknown due:someday", ""},
+		{"Not a token overdue:tomorrow", ""},
+		{"Document `due:tomorrow` syntax", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			result := ExtractDueDate(tt.text)
+			if tt.expected == "" {
+				if result != nil {
+					t.Errorf("ExtractDueDate(%q) = %v, want nil", tt.text, result)
+				}
+				return
+			}
+			if result == nil || result.Format("2006-01-02") != tt.expected {
+				t.Errorf("ExtractDueDate(%q) = %v, want %s", tt.text, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRelativeDueTokens_Filters(t *testing.T) {
+	setNow(t, time.Date(2025, 3, 5, 9, 0, 0, 0, time.Local))
+
+	fm := ParseMarkdown("# Todos\n\n- [ ] Call mom due:tomorrow\n- [ ] Pay rent due:today\n- [ ] Taxes due:+30d\n")
+	tomorrow, today, later := fm.Todos[0], fm.Todos[1], fm.Todos[2]
+
+	if !tomorrow.HasDueDateFilter("week") || tomorrow.HasDueDateFilter("today") || tomorrow.HasDueDateFilter("overdue") {
+		t.Error("due:tomorrow should be in the week bucket only")
+	}
+	if !today.HasDueDateFilter("today") || !today.HasDueDateFilter("week") {
+		t.Error("due:today should be due today")
+	}
+	if later.HasDueDateFilter("week") || !later.HasDueDateFilter("all") {
+		t.Error("due:+30d should only match the all filter")
+	}
+
+	// The token is kept as written
+	if tomorrow.Text != "Call mom due:tomorrow" {
+		t.Errorf("Text = %q, token should be preserved", tomorrow.Text)
+	}
+}
+
+func TestRelativeDueTokens_Helpers(t *testing.T) {
+	setNow(t, time.Date(2025, 3, 5, 9, 0, 0, 0, time.Local))
+
+	if !HasDueDate("Call mom due:tomorrow") {
+		t.Error("HasDueDate should see relative tokens")
+	}
+	if got := GetDueDateMarker("Call mom due:fri @due(2025-04-01)"); got != "due:fri" {
+		t.Errorf("GetDueDateMarker = %q, want due:fri", got)
+	}
+	if got := GetDueDateMarker("Call @due(2025-04-01) due:fri"); got != "@due(2025-04-01)" {
+		t.Errorf("GetDueDateMarker = %q, want @due(2025-04-01)", got)
+	}
+	if got := RemoveDueDate("Call mom due:tomorrow #family"); got != "Call mom #family" {
+		t.Errorf("RemoveDueDate = %q", got)
+	}
+}
+
 // Benchmark tests
 func BenchmarkExtractDueDate(b *testing.B) {
 	text := "Fix critical bug !p1 @due(2025-11-30) #urgent #backend"
@@ -722,3 +858,136 @@ func BenchmarkIsOverdue(b *testing.B) {
 		IsOverdue(&yesterday)
 	}
 }
+
+func TestFormatDate_Presets(t *testing.T) {
+	setNow(t, time.Date(2025, 1, 15, 9, 30, 0, 0, time.Local))
+	date := time.Date(2025, 1, 18, 0, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{"", "2025-01-18"},
+		{DateFormatISO, "2025-01-18"},
+		{DateFormatUS, "01/18/2025"},
+		{DateFormatEU, "18.01.2025"},
+		{DateFormatRelative, "in 3 days"},
+		{"Jan 2, 2006", "Jan 18, 2025"},
+	}
+	for _, tt := range tests {
+		if got := FormatDate(date, tt.format); got != tt.want {
+			t.Errorf("FormatDate(%q) = %q, want %q", tt.format, got, tt.want)
+		}
+	}
+}
+
+func TestFormatDate_Relative(t *testing.T) {
+	setNow(t, time.Date(2025, 3, 30, 23, 0, 0, 0, time.Local))
+
+	tests := []struct {
+		date time.Time
+		want string
+	}{
+		{time.Date(2025, 3, 30, 8, 0, 0, 0, time.Local), "today"},
+		{time.Date(2025, 3, 31, 0, 0, 0, 0, time.Local), "tomorrow"},
+		{time.Date(2025, 3, 29, 0, 0, 0, 0, time.Local), "yesterday"},
+		{time.Date(2025, 3, 28, 0, 0, 0, 0, time.Local), "2 days ago"},
+		{time.Date(2025, 4, 9, 0, 0, 0, 0, time.Local), "in 10 days"},
+		// Parsed @due dates are UTC midnight; only the calendar day counts
+		{time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC), "in 2 days"},
+	}
+	for _, tt := range tests {
+		if got := FormatDate(tt.date, DateFormatRelative); got != tt.want {
+			t.Errorf("FormatDate(%s) = %q, want %q", tt.date.Format(time.RFC3339), got, tt.want)
+		}
+	}
+}
+
+func TestTodoDueDate_CachedAtParse(t *testing.T) {
+	setNow(t, time.Date(2025, 3, 5, 9, 0, 0, 0, time.Local))
+
+	fm := ParseMarkdown("# Todos\n\n- [ ] Pay rent @due(2025-03-10)\n- [ ] Call mom due:tomorrow\n- [ ] Explain `@due(2025-01-01)`\n")
+
+	want := []string{"2025-03-10", "2025-03-06", ""}
+	for i, todo := range fm.Todos {
+		got := ""
+		if todo.DueDate != nil {
+			got = todo.DueDate.Format("2006-01-02")
+		}
+		if got != want[i] {
+			t.Errorf("Todo %d DueDate = %q, want %q", i, got, want[i])
+		}
+		if expected := ExtractDueDate(todo.Text); (expected == nil) != (todo.DueDate == nil) || (expected != nil && !expected.Equal(*todo.DueDate)) {
+			t.Errorf("Todo %d DueDate = %v, doesn't match its token (%v)", i, todo.DueDate, expected)
+		}
+	}
+}
+
+func TestTodoDueDate_UpdatedByEdit(t *testing.T) {
+	setNow(t, time.Date(2025, 3, 5, 9, 0, 0, 0, time.Local))
+	fm := ParseMarkdown("# Todos\n\n- [ ] Pay rent @due(2025-03-10)\n- [ ] Other @due(2025-04-01)\n")
+
+	if err := fm.UpdateTodoItem(0, "Pay rent @due(2025-03-01)", false); err != nil {
+		t.Fatal(err)
+	}
+	if due := fm.Todos[0].DueDate; due == nil || due.Format("2006-01-02") != "2025-03-01" {
+		t.Errorf("After changing the marker, DueDate = %v, want 2025-03-01", due)
+	}
+	if !fm.Todos[0].HasDueDateFilter("overdue") {
+		t.Error("The overdue filter should use the edited date")
+	}
+
+	if err := fm.UpdateTodoItem(0, "Pay rent", false); err != nil {
+		t.Fatal(err)
+	}
+	if fm.Todos[0].DueDate != nil || fm.Todos[0].HasDueDateFilter("all") {
+		t.Errorf("After removing the marker, DueDate = %v, want nil", fm.Todos[0].DueDate)
+	}
+
+	// Checking a todo keeps its date
+	if err := fm.UpdateTodoItem(1, fm.Todos[1].Text, true); err != nil {
+		t.Fatal(err)
+	}
+	if due := fm.Todos[1].DueDate; due == nil || due.Format("2006-01-02") != "2025-04-01" {
+		t.Errorf("After checking, DueDate = %v, want 2025-04-01", due)
+	}
+}
+
+func TestGroupByDue_FixedClock(t *testing.T) {
+	// Wednesday, so this week runs into next Wednesday
+	setNow(t, time.Date(2025, 6, 11, 9, 0, 0, 0, time.Local))
+	fm := ParseMarkdown(`# Todos
+
+- [ ] Launch @due(2025-07-01)
+- [ ] Report @due(2025-06-13)
+- [ ] Call bank @due(2025-06-11)
+- [ ] Rent @due(2025-06-01)
+- [x] Done late @due(2025-06-02)
+- [ ] Someday
+- [ ] Invoice @due(2025-06-10)
+- [ ] Review due:fri
+- [ ] Plan @due(2025-06-18)
+- [ ] Trip @due(2025-06-19)
+`)
+
+	groups := GroupByDue(fm.Todos)
+
+	want := map[string][]string{
+		DueOverdue:  {"Rent @due(2025-06-01)", "Invoice @due(2025-06-10)"},
+		DueToday:    {"Call bank @due(2025-06-11)"},
+		DueThisWeek: {"Report @due(2025-06-13)", "Review due:fri", "Plan @due(2025-06-18)"},
+		DueLater:    {"Trip @due(2025-06-19)", "Launch @due(2025-07-01)"},
+	}
+	for _, bucket := range DueBuckets {
+		var texts []string
+		for _, todo := range groups[bucket] {
+			texts = append(texts, todo.Text)
+		}
+		if strings.Join(texts, ", ") != strings.Join(want[bucket], ", ") {
+			t.Errorf("%s = %v, want %v", bucket, texts, want[bucket])
+		}
+	}
+	if len(groups) != len(DueBuckets) {
+		t.Errorf("Expected only the four buckets, got %d", len(groups))
+	}
+}
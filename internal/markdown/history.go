@@ -0,0 +1,124 @@
+package markdown
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// historyTimeLayout is how completion times are written to the log
+const historyTimeLayout = "2006-01-02 15:04"
+
+// historyHeader starts a new completion log
+const historyHeader = "# Completed\n\n"
+
+// Completion is a todo that was checked, as recorded in the completion log
+type Completion struct {
+	Time time.Time
+	File string // Absolute path of the todo file
+	Text string
+}
+
+// line returns the log line for the completion:
+// "- 2026-10-16 14:03 | /path/to/todo.md | Todo text"
+func (c Completion) line() string {
+	return fmt.Sprintf("- %s | %s | %s\n", c.Time.Format(historyTimeLayout), c.File, c.Text)
+}
+
+// parseCompletion reads a log line written by line
+func parseCompletion(line string) (Completion, bool) {
+	rest, ok := strings.CutPrefix(line, "- ")
+	if !ok {
+		return Completion{}, false
+	}
+	parts := strings.SplitN(rest, " | ", 3)
+	if len(parts) != 3 {
+		return Completion{}, false
+	}
+	t, err := time.ParseInLocation(historyTimeLayout, parts[0], time.Local)
+	if err != nil {
+		return Completion{}, false
+	}
+	return Completion{Time: t, File: parts[1], Text: parts[2]}, true
+}
+
+// LogCompleted appends todos that were checked in the file at filePath to the
+// completion log at historyPath
+func LogCompleted(historyPath, filePath string, todos []Todo) error {
+	if len(todos) == 0 {
+		return nil
+	}
+	if abs, err := filepath.Abs(filePath); err == nil {
+		filePath = abs
+	}
+	completions := make([]Completion, 0, len(todos))
+	for _, todo := range todos {
+		completions = append(completions, Completion{Time: now(), File: filePath, Text: todo.Text})
+	}
+	return AppendHistory(historyPath, completions)
+}
+
+// AppendHistory appends completions to the log at path, creating it if needed
+func AppendHistory(path string, completions []Completion) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+
+	var b strings.Builder
+	if info, err := f.Stat(); err == nil && info.Size() == 0 {
+		b.WriteString(historyHeader)
+	}
+	for _, c := range completions {
+		// Keep every completion on one line
+		c.Text = strings.ReplaceAll(c.Text, "\n", " ")
+		b.WriteString(c.line())
+	}
+	_, err = f.WriteString(b.String())
+	return err
+}
+
+// ReadHistory returns the completions in the log at path, oldest first.
+// A log that doesn't exist yet has none.
+func ReadHistory(path string) ([]Completion, error) {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+
+	var completions []Completion
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if c, ok := parseCompletion(scanner.Text()); ok {
+			completions = append(completions, c)
+		}
+	}
+	return completions, scanner.Err()
+}
+
+// CompletedSince returns the completions from the last days days, today
+// included, oldest first. days of 0 returns them all.
+func CompletedSince(completions []Completion, days int) []Completion {
+	if days <= 0 {
+		return completions
+	}
+	since := startOfDay(now()).AddDate(0, 0, 1-days)
+	var recent []Completion
+	for _, c := range completions {
+		if !c.Time.Before(since) {
+			recent = append(recent, c)
+		}
+	}
+	return recent
+}
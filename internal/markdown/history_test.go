@@ -0,0 +1,113 @@
+package markdown
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLogCompleted_AppendsLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tdx", "history.md")
+	setNow(t, time.Date(2026, 10, 16, 14, 3, 0, 0, time.Local))
+	file := filepath.Join(t.TempDir(), "todo.md")
+
+	if err := LogCompleted(path, file, []Todo{{Text: "Write report #work"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "# Completed\n\n- 2026-10-16 14:03 | " + file + " | Write report #work\n"
+	if string(content) != want {
+		t.Errorf("Log =\n%q\nwant\n%q", content, want)
+	}
+
+	completions, err := ReadHistory(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(completions) != 1 {
+		t.Fatalf("Read %d completions, want 1", len(completions))
+	}
+	c := completions[0]
+	if !c.Time.Equal(time.Date(2026, 10, 16, 14, 3, 0, 0, time.Local)) || c.File != file || c.Text != "Write report #work" {
+		t.Errorf("Completion = %+v", c)
+	}
+}
+
+func TestLogCompleted_AppendsAcrossCalls(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.md")
+
+	_ = LogCompleted(path, "todo.md", []Todo{{Text: "Write report"}})
+	_ = LogCompleted(path, "todo.md", nil)
+	_ = LogCompleted(path, "todo.md", []Todo{{Text: "Call mom"}, {Text: "Line\nbreak"}})
+
+	completions, _ := ReadHistory(path)
+	var texts []string
+	for _, c := range completions {
+		texts = append(texts, c.Text)
+		if !filepath.IsAbs(c.File) {
+			t.Errorf("Expected an absolute file path, got %q", c.File)
+		}
+	}
+	if strings.Join(texts, ", ") != "Write report, Call mom, Line break" {
+		t.Errorf("Logged %v, want every completion once in order", texts)
+	}
+	content, _ := os.ReadFile(path)
+	if strings.Count(string(content), "# Completed") != 1 {
+		t.Errorf("Expected a single heading:\n%s", content)
+	}
+}
+
+func TestReadHistory_SkipsOtherLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.md")
+	content := "# Completed\n\nSome notes\n- not a completion\n- 2026-10-15 09:30 | /tmp/a.md | Text with | a pipe\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	completions, err := ReadHistory(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(completions) != 1 || completions[0].Text != "Text with | a pipe" || completions[0].File != "/tmp/a.md" {
+		t.Errorf("ReadHistory = %+v", completions)
+	}
+
+	if completions, err := ReadHistory(filepath.Join(t.TempDir(), "missing.md")); err != nil || completions != nil {
+		t.Errorf("A missing log should have no completions, got %v, %v", completions, err)
+	}
+}
+
+func TestCompletedSince(t *testing.T) {
+	setNow(t, time.Date(2026, 10, 16, 9, 0, 0, 0, time.Local))
+	completions := []Completion{
+		{Time: time.Date(2026, 10, 9, 23, 59, 0, 0, time.Local), Text: "Too old"},
+		{Time: time.Date(2026, 10, 10, 0, 0, 0, 0, time.Local), Text: "Six days ago"},
+		{Time: time.Date(2026, 10, 15, 18, 0, 0, 0, time.Local), Text: "Yesterday"},
+		{Time: time.Date(2026, 10, 16, 8, 0, 0, 0, time.Local), Text: "Today"},
+	}
+
+	tests := []struct {
+		days int
+		want string
+	}{
+		{7, "Six days ago, Yesterday, Today"},
+		{2, "Yesterday, Today"},
+		{1, "Today"},
+		{0, "Too old, Six days ago, Yesterday, Today"},
+	}
+	for _, tt := range tests {
+		var texts []string
+		for _, c := range CompletedSince(completions, tt.days) {
+			texts = append(texts, c.Text)
+		}
+		if got := strings.Join(texts, ", "); got != tt.want {
+			t.Errorf("CompletedSince(%d) = %q, want %q", tt.days, got, tt.want)
+		}
+	}
+}
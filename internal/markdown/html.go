@@ -0,0 +1,180 @@
+package markdown
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/renderer"
+	gmhtml "github.com/yuin/goldmark/renderer/html"
+	"github.com/yuin/goldmark/util"
+)
+
+// markerRegex matches the tags, priorities and due dates highlighted in HTML output
+var markerRegex = regexp.MustCompile(tagRegex.String() + "|" + priorityRegex.String() + "|" + dueRegex.String())
+
+// htmlPage wraps the rendered document; the styles mirror the TUI colors
+const htmlPage = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>%s</title>
+<style>
+body { font-family: system-ui, sans-serif; max-width: 48rem; margin: 2rem auto; padding: 0 1rem; line-height: 1.5; color: #1f2335; }
+ul { list-style: none; padding-left: 1.5rem; }
+li:has(> input:checked) { color: #737aa2; text-decoration: line-through; }
+a { color: #2e7de9; }
+code { background: #e9e9ed; padding: 0 0.25rem; border-radius: 3px; }
+.tag { color: #007197; }
+.priority { font-weight: bold; color: #b15c00; }
+.priority-1 { color: #f52a65; }
+.priority-2 { color: #b15c00; }
+.priority-3 { color: #8c6c3e; }
+.due { color: #9854f1; }
+.note { color: #6172b0; font-size: 0.9em; }
+</style>
+</head>
+<body>
+%s</body>
+</html>
+`
+
+// RenderHTML renders the file as a standalone HTML page. Headings, nesting and links
+// come from the AST; tags, priorities and due dates are wrapped in styled spans.
+func RenderHTML(fm *FileModel) (string, error) {
+	fm.ensureAST()
+	if fm.dirty {
+		fm.syncTodosToAST()
+	}
+
+	defaults := nodeRendererFuncs{}
+	gmhtml.NewRenderer().RegisterFuncs(defaults)
+	r := &htmlRenderer{doc: fm.ast, defaults: defaults}
+
+	md := goldmark.New(
+		goldmark.WithExtensions(extension.GFM),
+		goldmark.WithRendererOptions(renderer.WithNodeRenderers(util.Prioritized(r, 100))),
+	)
+
+	var body bytes.Buffer
+	if err := md.Renderer().Render(&body, fm.ast.Source, fm.ast.AST); err != nil {
+		return "", err
+	}
+
+	title := "Todos"
+	if headings := fm.GetHeadings(); len(headings) > 0 {
+		title = headings[0].Text
+	}
+	return fmt.Sprintf(htmlPage, html.EscapeString(title), body.String()), nil
+}
+
+// nodeRendererFuncs collects the default goldmark render functions so they can be wrapped
+type nodeRendererFuncs map[ast.NodeKind]renderer.NodeRendererFunc
+
+func (f nodeRendererFuncs) Register(kind ast.NodeKind, fn renderer.NodeRendererFunc) {
+	f[kind] = fn
+}
+
+// htmlRenderer highlights markers in text and adds the notes kept outside the AST
+type htmlRenderer struct {
+	doc      *ASTDocument
+	defaults nodeRendererFuncs
+}
+
+func (r *htmlRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(ast.KindText, r.renderText)
+	reg.Register(ast.KindTextBlock, r.renderBlock)
+	reg.Register(ast.KindParagraph, r.renderBlock)
+}
+
+// renderText writes a run of adjacent text nodes at once, as goldmark may split a
+// marker like @due(2025-01-15) across several of them
+func (r *htmlRenderer) renderText(w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	if !entering {
+		return ast.WalkContinue, nil
+	}
+	n := node.(*ast.Text)
+	if prev, ok := n.PreviousSibling().(*ast.Text); ok && joinsText(prev, n) {
+		return ast.WalkContinue, nil // Written together with the previous node
+	}
+	if n.IsRaw() {
+		return r.defaults[ast.KindText](w, source, node, entering)
+	}
+
+	value := n.Segment.Value(source)
+	last := n
+	for next, ok := last.NextSibling().(*ast.Text); ok && joinsText(last, next); next, ok = last.NextSibling().(*ast.Text) {
+		value = append(value[:len(value):len(value)], next.Segment.Value(source)...)
+		last = next
+	}
+	writeMarked(w, value)
+
+	if last.HardLineBreak() {
+		_, _ = w.WriteString("<br>\n")
+	} else if last.SoftLineBreak() {
+		_ = w.WriteByte('\n')
+	}
+	return ast.WalkContinue, nil
+}
+
+// joinsText reports whether b continues a without a line break in between
+func joinsText(a, b *ast.Text) bool {
+	return !a.SoftLineBreak() && !a.HardLineBreak() && !a.IsRaw() && !b.IsRaw() && a.Segment.Stop == b.Segment.Start
+}
+
+// renderBlock renders paragraphs as usual and adds the notes of a todo after its text
+func (r *htmlRenderer) renderBlock(w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	status, err := r.defaults[node.Kind()](w, source, node, entering)
+	if err != nil || entering {
+		return status, err
+	}
+
+	listItem, ok := node.Parent().(*ast.ListItem)
+	if !ok || listItem.FirstChild() != node || len(r.doc.notes[listItem]) == 0 {
+		return status, nil
+	}
+	_, _ = w.WriteString(`<div class="note">`)
+	for i, note := range r.doc.notes[listItem] {
+		if i > 0 {
+			_, _ = w.WriteString("<br>\n")
+		}
+		writeMarked(w, []byte(strings.TrimSpace(note)))
+	}
+	_, _ = w.WriteString("</div>\n")
+	return status, nil
+}
+
+// writeMarked writes escaped text with tags, priorities and due dates wrapped in spans
+func writeMarked(w util.BufWriter, text []byte) {
+	last := 0
+	for _, loc := range markerRegex.FindAllIndex(text, -1) {
+		if loc[0] > 0 && text[loc[0]-1] == '&' {
+			continue // Numeric character reference like &#35;
+		}
+		gmhtml.DefaultWriter.Write(w, text[last:loc[0]])
+		marker := text[loc[0]:loc[1]]
+		_, _ = fmt.Fprintf(w, `<span class="%s">`, markerClass(string(marker)))
+		gmhtml.DefaultWriter.Write(w, marker)
+		_, _ = w.WriteString("</span>")
+		last = loc[1]
+	}
+	gmhtml.DefaultWriter.Write(w, text[last:])
+}
+
+// markerClass returns the CSS class of a tag, priority or due date marker
+func markerClass(marker string) string {
+	switch marker[0] {
+	case '#':
+		return "tag"
+	case '!':
+		return "priority priority-" + marker[2:]
+	default:
+		return "due"
+	}
+}
@@ -0,0 +1,97 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+)
+
+func renderHTMLString(t *testing.T, content string) string {
+	t.Helper()
+	page, err := RenderHTML(ParseMarkdown(content))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return page
+}
+
+func TestRenderHTML_Checkboxes(t *testing.T) {
+	page := renderHTMLString(t, "# Todos\n\n- [x] Done\n- [ ] Open\n")
+
+	if !strings.Contains(page, `<li><input checked="" disabled="" type="checkbox"> Done</li>`) {
+		t.Errorf("Checked todo should render a checked checkbox:\n%s", page)
+	}
+	if !strings.Contains(page, `<li><input disabled="" type="checkbox"> Open</li>`) {
+		t.Errorf("Open todo should render an unchecked checkbox:\n%s", page)
+	}
+}
+
+func TestRenderHTML_Markers(t *testing.T) {
+	page := renderHTMLString(t, "# Todos\n\n- [ ] Fix login !p1 #auth @due(2025-01-15)\n")
+
+	for _, want := range []string{
+		`<span class="tag">#auth</span>`,
+		`<span class="priority priority-1">!p1</span>`,
+		`<span class="due">@due(2025-01-15)</span>`,
+	} {
+		if !strings.Contains(page, want) {
+			t.Errorf("Expected %s in:\n%s", want, page)
+		}
+	}
+}
+
+func TestRenderHTML_CodeAndLinksNotHighlighted(t *testing.T) {
+	page := renderHTMLString(t, "# Todos\n\n- [ ] Fix `#define` see [spec](https://example.com/doc#intro)\n")
+
+	if !strings.Contains(page, "<code>#define</code>") {
+		t.Errorf("Code span should be left alone:\n%s", page)
+	}
+	if !strings.Contains(page, `<a href="https://example.com/doc#intro">spec</a>`) {
+		t.Errorf("Link should become an anchor:\n%s", page)
+	}
+	if strings.Contains(page, `class="tag"`) {
+		t.Errorf("No tag expected:\n%s", page)
+	}
+}
+
+func TestRenderHTML_StructureAndNotes(t *testing.T) {
+	page := renderHTMLString(t, "# Home\n\n- [ ] Parent\n  remember the receipt\n  - [x] Child\n\n## Later\n\nSome text.\n")
+
+	for _, want := range []string{
+		"<title>Home</title>",
+		"<h1>Home</h1>",
+		"<h2>Later</h2>",
+		"<p>Some text.</p>",
+		`<div class="note">remember the receipt</div>`,
+		"<ul>\n<li><input checked=\"\" disabled=\"\" type=\"checkbox\"> Child</li>\n</ul>",
+	} {
+		if !strings.Contains(page, want) {
+			t.Errorf("Expected %q in:\n%s", want, page)
+		}
+	}
+	if !strings.HasPrefix(page, "<!DOCTYPE html>") {
+		t.Error("Expected a standalone page")
+	}
+}
+
+func TestRenderHTML_EscapesText(t *testing.T) {
+	page := renderHTMLString(t, "# Todos\n\n- [ ] Compare a < b & c #math\n")
+
+	if !strings.Contains(page, `Compare a &lt; b &amp; c <span class="tag">#math</span>`) {
+		t.Errorf("Text should be escaped:\n%s", page)
+	}
+}
+
+func TestRenderHTML_EditedTodo(t *testing.T) {
+	fm := ParseMarkdown("# Todos\n\n- [ ] Old\n")
+	if err := fm.UpdateTodoItem(0, "New #tag", true); err != nil {
+		t.Fatal(err)
+	}
+
+	page, err := RenderHTML(fm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(page, `<input checked="" disabled="" type="checkbox"> New <span class="tag">#tag</span>`) {
+		t.Errorf("Edits should be rendered:\n%s", page)
+	}
+}
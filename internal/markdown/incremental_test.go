@@ -0,0 +1,114 @@
+package markdown
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// largeTodoFile returns a file with n todos spread over sections, with nesting,
+// notes, in-progress todos and tokens
+func largeTodoFile(n int) string {
+	var b strings.Builder
+	b.WriteString("# Todos\n")
+	for i := 0; i < n; i++ {
+		if i%100 == 0 {
+			fmt.Fprintf(&b, "\n## Section %d\n\n", i/100)
+		}
+		indent := strings.Repeat("  ", i%100%3) // Each section starts at the top level
+		box := [...]string{"[ ]", "[x]", "[-]", "[X]"}[i%4]
+		fmt.Fprintf(&b, "%s- %s Task %d #tag%d @ctx !p%d `code #%d` @due(2026-0%d-1%d)\n", indent, box, i, i%7, i%3+1, i, i%9+1, i%9)
+		if i%10 == 0 {
+			fmt.Fprintf(&b, "%s  A note for task %d\n", indent, i)
+		}
+	}
+	return b.String()
+}
+
+// withoutLineNo drops the byte offsets, which differ between an edited AST and a fresh parse
+func withoutLineNo(todos []Todo) []Todo {
+	out := make([]Todo, len(todos))
+	for i, todo := range todos {
+		todo.LineNo = 0
+		out[i] = todo
+	}
+	return out
+}
+
+func TestRefreshTodo_MatchesFullExtraction(t *testing.T) {
+	fm := ParseMarkdown(largeTodoFile(300))
+	if len(fm.Todos) != 300 {
+		t.Fatalf("Parsed %d todos, want 300", len(fm.Todos))
+	}
+
+	edits := []struct {
+		name string
+		edit func() error
+	}{
+		{"edit text", func() error { return fm.UpdateTodoItem(5, "Renamed #newtag !p1 @home", fm.Todos[5].Checked) }},
+		{"check", func() error { return fm.UpdateTodoItem(12, fm.Todos[12].Text, true) }},
+		{"uncheck capital X", func() error { return fm.UpdateTodoItem(3, fm.Todos[3].Text, false) }},
+		{"edit and check nested", func() error { return fm.UpdateTodoItem(7, "Nested edit `with code`", true) }},
+		{"toggle in progress", func() error { return fm.ToggleTodoItem(2) }},
+		{"start progress", func() error { return fm.SetTodoInProgress(8, true) }},
+		{"stop progress", func() error { return fm.SetTodoInProgress(6, false) }},
+		{"edit with note", func() error { return fm.UpdateTodoItem(10, "Has a note", false) }},
+		{"edit parent of nested", func() error { return fm.UpdateTodoItem(0, "Top parent", false) }},
+		{"edit last", func() error { return fm.UpdateTodoItem(len(fm.Todos)-1, "Last one", true) }},
+	}
+	for _, tt := range edits {
+		if err := tt.edit(); err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if full := fm.GetAST().ExtractTodos(); !reflect.DeepEqual(fm.Todos, full) {
+			t.Fatalf("%s: cached todos differ from a full extraction", tt.name)
+		}
+		reparsed := ParseMarkdown(SerializeMarkdown(fm))
+		if !reflect.DeepEqual(withoutLineNo(fm.Todos), withoutLineNo(reparsed.Todos)) {
+			t.Fatalf("%s: cached todos differ from a full reparse", tt.name)
+		}
+	}
+}
+
+func TestRefreshTodo_InvalidIndex(t *testing.T) {
+	fm := ParseMarkdown("- [ ] One\n")
+	if err := fm.GetAST().RefreshTodo(fm.Todos, 1); err == nil {
+		t.Error("Expected an error for an index past the end")
+	}
+}
+
+// BenchmarkEdit_FullReparse re-parses the whole file after each edit
+func BenchmarkEdit_FullReparse(b *testing.B) {
+	fm := ParseMarkdown(largeTodoFile(2000))
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		idx := i % len(fm.Todos)
+		_ = fm.UpdateTodoItem(idx, fm.Todos[idx].Text, !fm.Todos[idx].Checked)
+		fm = ParseMarkdown(SerializeMarkdown(fm))
+	}
+}
+
+// BenchmarkEdit_FullExtraction updates the AST and extracts every todo again
+func BenchmarkEdit_FullExtraction(b *testing.B) {
+	fm := ParseMarkdown(largeTodoFile(2000))
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		idx := i % len(fm.Todos)
+		_ = fm.ast.ToggleTodo(idx)
+		fm.Todos = fm.ast.ExtractTodos()
+	}
+}
+
+// BenchmarkEdit_Incremental updates the AST and refreshes only the edited todo
+func BenchmarkEdit_Incremental(b *testing.B) {
+	fm := ParseMarkdown(largeTodoFile(2000))
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		idx := i % len(fm.Todos)
+		_ = fm.UpdateTodoItem(idx, fm.Todos[idx].Text, !fm.Todos[idx].Checked)
+	}
+}
@@ -379,3 +379,73 @@ func TestIndentIntoExistingNestedList(t *testing.T) {
 		t.Errorf("Expected Task 2 to be indented, got:\n%s", output)
 	}
 }
+
+func TestFourSpaceIndent_DepthAndRoundTrip(t *testing.T) {
+	content := "# Todos\n\n- [ ] Task 1\n    - [ ] Subtask A\n        - [x] Detail\n- [ ] Task 2\n"
+	fm := ParseMarkdown(content)
+
+	wantDepth := []int{0, 1, 2, 0}
+	for i, todo := range fm.Todos {
+		if todo.Depth != wantDepth[i] {
+			t.Errorf("%s depth = %d, want %d", todo.Text, todo.Depth, wantDepth[i])
+		}
+	}
+
+	if got := SerializeMarkdown(fm); got != content {
+		t.Errorf("Round trip changed the file:\n%q", got)
+	}
+}
+
+func TestIndentTodo_UsesFileIndentUnit(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			name:  "four spaces",
+			input: "# Todos\n\n- [ ] Task 1\n    - [ ] Subtask A\n- [ ] Task 2\n- [ ] Task 3\n",
+			want:  "- [ ] Task 2\n    - [ ] Task 3\n",
+		},
+		{
+			name:  "tabs",
+			input: "# Todos\n\n- [ ] Task 1\n\t- [ ] Subtask A\n- [ ] Task 2\n- [ ] Task 3\n",
+			want:  "- [ ] Task 2\n\t- [ ] Task 3\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fm := ParseMarkdown(tt.input)
+			if err := fm.IndentTodoItem(3); err != nil {
+				t.Fatalf("IndentTodoItem failed: %v", err)
+			}
+			if fm.Todos[3].Depth != 1 {
+				t.Errorf("Task 3 depth = %d, want 1", fm.Todos[3].Depth)
+			}
+			output := SerializeMarkdown(fm)
+			if !strings.HasSuffix(output, tt.want) {
+				t.Errorf("Expected output to end with %q, got:\n%q", tt.want, output)
+			}
+			if reparsed := ParseMarkdown(output); reparsed.Todos[3].Depth != 1 {
+				t.Errorf("Reparsed Task 3 depth = %d, want 1", reparsed.Todos[3].Depth)
+			}
+		})
+	}
+}
+
+func TestIndentTodo_ConfiguredWidth(t *testing.T) {
+	old := IndentWidth
+	IndentWidth = 4
+	t.Cleanup(func() { IndentWidth = old })
+
+	fm := ParseMarkdown("# Todos\n\n- [ ] Task 1\n- [ ] Task 2\n")
+	if err := fm.IndentTodoItem(1); err != nil {
+		t.Fatalf("IndentTodoItem failed: %v", err)
+	}
+
+	output := SerializeMarkdown(fm)
+	if !strings.Contains(output, "\n    - [ ] Task 2\n") {
+		t.Errorf("Expected Task 2 indented by four spaces, got:\n%q", output)
+	}
+}
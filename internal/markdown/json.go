@@ -0,0 +1,292 @@
+package markdown
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/yuin/goldmark/ast"
+	extast "github.com/yuin/goldmark/extension/ast"
+)
+
+// JSONVersion is the version of the JSON export format. It changes whenever the
+// shape of JSONDocument changes in a way older importers can't read.
+const JSONVersion = 1
+
+// JSONDocument is the versioned JSON form of a todo file
+type JSONDocument struct {
+	Version  int        `json:"version"`
+	Metadata *Metadata  `json:"metadata,omitempty"`
+	Items    []JSONItem `json:"items"`
+}
+
+// JSONItem is a heading or a todo, listed in document order.
+// Tags, contexts, projects, priority and due date are derived from the text;
+// they are exported for other tools and ignored on import.
+type JSONItem struct {
+	Type     string   `json:"type"`            // "heading" or "todo"
+	Level    int      `json:"level,omitempty"` // Heading level, 1-6
+	Text     string   `json:"text"`
+	Checked  bool     `json:"checked,omitempty"`
+	Progress bool     `json:"in_progress,omitempty"` // Open todo marked in progress
+	Depth    int      `json:"depth,omitempty"`       // Nesting depth of a todo, 0 = top-level
+	Marker   string   `json:"marker,omitempty"`      // Bullet if not "-": "*", "+", or "1." / "1)" for a numbered item
+	Tags     []string `json:"tags,omitempty"`
+	Contexts []string `json:"contexts,omitempty"`
+	Projects []string `json:"projects,omitempty"`
+	Priority int      `json:"priority,omitempty"`
+	Due      string   `json:"due,omitempty"` // YYYY-MM-DD
+	Notes    []string `json:"notes,omitempty"`
+}
+
+// JSON item types
+const (
+	JSONHeading = "heading"
+	JSONTodo    = "todo"
+)
+
+// ToJSON converts a file to its JSON form. Text outside headings and todos isn't
+// included; UnrepresentableInJSON tells whether a file has any.
+func ToJSON(fm *FileModel) *JSONDocument {
+	doc := &JSONDocument{Version: JSONVersion, Items: []JSONItem{}}
+	if fm.Metadata != nil && !fm.Metadata.IsEmpty() {
+		doc.Metadata = fm.Metadata
+	}
+
+	headings := fm.GetHeadings()
+	next := 0
+	addHeadings := func(beforeTodo int) {
+		for next < len(headings) && (beforeTodo < 0 || headings[next].BeforeTodoIndex <= beforeTodo) {
+			h := headings[next]
+			doc.Items = append(doc.Items, JSONItem{Type: JSONHeading, Level: h.Level, Text: h.Text})
+			next++
+		}
+	}
+
+	for i, todo := range fm.Todos {
+		addHeadings(i)
+		item := JSONItem{
+			Type:     JSONTodo,
+			Text:     todo.Text,
+			Checked:  todo.Checked,
+			Progress: todo.InProgress,
+			Depth:    todo.Depth,
+			Marker:   jsonMarker(todo),
+			Tags:     todo.Tags,
+			Contexts: todo.Contexts,
+			Projects: todo.Projects,
+			Priority: todo.Priority,
+			Notes:    todo.Notes,
+		}
+		if todo.DueDate != nil {
+			item.Due = todo.DueDate.Format("2006-01-02")
+		}
+		doc.Items = append(doc.Items, item)
+	}
+	addHeadings(-1)
+
+	return doc
+}
+
+// jsonMarker returns the bullet of a todo for its JSON form, "" for the default "-"
+func jsonMarker(todo Todo) string {
+	switch {
+	case todo.Ordered:
+		// Numbered items are renumbered when saved, so only the delimiter matters
+		return "1" + string(todo.Marker)
+	case todo.Marker == 0 || todo.Marker == '-':
+		return ""
+	default:
+		return string(todo.Marker)
+	}
+}
+
+// jsonMarkers are the bullets a JSON todo may have
+var jsonMarkers = []string{"", "-", "*", "+", "1.", "1)"}
+
+// UnrepresentableInJSON describes the first part of a file the JSON form can't
+// hold, such as a paragraph or a list item without a checkbox, or returns "" if
+// the file is only headings and todos with their notes.
+func UnrepresentableInJSON(fm *FileModel) string {
+	if fm.ast == nil {
+		return ""
+	}
+	for block := fm.ast.AST.FirstChild(); block != nil; block = block.NextSibling() {
+		switch block.Kind() {
+		case ast.KindHeading:
+		case ast.KindList:
+			if desc := unrepresentableListItem(block); desc != "" {
+				return desc
+			}
+		default:
+			return describeBlock(block)
+		}
+	}
+	return ""
+}
+
+// unrepresentableListItem describes the first item of a list, or a list nested
+// in it, that isn't a todo
+func unrepresentableListItem(list ast.Node) string {
+	for item := list.FirstChild(); item != nil; item = item.NextSibling() {
+		if first := item.FirstChild(); first == nil || !hasTaskCheckBox(first) {
+			return "a list item without a checkbox"
+		}
+		for child := item.FirstChild(); child != nil; child = child.NextSibling() {
+			if child.Kind() != ast.KindList {
+				continue
+			}
+			if desc := unrepresentableListItem(child); desc != "" {
+				return desc
+			}
+		}
+	}
+	return ""
+}
+
+// describeBlock names a top-level block for UnrepresentableInJSON
+func describeBlock(block ast.Node) string {
+	switch block.Kind() {
+	case ast.KindParagraph:
+		return "a paragraph"
+	case extast.KindTable:
+		return "a table"
+	case ast.KindFencedCodeBlock, ast.KindCodeBlock:
+		return "a code block"
+	case ast.KindBlockquote:
+		return "a quote"
+	case ast.KindThematicBreak:
+		return "a horizontal rule"
+	case ast.KindHTMLBlock:
+		return "an HTML block"
+	default:
+		return "text outside headings and todos"
+	}
+}
+
+// ExportJSON renders a file as indented JSON
+func ExportJSON(fm *FileModel) (string, error) {
+	data, err := json.MarshalIndent(ToJSON(fm), "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data) + "\n", nil
+}
+
+// ImportJSON reconstructs a file from its JSON form. The version must match
+// JSONVersion and unknown fields or item types are rejected.
+func ImportJSON(data []byte) (*FileModel, error) {
+	var header struct {
+		Version *int `json:"version"`
+	}
+	if err := json.Unmarshal(data, &header); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+	if header.Version == nil {
+		return nil, fmt.Errorf("missing version (expected %d)", JSONVersion)
+	}
+	if *header.Version != JSONVersion {
+		return nil, fmt.Errorf("unsupported version %d (expected %d)", *header.Version, JSONVersion)
+	}
+
+	var doc JSONDocument
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("invalid document: %w", err)
+	}
+
+	content, err := jsonToMarkdown(&doc)
+	if err != nil {
+		return nil, err
+	}
+
+	fm := ParseMarkdown(content)
+	if doc.Metadata != nil {
+		fm.Metadata = doc.Metadata
+	}
+	return fm, nil
+}
+
+// jsonToMarkdown writes the items of a JSON document as markdown
+func jsonToMarkdown(doc *JSONDocument) (string, error) {
+	var b strings.Builder
+	indent := defaultIndent()
+	prevDepth := -1          // Depth of the previous todo, -1 outside a list
+	var childPrefix []string // Indentation of the items nested under the last todo at each depth
+	var last []string        // Bullet of the last todo at each depth, to number lists in sequence
+
+	for i, item := range doc.Items {
+		if strings.ContainsAny(item.Text, "\r\n") {
+			return "", fmt.Errorf("item %d: text must be a single line", i+1)
+		}
+
+		switch item.Type {
+		case JSONHeading:
+			if item.Level < 1 || item.Level > 6 {
+				return "", fmt.Errorf("item %d: heading level must be between 1 and 6, got %d", i+1, item.Level)
+			}
+			if b.Len() > 0 {
+				b.WriteString("\n")
+			}
+			b.WriteString(strings.Repeat("#", item.Level) + " " + item.Text + "\n")
+			prevDepth = -1
+			last = nil
+
+		case JSONTodo:
+			if !slices.Contains(jsonMarkers, item.Marker) {
+				return "", fmt.Errorf("item %d: marker must be one of -, *, +, 1. or 1), got %q", i+1, item.Marker)
+			}
+			if item.Depth < 0 || item.Depth > prevDepth+1 {
+				return "", fmt.Errorf("item %d: todo depth %d doesn't follow depth %d", i+1, item.Depth, max(prevDepth, 0))
+			}
+			if prevDepth < 0 && b.Len() > 0 {
+				b.WriteString("\n")
+			}
+
+			prefix := ""
+			if item.Depth > 0 {
+				prefix = childPrefix[item.Depth-1]
+			}
+			checkbox := "[ ]"
+			if item.Checked {
+				checkbox = "[x]"
+			} else if item.Progress {
+				checkbox = "[" + ProgressChar + "]"
+			}
+			bullet := cmp.Or(item.Marker, "-")
+			if item.Marker == "1." || item.Marker == "1)" {
+				// Continue the numbering of a preceding sibling in the same list
+				if item.Depth < len(last) && len(last[item.Depth]) > 1 && strings.HasSuffix(last[item.Depth], item.Marker[1:]) {
+					n, _ := strconv.Atoi(last[item.Depth][:len(last[item.Depth])-1])
+					bullet = strconv.Itoa(n+1) + item.Marker[1:]
+				}
+			}
+			last = append(last[:min(item.Depth, len(last))], bullet)
+			// Items nested under a number must be indented past it, e.g. 3 spaces under "1."
+			unit := indent
+			if !strings.Contains(unit, "\t") && len(unit) < len(bullet)+1 {
+				unit = strings.Repeat(" ", len(bullet)+1)
+			}
+			childPrefix = append(childPrefix[:item.Depth], prefix+unit)
+			b.WriteString(prefix + bullet + " " + checkbox + " " + item.Text + "\n")
+			for _, note := range item.Notes {
+				if note != "" {
+					// Notes line up with the checkbox
+					b.WriteString(prefix + strings.Repeat(" ", len(bullet)+1) + note)
+				}
+				b.WriteString("\n")
+			}
+			prevDepth = item.Depth
+
+		default:
+			return "", fmt.Errorf("item %d: unknown type %q (expected %q or %q)", i+1, item.Type, JSONHeading, JSONTodo)
+		}
+	}
+
+	return b.String(), nil
+}
@@ -0,0 +1,158 @@
+package markdown
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+)
+
+const jsonFixture = `---
+filter-done: true
+---
+# Work
+
+- [ ] Ship release !p1 #backend @due(2025-03-01)
+  check the changelog
+
+  and the docs
+  - [x] Write tests @office +tdx
+    - [ ] Edge cases
+- [x] Review ` + "`#define`" + ` [PR](https://example.com/pr#1)
+
+## Later
+
+- [-] Plan next sprint
+`
+
+// semanticItems describes a file by its headings and todos, ignoring formatting
+func semanticItems(fm *FileModel) []JSONItem {
+	return ToJSON(fm).Items
+}
+
+func TestJSON_RoundTrip(t *testing.T) {
+	metadata, content, err := ParseMetadata(jsonFixture)
+	if err != nil {
+		t.Fatal(err)
+	}
+	original := ParseMarkdown(content)
+	original.Metadata = metadata
+
+	exported, err := ExportJSON(original)
+	if err != nil {
+		t.Fatal(err)
+	}
+	imported, err := ImportJSON([]byte(exported))
+	if err != nil {
+		t.Fatalf("ImportJSON: %v\n%s", err, exported)
+	}
+
+	want, _ := json.Marshal(semanticItems(original))
+	got, _ := json.Marshal(semanticItems(imported))
+	if string(got) != string(want) {
+		t.Errorf("Round trip changed the document:\n got %s\nwant %s", got, want)
+	}
+	if imported.Metadata == nil || imported.Metadata.FilterDone == nil || !*imported.Metadata.FilterDone {
+		t.Errorf("Metadata not restored: %+v", imported.Metadata)
+	}
+
+	serialized := SerializeMarkdown(imported)
+	reparsedMeta, reparsed, err := ParseMetadata(serialized)
+	if err != nil || reparsedMeta.FilterDone == nil {
+		t.Fatalf("Serialized file lost its frontmatter:\n%s", serialized)
+	}
+	again, _ := json.Marshal(semanticItems(ParseMarkdown(reparsed)))
+	if string(again) != string(want) {
+		t.Errorf("Serialized markdown doesn't match the original:\n%s", serialized)
+	}
+}
+
+func TestJSON_ExportFields(t *testing.T) {
+	doc := ToJSON(ParseMarkdown("# Todos\n\n- [ ] Ship !p2 #backend @home +site @due(2025-03-01)\n  a note\n"))
+
+	if doc.Version != JSONVersion {
+		t.Errorf("Version = %d, want %d", doc.Version, JSONVersion)
+	}
+	if len(doc.Items) != 2 || doc.Items[0].Type != JSONHeading || doc.Items[0].Level != 1 {
+		t.Fatalf("Items = %+v", doc.Items)
+	}
+	todo := doc.Items[1]
+	if todo.Priority != 2 || todo.Due != "2025-03-01" || !slices.Equal(todo.Tags, []string{"backend"}) ||
+		!slices.Equal(todo.Contexts, []string{"home"}) || !slices.Equal(todo.Projects, []string{"site"}) ||
+		!slices.Equal(todo.Notes, []string{"a note"}) {
+		t.Errorf("Todo item = %+v", todo)
+	}
+}
+
+func TestJSON_HeadingsAfterLastTodo(t *testing.T) {
+	doc := ToJSON(ParseMarkdown("# Todos\n\n- [ ] Task\n\n## Archive\n"))
+
+	if len(doc.Items) != 3 || doc.Items[2].Type != JSONHeading || doc.Items[2].Text != "Archive" {
+		t.Errorf("Items = %+v", doc.Items)
+	}
+}
+
+func TestJSON_MarkersRoundTrip(t *testing.T) {
+	content := "# Todos\n\n1. [ ] First\n   - [ ] Nested\n2. [x] Second\n   a note\n\n## Later\n\n* [ ] Star\n+ [ ] Plus\n- [ ] Dash\n"
+
+	exported, err := ExportJSON(ParseMarkdown(content))
+	if err != nil {
+		t.Fatal(err)
+	}
+	imported, err := ImportJSON([]byte(exported))
+	if err != nil {
+		t.Fatalf("ImportJSON: %v\n%s", err, exported)
+	}
+
+	expected := "# Todos\n\n1. [ ] First\n   - [ ] Nested\n2. [x] Second\n   a note\n\n## Later\n\n* [ ] Star\n+ [ ] Plus\n- [ ] Dash\n"
+	if got := SerializeMarkdown(imported); got != expected {
+		t.Errorf("Expected:\n%q\nGot:\n%q", expected, got)
+	}
+}
+
+func TestUnrepresentableInJSON(t *testing.T) {
+	tests := []struct {
+		content string
+		want    string
+	}{
+		{"# Todos\n\n- [ ] Task\n  a note\n  - [x] Nested\n\n## Later\n", ""},
+		{"# Todos\n\n1. [ ] Numbered\n* [ ] Star\n", ""},
+		{"# Todos\n\nSome intro\n\n- [ ] Task\n", "a paragraph"},
+		{"# Todos\n\n- [ ] Task\n  - plain bullet\n", "a list item without a checkbox"},
+		{"# Todos\n\n| a | b |\n|---|---|\n| 1 | 2 |\n", "a table"},
+		{"# Todos\n\n- [ ] Task\n\n---\n", "a horizontal rule"},
+	}
+	for _, tt := range tests {
+		if got := UnrepresentableInJSON(ParseMarkdown(tt.content)); got != tt.want {
+			t.Errorf("UnrepresentableInJSON(%q) = %q, want %q", tt.content, got, tt.want)
+		}
+	}
+}
+
+func TestImportJSON_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"not json", `- [ ] task`, "invalid JSON"},
+		{"missing version", `{"items":[]}`, "missing version"},
+		{"newer version", `{"version":2,"items":[],"extra":true}`, "unsupported version 2"},
+		{"unknown field", `{"version":1,"items":[],"todos":[]}`, `unknown field "todos"`},
+		{"unknown item field", `{"version":1,"items":[{"type":"todo","text":"x","done":true}]}`, `unknown field "done"`},
+		{"unknown type", `{"version":1,"items":[{"type":"paragraph","text":"x"}]}`, `item 1: unknown type "paragraph"`},
+		{"bad heading level", `{"version":1,"items":[{"type":"heading","level":7,"text":"x"}]}`, "heading level"},
+		{"depth jump", `{"version":1,"items":[{"type":"todo","text":"a"},{"type":"todo","text":"b","depth":2}]}`, "item 2: todo depth 2"},
+		{"unknown marker", `{"version":1,"items":[{"type":"todo","text":"a","marker":"2."}]}`, "marker must be one of"},
+		{"multiline text", `{"version":1,"items":[{"type":"todo","text":"a\nb"}]}`, "single line"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ImportJSON([]byte(tt.input))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("ImportJSON error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
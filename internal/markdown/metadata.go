@@ -2,6 +2,7 @@ package markdown
 
 import (
 	"bytes"
+	"fmt"
 	"regexp"
 	"strings"
 
@@ -10,11 +11,14 @@ import (
 
 // Metadata represents per-file configuration options from YAML frontmatter
 type Metadata struct {
-	FilterDone   *bool `yaml:"filter-done,omitempty"`   // Filter out completed tasks
-	MaxVisible   *int  `yaml:"max-visible,omitempty"`   // Maximum visible tasks
-	ShowHeadings *bool `yaml:"show-headings,omitempty"` // Show headings between tasks
-	ReadOnly     *bool `yaml:"read-only,omitempty"`     // Open in read-only mode
-	WordWrap     *bool `yaml:"word-wrap,omitempty"`     // Enable word wrapping
+	FilterDone   *bool  `yaml:"filter-done,omitempty" json:"filter-done,omitempty"`     // Filter out completed tasks
+	MaxVisible   *int   `yaml:"max-visible,omitempty" json:"max-visible,omitempty"`     // Maximum visible tasks
+	ShowHeadings *bool  `yaml:"show-headings,omitempty" json:"show-headings,omitempty"` // Show headings between tasks
+	ReadOnly     *bool  `yaml:"read-only,omitempty" json:"read-only,omitempty"`         // Open in read-only mode
+	WordWrap     *bool  `yaml:"word-wrap,omitempty" json:"word-wrap,omitempty"`         // Enable word wrapping
+	Theme        string `yaml:"theme,omitempty" json:"theme,omitempty"`                 // Theme used for this file instead of the configured one
+
+	Warnings []string `yaml:"-" json:"-"` // Problems found while parsing that didn't stop it, like duplicate keys
 }
 
 // frontmatterRegex matches YAML frontmatter at the start of a file
@@ -33,6 +37,9 @@ func ParseMetadata(content string) (*Metadata, string, error) {
 	yamlContent := matches[1]
 	contentWithoutFrontmatter := strings.TrimPrefix(content, matches[0])
 
+	// yaml.v3 rejects repeated keys, so drop all but the last one first
+	yamlContent, warnings := removeDuplicateKeys(yamlContent)
+
 	var metadata Metadata
 	decoder := yaml.NewDecoder(bytes.NewBufferString(yamlContent))
 	decoder.KnownFields(true) // Reject unknown fields to catch typos
@@ -42,9 +49,50 @@ func ParseMetadata(content string) (*Metadata, string, error) {
 		return &Metadata{}, contentWithoutFrontmatter, err
 	}
 
+	metadata.Warnings = warnings
 	return &metadata, contentWithoutFrontmatter, nil
 }
 
+// removeDuplicateKeys keeps only the last value of each repeated top-level key
+// and returns a warning for every earlier one. Content that isn't a mapping is
+// returned unchanged.
+func removeDuplicateKeys(yamlContent string) (string, []string) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(yamlContent), &doc); err != nil || len(doc.Content) == 0 {
+		return yamlContent, nil
+	}
+	mapping := doc.Content[0]
+	if mapping.Kind != yaml.MappingNode {
+		return yamlContent, nil
+	}
+
+	last := make(map[string]int)
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		last[mapping.Content[i].Value] = i
+	}
+
+	var warnings []string
+	var kept []*yaml.Node
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		key := mapping.Content[i]
+		if last[key.Value] != i {
+			warnings = append(warnings, fmt.Sprintf("duplicate key %q on line %d", key.Value, key.Line))
+			continue
+		}
+		kept = append(kept, key, mapping.Content[i+1])
+	}
+	if len(warnings) == 0 {
+		return yamlContent, nil
+	}
+
+	mapping.Content = kept
+	out, err := yaml.Marshal(&doc)
+	if err != nil {
+		return yamlContent, nil
+	}
+	return string(out), warnings
+}
+
 // SerializeMetadata adds YAML frontmatter to markdown content
 func SerializeMetadata(metadata *Metadata, content string) string {
 	// Don't add frontmatter if all fields are nil (no configuration)
@@ -75,7 +123,8 @@ func (m *Metadata) IsEmpty() bool {
 		m.MaxVisible == nil &&
 		m.ShowHeadings == nil &&
 		m.ReadOnly == nil &&
-		m.WordWrap == nil
+		m.WordWrap == nil &&
+		m.Theme == ""
 }
 
 // GetBool returns the value of a bool pointer or the default if nil
@@ -255,3 +255,56 @@ filter-done: true
 		t.Error("New inline code should be preserved")
 	}
 }
+
+func TestParseMetadata_Theme(t *testing.T) {
+	content := "---\ntheme: dracula\n---\n# Todos\n\n- [ ] Task\n"
+
+	metadata, contentWithout, err := ParseMetadata(content)
+	if err != nil {
+		t.Fatalf("ParseMetadata failed: %v", err)
+	}
+	if metadata.Theme != "dracula" {
+		t.Errorf("Theme = %q, want dracula", metadata.Theme)
+	}
+	if metadata.IsEmpty() {
+		t.Error("Metadata with only a theme should not be empty")
+	}
+
+	fm := ParseMarkdown(contentWithout)
+	fm.Metadata = metadata
+	fm.AddTodoItem("New task", false)
+	if serialized := SerializeMarkdown(fm); !strings.HasPrefix(serialized, "---\ntheme: dracula\n---\n") {
+		t.Errorf("Theme should be preserved in the frontmatter. Got:\n%s", serialized)
+	}
+}
+
+func TestParseMetadata_DuplicateKeys(t *testing.T) {
+	content := "---\nfilter-done: true\nmax-visible: 5\nfilter-done: false\n---\n# Todos\n\n- [ ] Task\n"
+
+	metadata, contentWithout, err := ParseMetadata(content)
+	if err != nil {
+		t.Fatalf("ParseMetadata failed: %v", err)
+	}
+	if metadata.FilterDone == nil || *metadata.FilterDone {
+		t.Errorf("FilterDone = %v, want the last value (false)", metadata.FilterDone)
+	}
+	if metadata.MaxVisible == nil || *metadata.MaxVisible != 5 {
+		t.Errorf("MaxVisible = %v, want 5", metadata.MaxVisible)
+	}
+	if len(metadata.Warnings) != 1 || !strings.Contains(metadata.Warnings[0], `"filter-done" on line 1`) {
+		t.Errorf("Warnings = %q, want one for filter-done on line 1", metadata.Warnings)
+	}
+	if contentWithout != "# Todos\n\n- [ ] Task\n" {
+		t.Errorf("Content = %q, frontmatter should be stripped", contentWithout)
+	}
+}
+
+func TestParseMetadata_NoWarningsWithoutDuplicates(t *testing.T) {
+	metadata, _, err := ParseMetadata("---\nfilter-done: true\n---\n- [ ] Task\n")
+	if err != nil {
+		t.Fatalf("ParseMetadata failed: %v", err)
+	}
+	if len(metadata.Warnings) != 0 {
+		t.Errorf("Warnings = %q, want none", metadata.Warnings)
+	}
+}
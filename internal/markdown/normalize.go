@@ -0,0 +1,88 @@
+package markdown
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// NormalizeTokens rewrites the text of added and edited todos with
+// NormalizeTodoText, so tokens don't drift around as todos are changed.
+// Set by main.go from [defaults] normalize_tokens.
+var NormalizeTokens bool
+
+// tokenPattern matches one kind of token moved by NormalizeTodoText; group is
+// the submatch holding the token itself
+type tokenPattern struct {
+	re    *regexp.Regexp
+	group int
+}
+
+// tokenOrder lists the token groups in the order they end up at the end of the text
+var tokenOrder = [][]tokenPattern{
+	{{tagRegex, 0}},
+	{{priorityRegex, 0}},
+	{{dueRegex, 0}, {relativeDueRegex, 1}},
+}
+
+// NormalizeTodoText puts the tokens of a todo in canonical order: the plain
+// text first, then tags, then the priority, then due dates. Tokens of the same
+// kind keep their relative order. Contexts, projects, time tracking and tokens
+// inside code spans or links stay where they are.
+func NormalizeTodoText(text string) string {
+	// Whole links are masked, as moving a tag out of a link label would break it
+	masked := []byte(text)
+	for _, loc := range inlineSpanRegex.FindAllStringIndex(text, -1) {
+		for i := loc[0]; i < loc[1]; i++ {
+			masked[i] = spanMask
+		}
+	}
+
+	type span struct{ start, end, group int }
+	var spans []span
+	for group, patterns := range tokenOrder {
+		for _, p := range patterns {
+			for _, loc := range p.re.FindAllSubmatchIndex(masked, -1) {
+				spans = append(spans, span{loc[2*p.group], loc[2*p.group+1], group})
+			}
+		}
+	}
+	if len(spans) == 0 {
+		return text
+	}
+	sort.Slice(spans, func(i, j int) bool { return spans[i].start < spans[j].start })
+
+	var rest strings.Builder
+	tokens := make([][]string, len(tokenOrder))
+	last := 0
+	for _, s := range spans {
+		if s.start < last {
+			continue // Overlaps a token that was already taken
+		}
+		start := s.start
+		if start > last && text[start-1] == ' ' {
+			start--
+		}
+		rest.WriteString(text[last:start])
+		tokens[s.group] = append(tokens[s.group], text[s.start:s.end])
+		last = s.end
+	}
+	rest.WriteString(text[last:])
+
+	parts := []string{strings.TrimSpace(rest.String())}
+	for _, group := range tokens {
+		parts = append(parts, group...)
+	}
+	if parts[0] == "" {
+		parts = parts[1:]
+	}
+	return strings.Join(parts, " ")
+}
+
+// normalizeText applies NormalizeTodoText when NormalizeTokens is set
+func normalizeText(text string) string {
+	if NormalizeTokens {
+		return NormalizeTodoText(text)
+	}
+	return text
+}
@@ -0,0 +1,79 @@
+package markdown
+
+import "testing"
+
+func TestNormalizeTodoText_CanonicalOrder(t *testing.T) {
+	want := "Ship release #work #urgent !p1 @due(2025-03-01)"
+	inputs := []string{
+		"Ship release #work #urgent !p1 @due(2025-03-01)",
+		"Ship release !p1 #work #urgent @due(2025-03-01)",
+		"!p1 Ship release @due(2025-03-01) #work #urgent",
+		"#work Ship @due(2025-03-01) release !p1 #urgent",
+		"Ship release   #work !p1 #urgent @due(2025-03-01) ",
+	}
+	for _, in := range inputs {
+		if got := NormalizeTodoText(in); got != want {
+			t.Errorf("NormalizeTodoText(%q) = %q, want %q", in, got, want)
+		}
+	}
+
+	// Spacing inside the plain text is left alone
+	if got := NormalizeTodoText("Ship  release !p1"); got != "Ship  release !p1" {
+		t.Errorf("NormalizeTodoText = %q", got)
+	}
+}
+
+func TestNormalizeTodoText_KeepsOtherTokens(t *testing.T) {
+	tests := map[string]string{
+		"Plain text":                          "Plain text",
+		"#a #b":                               "#a #b",
+		"Call @phone +home !p2 est:1h #calls": "Call @phone +home est:1h #calls !p2",
+		"Pay due:tomorrow rent #bills":        "Pay rent #bills due:tomorrow",
+		"Fix `#define` and `!p1` #c":          "Fix `#define` and `!p1` #c",
+		"Read [#spec](https://x.io/#a) !p3":   "Read [#spec](https://x.io/#a) !p3",
+	}
+	for in, want := range tests {
+		if got := NormalizeTodoText(in); got != want {
+			t.Errorf("NormalizeTodoText(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestNormalizeTodoText_Idempotent(t *testing.T) {
+	in := "!p2 Review #code PR due:fri @due(2025-01-01) #team"
+	once := NormalizeTodoText(in)
+	if once != "Review PR #code #team !p2 due:fri @due(2025-01-01)" {
+		t.Errorf("NormalizeTodoText = %q", once)
+	}
+	if twice := NormalizeTodoText(once); twice != once {
+		t.Errorf("Second pass changed %q to %q", once, twice)
+	}
+}
+
+func TestNormalizeTokens_AppliedOnEdit(t *testing.T) {
+	content := "# Todos\n\n- [ ] Existing !p1 #keep\n- [ ] Other\n"
+
+	NormalizeTokens = true
+	t.Cleanup(func() { NormalizeTokens = false })
+
+	fm := ParseMarkdown(content)
+	if err := fm.UpdateTodoItem(1, "!p2 Other #tag", false); err != nil {
+		t.Fatal(err)
+	}
+	fm.AddTodoItem("@due(2025-01-01) New #x", false)
+
+	want := "# Todos\n\n- [ ] Existing !p1 #keep\n- [ ] Other #tag !p2\n- [ ] New #x @due(2025-01-01)\n"
+	if got := SerializeMarkdown(fm); got != want {
+		t.Errorf("Got:\n%s\nWant (untouched todos keep their order):\n%s", got, want)
+	}
+}
+
+func TestNormalizeTokens_OffByDefault(t *testing.T) {
+	fm := ParseMarkdown("- [ ] Task\n")
+	if err := fm.UpdateTodoItem(0, "!p1 Task #tag", false); err != nil {
+		t.Fatal(err)
+	}
+	if fm.Todos[0].Text != "!p1 Task #tag" {
+		t.Errorf("Text = %q, want it unchanged", fm.Todos[0].Text)
+	}
+}
@@ -0,0 +1,205 @@
+package markdown
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/yuin/goldmark/ast"
+	extast "github.com/yuin/goldmark/extension/ast"
+	"github.com/yuin/goldmark/text"
+)
+
+// recordNotes moves the continuation lines of every task item out of the AST and
+// into doc.notes. Notes are the indented lines under a checkbox that aren't list
+// items themselves; they are kept verbatim with the item's content indent removed.
+func (doc *ASTDocument) recordNotes() {
+	doc.notes = make(map[ast.Node][]string)
+
+	var items []*ast.ListItem
+	_ = ast.Walk(doc.AST, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if entering {
+			if item, ok := n.(*ast.ListItem); ok {
+				items = append(items, item)
+			}
+		}
+		return ast.WalkContinue, nil
+	})
+
+	for _, item := range items {
+		first := item.FirstChild()
+		if first == nil || !hasTaskCheckBox(first) || first.Lines().Len() == 0 {
+			continue
+		}
+
+		// Notes are indented to the column of the checkbox
+		firstLine := first.Lines().At(0)
+		column := firstLine.Start - lineStart(doc.Source, firstLine.Start)
+
+		var notes []string
+		var detached []ast.Node
+		end := lineEnd(doc.Source, firstLine.Stop)
+
+		// Lines continuing the checkbox paragraph
+		if lines := first.Lines(); lines.Len() > 1 {
+			last := lineEnd(doc.Source, lines.At(lines.Len()-1).Stop)
+			if splitFirstLine(first) {
+				notes = append(notes, doc.noteLines(end, last, column)...)
+				end = last
+			}
+		}
+
+		// Further blocks inside the item, e.g. paragraphs or code
+		afterList := false
+		for child := first.NextSibling(); child != nil; child = child.NextSibling() {
+			if child.Kind() == ast.KindList {
+				afterList = true
+				continue
+			}
+			start, stop, ok := blockLineSpan(doc.Source, child)
+			if !ok {
+				continue
+			}
+			if afterList {
+				end = start
+				afterList = false
+			}
+			notes = append(notes, doc.noteLines(end, stop, column)...)
+			end = stop
+			detached = append(detached, child)
+		}
+
+		for _, child := range detached {
+			item.RemoveChild(item, child)
+		}
+		if len(notes) > 0 {
+			doc.notes[item] = notes
+		}
+	}
+}
+
+// hasTaskCheckBox reports whether a block contains a task checkbox
+func hasTaskCheckBox(block ast.Node) bool {
+	found := false
+	_ = ast.Walk(block, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if entering && n.Kind() == extast.KindTaskCheckBox {
+			found = true
+			return ast.WalkStop, nil
+		}
+		return ast.WalkContinue, nil
+	})
+	return found
+}
+
+// splitFirstLine drops everything after the first line of a checkbox paragraph.
+// It reports false when the line break sits inside inline markup (e.g. emphasis
+// spanning two lines), in which case the paragraph is left whole.
+func splitFirstLine(block ast.Node) bool {
+	for child := block.FirstChild(); child != nil; child = child.NextSibling() {
+		t, ok := child.(*ast.Text)
+		if !ok || !(t.SoftLineBreak() || t.HardLineBreak()) {
+			continue
+		}
+		for rest := t.NextSibling(); rest != nil; {
+			next := rest.NextSibling()
+			block.RemoveChild(block, rest)
+			rest = next
+		}
+		t.SetSoftLineBreak(false)
+		t.SetHardLineBreak(false)
+		lines := text.NewSegments()
+		lines.Append(block.Lines().At(0))
+		block.SetLines(lines)
+		return true
+	}
+	return false
+}
+
+// blockLineSpan returns the offsets of the first and past-the-last line of a block
+func blockLineSpan(source []byte, block ast.Node) (start, stop int, ok bool) {
+	start, stop, ok = nodeSpan(block)
+	if !ok {
+		return 0, 0, false
+	}
+	start = lineStart(source, start)
+	stop = lineEnd(source, stop)
+
+	// Code fences are not part of a fenced block's segments
+	if fenced, isFenced := block.(*ast.FencedCodeBlock); isFenced {
+		if fenced.Info == nil && start > 0 {
+			start = lineStart(source, start-1)
+		}
+		closing := lineEnd(source, stop+1)
+		trimmed := bytes.TrimSpace(source[stop:closing])
+		if bytes.HasPrefix(trimmed, []byte("```")) || bytes.HasPrefix(trimmed, []byte("~~~")) {
+			stop = closing
+		}
+	}
+	return start, stop, true
+}
+
+// noteLines splits source[start:stop] into lines with up to column leading whitespace removed
+func (doc *ASTDocument) noteLines(start, stop, column int) []string {
+	if start >= stop {
+		return nil
+	}
+	raw := strings.TrimSuffix(string(doc.Source[start:stop]), "\n")
+	lines := strings.Split(raw, "\n")
+	for i, line := range lines {
+		line = strings.TrimRight(line, "\r")
+		trim := 0
+		for trim < len(line) && trim < column && (line[trim] == ' ' || line[trim] == '\t') {
+			trim++
+		}
+		lines[i] = line[trim:]
+		if strings.TrimSpace(lines[i]) == "" {
+			lines[i] = ""
+		}
+	}
+	return lines
+}
+
+// noteIndent returns the whitespace to write before a note line of a list item:
+// the item's indent plus room for its bullet, so notes line up with the checkbox
+func (doc *ASTDocument) noteIndent(listItem ast.Node, depth int, bullet string) string {
+	return doc.itemIndent(listItem, depth) + strings.Repeat(" ", len(bullet)+1)
+}
+
+// AddTodoNote appends a note line to a todo
+func (doc *ASTDocument) AddTodoNote(todoIndex int, note string) error {
+	node, err := doc.FindTodoNode(todoIndex)
+	if err != nil {
+		return err
+	}
+	doc.notes[node.ListItem] = append(doc.notes[node.ListItem], strings.Split(note, "\n")...)
+	return nil
+}
+
+// SetTodoNotes replaces all notes of a todo
+func (doc *ASTDocument) SetTodoNotes(todoIndex int, notes []string) error {
+	node, err := doc.FindTodoNode(todoIndex)
+	if err != nil {
+		return err
+	}
+	if len(notes) == 0 {
+		delete(doc.notes, node.ListItem)
+		return nil
+	}
+	doc.notes[node.ListItem] = append([]string(nil), notes...)
+	return nil
+}
+
+// AddTodoNote appends a note line to the todo at index
+func (fm *FileModel) AddTodoNote(index int, note string) error {
+	if index < 0 || index >= len(fm.Todos) {
+		return fmt.Errorf("invalid todo index: %d", index)
+	}
+
+	fm.ensureAST()
+	if err := fm.ast.AddTodoNote(index, note); err != nil {
+		return err
+	}
+	// Re-extract todos to keep cache in sync
+	fm.Todos = fm.ast.ExtractTodos()
+	return nil
+}
@@ -0,0 +1,116 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNotes_ParseContinuationLines(t *testing.T) {
+	fm := ParseMarkdown("# Todos\n\n- [ ] Task\n  first note\n  second note\n- [ ] Next\n")
+
+	if len(fm.Todos) != 2 {
+		t.Fatalf("Expected 2 todos, got %d", len(fm.Todos))
+	}
+	if fm.Todos[0].Text != "Task" {
+		t.Errorf("Expected note lines to stay out of the text, got %q", fm.Todos[0].Text)
+	}
+	expected := []string{"first note", "second note"}
+	if strings.Join(fm.Todos[0].Notes, "|") != strings.Join(expected, "|") {
+		t.Errorf("Expected notes %q, got %q", expected, fm.Todos[0].Notes)
+	}
+	if len(fm.Todos[1].Notes) != 0 {
+		t.Errorf("Expected no notes on second todo, got %q", fm.Todos[1].Notes)
+	}
+}
+
+func TestNotes_ParseBlocksAndNestedTodos(t *testing.T) {
+	content := "# Todos\n\n- [ ] Parent\n\n  A paragraph.\n\n  ```sh\n  make test\n  ```\n  - [ ] Child\n    child note\n"
+	fm := ParseMarkdown(content)
+
+	if len(fm.Todos) != 2 {
+		t.Fatalf("Expected 2 todos, got %d", len(fm.Todos))
+	}
+	expected := []string{"", "A paragraph.", "", "```sh", "make test", "```"}
+	if strings.Join(fm.Todos[0].Notes, "|") != strings.Join(expected, "|") {
+		t.Errorf("Expected parent notes %q, got %q", expected, fm.Todos[0].Notes)
+	}
+	if len(fm.Todos[1].Notes) != 1 || fm.Todos[1].Notes[0] != "child note" {
+		t.Errorf("Expected child note, got %q", fm.Todos[1].Notes)
+	}
+
+	// Untouched notes are written back unchanged
+	if got := SerializeMarkdown(fm); got != content {
+		t.Errorf("Round trip changed the file.\nExpected:\n%q\nGot:\n%q", content, got)
+	}
+}
+
+func TestNotes_SurviveToggleAndEdit(t *testing.T) {
+	content := "# Todos\n\n- [ ] Task\n  keep me\n  - [ ] Child\n- [ ] Next\n"
+	fm := ParseMarkdown(content)
+
+	if err := fm.UpdateTodoItem(0, "Task renamed", true); err != nil {
+		t.Fatalf("UpdateTodoItem failed: %v", err)
+	}
+
+	expected := "# Todos\n\n- [x] Task renamed\n  keep me\n  - [ ] Child\n- [ ] Next\n"
+	if got := SerializeMarkdown(fm); got != expected {
+		t.Errorf("Expected:\n%q\nGot:\n%q", expected, got)
+	}
+}
+
+func TestNotes_AddTodoNote(t *testing.T) {
+	fm := ParseMarkdown("# Todos\n\n- [ ] One\n  existing\n- [ ] Two\n    - [ ] Nested\n")
+
+	if err := fm.AddTodoNote(0, "appended"); err != nil {
+		t.Fatalf("AddTodoNote failed: %v", err)
+	}
+	if err := fm.AddTodoNote(2, "deep note"); err != nil {
+		t.Fatalf("AddTodoNote failed: %v", err)
+	}
+	if err := fm.AddTodoNote(5, "nope"); err == nil {
+		t.Error("Expected error for invalid index")
+	}
+
+	if got := fm.Todos[0].Notes; len(got) != 2 || got[1] != "appended" {
+		t.Errorf("Expected note appended to cache, got %q", got)
+	}
+
+	expected := "# Todos\n\n- [ ] One\n  existing\n  appended\n- [ ] Two\n    - [ ] Nested\n      deep note\n"
+	got := SerializeMarkdown(fm)
+	if got != expected {
+		t.Errorf("Expected:\n%q\nGot:\n%q", expected, got)
+	}
+
+	// Notes stay attached to their todo after re-parsing
+	reparsed := ParseMarkdown(got)
+	if notes := reparsed.Todos[2].Notes; len(notes) != 1 || notes[0] != "deep note" {
+		t.Errorf("Expected note to stay with nested todo, got %q", notes)
+	}
+}
+
+func TestNotes_FollowTodoWhenSorted(t *testing.T) {
+	fm := ParseMarkdown("# Todos\n\n- [ ] Banana\n  yellow\n- [ ] Apple\n  red\n")
+
+	if err := fm.SortTodos("alpha"); err != nil {
+		t.Fatalf("SortTodos failed: %v", err)
+	}
+
+	expected := "# Todos\n\n- [ ] Apple\n  red\n- [ ] Banana\n  yellow\n"
+	if got := SerializeMarkdown(fm); got != expected {
+		t.Errorf("Expected:\n%q\nGot:\n%q", expected, got)
+	}
+}
+
+func TestNotes_ModelWithoutAST(t *testing.T) {
+	fm := &FileModel{
+		Todos: []Todo{
+			{Text: "Parent", Notes: []string{"parent note"}},
+			{Text: "Child", Depth: 1, Notes: []string{"child note"}},
+		},
+	}
+
+	expected := "# Todos\n\n- [ ] Parent\n  parent note\n  - [ ] Child\n    child note\n"
+	if got := SerializeMarkdown(fm); got != expected {
+		t.Errorf("Expected:\n%q\nGot:\n%q", expected, got)
+	}
+}
@@ -4,21 +4,37 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
+	"slices"
 	"strings"
 	"time"
+
+	"github.com/niklas-heer/tdx/internal/util"
 )
 
+// taskLineRegex matches the start of a task list item line
+var taskLineRegex = regexp.MustCompile(`(?m)^[ \t]*(?:[-*+]|\d+[.)])[ \t]+\[.\]`)
+
 // Todo represents a single todo item
 type Todo struct {
 	Index       int
 	Checked     bool
+	InProgress  bool // Open todo marked in progress with [-] or [/]
 	Text        string
 	LineNo      int
-	Tags        []string   // Tags extracted from the text (e.g., #urgent #backend)
-	Priority    int        // Priority level (1=highest, 0=no priority) extracted from !p1, !p2, etc.
-	Depth       int        // Nesting depth: 0 = top-level, 1 = child, 2 = grandchild, etc.
-	ParentIndex int        // Index of parent todo in flat array, -1 for top-level
-	DueDate     *time.Time // Due date extracted from @due(YYYY-MM-DD), nil if not set
+	Tags        []string      // Tags extracted from the text (e.g., #urgent #backend)
+	Contexts    []string      // Contexts extracted from the text (e.g., @home @phone)
+	Projects    []string      // Projects extracted from the text (e.g., +website)
+	Color       string        // Label color from an @color token (e.g., @red), "" if none
+	Priority    int           // Priority level (1=highest, 0=no priority) extracted from !p1, !p2, etc.
+	Depth       int           // Nesting depth: 0 = top-level, 1 = child, 2 = grandchild, etc.
+	ParentIndex int           // Index of parent todo in flat array, -1 for top-level
+	DueDate     *time.Time    // Due date extracted from @due(YYYY-MM-DD), nil if not set
+	Estimate    time.Duration // Estimated time from est:2h tokens
+	Spent       time.Duration // Time spent from spent:45m tokens
+	Marker      byte          // List bullet used in the file: '-', '*' or '+', or '.' / ')' after the number of an ordered item
+	Ordered     bool          // Item of a numbered list ("1. [ ] ..."), renumbered when saved
+	Notes       []string      // Indented lines under the todo that aren't todos themselves
 }
 
 // FileModel holds parsed file content with AST backend
@@ -113,17 +129,7 @@ func WriteFile(filePath string, fm *FileModel) error {
 // WriteFileUnchecked writes a FileModel to disk without checking for external modifications
 // Use this when you've already checked for conflicts and handled them
 func WriteFileUnchecked(filePath string, fm *FileModel) error {
-	content := SerializeMarkdown(fm)
-
-	// Atomic write: temp file + rename
-	dir := filepath.Dir(filePath)
-	tmpFile := filepath.Join(dir, fmt.Sprintf(".tmp.%d", os.Getpid()))
-
-	if err := os.WriteFile(tmpFile, []byte(content), 0644); err != nil {
-		return err
-	}
-
-	if err := os.Rename(tmpFile, filePath); err != nil {
+	if err := WriteContent(filePath, SerializeMarkdown(fm)); err != nil {
 		return err
 	}
 
@@ -136,6 +142,22 @@ func WriteFileUnchecked(filePath string, fm *FileModel) error {
 	return nil
 }
 
+// WriteContent atomically replaces the file with the given markdown. Through a
+// symlink the file it points to is replaced, so the link stays a link.
+func WriteContent(filePath string, content string) error {
+	filePath = util.CanonicalPath(filePath)
+
+	// Atomic write: temp file + rename
+	dir := filepath.Dir(filePath)
+	tmpFile := filepath.Join(dir, fmt.Sprintf(".tmp.%d", os.Getpid()))
+
+	if err := os.WriteFile(tmpFile, []byte(content), 0644); err != nil {
+		return err
+	}
+
+	return os.Rename(tmpFile, filePath)
+}
+
 // ParseMarkdown parses markdown content into a FileModel with AST backend
 func ParseMarkdown(content string) *FileModel {
 	// Parse with goldmark AST
@@ -160,12 +182,15 @@ func ParseMarkdown(content string) *FileModel {
 	}
 }
 
+// CountTodoLines counts the task list lines in content without parsing it.
+// Lines in code blocks are counted too, so it is an estimate.
+func CountTodoLines(content string) int {
+	return len(taskLineRegex.FindAllStringIndex(content, -1))
+}
+
 // SerializeMarkdown converts a FileModel back to markdown using AST
 func SerializeMarkdown(fm *FileModel) string {
-	if fm.ast == nil {
-		// Fallback to old serialization if no AST
-		return serializeMarkdownLegacy(fm)
-	}
+	fm.ensureAST()
 
 	// If todos were modified, rebuild AST
 	if fm.dirty {
@@ -187,6 +212,49 @@ func SerializeMarkdown(fm *FileModel) string {
 	return content
 }
 
+// ensureAST builds an AST from the cached todos for models constructed without one
+// (e.g. &FileModel{Todos: ...}), so every write goes through the AST serializer
+func (fm *FileModel) ensureAST() {
+	if fm.ast != nil {
+		return
+	}
+
+	var sb strings.Builder
+	sb.WriteString("# Todos\n\n")
+	for _, todo := range fm.Todos {
+		marker := todo.Marker
+		if marker == 0 {
+			marker = '-'
+		}
+		indent := strings.Repeat(defaultIndent(), todo.Depth)
+		sb.WriteString(indent)
+		if todo.Ordered {
+			// The serializer numbers the items in sequence
+			sb.WriteString("1")
+		}
+		sb.WriteByte(marker)
+		if todo.Checked {
+			sb.WriteString(" [x] ")
+		} else if todo.InProgress {
+			sb.WriteString(" [" + ProgressChar + "] ")
+		} else {
+			sb.WriteString(" [ ] ")
+		}
+		sb.WriteString(todo.Text)
+		sb.WriteString("\n")
+		for _, note := range todo.Notes {
+			if note != "" {
+				sb.WriteString(indent + "  ")
+				sb.WriteString(note)
+			}
+			sb.WriteString("\n")
+		}
+	}
+
+	fm.ast, _ = ParseAST(sb.String())
+	fm.dirty = false
+}
+
 // syncTodosToAST synchronizes the Todos slice back to the AST
 // This is called when todos have been modified through the legacy API
 func (fm *FileModel) syncTodosToAST() {
@@ -197,9 +265,18 @@ func (fm *FileModel) syncTodosToAST() {
 	for i := range fm.Todos {
 		if i >= len(astTodos) {
 			// Todo was added
+			fm.Todos[i].Text = normalizeText(fm.Todos[i].Text)
 			_ = fm.ast.AddTodo(fm.Todos[i].Text, fm.Todos[i].Checked)
-		} else if fm.Todos[i].Text != astTodos[i].Text {
+			_ = fm.ast.SetTodoNotes(i, fm.Todos[i].Notes)
+			continue
+		}
+		if !slices.Equal(fm.Todos[i].Notes, astTodos[i].Notes) {
+			// Notes travel with their todo (e.g. when sorting)
+			_ = fm.ast.SetTodoNotes(i, fm.Todos[i].Notes)
+		}
+		if fm.Todos[i].Text != astTodos[i].Text {
 			// Text was modified
+			fm.Todos[i].Text = normalizeText(fm.Todos[i].Text)
 			_ = fm.ast.UpdateTodoText(i, fm.Todos[i].Text)
 			if fm.Todos[i].Checked != astTodos[i].Checked {
 				_ = fm.ast.ToggleTodo(i)
@@ -208,6 +285,9 @@ func (fm *FileModel) syncTodosToAST() {
 			// Checkbox was toggled
 			_ = fm.ast.ToggleTodo(i)
 		}
+		if fm.Todos[i].InProgress != astTodos[i].InProgress && !fm.Todos[i].Checked {
+			_ = fm.ast.SetTodoInProgress(i, fm.Todos[i].InProgress)
+		}
 	}
 
 	// Check for deletions (Todos slice is shorter than AST)
@@ -223,42 +303,25 @@ func (fm *FileModel) syncTodosToAST() {
 
 // AddTodoItem adds a new todo at the end of the file
 func (fm *FileModel) AddTodoItem(text string, checked bool) {
-	if fm.ast != nil {
-		// Use AST for adding
-		_ = fm.ast.AddTodo(text, checked)
-		// Re-extract todos to keep cache in sync
-		fm.Todos = fm.ast.ExtractTodos()
-	} else {
-		// Legacy fallback
-		newTodo := Todo{
-			Index:   len(fm.Todos) + 1,
-			Checked: checked,
-			Text:    text,
-			LineNo:  len(fm.Lines),
-		}
-		fm.Todos = append(fm.Todos, newTodo)
-		fm.Lines = append(fm.Lines, fmt.Sprintf("- [%s] %s", map[bool]string{true: "x", false: " "}[checked], text))
-	}
+	fm.ensureAST()
+	_ = fm.ast.AddTodo(normalizeText(text), checked)
+	// Re-extract todos to keep cache in sync
+	fm.Todos = fm.ast.ExtractTodos()
 }
 
 // InsertTodoItemAfter inserts a new todo after the specified index
 // If afterIndex is -1, inserts at the beginning
 // Returns the index of the newly inserted todo
 func (fm *FileModel) InsertTodoItemAfter(afterIndex int, text string, checked bool) int {
-	if fm.ast != nil {
-		// Use AST for inserting
-		_ = fm.ast.InsertTodoAfter(afterIndex, text, checked)
-		// Re-extract todos to keep cache in sync
-		fm.Todos = fm.ast.ExtractTodos()
-		// Return the new index (afterIndex + 1, or 0 if inserting at beginning)
-		if afterIndex < 0 {
-			return 0
-		}
-		return afterIndex + 1
+	fm.ensureAST()
+	_ = fm.ast.InsertTodoAfter(afterIndex, normalizeText(text), checked)
+	// Re-extract todos to keep cache in sync
+	fm.Todos = fm.ast.ExtractTodos()
+	// Return the new index (afterIndex + 1, or 0 if inserting at beginning)
+	if afterIndex < 0 {
+		return 0
 	}
-	// Legacy fallback - just append
-	fm.AddTodoItem(text, checked)
-	return len(fm.Todos) - 1
+	return afterIndex + 1
 }
 
 // UpdateTodoItem updates an existing todo
@@ -267,68 +330,71 @@ func (fm *FileModel) UpdateTodoItem(index int, text string, checked bool) error
 		return fmt.Errorf("invalid todo index: %d", index)
 	}
 
-	if fm.ast != nil {
-		// Update via AST
-		if fm.Todos[index].Text != text {
-			if err := fm.ast.UpdateTodoText(index, text); err != nil {
-				return err
-			}
+	fm.ensureAST()
+	if fm.Todos[index].Text != text {
+		text = normalizeText(text)
+		if err := fm.ast.UpdateTodoText(index, text); err != nil {
+			return err
 		}
-		if fm.Todos[index].Checked != checked {
-			if err := fm.ast.ToggleTodo(index); err != nil {
-				return err
-			}
+	}
+	if fm.Todos[index].Checked != checked {
+		if err := fm.ast.ToggleTodo(index); err != nil {
+			return err
 		}
-		// Re-extract todos to keep cache in sync
-		fm.Todos = fm.ast.ExtractTodos()
-	} else {
-		// Legacy fallback
-		fm.Todos[index].Text = text
-		fm.Todos[index].Checked = checked
-		fm.dirty = true
 	}
+	fm.refreshTodo(index)
 	return nil
 }
 
-// DeleteTodoItem removes a todo
-func (fm *FileModel) DeleteTodoItem(index int) error {
+// refreshTodo updates the cached todo at index after an edit that changed
+// only that todo, re-extracting every todo if it can't be found
+func (fm *FileModel) refreshTodo(index int) {
+	if fm.ast.RefreshTodo(fm.Todos, index) != nil {
+		fm.Todos = fm.ast.ExtractTodos()
+	}
+}
+
+// SetTodoInProgress marks an open todo as in progress, or back to open
+func (fm *FileModel) SetTodoInProgress(index int, inProgress bool) error {
 	if index < 0 || index >= len(fm.Todos) {
 		return fmt.Errorf("invalid todo index: %d", index)
 	}
 
-	if fm.ast != nil {
-		// Delete via AST
-		if err := fm.ast.DeleteTodo(index); err != nil {
-			return err
-		}
-		// Re-extract todos to keep cache in sync
-		fm.Todos = fm.ast.ExtractTodos()
-	} else {
-		// Legacy fallback
-		todo := fm.Todos[index]
-		// Remove the line from the file
-		newLines := make([]string, 0, len(fm.Lines)-1)
-		for i, line := range fm.Lines {
-			if i != todo.LineNo {
-				newLines = append(newLines, line)
-			}
-		}
-		fm.Lines = newLines
-		// Re-parse to update line numbers
-		content := strings.Join(fm.Lines, "\n")
+	fm.ensureAST()
+	if err := fm.ast.SetTodoInProgress(index, inProgress); err != nil {
+		return err
+	}
+	fm.refreshTodo(index)
+	return nil
+}
 
-		// Preserve metadata before re-parsing
-		oldMetadata := fm.Metadata
-		oldFilePath := fm.FilePath
-		oldModTime := fm.ModTime
+// ToggleTodoItem moves a todo to its next state. Open todos become done, or in
+// progress when CycleProgress is set; in-progress todos become done and done
+// todos open again.
+func (fm *FileModel) ToggleTodoItem(index int) error {
+	if index < 0 || index >= len(fm.Todos) {
+		return fmt.Errorf("invalid todo index: %d", index)
+	}
+
+	todo := fm.Todos[index]
+	if CycleProgress && !todo.Checked && !todo.InProgress {
+		return fm.SetTodoInProgress(index, true)
+	}
+	return fm.UpdateTodoItem(index, todo.Text, !todo.Checked)
+}
 
-		*fm = *ParseMarkdown(content)
+// DeleteTodoItem removes a todo
+func (fm *FileModel) DeleteTodoItem(index int) error {
+	if index < 0 || index >= len(fm.Todos) {
+		return fmt.Errorf("invalid todo index: %d", index)
+	}
 
-		// Restore preserved fields
-		fm.Metadata = oldMetadata
-		fm.FilePath = oldFilePath
-		fm.ModTime = oldModTime
+	fm.ensureAST()
+	if err := fm.ast.DeleteTodo(index); err != nil {
+		return err
 	}
+	// Re-extract todos to keep cache in sync
+	fm.Todos = fm.ast.ExtractTodos()
 	return nil
 }
 
@@ -342,16 +408,12 @@ func (fm *FileModel) MoveTodoItemToPosition(fromIndex, targetIndex int, insertAf
 		return nil // No-op
 	}
 
-	if fm.ast != nil {
-		// Move via AST using explicit insert position
-		if err := fm.ast.MoveTodoToPosition(fromIndex, targetIndex, insertAfter); err != nil {
-			return err
-		}
-		// Re-extract todos to keep cache in sync
-		fm.Todos = fm.ast.ExtractTodos()
-	} else {
-		return fmt.Errorf("AST not available for position-based move")
+	fm.ensureAST()
+	if err := fm.ast.MoveTodoToPosition(fromIndex, targetIndex, insertAfter); err != nil {
+		return err
 	}
+	// Re-extract todos to keep cache in sync
+	fm.Todos = fm.ast.ExtractTodos()
 	return nil
 }
 
@@ -365,30 +427,12 @@ func (fm *FileModel) MoveTodoItem(fromIndex, toIndex int) error {
 		return nil // No-op
 	}
 
-	if fm.ast != nil {
-		// Move via AST
-		if err := fm.ast.MoveTodo(fromIndex, toIndex); err != nil {
-			return err
-		}
-		// Re-extract todos to keep cache in sync
-		fm.Todos = fm.ast.ExtractTodos()
-	} else {
-		// Legacy fallback: array manipulation
-		todo := fm.Todos[fromIndex]
-		// Remove from old position
-		fm.Todos = append(fm.Todos[:fromIndex], fm.Todos[fromIndex+1:]...)
-		// Insert at new position (adjusted if moving down)
-		insertIdx := toIndex
-		if fromIndex < toIndex {
-			insertIdx-- // Account for the removal
-		}
-		fm.Todos = append(fm.Todos[:insertIdx+1], append([]Todo{todo}, fm.Todos[insertIdx+1:]...)...)
-		// Update all indices
-		for i := range fm.Todos {
-			fm.Todos[i].Index = i + 1
-		}
-		fm.dirty = true
+	fm.ensureAST()
+	if err := fm.ast.MoveTodo(fromIndex, toIndex); err != nil {
+		return err
 	}
+	// Re-extract todos to keep cache in sync
+	fm.Todos = fm.ast.ExtractTodos()
 	return nil
 }
 
@@ -398,27 +442,12 @@ func (fm *FileModel) SwapTodoItems(index1, index2 int) error {
 		return fmt.Errorf("invalid todo indices: %d, %d", index1, index2)
 	}
 
-	if fm.ast != nil {
-		// Swap via AST
-		if err := fm.ast.SwapTodos(index1, index2); err != nil {
-			return err
-		}
-		// Re-extract todos to keep cache in sync
-		fm.Todos = fm.ast.ExtractTodos()
-	} else {
-		// Legacy fallback
-		fm.Todos[index1], fm.Todos[index2] = fm.Todos[index2], fm.Todos[index1]
-		// Update indexes
-		fm.Todos[index1].Index = index1 + 1
-		fm.Todos[index2].Index = index2 + 1
-		// Swap lines
-		lineI := fm.Todos[index1].LineNo
-		lineJ := fm.Todos[index2].LineNo
-		fm.Lines[lineI], fm.Lines[lineJ] = fm.Lines[lineJ], fm.Lines[lineI]
-		// Swap line numbers
-		fm.Todos[index1].LineNo, fm.Todos[index2].LineNo = fm.Todos[index2].LineNo, fm.Todos[index1].LineNo
-		fm.dirty = true
+	fm.ensureAST()
+	if err := fm.ast.SwapTodos(index1, index2); err != nil {
+		return err
 	}
+	// Re-extract todos to keep cache in sync
+	fm.Todos = fm.ast.ExtractTodos()
 	return nil
 }
 
@@ -428,9 +457,7 @@ func (fm *FileModel) IndentTodoItem(index int) error {
 		return fmt.Errorf("invalid todo index: %d", index)
 	}
 
-	if fm.ast == nil {
-		return fmt.Errorf("AST not available for indent operation")
-	}
+	fm.ensureAST()
 
 	if err := fm.ast.IndentTodo(index); err != nil {
 		return err
@@ -446,9 +473,7 @@ func (fm *FileModel) OutdentTodoItem(index int) error {
 		return fmt.Errorf("invalid todo index: %d", index)
 	}
 
-	if fm.ast == nil {
-		return fmt.Errorf("AST not available for outdent operation")
-	}
+	fm.ensureAST()
 
 	if err := fm.ast.OutdentTodo(index); err != nil {
 		return err
@@ -492,164 +517,18 @@ func (fm *FileModel) Clone() *FileModel {
 	}
 
 	return &FileModel{
-		Lines: lines,
-		Todos: todos,
-		ast:   astCopy,
-		dirty: fm.dirty,
+		Lines:    lines,
+		Todos:    todos,
+		ast:      astCopy,
+		dirty:    fm.dirty,
+		FilePath: fm.FilePath,
+		ModTime:  fm.ModTime,
+		Metadata: fm.Metadata,
 	}
 }
 
-// RebuildFileStructure reconstructs Lines from Todos, preserving non-todo content
-// This uses AST to preserve all structure perfectly
+// RebuildFileStructure marks the todos as modified so they are synced back to the AST on next save
 func RebuildFileStructure(fm *FileModel) {
-	if fm.ast == nil {
-		rebuildFileStructureLegacy(fm)
-		return
-	}
-
-	// With AST, we don't need to rebuild - the AST is already correct
-	// Just mark as dirty so it gets synced on next save
+	fm.ensureAST()
 	fm.dirty = true
 }
-
-// Legacy fallback functions for when AST is not available
-
-func serializeMarkdownLegacy(fm *FileModel) string {
-	// Build a map of line numbers to updated todo content
-	todoLineMap := make(map[int]string)
-	var todosToAppend []Todo
-
-	for _, todo := range fm.Todos {
-		var line string
-		if todo.Checked {
-			line = fmt.Sprintf("- [x] %s", todo.Text)
-		} else {
-			line = fmt.Sprintf("- [ ] %s", todo.Text)
-		}
-
-		// If todo.LineNo is beyond current Lines array, we need to append it
-		if todo.LineNo >= len(fm.Lines) {
-			todosToAppend = append(todosToAppend, todo)
-		} else {
-			todoLineMap[todo.LineNo] = line
-		}
-	}
-
-	// Build result, updating todo lines in place and preserving everything else
-	var result []string
-	for i, line := range fm.Lines {
-		if updatedLine, exists := todoLineMap[i]; exists {
-			// This line is a todo - use updated version
-			result = append(result, updatedLine)
-		} else {
-			// Preserve non-todo lines as-is
-			result = append(result, line)
-		}
-	}
-
-	// Append todos that were beyond the current Lines array
-	for _, todo := range todosToAppend {
-		if todo.Checked {
-			result = append(result, fmt.Sprintf("- [x] %s", todo.Text))
-		} else {
-			result = append(result, fmt.Sprintf("- [ ] %s", todo.Text))
-		}
-	}
-
-	// If there are no lines at all, create minimal structure
-	if len(result) == 0 {
-		result = append(result, "# Todos", "")
-		for _, todo := range fm.Todos {
-			if todo.Checked {
-				result = append(result, fmt.Sprintf("- [x] %s", todo.Text))
-			} else {
-				result = append(result, fmt.Sprintf("- [ ] %s", todo.Text))
-			}
-		}
-	}
-
-	// Check if header is missing and add it
-	hasHeader := false
-	for _, line := range result {
-		if strings.HasPrefix(line, "#") {
-			hasHeader = true
-			break
-		}
-	}
-	if !hasHeader {
-		result = append([]string{"# Todos", ""}, result...)
-	}
-
-	// Ensure trailing newline
-	output := strings.Join(result, "\n")
-	if !strings.HasSuffix(output, "\n") {
-		output += "\n"
-	}
-	return output
-}
-
-func rebuildFileStructureLegacy(fm *FileModel) {
-	// Find all todo lines in the current file (before modification)
-	oldTodoLines := make(map[int]bool)
-	for i, line := range fm.Lines {
-		if strings.HasPrefix(line, "- [ ] ") || strings.HasPrefix(line, "- [x] ") {
-			oldTodoLines[i] = true
-		}
-	}
-
-	if len(oldTodoLines) == 0 {
-		// No original todos, just append new ones at the end
-		for i := range fm.Todos {
-			fm.Todos[i].Index = i + 1
-			fm.Todos[i].LineNo = len(fm.Lines)
-			var line string
-			if fm.Todos[i].Checked {
-				line = fmt.Sprintf("- [x] %s", fm.Todos[i].Text)
-			} else {
-				line = fmt.Sprintf("- [ ] %s", fm.Todos[i].Text)
-			}
-			fm.Lines = append(fm.Lines, line)
-		}
-		return
-	}
-
-	// Find first and last todo positions
-	firstTodoLine := len(fm.Lines)
-	lastTodoLine := -1
-	for lineNo := range oldTodoLines {
-		if lineNo < firstTodoLine {
-			firstTodoLine = lineNo
-		}
-		if lineNo > lastTodoLine {
-			lastTodoLine = lineNo
-		}
-	}
-
-	// Rebuild: keep non-todo lines, insert new todos at original position
-	var newLines []string
-
-	// Keep everything before first todo
-	for i := 0; i < firstTodoLine; i++ {
-		newLines = append(newLines, fm.Lines[i])
-	}
-
-	// Insert new todos
-	for i := range fm.Todos {
-		fm.Todos[i].Index = i + 1
-		fm.Todos[i].LineNo = len(newLines)
-		var line string
-		if fm.Todos[i].Checked {
-			line = fmt.Sprintf("- [x] %s", fm.Todos[i].Text)
-		} else {
-			line = fmt.Sprintf("- [ ] %s", fm.Todos[i].Text)
-		}
-		newLines = append(newLines, line)
-	}
-
-	// Keep everything after the last original todo line
-	for i := lastTodoLine + 1; i < len(fm.Lines); i++ {
-		newLines = append(newLines, fm.Lines[i])
-	}
-
-	fm.Lines = newLines
-}
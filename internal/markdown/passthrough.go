@@ -0,0 +1,371 @@
+package markdown
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/text"
+)
+
+// blockSource records the original source of a top-level block so that
+// content tdx never edits can be written back byte for byte
+type blockSource struct {
+	raw      []byte // Source from the start of this block up to the next block
+	tail     []byte // Source after the block's last line (lists only), e.g. link reference definitions
+	absorbed bool   // Block has no position of its own; its source lives in a neighbour's raw
+}
+
+// recordBlockSources captures the verbatim source of every top-level block.
+// Only lists are re-serialized from the AST; everything else (paragraphs, HTML,
+// tables, code blocks, reference definitions, ...) is passed through unchanged.
+func (doc *ASTDocument) recordBlockSources() {
+	doc.blocks = make(map[ast.Node]*blockSource)
+
+	type positioned struct {
+		node  ast.Node
+		start int
+		stop  int
+	}
+	var known []positioned
+	var pending []ast.Node // Blocks without a position since the last known one
+
+	for child := doc.AST.FirstChild(); child != nil; child = child.NextSibling() {
+		start, stop, ok := nodeSpan(child)
+		if !ok {
+			pending = append(pending, child)
+			continue
+		}
+		for _, n := range pending {
+			doc.blocks[n] = &blockSource{absorbed: true}
+		}
+		pending = nil
+		known = append(known, positioned{child, lineStart(doc.Source, start), lineEnd(doc.Source, stop)})
+	}
+	for _, n := range pending {
+		doc.blocks[n] = &blockSource{absorbed: true}
+	}
+
+	if len(known) == 0 {
+		doc.leading = doc.Source
+		return
+	}
+	doc.leading = doc.Source[:known[0].start]
+
+	for i, k := range known {
+		next := len(doc.Source)
+		if i+1 < len(known) {
+			next = known[i+1].start
+		}
+		src := &blockSource{raw: doc.Source[k.start:next]}
+		if k.node.Kind() == ast.KindList && k.stop < next {
+			src.tail = doc.Source[k.stop:next]
+		}
+		doc.blocks[k.node] = src
+	}
+}
+
+// nodeSpan returns the smallest and largest source offsets referenced by a node or its descendants
+func nodeSpan(node ast.Node) (start, stop int, ok bool) {
+	start, stop = -1, -1
+	include := func(seg text.Segment) {
+		if start == -1 || seg.Start < start {
+			start = seg.Start
+		}
+		if seg.Stop > stop {
+			stop = seg.Stop
+		}
+	}
+
+	_ = ast.Walk(node, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		if n.Type() == ast.TypeBlock {
+			lines := n.Lines()
+			if lines.Len() > 0 {
+				include(lines.At(0))
+				include(lines.At(lines.Len() - 1))
+			}
+		}
+		switch v := n.(type) {
+		case *ast.Text:
+			include(v.Segment)
+		case *ast.FencedCodeBlock:
+			if v.Info != nil {
+				include(v.Info.Segment)
+			}
+		}
+		return ast.WalkContinue, nil
+	})
+
+	return start, stop, start != -1
+}
+
+// lineStart returns the offset of the beginning of the line containing pos
+func lineStart(source []byte, pos int) int {
+	if pos > len(source) {
+		pos = len(source)
+	}
+	for pos > 0 && source[pos-1] != '\n' {
+		pos--
+	}
+	return pos
+}
+
+// lineEnd returns the offset just past the newline ending the line containing pos
+func lineEnd(source []byte, pos int) int {
+	if pos > 0 && pos <= len(source) && source[pos-1] == '\n' {
+		return pos
+	}
+	for pos < len(source) && source[pos] != '\n' {
+		pos++
+	}
+	if pos < len(source) {
+		pos++
+	}
+	return pos
+}
+
+// flattenRawInlines replaces autolinks and inline HTML with plain text nodes
+// covering their original source, so URLs like <https://example.com> or bare
+// https://example.com survive extraction and serialization unchanged
+func flattenRawInlines(root ast.Node, source []byte) {
+	var targets []ast.Node
+	_ = ast.Walk(root, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if entering && (n.Kind() == ast.KindAutoLink || n.Kind() == ast.KindRawHTML) {
+			targets = append(targets, n)
+			return ast.WalkSkipChildren, nil
+		}
+		return ast.WalkContinue, nil
+	})
+
+	for _, n := range targets {
+		seg, ok := rawInlineSegment(n, source)
+		if !ok {
+			continue
+		}
+		replacement := ast.NewTextSegment(seg)
+		parent := n.Parent()
+		parent.ReplaceChild(parent, n, replacement)
+	}
+}
+
+// rawInlineSegment finds the source range of an autolink or inline HTML node
+func rawInlineSegment(n ast.Node, source []byte) (text.Segment, bool) {
+	switch v := n.(type) {
+	case *ast.RawHTML:
+		if v.Segments.Len() == 0 {
+			return text.Segment{}, false
+		}
+		return text.NewSegment(v.Segments.At(0).Start, v.Segments.At(v.Segments.Len()-1).Stop), true
+
+	case *ast.AutoLink:
+		label := v.Label(source)
+		// Search from the end of the preceding text, or from the start of the line
+		from := 0
+		if prev, ok := n.PreviousSibling().(*ast.Text); ok {
+			from = prev.Segment.Stop
+		} else if parent := n.Parent(); parent != nil && parent.Type() == ast.TypeBlock && parent.Lines().Len() > 0 {
+			from = parent.Lines().At(0).Start
+		}
+		if from > len(source) {
+			return text.Segment{}, false
+		}
+		idx := bytes.Index(source[from:], label)
+		if idx == -1 {
+			return text.Segment{}, false
+		}
+		start := from + idx
+		stop := start + len(label)
+		// Keep the angle brackets of <url> autolinks
+		if start > 0 && source[start-1] == '<' && stop < len(source) && source[stop] == '>' {
+			start--
+			stop++
+		}
+		return text.NewSegment(start, stop), true
+	}
+	return text.Segment{}, false
+}
+
+// IndentWidth is the number of spaces used to indent nested todos in files that
+// don't have nested items yet. Files that do keep their own indentation (e.g. tabs).
+var IndentWidth = 2
+
+// itemLayout records how a list item was written in the original source
+type itemLayout struct {
+	marker      byte     // Bullet character: '-', '*' or '+'
+	indent      string   // Whitespace before the bullet
+	depth       int      // Nesting depth the indent belongs to
+	blankBefore int      // Blank lines directly above the item
+	list        ast.Node // List the item was parsed in
+	prev        ast.Node // Sibling the item was parsed after, nil for the first
+}
+
+// recordListItems remembers the bullet, indentation and preceding blank lines of
+// every list item so items keep them when toggled, edited or moved. Blank lines
+// are only kept while the item stays after the same sibling.
+func (doc *ASTDocument) recordListItems() {
+	doc.items = make(map[ast.Node]*itemLayout)
+
+	var walk func(list *ast.List, depth int, parent *itemLayout)
+	walk = func(list *ast.List, depth int, parent *itemLayout) {
+		for item := list.FirstChild(); item != nil; item = item.NextSibling() {
+			layout := &itemLayout{marker: list.Marker, depth: depth, list: list, prev: item.PreviousSibling()}
+			if list.IsOrdered() {
+				layout.marker = 0
+			}
+			if start, _, ok := nodeSpan(item); ok {
+				ls := lineStart(doc.Source, start)
+				line := doc.Source[ls:]
+				ws := len(line) - len(bytes.TrimLeft(line, " \t"))
+				layout.indent = string(line[:ws])
+				layout.blankBefore = countBlankLinesBefore(doc.Source, ls)
+			}
+			doc.items[item] = layout
+
+			// The first nested item tells how the file indents its levels
+			if parent != nil && doc.indentUnit == "" {
+				if unit, ok := strings.CutPrefix(layout.indent, parent.indent); ok && unit != "" {
+					doc.indentUnit = unit
+				}
+			}
+
+			for child := item.FirstChild(); child != nil; child = child.NextSibling() {
+				if nested, ok := child.(*ast.List); ok {
+					walk(nested, depth+1, layout)
+				}
+			}
+		}
+	}
+
+	for child := doc.AST.FirstChild(); child != nil; child = child.NextSibling() {
+		if list, ok := child.(*ast.List); ok {
+			walk(list, 0, nil)
+		}
+	}
+}
+
+// nestingIndent returns the whitespace that indents a list item one level past its
+// parent: the unit the file already uses, or IndentWidth spaces
+func (doc *ASTDocument) nestingIndent() string {
+	if doc.indentUnit != "" {
+		return doc.indentUnit
+	}
+	return defaultIndent()
+}
+
+// defaultIndent returns IndentWidth spaces, falling back to two
+func defaultIndent() string {
+	if IndentWidth <= 0 {
+		return "  "
+	}
+	return strings.Repeat(" ", IndentWidth)
+}
+
+// countBlankLinesBefore counts whitespace-only lines directly above the line starting at pos
+func countBlankLinesBefore(source []byte, pos int) int {
+	count := 0
+	for pos > 0 {
+		prev := lineStart(source, pos-1)
+		if len(bytes.TrimSpace(source[prev:pos])) > 0 {
+			break
+		}
+		count++
+		pos = prev
+	}
+	return count
+}
+
+// itemMarker returns the bullet to write for a list item
+func (doc *ASTDocument) itemMarker(listItem ast.Node) byte {
+	if layout, ok := doc.items[listItem]; ok && layout.marker != 0 {
+		return layout.marker
+	}
+	if list, ok := listItem.Parent().(*ast.List); ok && list.Marker != 0 {
+		return list.Marker
+	}
+	return '-'
+}
+
+// isOrderedItem reports whether a list item belongs to a numbered list
+func isOrderedItem(listItem ast.Node) bool {
+	list, ok := listItem.Parent().(*ast.List)
+	return ok && list.IsOrdered()
+}
+
+// itemNumber returns the number of an item in an ordered list: the list's start
+// number plus the item's position, so items stay in sequence after inserts and deletes
+func itemNumber(listItem ast.Node) int {
+	number := 0
+	if list, ok := listItem.Parent().(*ast.List); ok {
+		number = list.Start
+	}
+	for prev := listItem.PreviousSibling(); prev != nil; prev = prev.PreviousSibling() {
+		number++
+	}
+	return number
+}
+
+// itemBullet returns what to write before a list item's checkbox: its bullet, or
+// for an ordered list the given number and the list's delimiter (e.g. "3.")
+func (doc *ASTDocument) itemBullet(listItem ast.Node, number int) string {
+	if list, ok := listItem.Parent().(*ast.List); ok && list.IsOrdered() {
+		return strconv.Itoa(number) + string(list.Marker)
+	}
+	return string(doc.itemMarker(listItem))
+}
+
+// itemIndent returns the whitespace to write before a list item's bullet at the given depth.
+// The original indentation is kept while the item stays at its depth; otherwise the item
+// matches its siblings, or is indented one nesting level past its parent item.
+func (doc *ASTDocument) itemIndent(listItem ast.Node, depth int) string {
+	if layout, ok := doc.items[listItem]; ok && layout.depth == depth {
+		return layout.indent
+	}
+	list := listItem.Parent()
+	if list == nil {
+		return strings.Repeat(doc.nestingIndent(), depth)
+	}
+	for sibling := list.FirstChild(); sibling != nil; sibling = sibling.NextSibling() {
+		if layout, ok := doc.items[sibling]; ok && layout.depth == depth {
+			return layout.indent
+		}
+	}
+	if depth == 0 {
+		return ""
+	}
+	if parentItem, ok := list.Parent().(*ast.ListItem); ok {
+		unit := doc.nestingIndent()
+		// Items nested under a number must be indented past it, e.g. 3 spaces under "1."
+		if isOrderedItem(parentItem) && !strings.Contains(unit, "\t") {
+			if width := len(doc.itemBullet(parentItem, itemNumber(parentItem))) + 1; len(unit) < width {
+				unit = strings.Repeat(" ", width)
+			}
+		}
+		return doc.itemIndent(parentItem, depth-1) + unit
+	}
+	return strings.Repeat(doc.nestingIndent(), depth)
+}
+
+// itemBlankLines returns how many blank lines to write above a list item
+func (doc *ASTDocument) itemBlankLines(listItem ast.Node) int {
+	list, ok := listItem.Parent().(*ast.List)
+	if !ok {
+		return 0
+	}
+	// Spacing above the first item of a top-level list belongs to the preceding block
+	if listItem.PreviousSibling() == nil && list.Parent() != nil && list.Parent().Kind() == ast.KindDocument {
+		return 0
+	}
+	// A moved item doesn't take the spacing of its old position along
+	if layout, ok := doc.items[listItem]; ok && layout.list == list && layout.prev == listItem.PreviousSibling() {
+		return layout.blankBefore
+	}
+	// New items in a loose list are separated like their siblings
+	if !list.IsTight && listItem.PreviousSibling() != nil {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,225 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPassthrough_ParagraphBetweenTodosSurvivesToggle(t *testing.T) {
+	content := `# Todos
+
+- [ ] First task
+
+Some notes about the project, see [docs](https://example.com/docs).
+They span two lines.
+
+- [ ] Second task
+`
+	fm := ParseMarkdown(content)
+	if err := fm.UpdateTodoItem(0, "First task", true); err != nil {
+		t.Fatalf("UpdateTodoItem failed: %v", err)
+	}
+
+	expected := strings.Replace(content, "- [ ] First task", "- [x] First task", 1)
+	if got := SerializeMarkdown(fm); got != expected {
+		t.Errorf("Paragraph not preserved.\nExpected:\n%s\nGot:\n%s", expected, got)
+	}
+}
+
+func TestPassthrough_UnrepresentableBlocksVerbatim(t *testing.T) {
+	content := `# Todos
+
+- [ ] Task
+
+<div align="center">
+  <img src="logo.png">
+</div>
+
+| Name | Value |
+|------|------:|
+| a    |     1 |
+
+[ref]: https://example.com "Title"
+
+    indented code
+
+> quoted *text*
+continued lazily
+
+***
+
+` + "```go\nfmt.Println(\"hi\")\n```\n"
+
+	fm := ParseMarkdown(content)
+	if err := fm.UpdateTodoItem(0, "Task", true); err != nil {
+		t.Fatalf("UpdateTodoItem failed: %v", err)
+	}
+
+	expected := strings.Replace(content, "- [ ] Task", "- [x] Task", 1)
+	if got := SerializeMarkdown(fm); got != expected {
+		t.Errorf("Blocks not passed through.\nExpected:\n%s\nGot:\n%s", expected, got)
+	}
+}
+
+func TestPassthrough_ReferenceDefinitionAfterList(t *testing.T) {
+	content := "# Todos\n\n- [ ] Read [spec][ref]\n\n[ref]: https://example.com/spec\n"
+
+	fm := ParseMarkdown(content)
+	_ = fm.UpdateTodoItem(0, "Read [spec][ref]", true)
+
+	got := SerializeMarkdown(fm)
+	if !strings.Contains(got, "[ref]: https://example.com/spec\n") {
+		t.Errorf("Reference definition lost:\n%s", got)
+	}
+}
+
+func TestPassthrough_BareURLInTodo(t *testing.T) {
+	content := "# Todos\n\n- [ ] Check https://example.com/issue/1 today\n- [ ] Read <https://example.com/rfc>\n"
+
+	fm := ParseMarkdown(content)
+	if fm.Todos[0].Text != "Check https://example.com/issue/1 today" {
+		t.Errorf("Bare URL dropped from text: %q", fm.Todos[0].Text)
+	}
+	if fm.Todos[1].Text != "Read <https://example.com/rfc>" {
+		t.Errorf("Autolink dropped from text: %q", fm.Todos[1].Text)
+	}
+
+	_ = fm.UpdateTodoItem(0, fm.Todos[0].Text, true)
+	_ = fm.UpdateTodoItem(1, fm.Todos[1].Text+" now", false)
+
+	expected := "# Todos\n\n- [x] Check https://example.com/issue/1 today\n- [ ] Read <https://example.com/rfc> now\n"
+	if got := SerializeMarkdown(fm); got != expected {
+		t.Errorf("URLs not preserved.\nExpected:\n%s\nGot:\n%s", expected, got)
+	}
+}
+
+func TestPassthrough_InlineHTMLInTodo(t *testing.T) {
+	fm := ParseMarkdown("# Todos\n\n- [ ] Press <kbd>Ctrl</kbd>\n")
+	if fm.Todos[0].Text != "Press <kbd>Ctrl</kbd>" {
+		t.Errorf("Inline HTML dropped from text: %q", fm.Todos[0].Text)
+	}
+}
+
+func TestPassthrough_AddTodoAfterTrailingParagraph(t *testing.T) {
+	fm := ParseMarkdown("# Notes\n\nJust prose, no tasks yet.")
+	fm.AddTodoItem("First task", false)
+
+	expected := "# Notes\n\nJust prose, no tasks yet.\n\n- [ ] First task\n"
+	if got := SerializeMarkdown(fm); got != expected {
+		t.Errorf("Expected:\n%q\nGot:\n%q", expected, got)
+	}
+}
+
+func TestPassthrough_ModelWithoutAST(t *testing.T) {
+	fm := &FileModel{
+		Todos: []Todo{
+			{Text: "Parent", Checked: true},
+			{Text: "Child", Depth: 1},
+		},
+	}
+
+	expected := "# Todos\n\n- [x] Parent\n  - [ ] Child\n"
+	if got := SerializeMarkdown(fm); got != expected {
+		t.Errorf("Expected:\n%q\nGot:\n%q", expected, got)
+	}
+
+	fm.AddTodoItem("Another", false)
+	if len(fm.Todos) != 3 {
+		t.Errorf("Expected 3 todos after add, got %d", len(fm.Todos))
+	}
+}
+
+func TestPassthrough_BlankLinesBetweenTasksPreserved(t *testing.T) {
+	content := `# Todos
+
+- [ ] First
+
+- [ ] Second
+
+- [x] Third
+
+
+- [ ] Fourth after two blank lines
+
+## Later
+
+- [ ] Fifth
+
+- [ ] Sixth
+`
+	fm := ParseMarkdown(content)
+	if err := fm.UpdateTodoItem(1, "Second", true); err != nil {
+		t.Fatalf("UpdateTodoItem failed: %v", err)
+	}
+
+	expected := strings.Replace(content, "- [ ] Second", "- [x] Second", 1)
+	got := SerializeMarkdown(fm)
+	if got != expected {
+		t.Errorf("Output differs beyond the toggled checkbox.\nExpected:\n%q\nGot:\n%q", expected, got)
+	}
+
+	// Toggling back must restore the original file exactly
+	_ = fm.UpdateTodoItem(1, "Second", false)
+	if got := SerializeMarkdown(fm); got != content {
+		t.Errorf("No-op toggle changed the file.\nExpected:\n%q\nGot:\n%q", content, got)
+	}
+}
+
+func TestPassthrough_IndentationPreserved(t *testing.T) {
+	content := "# Todos\n\n  - [ ] Indented top level\n  - [ ] Parent\n      - [ ] Four-space child\n\t  - [ ] Tab child\n"
+
+	fm := ParseMarkdown(content)
+	if len(fm.Todos) != 4 {
+		t.Fatalf("Expected 4 todos, got %d", len(fm.Todos))
+	}
+	_ = fm.UpdateTodoItem(2, "Four-space child", true)
+
+	expected := strings.Replace(content, "- [ ] Four-space", "- [x] Four-space", 1)
+	if got := SerializeMarkdown(fm); got != expected {
+		t.Errorf("Indentation not preserved.\nExpected:\n%q\nGot:\n%q", expected, got)
+	}
+}
+
+func TestPassthrough_NewItemMatchesSiblingIndentation(t *testing.T) {
+	fm := ParseMarkdown("# Todos\n\n- [ ] Parent\n    - [ ] Child\n")
+
+	fm.InsertTodoItemAfter(1, "Second child", false)
+
+	expected := "# Todos\n\n- [ ] Parent\n    - [ ] Child\n    - [ ] Second child\n"
+	if got := SerializeMarkdown(fm); got != expected {
+		t.Errorf("Expected:\n%q\nGot:\n%q", expected, got)
+	}
+}
+
+func TestPassthrough_NewItemInLooseList(t *testing.T) {
+	fm := ParseMarkdown("# Todos\n\n- [ ] One\n\n- [ ] Two\n")
+
+	fm.AddTodoItem("Three", false)
+
+	expected := "# Todos\n\n- [ ] One\n\n- [ ] Two\n\n- [ ] Three\n"
+	if got := SerializeMarkdown(fm); got != expected {
+		t.Errorf("Expected:\n%q\nGot:\n%q", expected, got)
+	}
+}
+
+func TestPassthrough_MovingFirstItemKeepsListTight(t *testing.T) {
+	content := "# T\n\n- [ ] a\n- [ ] b\n- [ ] c\n- [ ] d\n"
+
+	fm := ParseMarkdown(content)
+	if err := fm.MoveTodoItem(0, 2); err != nil {
+		t.Fatalf("MoveTodoItem failed: %v", err)
+	}
+	expected := "# T\n\n- [ ] b\n- [ ] c\n- [ ] a\n- [ ] d\n"
+	if got := SerializeMarkdown(fm); got != expected {
+		t.Errorf("After move expected:\n%q\nGot:\n%q", expected, got)
+	}
+
+	fm = ParseMarkdown(content)
+	if err := fm.SwapTodoItems(0, 1); err != nil {
+		t.Fatalf("SwapTodoItems failed: %v", err)
+	}
+	expected = "# T\n\n- [ ] b\n- [ ] a\n- [ ] c\n- [ ] d\n"
+	if got := SerializeMarkdown(fm); got != expected {
+		t.Errorf("After swap expected:\n%q\nGot:\n%q", expected, got)
+	}
+}
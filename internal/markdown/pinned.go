@@ -0,0 +1,51 @@
+package markdown
+
+import (
+	"sort"
+	"strings"
+)
+
+// PinnedTag marks a todo that stays at the top of its section
+const PinnedTag = "pinned"
+
+// IsPinned reports whether the todo carries the #pinned tag
+func (t *Todo) IsPinned() bool {
+	return t.HasTag(PinnedTag)
+}
+
+// AddTag appends #tag to the text unless it is already there
+func AddTag(text, tag string) string {
+	for _, existing := range ExtractTags(text) {
+		if strings.EqualFold(existing, tag) {
+			return text
+		}
+	}
+	return strings.TrimSpace(text) + " #" + tag
+}
+
+// RemoveTag deletes every #tag (ignoring case) from the text, along with the
+// space before it. Other tags and tags inside code spans are kept.
+func RemoveTag(text, tag string) string {
+	var b strings.Builder
+	last := 0
+	for _, loc := range tagRegex.FindAllStringSubmatchIndex(maskInlineSpans(text), -1) {
+		if !strings.EqualFold(text[loc[2]:loc[3]], tag) {
+			continue
+		}
+		start := loc[0]
+		if start > last && text[start-1] == ' ' {
+			start--
+		}
+		b.WriteString(text[last:start])
+		last = loc[1]
+	}
+	b.WriteString(text[last:])
+	return strings.TrimSpace(b.String())
+}
+
+// SortByPinned moves pinned todos before the others, keeping the order within each group (stable)
+func SortByPinned(todos []Todo) {
+	sort.SliceStable(todos, func(i, j int) bool {
+		return todos[i].IsPinned() && !todos[j].IsPinned()
+	})
+}
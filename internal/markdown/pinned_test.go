@@ -0,0 +1,47 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAddTag(t *testing.T) {
+	if got := AddTag("Write report", PinnedTag); got != "Write report #pinned" {
+		t.Errorf("AddTag = %q", got)
+	}
+	if got := AddTag("Write report #Pinned", PinnedTag); got != "Write report #Pinned" {
+		t.Errorf("AddTag should not duplicate the tag, got %q", got)
+	}
+}
+
+func TestRemoveTag(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"Write report #pinned", "Write report"},
+		{"Write #pinned report #work", "Write report #work"},
+		{"#pinned first", "first"},
+		{"Keep #pinned-ish tag #PINNED", "Keep #pinned-ish tag"},
+		{"Explain `#pinned` syntax #pinned", "Explain `#pinned` syntax"},
+	}
+	for _, tt := range tests {
+		if got := RemoveTag(tt.text, PinnedTag); got != tt.want {
+			t.Errorf("RemoveTag(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestSortTodos_PinnedStayFirst(t *testing.T) {
+	fm := ParseMarkdown("# Todos\n\n- [ ] A !p1\n- [ ] B #pinned\n- [ ] C !p2\n\n## Later\n\n- [ ] D !p1\n- [ ] E #pinned\n")
+
+	if err := fm.SortTodos("priority"); err != nil {
+		t.Fatalf("SortTodos failed: %v", err)
+	}
+
+	got := strings.Join(todoTexts(fm.Todos), ",")
+	want := "B #pinned,A !p1,C !p2,E #pinned,D !p1"
+	if got != want {
+		t.Errorf("order = %q, want %q", got, want)
+	}
+}
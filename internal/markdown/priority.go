@@ -3,7 +3,6 @@ package markdown
 import (
 	"regexp"
 	"strconv"
-	"strings"
 )
 
 // priorityRegex matches priority markers like !p1, !p2, etc.
@@ -13,8 +12,9 @@ var priorityRegex = regexp.MustCompile(`!p(\d+)`)
 // ExtractPriority extracts the priority level from todo text.
 // Returns the priority number (1, 2, 3, etc.) or 0 if no priority is set.
 // If multiple priorities exist, returns the highest (lowest number).
+// Markers inside code spans or link targets are ignored.
 func ExtractPriority(text string) int {
-	matches := priorityRegex.FindAllStringSubmatch(text, -1)
+	matches := priorityRegex.FindAllStringSubmatch(maskInlineSpans(text), -1)
 	if len(matches) == 0 {
 		return 0
 	}
@@ -37,20 +37,23 @@ func ExtractPriority(text string) int {
 
 // HasPriority checks if the text contains a priority marker
 func HasPriority(text string) bool {
-	return priorityRegex.MatchString(text)
+	return priorityRegex.MatchString(maskInlineSpans(text))
 }
 
 // RemovePriority removes all priority markers from the text
 // This is useful for display purposes if you want text without priority markers
 func RemovePriority(text string) string {
-	return strings.TrimSpace(priorityRegex.ReplaceAllString(text, ""))
+	return removeOutsideSpans(priorityRegex, text)
 }
 
 // GetPriorityMarker returns the first priority marker found in the text (e.g., "!p1")
 // Returns empty string if no priority is set
 func GetPriorityMarker(text string) string {
-	match := priorityRegex.FindString(text)
-	return match
+	loc := priorityRegex.FindStringIndex(maskInlineSpans(text))
+	if loc == nil {
+		return ""
+	}
+	return text[loc[0]:loc[1]]
 }
 
 // GetAllPriorities returns all unique priorities from a list of todos, sorted ascending
@@ -23,6 +23,11 @@ func TestExtractPriority(t *testing.T) {
 			text:     "Write documentation !p3",
 			expected: 3,
 		},
+		{
+			name:     "p5 priority",
+			text:     "Someday maybe !p5",
+			expected: 5,
+		},
 		{
 			name:     "p10 priority (double digit)",
 			text:     "Low priority task !p10",
@@ -0,0 +1,144 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+)
+
+// withCycleProgress sets CycleProgress for the duration of a test
+func withCycleProgress(t *testing.T, cycle bool) {
+	t.Helper()
+	old := CycleProgress
+	CycleProgress = cycle
+	t.Cleanup(func() { CycleProgress = old })
+}
+
+func TestProgress_Parse(t *testing.T) {
+	fm := ParseMarkdown("# Todos\n\n- [ ] Open\n- [-] Doing\n* [/] Also doing\n- [x] Done\n")
+
+	if len(fm.Todos) != 4 {
+		t.Fatalf("Expected 4 todos, got %d", len(fm.Todos))
+	}
+	want := []struct {
+		checked, inProgress bool
+		text                string
+	}{
+		{false, false, "Open"},
+		{false, true, "Doing"},
+		{false, true, "Also doing"},
+		{true, false, "Done"},
+	}
+	for i, w := range want {
+		todo := fm.Todos[i]
+		if todo.Checked != w.checked || todo.InProgress != w.inProgress || todo.Text != w.text {
+			t.Errorf("Todo %d = {%v %v %q}, want {%v %v %q}",
+				i, todo.Checked, todo.InProgress, todo.Text, w.checked, w.inProgress, w.text)
+		}
+	}
+}
+
+func TestProgress_RoundTripKeepsChar(t *testing.T) {
+	content := "# Todos\n\n- [-] Dash\n- [/] Slash\n- [ ] Open\n"
+	fm := ParseMarkdown(content)
+	fm.AddTodoItem("New", false)
+
+	want := content + "- [ ] New\n"
+	if got := SerializeMarkdown(fm); got != want {
+		t.Errorf("Round trip changed the checkboxes:\n%q\nwant:\n%q", got, want)
+	}
+}
+
+func TestProgress_InCodeBlockUntouched(t *testing.T) {
+	content := "# Todos\n\n- [ ] Task\n\n```\n- [-] not a task\n```\n"
+	fm := ParseMarkdown(content)
+
+	if len(fm.Todos) != 1 {
+		t.Fatalf("Expected 1 todo, got %d", len(fm.Todos))
+	}
+	if got := SerializeMarkdown(fm); got != content {
+		t.Errorf("Code block changed:\n%q", got)
+	}
+}
+
+func TestProgress_CycleThroughStates(t *testing.T) {
+	withCycleProgress(t, true)
+	fm := ParseMarkdown("# Todos\n\n- [ ] Task\n")
+
+	steps := []struct {
+		checked, inProgress bool
+		line                string
+	}{
+		{false, true, "- [-] Task"},
+		{true, false, "- [x] Task"},
+		{false, false, "- [ ] Task"},
+	}
+	for i, step := range steps {
+		if err := fm.ToggleTodoItem(0); err != nil {
+			t.Fatal(err)
+		}
+		todo := fm.Todos[0]
+		if todo.Checked != step.checked || todo.InProgress != step.inProgress {
+			t.Errorf("Step %d: checked=%v inProgress=%v, want %v %v",
+				i+1, todo.Checked, todo.InProgress, step.checked, step.inProgress)
+		}
+		if got := SerializeMarkdown(fm); !strings.Contains(got, step.line+"\n") {
+			t.Errorf("Step %d: expected %q in:\n%s", i+1, step.line, got)
+		}
+	}
+}
+
+func TestProgress_ToggleWithoutCycle(t *testing.T) {
+	withCycleProgress(t, false)
+	fm := ParseMarkdown("# Todos\n\n- [ ] Open\n- [/] Doing\n")
+
+	_ = fm.ToggleTodoItem(0)
+	_ = fm.ToggleTodoItem(1)
+
+	if !fm.Todos[0].Checked || fm.Todos[0].InProgress {
+		t.Error("Open todo should become done without passing through in progress")
+	}
+	if !fm.Todos[1].Checked || fm.Todos[1].InProgress {
+		t.Error("In-progress todo should become done")
+	}
+	if got := SerializeMarkdown(fm); got != "# Todos\n\n- [x] Open\n- [x] Doing\n" {
+		t.Errorf("Serialized = %q", got)
+	}
+}
+
+func TestProgress_ConfiguredChar(t *testing.T) {
+	old := ProgressChar
+	ProgressChar = "/"
+	defer func() { ProgressChar = old }()
+
+	fm := ParseMarkdown("# Todos\n\n- [ ] New\n- [-] Existing\n")
+	_ = fm.SetTodoInProgress(0, true)
+
+	// New marks use the configured char, existing ones keep theirs
+	if got := SerializeMarkdown(fm); got != "# Todos\n\n- [/] New\n- [-] Existing\n" {
+		t.Errorf("Serialized = %q", got)
+	}
+}
+
+func TestProgress_KeptWhenEditingText(t *testing.T) {
+	fm := ParseMarkdown("# Todos\n\n- [/] Draft\n")
+	if err := fm.UpdateTodoItem(0, "Draft v2", false); err != nil {
+		t.Fatal(err)
+	}
+
+	if !fm.Todos[0].InProgress {
+		t.Error("Editing the text should keep the in-progress state")
+	}
+	if got := SerializeMarkdown(fm); got != "# Todos\n\n- [/] Draft v2\n" {
+		t.Errorf("Serialized = %q", got)
+	}
+}
+
+func TestProgress_SurvivesSort(t *testing.T) {
+	fm := ParseMarkdown("# Todos\n\n- [ ] B task\n- [-] A task\n")
+	SortTodosInSections(fm.Todos, fm.GetHeadings(), SortByAlpha)
+	RebuildFileStructure(fm)
+
+	if got := SerializeMarkdown(fm); got != "# Todos\n\n- [-] A task\n- [ ] B task\n" {
+		t.Errorf("Serialized = %q", got)
+	}
+}
@@ -19,9 +19,30 @@ func SerializeAST(doc *ASTDocument) string {
 func serializeNode(doc *ASTDocument, node ast.Node, buf *bytes.Buffer, depth int) {
 	switch n := node.(type) {
 	case *ast.Document:
-		// Serialize all children
+		// Content before the first block (e.g. reference definitions) is kept as-is
+		if len(bytes.TrimSpace(doc.leading)) > 0 {
+			buf.Write(doc.leading)
+		}
+
 		for child := n.FirstChild(); child != nil; child = child.NextSibling() {
-			serializeNode(doc, child, buf, depth)
+			src, known := doc.blocks[child]
+			switch {
+			case known && src.absorbed:
+				// Already written as part of a neighbouring block's source
+			case known && child.Kind() != ast.KindList:
+				// tdx never edits non-list blocks, so write the original bytes
+				buf.Write(src.raw)
+			default:
+				if !known {
+					// Block was created after parsing; separate it from what came before
+					ensureBlankLine(buf)
+				}
+				serializeNode(doc, child, buf, depth)
+				// Keep the original spacing after the list (none between lists with different bullets)
+				if known && (child.NextSibling() != nil || len(bytes.TrimSpace(src.tail)) > 0) {
+					buf.Write(src.tail)
+				}
+			}
 		}
 
 	case *ast.Heading:
@@ -65,50 +86,30 @@ func serializeNode(doc *ASTDocument, node ast.Node, buf *bytes.Buffer, depth int
 		}
 
 	case *ast.List:
-		// Serialize all list items
+		// Serialize all list items, numbering ordered ones in sequence
+		number := n.Start
 		for child := n.FirstChild(); child != nil; child = child.NextSibling() {
-			serializeNode(doc, child, buf, depth)
-		}
-		// Add blank line after list
-		if n.NextSibling() != nil {
-			buf.WriteString("\n")
-		}
-
-	case *ast.ListItem:
-		// Write list marker with indentation
-		indent := strings.Repeat("  ", depth)
-		marker := "-"
-		if list, ok := n.Parent().(*ast.List); ok {
-			marker = string(list.Marker)
-		}
-		buf.WriteString(indent)
-		buf.WriteString(marker)
-		buf.WriteString(" ")
-
-		// First pass: serialize non-list children (text content)
-		hasNestedList := false
-		for child := n.FirstChild(); child != nil; child = child.NextSibling() {
-			if _, isList := child.(*ast.List); isList {
-				hasNestedList = true
+			if item, ok := child.(*ast.ListItem); ok {
+				serializeListItem(doc, item, buf, depth, doc.itemBullet(item, number))
+				number++
 			} else {
 				serializeNode(doc, child, buf, depth)
 			}
 		}
-		buf.WriteString("\n")
-
-		// Second pass: serialize nested lists (after the newline)
-		if hasNestedList {
-			for child := n.FirstChild(); child != nil; child = child.NextSibling() {
-				if _, isList := child.(*ast.List); isList {
-					serializeNode(doc, child, buf, depth+1)
-				}
-			}
+		// Add blank line after list (lists from the original file keep their own spacing)
+		if _, known := doc.blocks[n]; !known && n.NextSibling() != nil {
+			buf.WriteString("\n")
 		}
 
+	case *ast.ListItem:
+		serializeListItem(doc, n, buf, depth, doc.itemBullet(n, itemNumber(n)))
+
 	case *extast.TaskCheckBox:
 		// Write checkbox with space after it
 		if n.IsChecked {
-			buf.WriteString("[x] ")
+			buf.WriteString("[" + doc.checkChar(n) + "] ")
+		} else if c := doc.progressChar(n); c != "" {
+			buf.WriteString("[" + c + "] ")
 		} else {
 			buf.WriteString("[ ] ")
 		}
@@ -238,6 +239,55 @@ func serializeNode(doc *ASTDocument, node ast.Node, buf *bytes.Buffer, depth int
 	}
 }
 
+// serializeListItem writes a list item, its notes and nested lists, with bullet
+// (e.g. "-" or "3.") before the checkbox
+func serializeListItem(doc *ASTDocument, n *ast.ListItem, buf *bytes.Buffer, depth int, bullet string) {
+	// Write list marker with the item's original spacing and indentation
+	buf.WriteString(strings.Repeat("\n", doc.itemBlankLines(n)))
+	buf.WriteString(doc.itemIndent(n, depth))
+	buf.WriteString(bullet)
+	buf.WriteString(" ")
+
+	// First pass: serialize non-list children (text content)
+	hasNestedList := false
+	for child := n.FirstChild(); child != nil; child = child.NextSibling() {
+		if _, isList := child.(*ast.List); isList {
+			hasNestedList = true
+		} else {
+			serializeNode(doc, child, buf, depth)
+		}
+	}
+	buf.WriteString("\n")
+
+	// Notes follow the todo line, indented to its content
+	for _, note := range doc.notes[n] {
+		if note != "" {
+			buf.WriteString(doc.noteIndent(n, depth, bullet))
+			buf.WriteString(note)
+		}
+		buf.WriteString("\n")
+	}
+
+	// Second pass: serialize nested lists (after the newline)
+	if hasNestedList {
+		for child := n.FirstChild(); child != nil; child = child.NextSibling() {
+			if _, isList := child.(*ast.List); isList {
+				serializeNode(doc, child, buf, depth+1)
+			}
+		}
+	}
+}
+
+// ensureBlankLine terminates any preceding content with a blank line
+func ensureBlankLine(buf *bytes.Buffer) {
+	if buf.Len() == 0 {
+		return
+	}
+	for !bytes.HasSuffix(buf.Bytes(), []byte("\n\n")) {
+		buf.WriteString("\n")
+	}
+}
+
 // EnsureTrailingNewline ensures the markdown ends with a newline
 func EnsureTrailingNewline(content string) string {
 	if !strings.HasSuffix(content, "\n") {
@@ -0,0 +1,176 @@
+package markdown
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// SortKeys lists the keys accepted by SortTodos, in the order shown to users
+var SortKeys = []string{"priority", "due", "alpha", "done"}
+
+// todoSection represents a group of todos under a heading (or before any heading)
+type todoSection struct {
+	startIndex int // Index of first todo in this section
+	endIndex   int // Index after last todo in this section (exclusive)
+}
+
+// getTodoSections divides todos into sections based on headings
+// Each section contains todos that belong under a particular heading
+func getTodoSections(todos []Todo, headings []Heading) []todoSection {
+	if len(todos) == 0 {
+		return nil
+	}
+
+	// Sort headings by BeforeTodoIndex to process in order
+	sortedHeadings := make([]Heading, len(headings))
+	copy(sortedHeadings, headings)
+	sort.Slice(sortedHeadings, func(i, j int) bool {
+		return sortedHeadings[i].BeforeTodoIndex < sortedHeadings[j].BeforeTodoIndex
+	})
+
+	var sections []todoSection
+
+	// Find section boundaries from headings
+	prevBoundary := 0
+	for _, h := range sortedHeadings {
+		// BeforeTodoIndex tells us which todo this heading appears before
+		if h.BeforeTodoIndex > prevBoundary && h.BeforeTodoIndex <= len(todos) {
+			// There are todos before this heading that form a section
+			sections = append(sections, todoSection{
+				startIndex: prevBoundary,
+				endIndex:   h.BeforeTodoIndex,
+			})
+			prevBoundary = h.BeforeTodoIndex
+		}
+	}
+
+	// Add final section for remaining todos
+	if prevBoundary < len(todos) {
+		sections = append(sections, todoSection{
+			startIndex: prevBoundary,
+			endIndex:   len(todos),
+		})
+	}
+
+	// If no sections were created (no headings), treat all todos as one section
+	if len(sections) == 0 {
+		sections = append(sections, todoSection{
+			startIndex: 0,
+			endIndex:   len(todos),
+		})
+	}
+
+	return sections
+}
+
+// SectionRange returns the range [start, end) of todo indices in the heading
+// section that contains the todo at idx
+func SectionRange(todos []Todo, headings []Heading, idx int) (start, end int) {
+	for _, section := range getTodoSections(todos, headings) {
+		if idx >= section.startIndex && idx < section.endIndex {
+			return section.startIndex, section.endIndex
+		}
+	}
+	return 0, 0
+}
+
+// SortTodosInSections sorts todos within each heading section using the provided sort function
+// sortFn should sort the slice in place
+func SortTodosInSections(todos []Todo, headings []Heading, sortFn func([]Todo)) {
+	sections := getTodoSections(todos, headings)
+
+	for _, section := range sections {
+		if section.endIndex > section.startIndex {
+			sectionTodos := todos[section.startIndex:section.endIndex]
+			sortFn(sectionTodos)
+		}
+	}
+}
+
+// SortByDone sorts incomplete todos before completed ones (stable)
+func SortByDone(todos []Todo) {
+	sort.SliceStable(todos, func(i, j int) bool {
+		// Incomplete (false) comes before complete (true)
+		return !todos[i].Checked && todos[j].Checked
+	})
+}
+
+// SortByDueDate sorts todos by due date, earliest first, with undated todos last (stable)
+func SortByDueDate(todos []Todo) {
+	sort.SliceStable(todos, func(i, j int) bool {
+		di, dj := todos[i].DueDate, todos[j].DueDate
+		// Both have no due date - maintain order
+		if di == nil && dj == nil {
+			return false
+		}
+		// No due date goes after those with due date
+		if di == nil {
+			return false
+		}
+		if dj == nil {
+			return true
+		}
+		// Both have due dates - earlier date first
+		return di.Before(*dj)
+	})
+}
+
+// SortByPriority sorts todos by priority, p1 first, with unprioritized todos last (stable)
+func SortByPriority(todos []Todo) {
+	sort.SliceStable(todos, func(i, j int) bool {
+		pi, pj := todos[i].Priority, todos[j].Priority
+		// Both unprioritized - maintain order
+		if pi == 0 && pj == 0 {
+			return false
+		}
+		// Unprioritized goes after prioritized
+		if pi == 0 {
+			return false
+		}
+		if pj == 0 {
+			return true
+		}
+		// Both prioritized - lower number = higher priority
+		return pi < pj
+	})
+}
+
+// SortByAlpha sorts todos alphabetically by text, ignoring case (stable)
+func SortByAlpha(todos []Todo) {
+	sort.SliceStable(todos, func(i, j int) bool {
+		return strings.ToLower(todos[i].Text) < strings.ToLower(todos[j].Text)
+	})
+}
+
+// SortTodos reorders todos by the given key within each heading section
+// Valid keys are listed in SortKeys
+func (fm *FileModel) SortTodos(key string) error {
+	var sortFn func([]Todo)
+	switch key {
+	case "priority":
+		sortFn = SortByPriority
+	case "due":
+		sortFn = SortByDueDate
+	case "alpha":
+		sortFn = SortByAlpha
+	case "done":
+		sortFn = SortByDone
+	default:
+		return fmt.Errorf("unknown sort key %q (use %s)", key, strings.Join(SortKeys, ", "))
+	}
+
+	// Sort within each heading section so todos never cross headings.
+	// Pinned todos stay at the top whatever the key.
+	SortTodosInSections(fm.Todos, fm.GetHeadings(), func(todos []Todo) {
+		sortFn(todos)
+		SortByPinned(todos)
+	})
+
+	// Update indices and mark for sync back to the AST on next save
+	for i := range fm.Todos {
+		fm.Todos[i].Index = i + 1
+	}
+	RebuildFileStructure(fm)
+	return nil
+}
@@ -0,0 +1,206 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGetTodoSections_NoHeadings(t *testing.T) {
+	todos := []Todo{
+		{Text: "Task 1"},
+		{Text: "Task 2"},
+		{Text: "Task 3"},
+	}
+	headings := []Heading{}
+
+	sections := getTodoSections(todos, headings)
+	if len(sections) != 1 {
+		t.Fatalf("Expected 1 section, got %d", len(sections))
+	}
+	if sections[0].startIndex != 0 || sections[0].endIndex != 3 {
+		t.Errorf("Section = {%d, %d}, want {0, 3}", sections[0].startIndex, sections[0].endIndex)
+	}
+}
+
+func TestSectionRange(t *testing.T) {
+	todos := []Todo{{Text: "A"}, {Text: "B"}, {Text: "C"}, {Text: "D"}}
+	headings := []Heading{
+		{Text: "Section A", BeforeTodoIndex: 0},
+		{Text: "Section B", BeforeTodoIndex: 2},
+	}
+
+	if start, end := SectionRange(todos, headings, 1); start != 0 || end != 2 {
+		t.Errorf("SectionRange(1) = %d, %d, want 0, 2", start, end)
+	}
+	if start, end := SectionRange(todos, headings, 3); start != 2 || end != 4 {
+		t.Errorf("SectionRange(3) = %d, %d, want 2, 4", start, end)
+	}
+	if start, end := SectionRange(todos, headings, 9); start != 0 || end != 0 {
+		t.Errorf("SectionRange(out of range) = %d, %d, want 0, 0", start, end)
+	}
+}
+
+func TestGetTodoSections_WithHeadings(t *testing.T) {
+	todos := []Todo{
+		{Text: "Task 1"},
+		{Text: "Task 2"},
+		{Text: "Task 3"},
+		{Text: "Task 4"},
+	}
+	headings := []Heading{
+		{Text: "Section A", BeforeTodoIndex: 0},
+		{Text: "Section B", BeforeTodoIndex: 2},
+	}
+
+	sections := getTodoSections(todos, headings)
+	if len(sections) != 2 {
+		t.Fatalf("Expected 2 sections, got %d", len(sections))
+	}
+	// Section A: todos 0-1
+	if sections[0].startIndex != 0 || sections[0].endIndex != 2 {
+		t.Errorf("Section 0 = {%d, %d}, want {0, 2}", sections[0].startIndex, sections[0].endIndex)
+	}
+	// Section B: todos 2-3
+	if sections[1].startIndex != 2 || sections[1].endIndex != 4 {
+		t.Errorf("Section 1 = {%d, %d}, want {2, 4}", sections[1].startIndex, sections[1].endIndex)
+	}
+}
+
+func TestGetTodoSections_EmptyTodos(t *testing.T) {
+	todos := []Todo{}
+	headings := []Heading{{Text: "Heading", BeforeTodoIndex: 0}}
+
+	sections := getTodoSections(todos, headings)
+	if sections != nil {
+		t.Errorf("Expected nil, got %v", sections)
+	}
+}
+
+func TestSortTodosInSections_Priority(t *testing.T) {
+	todos := []Todo{
+		{Text: "B Task", Priority: 2},
+		{Text: "A Task", Priority: 1},
+		{Text: "D Task", Priority: 4},
+		{Text: "C Task", Priority: 3},
+	}
+	headings := []Heading{
+		{Text: "Section 1", BeforeTodoIndex: 0},
+		{Text: "Section 2", BeforeTodoIndex: 2},
+	}
+
+	// Sort by priority within sections
+	SortTodosInSections(todos, headings, func(slice []Todo) {
+		// Simple bubble sort by priority
+		for i := 0; i < len(slice); i++ {
+			for j := i + 1; j < len(slice); j++ {
+				if slice[j].Priority < slice[i].Priority {
+					slice[i], slice[j] = slice[j], slice[i]
+				}
+			}
+		}
+	})
+
+	// Section 1: A (p1), B (p2)
+	if todos[0].Text != "A Task" {
+		t.Errorf("todos[0] = %q, want 'A Task'", todos[0].Text)
+	}
+	if todos[1].Text != "B Task" {
+		t.Errorf("todos[1] = %q, want 'B Task'", todos[1].Text)
+	}
+	// Section 2: C (p3), D (p4)
+	if todos[2].Text != "C Task" {
+		t.Errorf("todos[2] = %q, want 'C Task'", todos[2].Text)
+	}
+	if todos[3].Text != "D Task" {
+		t.Errorf("todos[3] = %q, want 'D Task'", todos[3].Text)
+	}
+}
+
+// todoTexts returns the text of each todo in order
+func todoTexts(todos []Todo) []string {
+	texts := make([]string, len(todos))
+	for i, todo := range todos {
+		texts[i] = todo.Text
+	}
+	return texts
+}
+
+func TestSortTodos_Priority(t *testing.T) {
+	fm := ParseMarkdown("# Todos\n\n- [ ] A !p2\n- [ ] B\n- [ ] C !p1\n- [ ] D !p2\n- [ ] E\n")
+
+	if err := fm.SortTodos("priority"); err != nil {
+		t.Fatalf("SortTodos failed: %v", err)
+	}
+
+	// Equal priorities (A, D) and unprioritized (B, E) keep their original order
+	got := strings.Join(todoTexts(fm.Todos), ",")
+	want := "C !p1,A !p2,D !p2,B,E"
+	if got != want {
+		t.Errorf("order = %q, want %q", got, want)
+	}
+}
+
+func TestSortTodos_Due(t *testing.T) {
+	fm := ParseMarkdown("# Todos\n\n- [ ] A\n- [ ] B @due(2025-03-01)\n- [ ] C @due(2025-01-01)\n- [ ] D @due(2025-03-01)\n- [ ] E\n")
+
+	if err := fm.SortTodos("due"); err != nil {
+		t.Fatalf("SortTodos failed: %v", err)
+	}
+
+	got := strings.Join(todoTexts(fm.Todos), ",")
+	want := "C @due(2025-01-01),B @due(2025-03-01),D @due(2025-03-01),A,E"
+	if got != want {
+		t.Errorf("order = %q, want %q", got, want)
+	}
+}
+
+func TestSortTodos_Alpha(t *testing.T) {
+	fm := ParseMarkdown("# Todos\n\n- [ ] banana\n- [ ] Apple\n- [ ] cherry\n- [ ] apple\n")
+
+	if err := fm.SortTodos("alpha"); err != nil {
+		t.Fatalf("SortTodos failed: %v", err)
+	}
+
+	// Case-insensitive; "Apple" and "apple" compare equal and keep their order
+	got := strings.Join(todoTexts(fm.Todos), ",")
+	want := "Apple,apple,banana,cherry"
+	if got != want {
+		t.Errorf("order = %q, want %q", got, want)
+	}
+}
+
+func TestSortTodos_Done(t *testing.T) {
+	fm := ParseMarkdown("# Todos\n\n- [x] A\n- [ ] B\n- [x] C\n- [ ] D\n")
+
+	if err := fm.SortTodos("done"); err != nil {
+		t.Fatalf("SortTodos failed: %v", err)
+	}
+
+	got := strings.Join(todoTexts(fm.Todos), ",")
+	want := "B,D,A,C"
+	if got != want {
+		t.Errorf("order = %q, want %q", got, want)
+	}
+}
+
+func TestSortTodos_RespectsSections(t *testing.T) {
+	fm := ParseMarkdown("# Todos\n\n## One\n\n- [ ] B\n- [ ] A\n\n## Two\n\n- [ ] D\n- [ ] C\n")
+
+	if err := fm.SortTodos("alpha"); err != nil {
+		t.Fatalf("SortTodos failed: %v", err)
+	}
+
+	output := SerializeMarkdown(fm)
+	expected := "## One\n\n- [ ] A\n- [ ] B\n\n## Two\n\n- [ ] C\n- [ ] D\n"
+	if !strings.Contains(output, expected) {
+		t.Errorf("Expected todos sorted within sections, got:\n%s", output)
+	}
+}
+
+func TestSortTodos_UnknownKey(t *testing.T) {
+	fm := ParseMarkdown("# Todos\n\n- [ ] A\n")
+
+	if err := fm.SortTodos("size"); err == nil {
+		t.Error("Expected error for unknown sort key")
+	}
+}
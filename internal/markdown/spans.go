@@ -0,0 +1,47 @@
+package markdown
+
+import (
+	"regexp"
+	"strings"
+)
+
+// inlineSpanRegex matches markdown links and code spans, the same segments the
+// TUI renders specially. Group 1 is a link's destination.
+var inlineSpanRegex = regexp.MustCompile("\\[[^\\]]+\\]\\(([^)]+)\\)|`[^`]+`")
+
+// spanMask replaces masked bytes; it is neither a word character nor whitespace
+const spanMask = '\x00'
+
+// maskInlineSpans blanks out code spans and link destinations so that markers
+// inside them (e.g. `#define` or url#anchor) aren't parsed as tags, priorities,
+// due dates, contexts or projects. Byte offsets are preserved.
+func maskInlineSpans(text string) string {
+	if !strings.ContainsRune(text, '`') && !strings.Contains(text, "](") {
+		return text
+	}
+
+	masked := []byte(text)
+	for _, loc := range inlineSpanRegex.FindAllStringSubmatchIndex(text, -1) {
+		start, end := loc[0], loc[1]
+		if loc[2] >= 0 {
+			// Link: only the destination is hidden, the link text stays visible
+			start, end = loc[2], loc[3]
+		}
+		for i := start; i < end; i++ {
+			masked[i] = spanMask
+		}
+	}
+	return string(masked)
+}
+
+// removeOutsideSpans deletes every match of re that isn't inside a code span or link target
+func removeOutsideSpans(re *regexp.Regexp, text string) string {
+	var b strings.Builder
+	last := 0
+	for _, loc := range re.FindAllStringIndex(maskInlineSpans(text), -1) {
+		b.WriteString(text[last:loc[0]])
+		last = loc[1]
+	}
+	b.WriteString(text[last:])
+	return strings.TrimSpace(b.String())
+}
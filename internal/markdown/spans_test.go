@@ -0,0 +1,83 @@
+package markdown
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestExtractTags_IgnoresCodeSpans(t *testing.T) {
+	tags := ExtractTags("Fix `#define` macro #c")
+	if !slices.Equal(tags, []string{"c"}) {
+		t.Errorf("ExtractTags = %v, want [c]", tags)
+	}
+}
+
+func TestExtractTags_IgnoresLinkTargets(t *testing.T) {
+	tags := ExtractTags("Read [the #spec](https://example.com/doc#section-1) #docs")
+	if !slices.Equal(tags, []string{"spec", "docs"}) {
+		t.Errorf("ExtractTags = %v, want [spec docs]", tags)
+	}
+}
+
+func TestExtractPriority_IgnoresCodeSpans(t *testing.T) {
+	if p := ExtractPriority("Escape `!p1` in templates"); p != 0 {
+		t.Errorf("ExtractPriority = %d, want 0", p)
+	}
+	if p := ExtractPriority("Escape `!p1` in templates !p3"); p != 3 {
+		t.Errorf("ExtractPriority = %d, want 3", p)
+	}
+	if HasPriority("Use `!p2` syntax") {
+		t.Error("HasPriority should ignore code spans")
+	}
+	if m := GetPriorityMarker("`!p1` then !p2"); m != "!p2" {
+		t.Errorf("GetPriorityMarker = %q, want !p2", m)
+	}
+}
+
+func TestExtractDueDate_IgnoresCodeSpansAndLinks(t *testing.T) {
+	if d := ExtractDueDate("Document `@due(2025-01-15)` syntax"); d != nil {
+		t.Errorf("ExtractDueDate = %v, want nil", d)
+	}
+	if d := ExtractDueDate("See [docs](https://example.com/@due(2025-01-15))"); d != nil {
+		t.Errorf("ExtractDueDate = %v, want nil", d)
+	}
+	if m := GetDueDateMarker("`@due(2025-01-01)` @due(2025-02-02)"); m != "@due(2025-02-02)" {
+		t.Errorf("GetDueDateMarker = %q", m)
+	}
+}
+
+func TestExtractContexts_IgnoresCodeSpans(t *testing.T) {
+	contexts := ExtractContexts("Run ` @decorator` tests @work")
+	if !slices.Equal(contexts, []string{"work"}) {
+		t.Errorf("ExtractContexts = %v, want [work]", contexts)
+	}
+	projects := ExtractProjects("Bump ` +1` counter +website")
+	if !slices.Equal(projects, []string{"website"}) {
+		t.Errorf("ExtractProjects = %v, want [website]", projects)
+	}
+}
+
+func TestRemoveTags_KeepsCodeSpans(t *testing.T) {
+	if got := RemoveTags("Fix `#define` macro #c"); got != "Fix `#define` macro" {
+		t.Errorf("RemoveTags = %q", got)
+	}
+	if got := RemovePriority("Use `!p1` !p2"); got != "Use `!p1`" {
+		t.Errorf("RemovePriority = %q", got)
+	}
+}
+
+func TestParseMarkdown_CodeSpanTagNotExtracted(t *testing.T) {
+	content := "# Todos\n\n- [ ] Fix `#define` macro #c\n"
+	fm := ParseMarkdown(content)
+
+	todo := fm.Todos[0]
+	if !slices.Equal(todo.Tags, []string{"c"}) {
+		t.Errorf("Tags = %v, want [c]", todo.Tags)
+	}
+	if todo.Text != "Fix `#define` macro #c" {
+		t.Errorf("Text = %q, code span should be untouched", todo.Text)
+	}
+	if got := SerializeMarkdown(fm); got != content {
+		t.Errorf("Round trip changed the file:\n%q", got)
+	}
+}
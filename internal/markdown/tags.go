@@ -10,10 +10,41 @@ import (
 // Format: #tag (alphanumeric, dash, underscore)
 var tagRegex = regexp.MustCompile(`#([a-zA-Z0-9_-]+)`)
 
+// tagNameRegex matches a whole tag name, without the #
+var tagNameRegex = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+
+// IsValidTagName reports whether name (without the #) can be written as a tag
+func IsValidTagName(name string) bool {
+	return tagNameRegex.MatchString(name)
+}
+
+// NormalizeTagCase lowercases extracted tags so #Work and #work are one tag.
+// The todo text keeps the case it was written with.
+var NormalizeTagCase bool
+
+// TagAliases maps alternative tag names to the tag they stand for (e.g. wip = in-progress).
+// Aliases are matched ignoring case.
+var TagAliases map[string]string
+
+// CanonicalTag returns the name used to filter and group a tag, resolving aliases and case
+func CanonicalTag(tag string) string {
+	for alias, canonical := range TagAliases {
+		if strings.EqualFold(strings.TrimPrefix(alias, "#"), tag) {
+			tag = strings.TrimPrefix(canonical, "#")
+			break
+		}
+	}
+	if NormalizeTagCase {
+		tag = strings.ToLower(tag)
+	}
+	return tag
+}
+
 // ExtractTags extracts all tags from todo text
-// Tags are hashtags like #urgent #backend
+// Tags are hashtags like #urgent #backend; code spans and link targets are ignored.
+// Tags are returned in their canonical form (see CanonicalTag).
 func ExtractTags(text string) []string {
-	matches := tagRegex.FindAllStringSubmatch(text, -1)
+	matches := tagRegex.FindAllStringSubmatch(maskInlineSpans(text), -1)
 	if len(matches) == 0 {
 		return []string{}
 	}
@@ -23,7 +54,7 @@ func ExtractTags(text string) []string {
 
 	for _, match := range matches {
 		if len(match) > 1 {
-			tag := match[1] // Extract the tag without the # prefix
+			tag := CanonicalTag(match[1]) // Extract the tag without the # prefix
 			// Deduplicate tags
 			if !seen[tag] {
 				tags = append(tags, tag)
@@ -38,11 +69,36 @@ func ExtractTags(text string) []string {
 // RemoveTags removes all tags from the text
 // This is useful if you want to display text without tags
 func RemoveTags(text string) string {
-	return strings.TrimSpace(tagRegex.ReplaceAllString(text, ""))
+	return removeOutsideSpans(tagRegex, text)
+}
+
+// RenameTag replaces every #oldTag (ignoring case) in the text with #newTag.
+// When the text already has #newTag the old tag is removed instead, so the
+// tag isn't repeated. Tags inside code spans are kept.
+func RenameTag(text, oldTag, newTag string) string {
+	for _, existing := range tagRegex.FindAllStringSubmatch(maskInlineSpans(text), -1) {
+		if strings.EqualFold(existing[1], newTag) && !strings.EqualFold(existing[1], oldTag) {
+			return RemoveTag(text, oldTag)
+		}
+	}
+
+	var b strings.Builder
+	last := 0
+	for _, loc := range tagRegex.FindAllStringSubmatchIndex(maskInlineSpans(text), -1) {
+		if !strings.EqualFold(text[loc[2]:loc[3]], oldTag) {
+			continue
+		}
+		b.WriteString(text[last:loc[2]])
+		b.WriteString(newTag)
+		last = loc[3]
+	}
+	b.WriteString(text[last:])
+	return b.String()
 }
 
-// HasTag checks if a todo has a specific tag
+// HasTag checks if a todo has a specific tag, or an alias of it
 func (t *Todo) HasTag(tag string) bool {
+	tag = CanonicalTag(tag)
 	for _, todoTag := range t.Tags {
 		if strings.EqualFold(todoTag, tag) {
 			return true
@@ -69,7 +125,7 @@ func GetAllTags(todos []Todo) []string {
 	tagSet := make(map[string]bool)
 	for _, todo := range todos {
 		for _, tag := range todo.Tags {
-			tagSet[tag] = true
+			tagSet[CanonicalTag(tag)] = true
 		}
 	}
 
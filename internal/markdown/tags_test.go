@@ -0,0 +1,111 @@
+package markdown
+
+import (
+	"slices"
+	"testing"
+)
+
+// withTagConfig sets the tag normalization globals for the duration of a test
+func withTagConfig(t *testing.T, normalizeCase bool, aliases map[string]string) {
+	t.Helper()
+	oldCase, oldAliases := NormalizeTagCase, TagAliases
+	NormalizeTagCase, TagAliases = normalizeCase, aliases
+	t.Cleanup(func() { NormalizeTagCase, TagAliases = oldCase, oldAliases })
+}
+
+func TestExtractTags_KeepsCaseByDefault(t *testing.T) {
+	withTagConfig(t, false, nil)
+
+	fm := ParseMarkdown("- [ ] A #Work\n- [ ] B #work\n")
+	if got := GetAllTags(fm.Todos); !slices.Equal(got, []string{"Work", "work"}) {
+		t.Errorf("GetAllTags = %v, want [Work work]", got)
+	}
+}
+
+func TestExtractTags_NormalizeCase(t *testing.T) {
+	withTagConfig(t, true, nil)
+
+	content := "# Todos\n\n- [ ] A #Work\n- [ ] B #work\n- [ ] C #WORK #work\n"
+	fm := ParseMarkdown(content)
+
+	if got := GetAllTags(fm.Todos); !slices.Equal(got, []string{"work"}) {
+		t.Errorf("GetAllTags = %v, want [work]", got)
+	}
+	if got := fm.Todos[2].Tags; !slices.Equal(got, []string{"work"}) {
+		t.Errorf("Tags = %v, want duplicates collapsed", got)
+	}
+	for i := range fm.Todos {
+		if !fm.Todos[i].HasTag("work") {
+			t.Errorf("todo %d should match the work filter", i)
+		}
+	}
+	if got := SerializeMarkdown(fm); got != content {
+		t.Errorf("The file text should keep its case:\n%q", got)
+	}
+}
+
+func TestExtractTags_Aliases(t *testing.T) {
+	withTagConfig(t, false, map[string]string{"wip": "in-progress", "#todo": "#backlog"})
+
+	fm := ParseMarkdown("- [ ] A #wip\n- [ ] B #in-progress\n- [ ] C #WIP #todo\n- [ ] D #other\n")
+
+	if got := GetAllTags(fm.Todos); !slices.Equal(got, []string{"backlog", "in-progress", "other"}) {
+		t.Errorf("GetAllTags = %v", got)
+	}
+	for i := range 3 {
+		if !fm.Todos[i].HasTag("in-progress") {
+			t.Errorf("todo %d should match the canonical tag", i)
+		}
+	}
+	if !fm.Todos[1].HasTag("wip") {
+		t.Error("Filtering by an alias should match the canonical tag too")
+	}
+	if fm.Todos[3].HasTag("in-progress") {
+		t.Error("Unrelated todo should not match")
+	}
+}
+
+func TestCanonicalTag_AliasThenCase(t *testing.T) {
+	withTagConfig(t, true, map[string]string{"WIP": "In-Progress"})
+
+	if got := CanonicalTag("wip"); got != "in-progress" {
+		t.Errorf("CanonicalTag(wip) = %q, want in-progress", got)
+	}
+	if got := CanonicalTag("Docs"); got != "docs" {
+		t.Errorf("CanonicalTag(Docs) = %q, want docs", got)
+	}
+}
+
+func TestRenameTag(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"Write report #work", "Write report #job"},
+		{"#WORK first and #work again", "#job first and #job again"},
+		{"Keep #work-ish #work", "Keep #work-ish #job"},
+		{"Explain `#work` syntax #work", "Explain `#work` syntax #job"},
+		{"Already #job and #work", "Already #job and"},
+		{"No tag here", "No tag here"},
+	}
+	for _, tt := range tests {
+		if got := RenameTag(tt.text, "work", "job"); got != tt.want {
+			t.Errorf("RenameTag(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+
+	if got := RenameTag("Fix #work", "work", "Work"); got != "Fix #Work" {
+		t.Errorf("Changing only the case = %q, want %q", got, "Fix #Work")
+	}
+}
+
+func TestIsValidTagName(t *testing.T) {
+	for name, want := range map[string]bool{
+		"work": true, "in-progress": true, "v2_beta": true,
+		"": false, "two words": false, "#work": false, "ünïcode": false,
+	} {
+		if got := IsValidTagName(name); got != want {
+			t.Errorf("IsValidTagName(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
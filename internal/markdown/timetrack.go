@@ -0,0 +1,76 @@
+package markdown
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// timeTokenRegex matches time tracking tokens like est:2h, spent:45m or est:1h30m.
+// Tokens with anything else after the colon (est:banana) are not matched.
+var timeTokenRegex = regexp.MustCompile(`(?i)(?:^|\s)((est|spent):(\d+h(?:\d+m)?|\d+m))\b`)
+
+// extractTimeToken sums all est: or spent: tokens in the text.
+// Tokens inside code spans or link targets are ignored.
+func extractTimeToken(text, kind string) time.Duration {
+	var total time.Duration
+	for _, match := range timeTokenRegex.FindAllStringSubmatch(maskInlineSpans(text), -1) {
+		if !strings.EqualFold(match[2], kind) {
+			continue
+		}
+		if d, err := time.ParseDuration(strings.ToLower(match[3])); err == nil {
+			total += d
+		}
+	}
+	return total
+}
+
+// ExtractEstimate returns the estimated time from est: tokens, or 0 if there are none
+func ExtractEstimate(text string) time.Duration {
+	return extractTimeToken(text, "est")
+}
+
+// ExtractSpent returns the time spent from spent: tokens, or 0 if there are none
+func ExtractSpent(text string) time.Duration {
+	return extractTimeToken(text, "spent")
+}
+
+// AddSpent adds d to the first spent: token in the text, appending a new token if there is none
+func AddSpent(text string, d time.Duration) string {
+	masked := maskInlineSpans(text)
+	for _, loc := range timeTokenRegex.FindAllStringSubmatchIndex(masked, -1) {
+		if !strings.EqualFold(text[loc[4]:loc[5]], "spent") {
+			continue
+		}
+		spent, err := time.ParseDuration(strings.ToLower(text[loc[6]:loc[7]]))
+		if err != nil {
+			continue
+		}
+		return text[:loc[2]] + "spent:" + FormatDuration(spent+d) + text[loc[3]:]
+	}
+	return strings.TrimSpace(text) + " spent:" + FormatDuration(d)
+}
+
+// FormatDuration formats a duration in the token style: 2h, 45m or 1h30m
+func FormatDuration(d time.Duration) string {
+	minutes := int(d.Round(time.Minute) / time.Minute)
+	h, m := minutes/60, minutes%60
+	switch {
+	case h == 0:
+		return fmt.Sprintf("%dm", m)
+	case m == 0:
+		return fmt.Sprintf("%dh", h)
+	default:
+		return fmt.Sprintf("%dh%dm", h, m)
+	}
+}
+
+// SumTime returns the total estimated and spent time of the todos
+func SumTime(todos []Todo) (estimate, spent time.Duration) {
+	for _, todo := range todos {
+		estimate += todo.Estimate
+		spent += todo.Spent
+	}
+	return estimate, spent
+}
@@ -0,0 +1,97 @@
+package markdown
+
+import (
+	"testing"
+	"time"
+)
+
+func TestExtractTimeTokens(t *testing.T) {
+	tests := []struct {
+		text     string
+		estimate time.Duration
+		spent    time.Duration
+	}{
+		{"Write proposal est:2h", 2 * time.Hour, 0},
+		{"Write proposal spent:45m", 0, 45 * time.Minute},
+		{"Write proposal est:1h30m spent:20m", 90 * time.Minute, 20 * time.Minute},
+		{"est:2h at the start", 2 * time.Hour, 0},
+		{"Mixed case EST:1H", time.Hour, 0},
+		{"Sessions spent:30m spent:15m", 0, 45 * time.Minute},
+		{"No tokens here", 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			if got := ExtractEstimate(tt.text); got != tt.estimate {
+				t.Errorf("ExtractEstimate = %v, want %v", got, tt.estimate)
+			}
+			if got := ExtractSpent(tt.text); got != tt.spent {
+				t.Errorf("ExtractSpent = %v, want %v", got, tt.spent)
+			}
+		})
+	}
+}
+
+func TestExtractTimeTokens_MalformedIgnored(t *testing.T) {
+	for _, text := range []string{
+		"Buy fruit est:banana",
+		"Buy fruit est:",
+		"Buy fruit est:2hours",
+		"Buy fruit est:2h30",
+		"Buy fruit forest:2h",
+		"Document `est:2h` syntax",
+	} {
+		if d := ExtractEstimate(text); d != 0 {
+			t.Errorf("ExtractEstimate(%q) = %v, want 0", text, d)
+		}
+	}
+}
+
+func TestParseMarkdown_TimeTokens(t *testing.T) {
+	fm := ParseMarkdown("- [ ] Write proposal est:3h spent:1h15m\n- [ ] Review est:45m\n- [ ] Shop est:banana\n")
+
+	if fm.Todos[0].Estimate != 3*time.Hour || fm.Todos[0].Spent != 75*time.Minute {
+		t.Errorf("Todo 0 = %v / %v", fm.Todos[0].Estimate, fm.Todos[0].Spent)
+	}
+	if fm.Todos[2].Estimate != 0 {
+		t.Errorf("Malformed token should be ignored, got %v", fm.Todos[2].Estimate)
+	}
+
+	estimate, spent := SumTime(fm.Todos)
+	if estimate != 225*time.Minute || spent != 75*time.Minute {
+		t.Errorf("SumTime = %v / %v, want 3h45m / 1h15m", estimate, spent)
+	}
+}
+
+func TestAddSpent(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"Write proposal", "Write proposal spent:15m"},
+		{"Write proposal spent:50m #work", "Write proposal spent:1h5m #work"},
+		{"Write proposal est:2h spent:1h", "Write proposal est:2h spent:1h15m"},
+		{"Write `spent:1h` docs", "Write `spent:1h` docs spent:15m"},
+	}
+	for _, tt := range tests {
+		if got := AddSpent(tt.text, 15*time.Minute); got != tt.want {
+			t.Errorf("AddSpent(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	tests := map[time.Duration]string{
+		0:                "0m",
+		45 * time.Minute: "45m",
+		2 * time.Hour:    "2h",
+		90 * time.Minute: "1h30m",
+		26 * time.Hour:   "26h",
+		61 * time.Second: "1m",
+	}
+	for d, want := range tests {
+		if got := FormatDuration(d); got != want {
+			t.Errorf("FormatDuration(%v) = %q, want %q", d, got, want)
+		}
+	}
+}
@@ -53,6 +53,17 @@ func BenchmarkUpdateSearchResults_500Todos(b *testing.B) {
 	}
 }
 
+func BenchmarkUpdateSearchResults_5000Todos(b *testing.B) {
+	fm := &markdown.FileModel{Todos: generateTodos(5000)}
+	m := New("/tmp/test.md", fm, false, false, -1, testConfig(), testStyles(), "")
+	m.InputBuffer = "task"
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		m.updateSearchResults()
+	}
+}
+
 func BenchmarkUpdateSearchResults_EmptyQuery(b *testing.B) {
 	fm := &markdown.FileModel{Todos: generateTodos(100)}
 	m := New("/tmp/test.md", fm, false, false, -1, testConfig(), testStyles(), "")
@@ -0,0 +1,51 @@
+package tui
+
+import (
+	"fmt"
+
+	"github.com/niklas-heer/tdx/internal/markdown"
+)
+
+// setSectionChecked marks every todo in the heading section of the selected
+// todo as complete or incomplete, as a single undo step
+func (m *Model) setSectionChecked(checked bool) {
+	if len(m.FileModel.Todos) == 0 {
+		return
+	}
+	if m.ReadOnly {
+		m.setStatus("Read-only mode")
+		return
+	}
+
+	start, end := markdown.SectionRange(m.FileModel.Todos, m.GetHeadings(), m.SelectedIndex)
+	var changed []int
+	for i := start; i < end; i++ {
+		if m.FileModel.Todos[i].Checked != checked {
+			changed = append(changed, i)
+		}
+	}
+	if len(changed) == 0 {
+		m.setStatus("Nothing to change in this section")
+		return
+	}
+
+	m.saveHistory()
+	for _, i := range changed {
+		_ = m.FileModel.UpdateTodoItem(i, m.FileModel.Todos[i].Text, checked)
+		m.noteCompleted(i)
+	}
+
+	verb := "Checked"
+	if !checked {
+		verb = "Unchecked"
+	}
+	if len(changed) == 1 {
+		m.setStatus(verb + " 1 todo")
+	} else {
+		m.setStatus(fmt.Sprintf("%s %d todos", verb, len(changed)))
+	}
+
+	m.InvalidateDocumentTree()
+	m.writeIfPersist()
+	m.adjustSelectionForFilter()
+}
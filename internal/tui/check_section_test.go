@@ -0,0 +1,82 @@
+package tui
+
+import (
+	"testing"
+)
+
+const checkSectionMarkdown = `# Sprint
+
+## Sprint 1
+
+- [ ] Design
+- [x] Build
+- [ ] Ship
+
+## Sprint 2
+
+- [ ] Plan
+- [x] Review
+`
+
+func checkedStates(m Model) []bool {
+	states := make([]bool, len(m.FileModel.Todos))
+	for i, todo := range m.FileModel.Todos {
+		states[i] = todo.Checked
+	}
+	return states
+}
+
+func assertCheckedStates(t *testing.T, m Model, want ...bool) {
+	t.Helper()
+	got := checkedStates(m)
+	if len(got) != len(want) {
+		t.Fatalf("Checked states = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("Checked states = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestCheckSection_OnlyCurrentSection(t *testing.T) {
+	m := testModelWithMarkdown(checkSectionMarkdown)
+	m.SelectedIndex = 1
+
+	executeCommand(&m, "check-section")
+	assertCheckedStates(t, m, true, true, true, false, true)
+
+	m.SelectedIndex = 3
+	executeCommand(&m, "uncheck-section")
+	assertCheckedStates(t, m, true, true, true, false, false)
+}
+
+func TestCheckSection_SingleUndoStep(t *testing.T) {
+	m := testModelWithMarkdown(checkSectionMarkdown)
+	m.SelectedIndex = 0
+
+	executeCommand(&m, "check-section")
+	assertCheckedStates(t, m, true, true, true, false, true)
+
+	m = pressKey(t, m, "u")
+	assertCheckedStates(t, m, false, true, false, false, true)
+}
+
+func TestCheckSection_NoHeadings(t *testing.T) {
+	m := testModelWithMarkdown("- [ ] One\n- [ ] Two\n")
+
+	executeCommand(&m, "check-section")
+	assertCheckedStates(t, m, true, true)
+}
+
+func TestCheckSection_ReadOnly(t *testing.T) {
+	m := testModelWithMarkdown(checkSectionMarkdown)
+	m.ReadOnly = true
+	m.SelectedIndex = 0
+
+	executeCommand(&m, "check-section")
+	assertCheckedStates(t, m, false, true, false, false, true)
+	if m.StatusHint != "Read-only mode" {
+		t.Errorf("StatusHint = %q, want read-only hint", m.StatusHint)
+	}
+}
@@ -0,0 +1,82 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/niklas-heer/tdx/internal/util"
+)
+
+const childProgressMarkdown = `# Todos
+
+- [ ] Parent
+  - [x] Child 1
+  - [x] Child 2
+  - [ ] Child 3
+    - [ ] Grandchild
+- [ ] Leaf
+`
+
+// renderedLine returns the rendered line containing text, without ANSI codes
+func renderedLine(t *testing.T, m Model, text string) string {
+	t.Helper()
+	for _, line := range strings.Split(util.StripANSI(m.View()), "\n") {
+		if strings.Contains(line, text) {
+			return line
+		}
+	}
+	t.Fatalf("No line contains %q", text)
+	return ""
+}
+
+func TestChildProgress_CountsDirectChildren(t *testing.T) {
+	m := testModelWithMarkdown(childProgressMarkdown)
+
+	tests := []struct {
+		idx         int
+		done, total int
+	}{
+		{0, 2, 3}, // The grandchild isn't counted for the parent
+		{1, 0, 0},
+		{3, 0, 1},
+		{5, 0, 0},
+	}
+	for _, tt := range tests {
+		done, total := m.childProgress(tt.idx)
+		if done != tt.done || total != tt.total {
+			t.Errorf("childProgress(%d) = %d/%d, want %d/%d", tt.idx, done, total, tt.done, tt.total)
+		}
+	}
+}
+
+func TestChildProgress_BadgeShownForParents(t *testing.T) {
+	m := testModelWithMarkdown(childProgressMarkdown)
+
+	if line := renderedLine(t, m, "Parent"); !strings.Contains(line, "Parent [2/3]") {
+		t.Errorf("Expected a [2/3] badge after the parent: %q", line)
+	}
+	if line := renderedLine(t, m, "Child 3"); !strings.Contains(line, "Child 3 [0/1]") {
+		t.Errorf("Expected a [0/1] badge after Child 3: %q", line)
+	}
+	for _, leaf := range []string{"Child 1", "Grandchild", "Leaf"} {
+		if line := renderedLine(t, m, leaf); strings.Contains(line, "/") {
+			t.Errorf("Expected no badge on leaf %q: %q", leaf, line)
+		}
+	}
+}
+
+func TestChildProgress_BadgeUpdatesOnToggle(t *testing.T) {
+	m := testModelWithMarkdown(childProgressMarkdown)
+	m.SelectedIndex = 3 // Child 3
+
+	m = pressKey(t, m, " ")
+	if line := renderedLine(t, m, "Parent"); !strings.Contains(line, "Parent [3/3]") {
+		t.Errorf("After checking Child 3, expected [3/3]: %q", line)
+	}
+
+	m.SelectedIndex = 1 // Child 1
+	m = pressKey(t, m, " ")
+	if line := renderedLine(t, m, "Parent"); !strings.Contains(line, "Parent [2/3]") {
+		t.Errorf("After unchecking Child 1, expected [2/3]: %q", line)
+	}
+}
@@ -0,0 +1,53 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// fakeClipboard puts a pbcopy running script first on the PATH, or leaves no
+// clipboard tool on it when script is empty
+func fakeClipboard(t *testing.T, script string) {
+	t.Helper()
+	dir := t.TempDir()
+	if script != "" {
+		if err := os.WriteFile(filepath.Join(dir, "pbcopy"), []byte("#!/bin/sh\n"+script+"\n"), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Setenv("PATH", dir)
+}
+
+func TestCopy_Succeeds(t *testing.T) {
+	fakeClipboard(t, "while read -r line; do :; done")
+	m := testModelWithMarkdown("- [ ] Buy milk\n")
+
+	m = pressKey(t, m, "c")
+
+	if m.StatusHint != "✓ Copied to clipboard!" {
+		t.Errorf("StatusHint = %q, want the success message", m.StatusHint)
+	}
+}
+
+func TestCopy_NoClipboardTool(t *testing.T) {
+	fakeClipboard(t, "")
+	m := testModelWithMarkdown("- [ ] Buy milk\n")
+
+	m = pressKey(t, m, "c")
+
+	if m.StatusHint != "Copy failed (no clipboard tool)" {
+		t.Errorf("StatusHint = %q, want %q", m.StatusHint, "Copy failed (no clipboard tool)")
+	}
+}
+
+func TestCopy_ClipboardCommandFails(t *testing.T) {
+	fakeClipboard(t, "exit 1")
+	m := testModelWithMarkdown("- [ ] Buy milk\n")
+
+	m = pressKey(t, m, "c")
+
+	if m.StatusHint != "Copy failed (pbcopy: exit status 1)" {
+		t.Errorf("StatusHint = %q, want the failing command's error", m.StatusHint)
+	}
+}
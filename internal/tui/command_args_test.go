@@ -0,0 +1,119 @@
+package tui
+
+import (
+	"slices"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// typeCommand opens the palette, types input and presses enter
+func typeCommand(t *testing.T, m Model, input string) Model {
+	t.Helper()
+	m = pressKey(t, m, ":")
+	for _, r := range input {
+		m = pressKey(t, m, string(r))
+	}
+	return pressKeyType(t, m, tea.KeyEnter)
+}
+
+func TestCommandArgs_AddCreatesTodo(t *testing.T) {
+	m := testModelWithMarkdown("# Todos\n\n- [ ] Existing\n")
+
+	m = typeCommand(t, m, "add foo")
+
+	if got := todoTexts(m); !slices.Equal(got, []string{"Existing", "foo"}) {
+		t.Errorf("Todos = %v, want [Existing foo]", got)
+	}
+	if m.FileModel.Todos[m.SelectedIndex].Text != "foo" {
+		t.Errorf("Expected the new todo to be selected, got index %d", m.SelectedIndex)
+	}
+	if m.CommandMode || m.InputMode || m.InputBuffer != "" {
+		t.Errorf("Expected the palette to close, CommandMode=%v InputMode=%v InputBuffer=%q",
+			m.CommandMode, m.InputMode, m.InputBuffer)
+	}
+}
+
+func TestCommandArgs_AddKeepsSpacesInText(t *testing.T) {
+	m := testModelWithMarkdown("# Todos\n\n- [ ] Existing\n")
+
+	m = typeCommand(t, m, "add buy milk #home")
+
+	if got := m.FileModel.Todos[len(m.FileModel.Todos)-1].Text; got != "buy milk #home" {
+		t.Errorf("Added %q, want %q", got, "buy milk #home")
+	}
+}
+
+func TestCommandArgs_AddWithoutArgPrompts(t *testing.T) {
+	m := testModelWithMarkdown("# Todos\n\n- [ ] Existing\n")
+
+	m = typeCommand(t, m, "add")
+
+	if !m.InputMode {
+		t.Error("Expected :add without text to open the new todo prompt")
+	}
+	if len(m.FileModel.Todos) != 1 {
+		t.Errorf("Expected no todo to be added yet, got %v", todoTexts(m))
+	}
+}
+
+func TestCommandArgs_TagTogglesFilter(t *testing.T) {
+	m := testModelWithMarkdown("# Todos\n\n- [ ] Call mom #home\n- [ ] Fix bug #urgent\n")
+
+	m = typeCommand(t, m, "tag urgent")
+	if !slices.Equal(m.FilteredTags, []string{"urgent"}) {
+		t.Fatalf("FilteredTags = %v, want [urgent]", m.FilteredTags)
+	}
+	if m.FileModel.Todos[m.SelectedIndex].Text != "Fix bug #urgent" {
+		t.Errorf("Expected the selection to move to the visible todo, got index %d", m.SelectedIndex)
+	}
+
+	m = typeCommand(t, m, "tag #URGENT")
+	if len(m.FilteredTags) != 0 {
+		t.Errorf("Expected the second :tag to remove the filter, got %v", m.FilteredTags)
+	}
+}
+
+func TestCommandArgs_UnknownTagShowsHint(t *testing.T) {
+	m := testModelWithMarkdown("# Todos\n\n- [ ] Call mom #home\n")
+
+	m = typeCommand(t, m, "tag nope")
+
+	if len(m.FilteredTags) != 0 {
+		t.Errorf("FilteredTags = %v, want none", m.FilteredTags)
+	}
+	if m.StatusHint != "No todos tagged #nope" {
+		t.Errorf("StatusHint = %q", m.StatusHint)
+	}
+}
+
+func TestCommandArgs_RejectedByCommandWithoutArgs(t *testing.T) {
+	m := testModelWithMarkdown("# Todos\n\n- [ ] Task\n")
+
+	m = typeCommand(t, m, "check-all now")
+
+	if m.FileModel.Todos[0].Checked {
+		t.Error("check-all should not run when given an argument")
+	}
+	if m.StatusHint != "check-all takes no argument" {
+		t.Errorf("StatusHint = %q", m.StatusHint)
+	}
+}
+
+func TestCommandArgs_FilterMatchesOnlyName(t *testing.T) {
+	m := testModelWithMarkdown("# Todos\n\n- [ ] Task\n")
+	m.InputBuffer = "ad some words"
+	m.updateFilteredCommands()
+
+	if len(m.FilteredCmds) == 0 || m.Commands[m.FilteredCmds[0]].Name != "add" {
+		t.Fatalf("Expected add as the best match, got %v", m.FilteredCmds)
+	}
+
+	// Tab completes the name and keeps the argument
+	m.CommandMode = true
+	m.CursorPos = len(m.InputBuffer)
+	m = pressKeyType(t, m, tea.KeyTab)
+	if m.InputBuffer != "add some words" {
+		t.Errorf("InputBuffer = %q, want %q", m.InputBuffer, "add some words")
+	}
+}
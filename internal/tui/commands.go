@@ -2,8 +2,6 @@ package tui
 
 import (
 	"fmt"
-	"os"
-	"sort"
 	"strings"
 
 	"github.com/niklas-heer/tdx/internal/markdown"
@@ -15,74 +13,9 @@ type Command struct {
 	Name        string
 	Description string
 	Handler     func(m *Model)
-}
-
-// todoSection represents a group of todos under a heading (or before any heading)
-type todoSection struct {
-	startIndex int // Index of first todo in this section
-	endIndex   int // Index after last todo in this section (exclusive)
-}
-
-// getTodoSections divides todos into sections based on headings
-// Each section contains todos that belong under a particular heading
-func getTodoSections(todos []markdown.Todo, headings []markdown.Heading) []todoSection {
-	if len(todos) == 0 {
-		return nil
-	}
-
-	// Sort headings by BeforeTodoIndex to process in order
-	sortedHeadings := make([]markdown.Heading, len(headings))
-	copy(sortedHeadings, headings)
-	sort.Slice(sortedHeadings, func(i, j int) bool {
-		return sortedHeadings[i].BeforeTodoIndex < sortedHeadings[j].BeforeTodoIndex
-	})
-
-	var sections []todoSection
-
-	// Find section boundaries from headings
-	prevBoundary := 0
-	for _, h := range sortedHeadings {
-		// BeforeTodoIndex tells us which todo this heading appears before
-		if h.BeforeTodoIndex > prevBoundary && h.BeforeTodoIndex <= len(todos) {
-			// There are todos before this heading that form a section
-			sections = append(sections, todoSection{
-				startIndex: prevBoundary,
-				endIndex:   h.BeforeTodoIndex,
-			})
-			prevBoundary = h.BeforeTodoIndex
-		}
-	}
-
-	// Add final section for remaining todos
-	if prevBoundary < len(todos) {
-		sections = append(sections, todoSection{
-			startIndex: prevBoundary,
-			endIndex:   len(todos),
-		})
-	}
-
-	// If no sections were created (no headings), treat all todos as one section
-	if len(sections) == 0 {
-		sections = append(sections, todoSection{
-			startIndex: 0,
-			endIndex:   len(todos),
-		})
-	}
-
-	return sections
-}
-
-// sortTodosInSections sorts todos within each section using the provided sort function
-// sortFn should sort the slice in place
-func sortTodosInSections(todos []markdown.Todo, headings []markdown.Heading, sortFn func([]markdown.Todo)) {
-	sections := getTodoSections(todos, headings)
-
-	for _, section := range sections {
-		if section.endIndex > section.startIndex {
-			sectionTodos := todos[section.startIndex:section.endIndex]
-			sortFn(sectionTodos)
-		}
-	}
+	// ArgHandler runs instead of Handler when text follows the command name,
+	// as in "add buy milk". Commands without one take no argument.
+	ArgHandler func(m *Model, arg string)
 }
 
 // InitCommands initializes the command palette with all available commands
@@ -102,6 +35,7 @@ func InitCommands() []Command {
 					todo := m.FileModel.Todos[i]
 					if !todo.Checked {
 						_ = m.FileModel.UpdateTodoItem(i, todo.Text, true)
+						m.noteCompleted(i)
 					}
 				}
 				m.InvalidateDocumentTree()
@@ -128,27 +62,71 @@ func InitCommands() []Command {
 				m.writeIfPersist()
 			},
 		},
+		{
+			Name:        "add",
+			Description: "Add a todo at the end of the file (add <text>)",
+			Handler: func(m *Model) {
+				m.saveHistory()
+				m.InputMode = true
+				m.InsertAfterCursor = false
+				m.InputBuffer = ""
+				m.CursorPos = 0
+			},
+			ArgHandler: func(m *Model, arg string) {
+				m.saveHistory()
+				m.InsertAfterCursor = false
+				m.InputBuffer = arg
+				m.addNewTodo()
+			},
+		},
+		{
+			Name:        "tag",
+			Description: "Toggle a tag filter (tag <name>)",
+			Handler: func(m *Model) {
+				m.RefreshAvailableTags()
+				m.FilterMode = true
+				m.TagFilterCursor = 0
+			},
+			ArgHandler: func(m *Model, arg string) {
+				name := strings.TrimPrefix(arg, "#")
+				m.RefreshAvailableTags()
+				for _, tag := range m.AvailableTags {
+					if strings.EqualFold(tag, name) {
+						m.toggleTagFilter(tag)
+						m.adjustSelectionForFilter()
+						return
+					}
+				}
+				m.setStatus("No todos tagged #" + name)
+			},
+		},
+		{
+			Name:        "rename-tag",
+			Description: "Rename a tag on every todo (rename-tag <old> <new>)",
+			Handler: func(m *Model) {
+				m.setStatus("Usage: rename-tag <old> <new>")
+			},
+			ArgHandler: func(m *Model, arg string) {
+				m.renameTagArgs(arg)
+			},
+		},
+		{
+			Name:        "remove-tag",
+			Description: "Remove a tag from every todo (remove-tag <name>)",
+			Handler: func(m *Model) {
+				m.setStatus("Usage: remove-tag <name>")
+			},
+			ArgHandler: func(m *Model, arg string) {
+				m.removeTagArgs(arg)
+			},
+		},
 		{
 			Name:        "sort-done",
 			Description: "Sort todos by completion (incomplete first)",
 			Handler: func(m *Model) {
 				m.saveHistory()
-				// Get headings to sort within sections
-				headings := m.FileModel.GetHeadings()
-
-				// Sort function: incomplete first, then complete (stable)
-				sortByDone := func(todos []markdown.Todo) {
-					sort.SliceStable(todos, func(i, j int) bool {
-						// Incomplete (false) comes before complete (true)
-						return !todos[i].Checked && todos[j].Checked
-					})
-				}
-
 				// Sort within each heading section
-				sortTodosInSections(m.FileModel.Todos, headings, sortByDone)
-
-				// Update indices and rebuild line structure
-				markdown.RebuildFileStructure(&m.FileModel)
+				_ = m.FileModel.SortTodos("done")
 				m.InvalidateDocumentTree()
 				m.writeIfPersist()
 				// Adjust selection if needed
@@ -162,34 +140,8 @@ func InitCommands() []Command {
 			Description: "Sort todos by due date (earliest first)",
 			Handler: func(m *Model) {
 				m.saveHistory()
-				// Get headings to sort within sections
-				headings := m.FileModel.GetHeadings()
-
-				// Sort function: by due date (earliest first), no due date at end (stable)
-				sortByDueDate := func(todos []markdown.Todo) {
-					sort.SliceStable(todos, func(i, j int) bool {
-						di, dj := todos[i].DueDate, todos[j].DueDate
-						// Both have no due date - maintain order
-						if di == nil && dj == nil {
-							return false
-						}
-						// No due date goes after those with due date
-						if di == nil {
-							return false
-						}
-						if dj == nil {
-							return true
-						}
-						// Both have due dates - earlier date first
-						return di.Before(*dj)
-					})
-				}
-
 				// Sort within each heading section
-				sortTodosInSections(m.FileModel.Todos, headings, sortByDueDate)
-
-				// Update indices and rebuild line structure
-				markdown.RebuildFileStructure(&m.FileModel)
+				_ = m.FileModel.SortTodos("due")
 				m.InvalidateDocumentTree()
 				m.writeIfPersist()
 				// Adjust selection if needed
@@ -203,34 +155,8 @@ func InitCommands() []Command {
 			Description: "Sort todos by priority (p1 first, then p2, etc.)",
 			Handler: func(m *Model) {
 				m.saveHistory()
-				// Get headings to sort within sections
-				headings := m.FileModel.GetHeadings()
-
-				// Sort function: by priority (p1 first), unprioritized at end (stable)
-				sortByPriority := func(todos []markdown.Todo) {
-					sort.SliceStable(todos, func(i, j int) bool {
-						pi, pj := todos[i].Priority, todos[j].Priority
-						// Both unprioritized - maintain order
-						if pi == 0 && pj == 0 {
-							return false
-						}
-						// Unprioritized goes after prioritized
-						if pi == 0 {
-							return false
-						}
-						if pj == 0 {
-							return true
-						}
-						// Both prioritized - lower number = higher priority
-						return pi < pj
-					})
-				}
-
 				// Sort within each heading section
-				sortTodosInSections(m.FileModel.Todos, headings, sortByPriority)
-
-				// Update indices and rebuild line structure
-				markdown.RebuildFileStructure(&m.FileModel)
+				_ = m.FileModel.SortTodos("priority")
 				m.InvalidateDocumentTree()
 				m.writeIfPersist()
 				// Adjust selection if needed
@@ -242,79 +168,82 @@ func InitCommands() []Command {
 		{
 			Name:        "filter-done",
 			Description: "Toggle showing/hiding completed todos",
-			Handler: func(m *Model) {
-				m.FilterDone = !m.FilterDone
-				// Invalidate document tree since visibility changed
-				m.InvalidateDocumentTree()
-				// Adjust selection if current item is now hidden
-				if m.FilterDone {
-					m.adjustSelectionForFilter()
-				}
-			},
+			Handler:     (*Model).toggleFilterDone,
 		},
 		{
 			Name:        "filter-due",
 			Description: "Toggle showing only todos with due dates",
 			Handler: func(m *Model) {
 				// Toggle between "all" (has due date) and "" (no filter)
-				if m.FilteredDueDate == "all" {
-					m.FilteredDueDate = ""
-				} else {
-					m.FilteredDueDate = "all"
-				}
-				// Invalidate document tree since visibility changed
-				m.InvalidateDocumentTree()
-				// Adjust selection if current item is now hidden
-				if m.FilteredDueDate != "" {
-					m.adjustSelectionForFilter()
-				}
+				m.toggleDueFilter("all")
 			},
 		},
 		{
 			Name:        "filter-overdue",
 			Description: "Toggle showing only overdue todos",
 			Handler: func(m *Model) {
-				if m.FilteredDueDate == "overdue" {
-					m.FilteredDueDate = ""
-				} else {
-					m.FilteredDueDate = "overdue"
-				}
-				m.InvalidateDocumentTree()
-				if m.FilteredDueDate != "" {
-					m.adjustSelectionForFilter()
-				}
+				m.toggleDueFilter("overdue")
 			},
 		},
 		{
 			Name:        "filter-today",
 			Description: "Toggle showing only todos due today",
 			Handler: func(m *Model) {
-				if m.FilteredDueDate == "today" {
-					m.FilteredDueDate = ""
-				} else {
-					m.FilteredDueDate = "today"
-				}
-				m.InvalidateDocumentTree()
-				if m.FilteredDueDate != "" {
-					m.adjustSelectionForFilter()
-				}
+				m.toggleDueFilter("today")
 			},
 		},
 		{
 			Name:        "filter-week",
 			Description: "Toggle showing only todos due this week",
 			Handler: func(m *Model) {
-				if m.FilteredDueDate == "week" {
-					m.FilteredDueDate = ""
-				} else {
-					m.FilteredDueDate = "week"
+				m.toggleDueFilter("week")
+			},
+		},
+		{
+			Name:        "dedupe",
+			Description: "Remove todos with the same text as an earlier one",
+			Handler: func(m *Model) {
+				m.saveHistory()
+				removed := m.FileModel.RemoveDuplicates()
+				switch removed {
+				case 0:
+					m.setStatus("No duplicate todos")
+					return
+				case 1:
+					m.setStatus("Removed 1 duplicate")
+				default:
+					m.setStatus(fmt.Sprintf("Removed %d duplicates", removed))
 				}
+				m.InvalidateHeadingsCache()
 				m.InvalidateDocumentTree()
-				if m.FilteredDueDate != "" {
-					m.adjustSelectionForFilter()
+				m.RefreshAvailableTags()
+				m.writeIfPersist()
+				if m.SelectedIndex >= len(m.FileModel.Todos) {
+					m.SelectedIndex = util.Max(0, len(m.FileModel.Todos)-1)
 				}
 			},
 		},
+		{
+			Name:        "check-section",
+			Description: "Mark all todos in the current section as complete",
+			Handler: func(m *Model) {
+				m.setSectionChecked(true)
+			},
+		},
+		{
+			Name:        "uncheck-section",
+			Description: "Mark all todos in the current section as incomplete",
+			Handler: func(m *Model) {
+				m.setSectionChecked(false)
+			},
+		},
+		{
+			Name:        "log-time",
+			Description: "Add 15 minutes to the time spent on the selected todo",
+			Handler: func(m *Model) {
+				m.logTime(timeLogIncrement)
+			},
+		},
 		{
 			Name:        "clear-done",
 			Description: "Delete all completed todos",
@@ -333,6 +262,13 @@ func InitCommands() []Command {
 				}
 			},
 		},
+		{
+			Name:        "edit-external",
+			Description: "Edit the selected todo in $EDITOR",
+			Handler: func(m *Model) {
+				m.pendingCmd = m.openExternalEditor()
+			},
+		},
 		{
 			Name:        "read-only",
 			Description: "Toggle read-only mode (changes not saved)",
@@ -344,11 +280,14 @@ func InitCommands() []Command {
 			Name:        "save",
 			Description: "Save current state to file",
 			Handler: func(m *Model) {
-				_ = markdown.WriteFile(m.FilePath, &m.FileModel)
+				if err := markdown.WriteFile(m.FilePath, &m.FileModel); err == nil {
+					m.Unsaved = false
+					m.logCompleted()
+				}
 			},
 		},
 		{
-			Name:        "wrap",
+			Name:        "toggle-wrap",
 			Description: "Toggle word wrap for long lines",
 			Handler: func(m *Model) {
 				m.WordWrap = !m.WordWrap
@@ -372,6 +311,34 @@ func InitCommands() []Command {
 				m.CursorPos = 0
 			},
 		},
+		{
+			Name:        "split",
+			Description: "Show another file next to this one (split <file>); ctrl+w switches panes",
+			Handler: func(m *Model) {
+				m.setStatus("Usage: split <file>")
+			},
+			ArgHandler: func(m *Model, arg string) {
+				m.openSplit(arg)
+			},
+		},
+		{
+			Name:        "unsplit",
+			Description: "Close the other pane of a split view",
+			Handler: func(m *Model) {
+				m.closeSplit()
+			},
+		},
+		{
+			Name:        "goto",
+			Description: "Jump to a todo by its number among those shown (goto <n>)",
+			Handler: func(m *Model) {
+				m.CommandMode = false
+				m.startGotoInput()
+			},
+			ArgHandler: func(m *Model, arg string) {
+				m.gotoArg(arg)
+			},
+		},
 		{
 			Name:        "show-headings",
 			Description: "Toggle displaying markdown headings between tasks",
@@ -379,11 +346,43 @@ func InitCommands() []Command {
 				m.ShowHeadings = !m.ShowHeadings
 			},
 		},
+		{
+			Name:        "fold-all",
+			Description: "Collapse every heading section to show only the headings",
+			Handler: func(m *Model) {
+				if !m.ShowHeadings {
+					m.setStatus("Headings are hidden (show-headings)")
+					return
+				}
+				m.foldAll()
+			},
+		},
+		{
+			Name:        "unfold-all",
+			Description: "Expand every collapsed heading section",
+			Handler: func(m *Model) {
+				m.unfoldAll()
+			},
+		},
+		{
+			Name:        "toggle-progress",
+			Description: "Toggle the completion progress bar in the status bar",
+			Handler: func(m *Model) {
+				m.ShowProgress = !m.ShowProgress
+			},
+		},
+		{
+			Name:        "toggle-hints",
+			Description: "Cycle the status bar between full, minimal and no hints",
+			Handler: func(m *Model) {
+				m.StatusHints = nextStatusHints(m.StatusHints)
+				m.setStatus("Status hints: " + m.StatusHints)
+			},
+		},
 		{
 			Name:        "reload",
 			Description: "Reload file from disk (discards unsaved changes)",
 			Handler: func(m *Model) {
-				// Reload file from disk
 				fm, err := markdown.ReadFile(m.FilePath)
 				if err != nil {
 					m.Err = err
@@ -391,24 +390,34 @@ func InitCommands() []Command {
 				}
 				m.FileModel = *fm
 				m.History = nil // Clear history
+				m.LocallyModified = make(map[string]bool)
+				m.completed = nil // Unsaved checks were discarded
+				m.Unsaved = false
+				m.Err = nil
 				if m.SelectedIndex >= len(m.FileModel.Todos) {
 					m.SelectedIndex = util.Max(0, len(m.FileModel.Todos)-1)
 				}
+				m.InvalidateHeadingsCache()
+				m.InvalidateDocumentTree()
+				m.RefreshAvailableTags()
+				m.adjustSelectionForFilter()
 			},
 		},
 		{
 			Name:        "force-save",
 			Description: "Force save even if file was modified externally",
 			Handler: func(m *Model) {
-				// Save without checking for external modifications
-				content := markdown.SerializeMarkdown(&m.FileModel)
-				err := markdown.WriteFile(m.FilePath, &m.FileModel)
-				if err != nil {
-					// If still fails, write directly without checks
-					if err := os.WriteFile(m.FilePath, []byte(content), 0644); err != nil {
-						m.Err = err
-					}
+				// Overwrite the external changes with the in-memory state
+				if err := markdown.WriteFileUnchecked(m.FilePath, &m.FileModel); err != nil {
+					m.Err = err
+					return
 				}
+				m.LocallyModified = make(map[string]bool)
+				m.Unsaved = false
+				m.logCompleted()
+				m.Err = nil
+				m.InvalidateHeadingsCache()
+				m.InvalidateDocumentTree()
 			},
 		},
 		{
@@ -442,23 +451,24 @@ func HighlightMatches(text, query string, greenStyle func(string) string) string
 		return text
 	}
 
-	lowerText := strings.ToLower(text)
-	lowerQuery := strings.ToLower(query)
+	// Match on folded text and map the positions back to the original runes
+	foldedText, offsets := util.FoldTextOffsets(text)
+	foldedQuery := util.FoldText(query)
 
 	// Find match positions
 	var matchPositions []int
 
 	// First try exact substring match
-	if idx := strings.Index(lowerText, lowerQuery); idx != -1 {
-		for i := idx; i < idx+len(query); i++ {
-			matchPositions = append(matchPositions, i)
+	if idx := strings.Index(foldedText, foldedQuery); idx != -1 {
+		for i := idx; i < idx+len(foldedQuery); i++ {
+			matchPositions = append(matchPositions, offsets[i])
 		}
 	} else {
 		// Fuzzy match positions
 		queryIdx := 0
-		for i := 0; i < len(lowerText) && queryIdx < len(lowerQuery); i++ {
-			if lowerText[i] == lowerQuery[queryIdx] {
-				matchPositions = append(matchPositions, i)
+		for i := 0; i < len(foldedText) && queryIdx < len(foldedQuery); i++ {
+			if foldedText[i] == foldedQuery[queryIdx] {
+				matchPositions = append(matchPositions, offsets[i])
 				queryIdx++
 			}
 		}
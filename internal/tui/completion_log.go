@@ -0,0 +1,20 @@
+package tui
+
+import "github.com/niklas-heer/tdx/internal/markdown"
+
+// noteCompleted remembers the todo at idx for the completion log if it's now checked
+func (m *Model) noteCompleted(idx int) {
+	if m.config == nil || m.config.HistoryFile == "" || !m.FileModel.Todos[idx].Checked {
+		return
+	}
+	m.completed = append(m.completed, m.FileModel.Todos[idx])
+}
+
+// logCompleted appends the todos checked since the last write to the completion
+// log. A log that can't be written doesn't fail saving the todos.
+func (m *Model) logCompleted() {
+	if len(m.completed) > 0 && m.config != nil {
+		_ = markdown.LogCompleted(m.config.HistoryFile, m.FilePath, m.completed)
+	}
+	m.completed = nil
+}
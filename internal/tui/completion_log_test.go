@@ -0,0 +1,71 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/niklas-heer/tdx/internal/markdown"
+)
+
+// completionLogFixture returns a model for a todo file with the completion log at the returned path
+func completionLogFixture(t *testing.T, readOnly bool) (Model, string) {
+	t.Helper()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "todo.md")
+	if err := os.WriteFile(path, []byte("# Todos\n\n- [ ] Write report\n- [x] Buy milk\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	fm, err := markdown.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := testConfig()
+	cfg.HistoryFile = filepath.Join(dir, "history.md")
+	return New(path, fm, readOnly, false, -1, cfg, testStyles(), "test"), cfg.HistoryFile
+}
+
+func TestCompletionLog_ToggleLogsChecked(t *testing.T) {
+	m, historyFile := completionLogFixture(t, false)
+
+	m = pressKey(t, m, " ")
+	// Unchecking isn't a completion
+	m = pressKey(t, m, "j")
+	m = pressKey(t, m, " ")
+
+	completions, err := markdown.ReadHistory(historyFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(completions) != 1 || completions[0].Text != "Write report" || completions[0].File != m.FilePath {
+		t.Errorf("Logged %+v, want only Write report in %s", completions, m.FilePath)
+	}
+}
+
+func TestCompletionLog_ReadOnlyLogsOnSave(t *testing.T) {
+	m, historyFile := completionLogFixture(t, true)
+
+	m = pressKey(t, m, " ")
+	if completions, _ := markdown.ReadHistory(historyFile); len(completions) != 0 {
+		t.Fatalf("Expected nothing logged before saving, got %+v", completions)
+	}
+
+	executeCommand(&m, "save")
+	executeCommand(&m, "save")
+
+	completions, _ := markdown.ReadHistory(historyFile)
+	if len(completions) != 1 || completions[0].Text != "Write report" {
+		t.Errorf("Logged %+v, want Write report once", completions)
+	}
+}
+
+func TestCompletionLog_Off(t *testing.T) {
+	m, historyFile := completionLogFixture(t, false)
+	m.config.HistoryFile = ""
+
+	_ = pressKey(t, m, " ")
+
+	if _, err := os.Stat(historyFile); !os.IsNotExist(err) {
+		t.Error("Expected no completion log without a HistoryFile")
+	}
+}
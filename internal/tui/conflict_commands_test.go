@@ -0,0 +1,92 @@
+package tui
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/niklas-heer/tdx/internal/markdown"
+)
+
+// conflictFixture returns a model with an unsaved local edit to a file that was then changed on disk
+func conflictFixture(t *testing.T) (Model, string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "todo.md")
+	if err := os.WriteFile(path, []byte("# Todos\n\n- [ ] Task A #work\n- [ ] Task B\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	fm, err := markdown.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := New(path, fm, false, false, -1, testConfig(), testStyles(), "test")
+
+	// A local edit that couldn't be saved because of the external change below
+	_ = m.FileModel.UpdateTodoItem(0, "Task A edited here", false)
+	m.LocallyModified["Task A edited here"] = true
+	m.Err = errors.New("file changed externally")
+
+	external := "# Todos\n\n- [ ] Task A edited elsewhere #home\n- [ ] Task B\n- [ ] Task C\n"
+	if err := os.WriteFile(path, []byte(external), 0644); err != nil {
+		t.Fatal(err)
+	}
+	later := time.Now().Add(5 * time.Second)
+	if err := os.Chtimes(path, later, later); err != nil {
+		t.Fatal(err)
+	}
+	return m, path
+}
+
+func TestCommand_ReloadAfterExternalChange(t *testing.T) {
+	m, _ := conflictFixture(t)
+
+	executeCommand(&m, "reload")
+
+	if m.Err != nil {
+		t.Errorf("Err should be cleared after reload, got %v", m.Err)
+	}
+	if len(m.FileModel.Todos) != 3 || m.FileModel.Todos[0].Text != "Task A edited elsewhere #home" {
+		t.Errorf("Expected external content after reload, got %+v", m.FileModel.Todos)
+	}
+	if len(m.LocallyModified) != 0 {
+		t.Errorf("LocallyModified should be cleared, got %v", m.LocallyModified)
+	}
+	if len(m.AvailableTags) != 1 || m.AvailableTags[0] != "home" {
+		t.Errorf("AvailableTags = %v, want [home]", m.AvailableTags)
+	}
+	if !strings.Contains(m.View(), "Task C") {
+		t.Errorf("View should show reloaded todos:\n%s", m.View())
+	}
+}
+
+func TestCommand_ForceSaveOverwritesExternalChange(t *testing.T) {
+	m, path := conflictFixture(t)
+
+	executeCommand(&m, "force-save")
+
+	if m.Err != nil {
+		t.Errorf("Err should be cleared after force-save, got %v", m.Err)
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(content) != "# Todos\n\n- [ ] Task A edited here\n- [ ] Task B\n" {
+		t.Errorf("File should contain the local state, got:\n%s", content)
+	}
+	if len(m.LocallyModified) != 0 {
+		t.Errorf("LocallyModified should be cleared, got %v", m.LocallyModified)
+	}
+
+	// Later edits save normally again
+	m = pressKey(t, m, " ")
+	if m.Err != nil {
+		t.Errorf("Toggle after force-save should save cleanly, got %v", m.Err)
+	}
+	if fm, _ := markdown.ReadFile(path); !fm.Todos[0].Checked {
+		t.Error("Toggle after force-save should be written to disk")
+	}
+}
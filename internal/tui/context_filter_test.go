@@ -0,0 +1,120 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const contextFilterContent = `# Todos
+
+- [ ] Fix sink @home
+- [ ] Prepare slides @work +conference
+- [ ] Book hotel @work @phone +conference
+- [ ] Water plants @home +garden
+`
+
+func TestContextFilter_HidesOtherContexts(t *testing.T) {
+	m := testModelWithMarkdown(contextFilterContent)
+
+	m.FilteredContexts = []string{"work"}
+	m.InvalidateDocumentTree()
+
+	visible := m.getVisibleTodos()
+	if len(visible) != 2 {
+		t.Fatalf("Expected 2 visible todos with @work filter, got %d", len(visible))
+	}
+	for _, idx := range visible {
+		if strings.Contains(m.FileModel.Todos[idx].Text, "@home") {
+			t.Errorf("Todo with @home should be hidden when filtering @work: %q", m.FileModel.Todos[idx].Text)
+		}
+	}
+	if m.isTodoVisible(0) {
+		t.Error("Expected 'Fix sink @home' to be hidden")
+	}
+}
+
+func TestProjectFilter_ShowsOnlyProject(t *testing.T) {
+	m := testModelWithMarkdown(contextFilterContent)
+
+	m.FilteredProjects = []string{"garden"}
+	m.InvalidateDocumentTree()
+
+	visible := m.getVisibleTodos()
+	if len(visible) != 1 || m.FileModel.Todos[visible[0]].Text != "Water plants @home +garden" {
+		t.Errorf("Expected only the +garden todo, got %v", visible)
+	}
+}
+
+func TestContextFilter_OverlayKeys(t *testing.T) {
+	m := testModelWithMarkdown(contextFilterContent)
+	m.TermWidth = 80
+
+	if strings.Join(m.AvailableContexts, ",") != "home,phone,work" {
+		t.Fatalf("Unexpected available contexts: %v", m.AvailableContexts)
+	}
+
+	m = pressKey(t, m, "@")
+	if !m.ContextFilterMode {
+		t.Fatal("Expected context filter mode after pressing @")
+	}
+	view := m.View()
+	if !strings.Contains(view, "@phone") {
+		t.Errorf("Expected context overlay in view:\n%s", view)
+	}
+
+	// Select @work (third entry)
+	m = pressKey(t, m, "j")
+	m = pressKey(t, m, "j")
+	m = pressKeyType(t, m, tea.KeyEnter)
+
+	if m.ContextFilterMode {
+		t.Error("Expected overlay to close after selection")
+	}
+	if len(m.FilteredContexts) != 1 || m.FilteredContexts[0] != "work" {
+		t.Fatalf("Expected @work filter, got %v", m.FilteredContexts)
+	}
+	if strings.Contains(m.View(), "Fix sink") {
+		t.Error("Expected @home todo to be hidden from the view")
+	}
+
+	// Clearing from the overlay shows everything again
+	m = pressKey(t, m, "@")
+	m = pressKey(t, m, "c")
+	m = pressKeyType(t, m, tea.KeyEsc)
+	if len(m.FilteredContexts) != 0 || len(m.getVisibleTodos()) != 4 {
+		t.Errorf("Expected filters cleared, got %v", m.FilteredContexts)
+	}
+}
+
+func TestProjectFilter_OverlayKeys(t *testing.T) {
+	m := testModelWithMarkdown(contextFilterContent)
+
+	m = pressKey(t, m, "+")
+	if !m.ProjectFilterMode {
+		t.Fatal("Expected project filter mode after pressing +")
+	}
+	m = pressKeyType(t, m, tea.KeyEnter)
+
+	if len(m.FilteredProjects) != 1 || m.FilteredProjects[0] != "conference" {
+		t.Errorf("Expected +conference filter, got %v", m.FilteredProjects)
+	}
+	if !m.hasActiveFilters() {
+		t.Error("Expected project filter to count as active filter")
+	}
+}
+
+func TestColorizeContextsAndProjects(t *testing.T) {
+	mark := func(s string) string { return "<" + s + ">" }
+
+	got := ColorizeContexts("Call @phone at @due(2025-01-01) or bob@example.com", mark)
+	if got != "Call <@phone> at @due(2025-01-01) or bob@example.com" {
+		t.Errorf("Unexpected context colorization: %q", got)
+	}
+
+	got = ColorizeProjects("+website uses C++", mark)
+	if got != "<+website> uses C++" {
+		t.Errorf("Unexpected project colorization: %q", got)
+	}
+}
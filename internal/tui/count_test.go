@@ -0,0 +1,85 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCount_DeleteThreeIsOneUndoStep(t *testing.T) {
+	m := testModelWithMarkdown("- [ ] A\n- [ ] B\n- [ ] C\n- [ ] D\n- [ ] E\n")
+	m = pressKey(t, m, "j")
+
+	m = pressKey(t, m, "3")
+	m = pressKey(t, m, "d")
+
+	if got := strings.Join(todoTexts(m), ","); got != "A,E" {
+		t.Fatalf("Todos = %s, want A,E", got)
+	}
+	if m.SelectedIndex != 1 {
+		t.Errorf("SelectedIndex = %d, want 1 (E)", m.SelectedIndex)
+	}
+
+	m = pressKey(t, m, "u")
+	if got := strings.Join(todoTexts(m), ","); got != "A,B,C,D,E" {
+		t.Errorf("Todos after one undo = %s, want all restored", got)
+	}
+}
+
+func TestCount_DeleteWalksVisibleTodos(t *testing.T) {
+	m := testModelWithMarkdown("- [ ] A\n- [x] Hidden\n- [ ] B\n- [ ] C\n")
+	m.FilterDone = true
+
+	m = pressKey(t, m, "2")
+	m = pressKey(t, m, "d")
+
+	if got := strings.Join(todoTexts(m), ","); got != "Hidden,C" {
+		t.Errorf("Todos = %s, want Hidden,C (hidden todo skipped)", got)
+	}
+	if m.SelectedIndex != 1 {
+		t.Errorf("SelectedIndex = %d, want 1 (C)", m.SelectedIndex)
+	}
+}
+
+func TestCount_DeletePastEndStopsAtLastTodo(t *testing.T) {
+	m := testModelWithMarkdown("- [ ] A\n- [ ] B\n- [ ] C\n")
+	m = pressKey(t, m, "j")
+
+	m = pressKey(t, m, "9")
+	m = pressKey(t, m, "d")
+
+	if got := strings.Join(todoTexts(m), ","); got != "A" {
+		t.Errorf("Todos = %s, want A", got)
+	}
+	if m.SelectedIndex != 0 {
+		t.Errorf("SelectedIndex = %d, want 0", m.SelectedIndex)
+	}
+}
+
+func TestCount_ToggleTwo(t *testing.T) {
+	m := testModelWithMarkdown("- [ ] A\n- [x] B\n- [ ] C\n")
+
+	m = pressKey(t, m, "2")
+	m = pressKey(t, m, " ")
+
+	checked := []bool{m.FileModel.Todos[0].Checked, m.FileModel.Todos[1].Checked, m.FileModel.Todos[2].Checked}
+	if !checked[0] || checked[1] || checked[2] {
+		t.Errorf("Checked = %v, want [true false false]", checked)
+	}
+
+	m = pressKey(t, m, "u")
+	if m.FileModel.Todos[0].Checked || !m.FileModel.Todos[1].Checked {
+		t.Error("One undo should restore both todos")
+	}
+}
+
+func TestCount_ToggleWalksVisibleTodos(t *testing.T) {
+	m := testModelWithMarkdown("- [ ] A #work\n- [ ] B #home\n- [ ] C #work\n")
+	m.FilteredTags = []string{"work"}
+
+	m = pressKey(t, m, "2")
+	m = pressKey(t, m, " ")
+
+	if !m.FileModel.Todos[0].Checked || m.FileModel.Todos[1].Checked || !m.FileModel.Todos[2].Checked {
+		t.Errorf("Expected A and C toggled, B untouched")
+	}
+}
@@ -311,99 +311,6 @@ func TestVisibleTree_GetVisibleTodoIndices(t *testing.T) {
 
 // ==================== commands.go tests ====================
 
-func TestGetTodoSections_NoHeadings(t *testing.T) {
-	todos := []markdown.Todo{
-		{Text: "Task 1"},
-		{Text: "Task 2"},
-		{Text: "Task 3"},
-	}
-	headings := []markdown.Heading{}
-
-	sections := getTodoSections(todos, headings)
-	if len(sections) != 1 {
-		t.Fatalf("Expected 1 section, got %d", len(sections))
-	}
-	if sections[0].startIndex != 0 || sections[0].endIndex != 3 {
-		t.Errorf("Section = {%d, %d}, want {0, 3}", sections[0].startIndex, sections[0].endIndex)
-	}
-}
-
-func TestGetTodoSections_WithHeadings(t *testing.T) {
-	todos := []markdown.Todo{
-		{Text: "Task 1"},
-		{Text: "Task 2"},
-		{Text: "Task 3"},
-		{Text: "Task 4"},
-	}
-	headings := []markdown.Heading{
-		{Text: "Section A", BeforeTodoIndex: 0},
-		{Text: "Section B", BeforeTodoIndex: 2},
-	}
-
-	sections := getTodoSections(todos, headings)
-	if len(sections) != 2 {
-		t.Fatalf("Expected 2 sections, got %d", len(sections))
-	}
-	// Section A: todos 0-1
-	if sections[0].startIndex != 0 || sections[0].endIndex != 2 {
-		t.Errorf("Section 0 = {%d, %d}, want {0, 2}", sections[0].startIndex, sections[0].endIndex)
-	}
-	// Section B: todos 2-3
-	if sections[1].startIndex != 2 || sections[1].endIndex != 4 {
-		t.Errorf("Section 1 = {%d, %d}, want {2, 4}", sections[1].startIndex, sections[1].endIndex)
-	}
-}
-
-func TestGetTodoSections_EmptyTodos(t *testing.T) {
-	todos := []markdown.Todo{}
-	headings := []markdown.Heading{{Text: "Heading", BeforeTodoIndex: 0}}
-
-	sections := getTodoSections(todos, headings)
-	if sections != nil {
-		t.Errorf("Expected nil, got %v", sections)
-	}
-}
-
-func TestSortTodosInSections(t *testing.T) {
-	todos := []markdown.Todo{
-		{Text: "B Task", Priority: 2},
-		{Text: "A Task", Priority: 1},
-		{Text: "D Task", Priority: 4},
-		{Text: "C Task", Priority: 3},
-	}
-	headings := []markdown.Heading{
-		{Text: "Section 1", BeforeTodoIndex: 0},
-		{Text: "Section 2", BeforeTodoIndex: 2},
-	}
-
-	// Sort by priority within sections
-	sortTodosInSections(todos, headings, func(slice []markdown.Todo) {
-		// Simple bubble sort by priority
-		for i := 0; i < len(slice); i++ {
-			for j := i + 1; j < len(slice); j++ {
-				if slice[j].Priority < slice[i].Priority {
-					slice[i], slice[j] = slice[j], slice[i]
-				}
-			}
-		}
-	})
-
-	// Section 1: A (p1), B (p2)
-	if todos[0].Text != "A Task" {
-		t.Errorf("todos[0] = %q, want 'A Task'", todos[0].Text)
-	}
-	if todos[1].Text != "B Task" {
-		t.Errorf("todos[1] = %q, want 'B Task'", todos[1].Text)
-	}
-	// Section 2: C (p3), D (p4)
-	if todos[2].Text != "C Task" {
-		t.Errorf("todos[2] = %q, want 'C Task'", todos[2].Text)
-	}
-	if todos[3].Text != "D Task" {
-		t.Errorf("todos[3] = %q, want 'D Task'", todos[3].Text)
-	}
-}
-
 func TestHighlightMatches(t *testing.T) {
 	// Identity function for styling
 	highlight := func(s string) string { return "[" + s + "]" }
@@ -0,0 +1,52 @@
+package tui
+
+import (
+	"testing"
+)
+
+func TestDedupeCommand_RemovesDuplicates(t *testing.T) {
+	m := testModelWithMarkdown("- [ ] Water plants\n- [x] Water plants\n- [ ] water plants\n")
+	m.SelectedIndex = 2
+
+	executeCommand(&m, "dedupe")
+
+	if len(m.FileModel.Todos) != 1 {
+		t.Fatalf("Expected 1 todo, got %v", todoTexts(m))
+	}
+	if !m.FileModel.Todos[0].Checked {
+		t.Error("Remaining todo should be checked since a duplicate was")
+	}
+	if m.StatusHint != "Removed 2 duplicates" {
+		t.Errorf("StatusHint = %q", m.StatusHint)
+	}
+	if m.SelectedIndex != 0 {
+		t.Errorf("SelectedIndex = %d, want 0", m.SelectedIndex)
+	}
+}
+
+func TestDedupeCommand_SingleUndo(t *testing.T) {
+	m := testModelWithMarkdown("- [ ] A\n- [x] A\n- [ ] A\n")
+
+	executeCommand(&m, "dedupe")
+	m = pressKey(t, m, "u")
+
+	if len(m.FileModel.Todos) != 3 {
+		t.Errorf("Expected undo to restore all 3 todos, got %v", todoTexts(m))
+	}
+	if m.FileModel.Todos[0].Checked {
+		t.Error("Undo should restore the original checked state")
+	}
+}
+
+func TestDedupeCommand_NothingToRemove(t *testing.T) {
+	m := testModelWithMarkdown("- [ ] A\n- [ ] B\n")
+
+	executeCommand(&m, "dedupe")
+
+	if m.StatusHint != "No duplicate todos" {
+		t.Errorf("StatusHint = %q", m.StatusHint)
+	}
+	if len(m.FileModel.Todos) != 2 {
+		t.Errorf("todos = %v", todoTexts(m))
+	}
+}
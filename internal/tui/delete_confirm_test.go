@@ -0,0 +1,126 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const deleteConfirmMarkdown = "- [ ] Before\n- [ ] Parent\n  - [ ] Child\n    - [ ] Grandchild\n- [ ] After\n"
+
+func deleteConfirmModel(t *testing.T) Model {
+	t.Helper()
+	m := testModelWithMarkdown(deleteConfirmMarkdown)
+	m.config.Defaults.ConfirmDeleteParent = true
+	m = pressKey(t, m, "j")
+	m = pressKey(t, m, "d")
+	if !m.ConfirmDeleteMode {
+		t.Fatal("Expected confirmation prompt when deleting a parent")
+	}
+	return m
+}
+
+func TestConfirmDelete_PromptInStatusBar(t *testing.T) {
+	m := deleteConfirmModel(t)
+
+	if view := m.View(); !strings.Contains(view, "Delete 2 subtasks too? (y/n)") {
+		t.Errorf("Expected prompt in status bar:\n%s", view)
+	}
+	if len(m.FileModel.Todos) != 5 {
+		t.Errorf("Nothing should be deleted before answering, got %d todos", len(m.FileModel.Todos))
+	}
+}
+
+func TestConfirmDelete_YesDeletesSubtree(t *testing.T) {
+	m := deleteConfirmModel(t)
+
+	m = pressKey(t, m, "y")
+
+	if m.ConfirmDeleteMode {
+		t.Error("Prompt should close after answering")
+	}
+	if got := strings.Join(todoTexts(m), ","); got != "Before,After" {
+		t.Errorf("Todos = %s, want Before,After", got)
+	}
+	if m.SelectedIndex != 1 {
+		t.Errorf("SelectedIndex = %d, want 1 (After)", m.SelectedIndex)
+	}
+
+	m = pressKey(t, m, "u")
+	if got := strings.Join(todoTexts(m), ","); got != "Before,Parent,Child,Grandchild,After" {
+		t.Errorf("Todos after undo = %s", got)
+	}
+}
+
+func TestConfirmDelete_NoPromotesChildren(t *testing.T) {
+	m := deleteConfirmModel(t)
+
+	m = pressKey(t, m, "n")
+
+	if got := strings.Join(todoTexts(m), ","); got != "Before,Child,Grandchild,After" {
+		t.Fatalf("Todos = %s, want Before,Child,Grandchild,After", got)
+	}
+	if m.FileModel.Todos[1].Depth != 0 || m.FileModel.Todos[2].Depth != 1 {
+		t.Errorf("Depths = %d, %d, want children promoted one level", m.FileModel.Todos[1].Depth, m.FileModel.Todos[2].Depth)
+	}
+
+	m = pressKey(t, m, "u")
+	if got := strings.Join(todoTexts(m), ","); got != "Before,Parent,Child,Grandchild,After" {
+		t.Errorf("Todos after undo = %s", got)
+	}
+	if m.FileModel.Todos[2].Depth != 1 {
+		t.Errorf("Child depth after undo = %d, want 1", m.FileModel.Todos[2].Depth)
+	}
+}
+
+func TestConfirmDelete_EscCancels(t *testing.T) {
+	m := deleteConfirmModel(t)
+
+	m = pressKeyType(t, m, tea.KeyEsc)
+
+	if m.ConfirmDeleteMode {
+		t.Error("Esc should close the prompt")
+	}
+	if got := strings.Join(todoTexts(m), ","); got != "Before,Parent,Child,Grandchild,After" {
+		t.Errorf("Todos = %s, want unchanged", got)
+	}
+}
+
+func TestConfirmDelete_OtherKeysIgnored(t *testing.T) {
+	m := deleteConfirmModel(t)
+
+	m = pressKey(t, m, "j")
+
+	if !m.ConfirmDeleteMode || m.SelectedIndex != 1 {
+		t.Errorf("Prompt should stay open, ConfirmDeleteMode=%v SelectedIndex=%d", m.ConfirmDeleteMode, m.SelectedIndex)
+	}
+}
+
+func TestConfirmDelete_LeafDeletesWithoutPrompt(t *testing.T) {
+	m := testModelWithMarkdown(deleteConfirmMarkdown)
+	m.config.Defaults.ConfirmDeleteParent = true
+
+	m = pressKey(t, m, "d")
+
+	if m.ConfirmDeleteMode {
+		t.Error("Todos without subtasks shouldn't ask")
+	}
+	if len(m.FileModel.Todos) != 4 {
+		t.Errorf("Expected 4 todos, got %d", len(m.FileModel.Todos))
+	}
+}
+
+func TestConfirmDelete_DisabledByDefault(t *testing.T) {
+	m := testModelWithMarkdown(deleteConfirmMarkdown)
+
+	m = pressKey(t, m, "j")
+	m = pressKey(t, m, "d")
+
+	if m.ConfirmDeleteMode {
+		t.Error("Prompt should only appear with confirm_delete_parent enabled")
+	}
+	if got := strings.Join(todoTexts(m), ","); got != "Before,Child,Grandchild,After" {
+		t.Errorf("Todos = %s", got)
+	}
+}
@@ -0,0 +1,72 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// withDoneStyle returns a model for content using the given done_style
+func withDoneStyle(t *testing.T, content, style string) Model {
+	t.Helper()
+	m := testModelWithMarkdown(content)
+	cfg := *m.Config()
+	cfg.Display.DoneStyle = style
+	m.config = &cfg
+	return m
+}
+
+func TestDoneStyle_Strikethrough(t *testing.T) {
+	old := lipgloss.ColorProfile()
+	lipgloss.SetColorProfile(termenv.ANSI)
+	t.Cleanup(func() { lipgloss.SetColorProfile(old) })
+
+	m := withDoneStyle(t, "- [x] Finished task\n- [ ] Open task\n", DoneStyleStrikethrough)
+	view := m.View()
+
+	if !strings.Contains(view, "\x1b[9mF") {
+		t.Errorf("Expected strikethrough SGR for the checked todo:\n%q", view)
+	}
+	if strings.Contains(view, "\x1b[9mO") {
+		t.Errorf("Open todos should not be struck through:\n%q", view)
+	}
+}
+
+func TestDoneStyle_HiddenText(t *testing.T) {
+	m := withDoneStyle(t, "- [x] Finished task #work\n- [ ] Open task\n", DoneStyleHiddenText)
+	view := m.View()
+
+	if strings.Contains(view, "Finished task") || strings.Contains(view, "#work") {
+		t.Errorf("Completed todo text should be hidden:\n%s", view)
+	}
+	if !strings.Contains(view, "[x]") || !strings.Contains(view, "Open task") {
+		t.Errorf("Expected the checkbox and open todos to show:\n%s", view)
+	}
+
+	// The text is back while editing
+	m = pressKey(t, m, "e")
+	if view := m.View(); !strings.Contains(view, "Finished task") {
+		t.Errorf("Expected the text while editing:\n%s", view)
+	}
+}
+
+func TestDoneStyle_Dim(t *testing.T) {
+	m := withDoneStyle(t, "- [x] Finished task\n", DoneStyleDim)
+	styles := *testStyles()
+	styles.Dim = func(s string) string { return "<dim>" + s }
+	styles.Magenta = func(s string) string { return "<magenta>" + s }
+	m.styles = &styles
+
+	if view := m.View(); !strings.Contains(view, "<dim>Finished task") {
+		t.Errorf("Expected dim text for the checked todo:\n%s", view)
+	}
+
+	cfg := *m.Config()
+	cfg.Display.DoneStyle = ""
+	m.config = &cfg
+	if view := m.View(); !strings.Contains(view, "<magenta>Finished task") {
+		t.Errorf("Expected magenta text by default:\n%s", view)
+	}
+}
@@ -371,3 +371,25 @@ func TestDueFilter_OverlayRendering(t *testing.T) {
 		t.Error("Due filter overlay should contain 'This Week' option")
 	}
 }
+
+func TestDueFilter_FollowsEditedDate(t *testing.T) {
+	m := testModelWithMarkdown("# Todos\n\n- [ ] Pay rent @due(2000-01-01)\n- [ ] Later @due(2999-01-01)\n")
+	m.FilteredDueDate = "overdue"
+	if !m.isTodoVisible(0) || m.isTodoVisible(1) {
+		t.Fatal("Expected only the first todo to be overdue")
+	}
+
+	m.SelectedIndex = 1
+	m = pressKey(t, m, "e")
+	for range len("2999-01-01)") {
+		m = pressKeyType(t, m, tea.KeyBackspace)
+	}
+	for _, key := range "2000-01-02)" {
+		m = pressKey(t, m, string(key))
+	}
+	m = pressKeyType(t, m, tea.KeyEnter)
+
+	if !m.isTodoVisible(1) {
+		t.Errorf("Expected the edited todo to be overdue, text %q, due %v", m.FileModel.Todos[1].Text, m.FileModel.Todos[1].DueDate)
+	}
+}
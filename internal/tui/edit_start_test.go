@@ -0,0 +1,63 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/niklas-heer/tdx/internal/markdown"
+)
+
+func TestStartEditAt_PipedEditSavesTodo(t *testing.T) {
+	path := viewStateFixture(t, "# Todos\n\n- [ ] First\n- [ ] Second\n- [ ] Third\n")
+	fm, err := markdown.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := New(path, fm, false, false, -1, testConfig(), testStyles(), "test")
+
+	m.startEditAt(1)
+
+	if !m.EditMode {
+		t.Fatal("Expected model to start in edit mode")
+	}
+	if m.SelectedIndex != 1 {
+		t.Errorf("SelectedIndex = %d, want 1", m.SelectedIndex)
+	}
+	if m.InputBuffer != "Second" || m.CursorPos != len("Second") {
+		t.Errorf("InputBuffer = %q, CursorPos = %d", m.InputBuffer, m.CursorPos)
+	}
+
+	m.ProcessPipedInput([]byte(" todo\r"))
+
+	fm, err = markdown.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fm.Todos[1].Text != "Second todo" {
+		t.Errorf("Saved text = %q, want %q", fm.Todos[1].Text, "Second todo")
+	}
+}
+
+func TestStartEditAt_ClearsFiltersHidingTodo(t *testing.T) {
+	m := testModelWithMarkdown("- [ ] Open #work\n- [x] Done #home\n")
+	m.FilterDone = true
+	m.FilteredTags = []string{"work"}
+
+	m.startEditAt(1)
+
+	if m.FilterDone || len(m.FilteredTags) != 0 {
+		t.Errorf("Filters should be cleared, got FilterDone=%v FilteredTags=%v", m.FilterDone, m.FilteredTags)
+	}
+	if !m.EditMode || m.InputBuffer != "Done #home" {
+		t.Errorf("EditMode = %v, InputBuffer = %q", m.EditMode, m.InputBuffer)
+	}
+}
+
+func TestStartEditAt_OutOfRangeIgnored(t *testing.T) {
+	m := testModelWithMarkdown("- [ ] Only\n")
+
+	m.startEditAt(3)
+
+	if m.EditMode {
+		t.Error("Out of range index should not enter edit mode")
+	}
+}
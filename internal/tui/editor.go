@@ -0,0 +1,99 @@
+package tui
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// execProcess runs a command with the terminal released (swapped out in tests)
+var execProcess = tea.ExecProcess
+
+// editorFinishedMsg is sent when the external editor exits
+type editorFinishedMsg struct {
+	index int    // Todo being edited
+	path  string // Temp file holding the text
+	err   error
+}
+
+// editorCommand returns the user's $EDITOR, falling back to vi or nano
+func editorCommand() ([]string, error) {
+	if editor := strings.Fields(os.Getenv("EDITOR")); len(editor) > 0 {
+		return editor, nil
+	}
+	for _, name := range []string{"vi", "nano"} {
+		if path, err := exec.LookPath(name); err == nil {
+			return []string{path}, nil
+		}
+	}
+	return nil, errors.New("no editor found, set $EDITOR")
+}
+
+// openExternalEditor writes the selected todo's text to a temp file and opens it in the editor
+func (m *Model) openExternalEditor() tea.Cmd {
+	if len(m.FileModel.Todos) == 0 {
+		return nil
+	}
+
+	editor, err := editorCommand()
+	if err != nil {
+		m.Err = err
+		return nil
+	}
+
+	file, err := os.CreateTemp("", "tdx-*.md")
+	if err != nil {
+		m.Err = err
+		return nil
+	}
+	index := m.SelectedIndex
+	_, err = file.WriteString(m.FileModel.Todos[index].Text + "\n")
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(file.Name())
+		m.Err = err
+		return nil
+	}
+
+	args := append(editor[1:], file.Name())
+	cmd := exec.Command(editor[0], args...)
+	return execProcess(cmd, func(err error) tea.Msg {
+		return editorFinishedMsg{index: index, path: file.Name(), err: err}
+	})
+}
+
+// applyExternalEdit reads the first line of the edited file back as the todo's new text
+func (m *Model) applyExternalEdit(msg editorFinishedMsg) {
+	defer func() { _ = os.Remove(msg.path) }()
+
+	if msg.err != nil {
+		m.Err = msg.err
+		return
+	}
+	data, err := os.ReadFile(msg.path)
+	if err != nil {
+		m.Err = err
+		return
+	}
+
+	text, _, _ := strings.Cut(string(data), "\n")
+	text = strings.TrimSpace(text)
+	if text == "" || msg.index >= len(m.FileModel.Todos) {
+		return
+	}
+
+	todo := m.FileModel.Todos[msg.index]
+	if text == todo.Text {
+		return
+	}
+	m.saveHistory()
+	_ = m.FileModel.UpdateTodoItem(msg.index, text, todo.Checked)
+	m.InvalidateDocumentTree()
+	m.RefreshAvailableTags()
+	m.writeIfPersist()
+}
@@ -0,0 +1,116 @@
+package tui
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/niklas-heer/tdx/internal/markdown"
+)
+
+// stubEditor points $EDITOR at a script that replaces the file with content,
+// and runs editor processes synchronously instead of through the terminal
+func stubEditor(t *testing.T, content string) {
+	t.Helper()
+	dir := t.TempDir()
+	script := filepath.Join(dir, "editor.sh")
+	source := filepath.Join(dir, "content.txt")
+	if err := os.WriteFile(source, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(script, []byte("#!/bin/sh\ncat '"+source+"' > \"$1\"\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("EDITOR", script)
+
+	original := execProcess
+	execProcess = func(c *exec.Cmd, fn tea.ExecCallback) tea.Cmd {
+		return func() tea.Msg { return fn(c.Run()) }
+	}
+	t.Cleanup(func() { execProcess = original })
+}
+
+// runEditorKey presses E and delivers the editor's result back to the model
+func runEditorKey(t *testing.T, m Model) Model {
+	t.Helper()
+	result, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("E")})
+	m = result.(Model)
+	if cmd == nil {
+		t.Fatalf("Expected a command to launch the editor (err: %v)", m.Err)
+	}
+	result, _ = m.Update(cmd())
+	return result.(Model)
+}
+
+func editorTestModel(t *testing.T) (Model, string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "todo.md")
+	if err := os.WriteFile(path, []byte("# Todos\n\n- [ ] First\n- [x] Second\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	fm, err := markdown.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return New(path, fm, false, false, -1, testConfig(), testStyles(), "test"), path
+}
+
+func TestExternalEditor_UpdatesTodo(t *testing.T) {
+	stubEditor(t, "Second, rewritten in vim\nignored second line\n\n")
+	m, path := editorTestModel(t)
+
+	m = pressKey(t, m, "j")
+	m = runEditorKey(t, m)
+
+	if m.Err != nil {
+		t.Fatalf("Unexpected error: %v", m.Err)
+	}
+	todo := m.FileModel.Todos[1]
+	if todo.Text != "Second, rewritten in vim" || !todo.Checked {
+		t.Errorf("Expected checked todo with new text, got %+v", todo)
+	}
+
+	data, _ := os.ReadFile(path)
+	expected := "# Todos\n\n- [ ] First\n- [x] Second, rewritten in vim\n"
+	if string(data) != expected {
+		t.Errorf("Expected file:\n%q\nGot:\n%q", expected, string(data))
+	}
+}
+
+func TestExternalEditor_IgnoresEmptyResult(t *testing.T) {
+	stubEditor(t, "\n\n")
+	m, _ := editorTestModel(t)
+
+	m = runEditorKey(t, m)
+
+	if m.FileModel.Todos[0].Text != "First" {
+		t.Errorf("Expected text unchanged for empty editor result, got %q", m.FileModel.Todos[0].Text)
+	}
+	if m.History != nil {
+		t.Error("Expected no undo history for an empty edit")
+	}
+}
+
+func TestExternalEditor_CommandPalette(t *testing.T) {
+	stubEditor(t, "From the palette\n")
+	m, _ := editorTestModel(t)
+
+	m = pressKey(t, m, ":")
+	for _, c := range "edit-external" {
+		m = pressKey(t, m, string(c))
+	}
+	m.updateFilteredCommands()
+	result, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = result.(Model)
+	if cmd == nil {
+		t.Fatal("Expected edit-external to launch the editor")
+	}
+	result, _ = m.Update(cmd())
+	m = result.(Model)
+
+	if m.FileModel.Todos[0].Text != "From the palette" {
+		t.Errorf("Expected todo updated from palette command, got %q", m.FileModel.Todos[0].Text)
+	}
+}
@@ -0,0 +1,80 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mattn/go-runewidth"
+)
+
+func fileInfoModel(t *testing.T, content string) Model {
+	t.Helper()
+	m := testModelWithMarkdown(content)
+	m.config.Display.ShowFilename = true
+	m.FilePath = filepath.Join(t.TempDir(), "groceries.md")
+	m.TermWidth = 80
+	return m
+}
+
+func statusLine(m Model) string {
+	lines := strings.Split(strings.TrimRight(m.View(), "\n"), "\n")
+	return lines[len(lines)-1]
+}
+
+func TestFileInfo_BasenameAndCount(t *testing.T) {
+	m := fileInfoModel(t, "- [ ] Milk\n- [x] Eggs\n- [ ] Bread\n")
+
+	line := statusLine(m)
+	if !strings.Contains(line, "groceries.md") || !strings.Contains(line, "3 todos") {
+		t.Errorf("Expected filename and count in status bar:\n%s", line)
+	}
+	if !strings.HasSuffix(line, "3 todos") || runewidth.StringWidth(line) != 80 {
+		t.Errorf("Expected info right-aligned at width 80, got width %d:\n%s", runewidth.StringWidth(line), line)
+	}
+}
+
+func TestFileInfo_FilteredCount(t *testing.T) {
+	m := fileInfoModel(t, "- [ ] Milk\n- [x] Eggs\n- [ ] Bread\n")
+	m.FilterDone = true
+
+	if line := statusLine(m); !strings.Contains(line, "2/3 shown") {
+		t.Errorf("Expected filtered count:\n%s", line)
+	}
+}
+
+func TestFileInfo_SingularAndHomePath(t *testing.T) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		t.Skip("no home directory")
+	}
+	m := fileInfoModel(t, "- [ ] Milk\n")
+	m.FilePath = filepath.Join(home, "notes", "todo.md")
+
+	if line := statusLine(m); !strings.Contains(line, "~/notes/todo.md · 1 todo") {
+		t.Errorf("Expected ~ path and singular count:\n%s", line)
+	}
+}
+
+func TestFileInfo_LongPathShortenedFromLeft(t *testing.T) {
+	m := fileInfoModel(t, "- [ ] Milk\n")
+	m.FilePath = "/" + strings.Repeat("very-long-directory/", 5) + "groceries.md"
+
+	line := statusLine(m)
+	if !strings.Contains(line, "…") || !strings.Contains(line, "groceries.md · 1 todo") {
+		t.Errorf("Expected path shortened from the left:\n%s", line)
+	}
+	if runewidth.StringWidth(line) != 80 {
+		t.Errorf("Status bar width = %d, want 80", runewidth.StringWidth(line))
+	}
+}
+
+func TestFileInfo_Disabled(t *testing.T) {
+	m := fileInfoModel(t, "- [ ] Milk\n")
+	m.config.Display.ShowFilename = false
+
+	if line := statusLine(m); strings.Contains(line, "groceries.md") || strings.Contains(line, "1 todo") {
+		t.Errorf("File info should be hidden:\n%s", line)
+	}
+}
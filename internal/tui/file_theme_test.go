@@ -0,0 +1,93 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/niklas-heer/tdx/internal/markdown"
+)
+
+// withThemes installs a theme function that only knows dracula, whose styles tag their output
+func withThemes(t *testing.T) {
+	t.Helper()
+	oldApply, oldName := ThemeApplyFunc, CurrentThemeName
+	ThemeApplyFunc = func(name string) *StyleFuncsType {
+		if name != "dracula" {
+			return nil
+		}
+		styles := testStyles()
+		styles.Magenta = func(s string) string { return "dracula:" + s }
+		return styles
+	}
+	CurrentThemeName = "tokyo-night"
+	t.Cleanup(func() { ThemeApplyFunc, CurrentThemeName = oldApply, oldName })
+}
+
+func modelFromFile(content string) Model {
+	metadata, body, _ := markdown.ParseMetadata(content)
+	fm := markdown.ParseMarkdown(body)
+	fm.Metadata = metadata
+	return New("/tmp/test.md", fm, false, false, -1, testConfig(), testStyles(), "test")
+}
+
+func TestFileTheme_AppliedFromFrontmatter(t *testing.T) {
+	withThemes(t)
+	m := modelFromFile("---\ntheme: dracula\n---\n# Todos\n\n- [ ] Task\n")
+
+	if got := m.Styles().Magenta("x"); got != "dracula:x" {
+		t.Errorf("Magenta(x) = %q, want styles from the dracula theme", got)
+	}
+	if m.CurrentThemeName != "dracula" {
+		t.Errorf("CurrentThemeName = %q, want dracula", m.CurrentThemeName)
+	}
+	if m.Err != nil {
+		t.Errorf("Unexpected error: %v", m.Err)
+	}
+}
+
+func TestFileTheme_UnknownFallsBack(t *testing.T) {
+	withThemes(t)
+	m := modelFromFile("---\ntheme: neon\n---\n# Todos\n\n- [ ] Task\n")
+
+	if got := m.Styles().Magenta("x"); got == "dracula:x" {
+		t.Errorf("Magenta(x) = %q, want the configured styles", got)
+	}
+	if m.CurrentThemeName != "tokyo-night" {
+		t.Errorf("CurrentThemeName = %q, want tokyo-night", m.CurrentThemeName)
+	}
+	if m.Err == nil || !strings.Contains(m.Err.Error(), "neon") {
+		t.Errorf("Err = %v, want a warning about the unknown theme", m.Err)
+	}
+}
+
+func TestFileTheme_NoneKeepsConfigured(t *testing.T) {
+	withThemes(t)
+	m := modelFromFile("# Todos\n\n- [ ] Task\n")
+
+	if m.CurrentThemeName != "tokyo-night" || m.Err != nil {
+		t.Errorf("CurrentThemeName = %q, Err = %v; want configured theme and no warning", m.CurrentThemeName, m.Err)
+	}
+}
+
+func TestFrontmatterWarning_ShownOnLoad(t *testing.T) {
+	m := modelFromFile("---\nfilter-done: true\nfilter-done: false\n---\n# Todos\n\n- [x] Done\n- [ ] Open\n")
+
+	if m.FilterDone {
+		t.Error("FilterDone should use the last value (false)")
+	}
+	if !strings.Contains(m.StatusHint, "duplicate key \"filter-done\"") {
+		t.Errorf("StatusHint = %q, want a duplicate key warning", m.StatusHint)
+	}
+	if m.Err != nil {
+		t.Errorf("Duplicate keys should not be an error: %v", m.Err)
+	}
+	if !strings.Contains(m.renderStatusBar(), "duplicate key") {
+		t.Errorf("Expected the warning in the status bar:\n%s", m.renderStatusBar())
+	}
+
+	// Any key dismisses the warning
+	m = pressKey(t, m, "j")
+	if m.StatusHint != "" {
+		t.Errorf("StatusHint = %q, want it dismissed", m.StatusHint)
+	}
+}
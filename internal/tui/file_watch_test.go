@@ -0,0 +1,187 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/niklas-heer/tdx/internal/markdown"
+)
+
+// watchFixture opens a model on a temp file
+func watchFixture(t *testing.T, content string) (Model, string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "todo.md")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	fm, err := markdown.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return New(path, fm, false, false, -1, testConfig(), testStyles(), "test"), path
+}
+
+// writeExternally changes the file with a modification time the model will notice
+func writeExternally(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	later := time.Now().Add(5 * time.Second)
+	if err := os.Chtimes(path, later, later); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFileWatch_TickEmitsFileChangedMsg(t *testing.T) {
+	if fileWatchInterval > 2*time.Second {
+		t.Fatalf("fileWatchInterval = %v, expected a short poll", fileWatchInterval)
+	}
+	if _, ok := watchFileChanges()().(FileChangedMsg); !ok {
+		t.Error("watchFileChanges should produce a FileChangedMsg")
+	}
+}
+
+func TestFileWatch_ReloadsExternalChanges(t *testing.T) {
+	m, path := watchFixture(t, "# Todos\n\n- [ ] Task A\n")
+	writeExternally(t, path, "# Todos\n\n- [ ] Task A\n- [ ] Task B #new\n")
+
+	result, cmd := m.Update(FileChangedMsg{})
+	m = result.(Model)
+
+	if len(m.FileModel.Todos) != 2 || m.FileModel.Todos[1].Text != "Task B #new" {
+		t.Errorf("Expected external todo to be merged, got %+v", m.FileModel.Todos)
+	}
+	if len(m.AvailableTags) != 1 || m.AvailableTags[0] != "new" {
+		t.Errorf("AvailableTags = %v, want [new]", m.AvailableTags)
+	}
+	if cmd == nil {
+		t.Error("Watching should continue after a reload")
+	}
+}
+
+func TestFileWatch_KeepsLocalToggles(t *testing.T) {
+	m, path := watchFixture(t, "# Todos\n\n- [ ] Task A\n")
+	m.ReadOnly = true
+	m = pressKey(t, m, " ") // toggled in memory only
+	m.ReadOnly = false
+
+	writeExternally(t, path, "# Todos\n\n- [ ] Task A\n- [ ] Task B\n")
+	result, _ := m.Update(FileChangedMsg{})
+	m = result.(Model)
+
+	if len(m.FileModel.Todos) != 2 || !m.FileModel.Todos[0].Checked {
+		t.Errorf("Expected merge of local toggle and external todo, got %+v", m.FileModel.Todos)
+	}
+}
+
+func TestFileWatch_DefersReloadWhileEditing(t *testing.T) {
+	m, path := watchFixture(t, "# Todos\n\n- [ ] Task A\n")
+	m = pressKey(t, m, "e")
+	if !m.EditMode {
+		t.Fatal("Expected edit mode")
+	}
+
+	writeExternally(t, path, "# Todos\n\n- [ ] Task A\n- [ ] Task B\n")
+	result, cmd := m.Update(FileChangedMsg{})
+	m = result.(Model)
+
+	if len(m.FileModel.Todos) != 1 {
+		t.Errorf("Reload should wait until editing is done, got %d todos", len(m.FileModel.Todos))
+	}
+	if cmd == nil {
+		t.Error("Watching should continue while editing")
+	}
+
+	// After leaving edit mode the next check picks up the change
+	m = pressKeyType(t, m, tea.KeyEsc)
+	result, _ = m.Update(FileChangedMsg{})
+	m = result.(Model)
+	if len(m.FileModel.Todos) != 2 {
+		t.Errorf("Expected deferred reload after editing, got %d todos", len(m.FileModel.Todos))
+	}
+}
+
+func TestFileWatch_ReadOnlyDoesNotReload(t *testing.T) {
+	m, path := watchFixture(t, "# Todos\n\n- [ ] Task A\n")
+	m.ReadOnly = true
+
+	writeExternally(t, path, "# Todos\n\n- [ ] Changed\n")
+	result, _ := m.Update(FileChangedMsg{})
+	m = result.(Model)
+
+	if m.FileModel.Todos[0].Text != "Task A" {
+		t.Errorf("Read-only model should not reload, got %q", m.FileModel.Todos[0].Text)
+	}
+}
+
+func TestFileWatch_UndoIsNotReverted(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "todo.md")
+	if err := os.WriteFile(path, []byte("# Todos\n\n- [ ] Task A\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	earlier := time.Now().Add(-10 * time.Second)
+	if err := os.Chtimes(path, earlier, earlier); err != nil {
+		t.Fatal(err)
+	}
+	fm, err := markdown.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := New(path, fm, false, false, -1, testConfig(), testStyles(), "test")
+
+	m = pressKey(t, m, " ")
+	m = pressKey(t, m, "u")
+	result, _ := m.Update(FileChangedMsg{})
+	m = result.(Model)
+
+	if m.FileModel.Todos[0].Checked {
+		t.Error("Undo should not be reverted by the file watcher")
+	}
+	if disk, _ := markdown.ReadFile(path); disk.Todos[0].Checked {
+		t.Error("Undo should be written to disk")
+	}
+}
+
+func TestFileWatch_CursorFollowsSelectedTodo(t *testing.T) {
+	m, path := watchFixture(t, "# Todos\n\n- [ ] Task A\n- [ ] Task B\n- [ ] Task C\n")
+	m.SelectedIndex = 1 // Task B
+
+	writeExternally(t, path, "# Todos\n\n- [ ] New first\n- [ ] Task A\n- [ ] Added above\n- [ ] Task B\n- [ ] Task C\n")
+	result, _ := m.Update(FileChangedMsg{})
+	m = result.(Model)
+
+	if got := m.FileModel.Todos[m.SelectedIndex].Text; got != "Task B" {
+		t.Errorf("Selected %q (index %d), want Task B", got, m.SelectedIndex)
+	}
+}
+
+func TestFileWatch_CursorNearestWhenSelectedRemoved(t *testing.T) {
+	m, path := watchFixture(t, "# Todos\n\n- [ ] Task A\n- [ ] Task B\n- [ ] Task C\n")
+	m.SelectedIndex = 2 // Task C
+
+	writeExternally(t, path, "# Todos\n\n- [ ] Task A\n- [ ] Task B\n")
+	result, _ := m.Update(FileChangedMsg{})
+	m = result.(Model)
+
+	if m.SelectedIndex != 1 {
+		t.Errorf("SelectedIndex = %d, want 1 (nearest remaining todo)", m.SelectedIndex)
+	}
+}
+
+func TestRelocateSelection_PrefersNearestDuplicate(t *testing.T) {
+	m := testModelWithMarkdown("- [ ] Call\n- [ ] Other\n- [ ] Other\n- [ ] Call\n")
+
+	if got := m.relocateSelection("Call", 2); got != 3 {
+		t.Errorf("relocateSelection(Call, 2) = %d, want 3", got)
+	}
+	if got := m.relocateSelection("Call", 1); got != 0 {
+		t.Errorf("relocateSelection(Call, 1) = %d, want 0", got)
+	}
+	if got := m.relocateSelection("Missing", 9); got != 3 {
+		t.Errorf("relocateSelection(Missing, 9) = %d, want 3", got)
+	}
+}
@@ -0,0 +1,122 @@
+package tui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/niklas-heer/tdx/internal/markdown"
+)
+
+// foldAll collapses every heading section, leaving only the heading lines.
+// The selection moves to the nearest todo that is still visible, if any.
+func (m *Model) foldAll() {
+	m.FoldedHeadings = make(map[int]bool)
+	for i := range m.GetHeadings() {
+		m.FoldedHeadings[i] = true
+	}
+	m.InvalidateDocumentTree()
+	// Keep the selection when everything is folded so it is marked on its heading.
+	// Keys that act on the selected todo are ignored until it is unfolded.
+	if len(m.getVisibleTodos()) > 0 {
+		m.adjustSelectionForFilter()
+	}
+}
+
+// unfoldAll expands every collapsed heading section
+func (m *Model) unfoldAll() {
+	m.FoldedHeadings = nil
+	m.InvalidateDocumentTree()
+}
+
+// unfoldSection expands the section containing the todo at idx
+func (m *Model) unfoldSection(idx int) {
+	if h := m.sectionHeading(idx); h >= 0 {
+		delete(m.FoldedHeadings, h)
+		m.InvalidateDocumentTree()
+	}
+}
+
+// sectionHeading returns the index of the heading directly above the todo at idx,
+// or -1 if the todo comes before the first heading
+func (m *Model) sectionHeading(idx int) int {
+	section := -1
+	for i, h := range m.GetHeadings() {
+		if h.BeforeTodoIndex > idx {
+			break
+		}
+		section = i
+	}
+	return section
+}
+
+// isTodoFolded reports whether the todo at idx is hidden in a collapsed section.
+// Sections only fold while headings are shown.
+func (m *Model) isTodoFolded(idx int) bool {
+	if !m.ShowHeadings || len(m.FoldedHeadings) == 0 {
+		return false
+	}
+	h := m.sectionHeading(idx)
+	return h >= 0 && m.FoldedHeadings[h]
+}
+
+// selectionKeys act on the selected todo
+var selectionKeys = []string{" ", "enter", "T", "e", "E", "a", "d", "c", "s", "m", "y", "tab", "shift+tab"}
+
+// blockedByFold reports whether key would act on a selected todo that is
+// folded away, and says so in the status bar
+func (m *Model) blockedByFold(key string) bool {
+	if len(m.FileModel.Todos) == 0 || !m.isTodoFolded(m.SelectedIndex) || !slices.Contains(selectionKeys, key) {
+		return false
+	}
+	m.setStatus("The selected todo is folded (:unfold-all to show it)")
+	return true
+}
+
+// renderHeadingLine renders the heading at index i of headings. Collapsed
+// headings show how many todos they hide, and carry the selection marker when
+// the selected todo is folded away beneath them.
+func (m Model) renderHeadingLine(headings []markdown.Heading, i int) string {
+	styles := m.Styles()
+	heading := headings[i]
+	line := styles.Cyan(strings.Repeat("#", heading.Level) + " " + heading.Text)
+
+	// Headings line up with the arrow column
+	arrowWidth := m.arrowWidth()
+	if !m.FoldedHeadings[i] {
+		return fmt.Sprintf("   %s%s\n", strings.Repeat(" ", arrowWidth), line)
+	}
+
+	end := len(m.FileModel.Todos)
+	if i+1 < len(headings) {
+		end = headings[i+1].BeforeTodoIndex
+	}
+	if hidden := end - heading.BeforeTodoIndex; hidden > 0 {
+		line += styles.Dim(fmt.Sprintf(" [+%d]", hidden))
+	}
+
+	marker := strings.Repeat(" ", arrowWidth)
+	if !m.InputMode && m.isTodoFolded(m.SelectedIndex) && m.sectionHeading(m.SelectedIndex) == i {
+		selectMarker := " " + m.Config().Display.SelectMarker + " "
+		marker = styles.Cyan(selectMarker) + padCell(selectMarker, arrowWidth)
+	}
+	return fmt.Sprintf("   %s%s\n", marker, line)
+}
+
+// sectionRule returns the separator line drawn before a heading, if any. The
+// rule spans the terminal from the heading indentation; 40 cells when the
+// width isn't known yet.
+func (m Model) sectionRule() string {
+	switch m.Config().Display.SectionRule {
+	case SectionRuleLine:
+		width := 40
+		if m.TermWidth > 0 {
+			width = max(m.TermWidth-6, 1)
+		}
+		return "      " + m.Styles().Dim(strings.Repeat("─", width)) + "\n"
+	case SectionRuleBlank:
+		return "\n"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,165 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const foldMarkdown = "## Work\n\n- [ ] Write report\n- [ ] Review PR\n\n## Home\n\n- [ ] Groceries\n\n## Later\n\n- [ ] Read book\n"
+
+func foldedModel(t *testing.T) Model {
+	t.Helper()
+	m := testModelWithMarkdown(foldMarkdown)
+	m.ShowHeadings = true
+	m.TermWidth = 80
+	executeCommand(&m, "fold-all")
+	return m
+}
+
+func TestFoldAll_ShowsOnlyHeadings(t *testing.T) {
+	m := foldedModel(t)
+	view := m.View()
+
+	for _, heading := range []string{"## Work", "## Home", "## Later"} {
+		if !strings.Contains(view, heading) {
+			t.Errorf("Expected %q in folded view:\n%s", heading, view)
+		}
+	}
+	for _, text := range todoTexts(m) {
+		if strings.Contains(view, text) {
+			t.Errorf("Expected %q to be folded away:\n%s", text, view)
+		}
+	}
+	if strings.Contains(view, "No todos match") {
+		t.Errorf("Folded view should not report empty filters:\n%s", view)
+	}
+	if !strings.Contains(view, "[+2]") {
+		t.Errorf("Expected hidden count on the Work heading:\n%s", view)
+	}
+}
+
+func TestFoldAll_MarksSelectedSection(t *testing.T) {
+	m := testModelWithMarkdown(foldMarkdown)
+	m.ShowHeadings = true
+	m.SelectedIndex = 2 // Groceries
+	executeCommand(&m, "fold-all")
+
+	if m.SelectedIndex != 2 {
+		t.Errorf("SelectedIndex = %d, want 2 kept while everything is folded", m.SelectedIndex)
+	}
+	marker := m.Config().Display.SelectMarker
+	for _, line := range strings.Split(m.View(), "\n") {
+		if strings.Contains(line, "## Home") && !strings.Contains(line, marker) {
+			t.Errorf("Expected selection marker on the Home heading: %q", line)
+		}
+		if strings.Contains(line, "## Work") && strings.Contains(line, marker) {
+			t.Errorf("Unexpected selection marker on the Work heading: %q", line)
+		}
+	}
+}
+
+func TestFoldAll_SnapsToVisibleTodo(t *testing.T) {
+	m := testModelWithMarkdown("- [ ] Inbox item\n\n## Work\n\n- [ ] Write report\n")
+	m.ShowHeadings = true
+	m.SelectedIndex = 1
+	executeCommand(&m, "fold-all")
+
+	if m.SelectedIndex != 0 {
+		t.Errorf("SelectedIndex = %d, want 0 (the todo above the first heading)", m.SelectedIndex)
+	}
+	view := m.View()
+	if !strings.Contains(view, "Inbox item") || strings.Contains(view, "Write report") {
+		t.Errorf("Expected only the todo outside any section to remain:\n%s", view)
+	}
+}
+
+func TestUnfoldAll_RestoresTodos(t *testing.T) {
+	m := foldedModel(t)
+	executeCommand(&m, "unfold-all")
+
+	view := m.View()
+	for _, text := range todoTexts(m) {
+		if !strings.Contains(view, text) {
+			t.Errorf("Expected %q after unfold-all:\n%s", text, view)
+		}
+	}
+}
+
+func TestFoldAll_RequiresHeadings(t *testing.T) {
+	m := testModelWithMarkdown(foldMarkdown)
+	executeCommand(&m, "fold-all")
+
+	if len(m.FoldedHeadings) != 0 {
+		t.Errorf("FoldedHeadings = %v, want none while headings are hidden", m.FoldedHeadings)
+	}
+	if len(m.getVisibleTodos()) != 4 {
+		t.Errorf("Visible todos = %v, want all 4", m.getVisibleTodos())
+	}
+}
+
+func TestFoldAll_SearchUnfoldsSection(t *testing.T) {
+	m := foldedModel(t)
+
+	m = pressKey(t, m, "/")
+	m.InputBuffer = "groceries"
+	m.updateSearchResults()
+	m = pressKeyType(t, m, tea.KeyEnter)
+
+	if m.SelectedIndex != 2 {
+		t.Fatalf("SelectedIndex = %d, want 2 (Groceries)", m.SelectedIndex)
+	}
+	if !m.isTodoVisible(2) {
+		t.Error("Expected the Home section to be unfolded after selecting a search result in it")
+	}
+	if m.isTodoVisible(0) {
+		t.Error("Expected other sections to stay folded")
+	}
+}
+
+func TestFoldAll_ProgressCountsFoldedTodos(t *testing.T) {
+	m := foldedModel(t)
+	if _, total, _ := m.progressCounts(); total != 4 {
+		t.Errorf("progress total = %d, want 4", total)
+	}
+}
+
+func TestFoldAll_IgnoresKeysOnFoldedSelection(t *testing.T) {
+	m := foldedModel(t)
+	before := m.FileModel.Todos
+
+	m = pressKey(t, m, "d")
+	m = pressKey(t, m, " ")
+
+	if len(m.FileModel.Todos) != len(before) {
+		t.Fatalf("d deleted a folded todo: %v", todoTexts(m))
+	}
+	if m.FileModel.Todos[m.SelectedIndex].Checked {
+		t.Error("space toggled a folded todo")
+	}
+	if !strings.Contains(m.StatusHint, "folded") {
+		t.Errorf("Expected a hint that the todo is folded, got %q", m.StatusHint)
+	}
+
+	executeCommand(&m, "unfold-all")
+	m = pressKey(t, m, " ")
+	if !m.FileModel.Todos[m.SelectedIndex].Checked {
+		t.Error("Expected space to toggle once the section is unfolded")
+	}
+}
+
+func TestFoldAll_SameHeadingTextFoldsSeparately(t *testing.T) {
+	m := testModelWithMarkdown("## Tasks\n\n- [ ] First\n\n## Tasks\n\n- [ ] Second\n")
+	m.ShowHeadings = true
+	executeCommand(&m, "fold-all")
+
+	m.unfoldSection(1)
+
+	if !m.isTodoVisible(1) {
+		t.Error("Expected the second Tasks section to be unfolded")
+	}
+	if m.isTodoVisible(0) {
+		t.Error("Expected the first Tasks section to stay folded")
+	}
+}
@@ -0,0 +1,70 @@
+package tui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// gotoTodo moves the cursor to the nth (1-based) todo of those shown, so
+// filters, folded sections and hidden done todos don't count
+func (m *Model) gotoTodo(n int) {
+	visible := m.getVisibleTodos()
+	switch {
+	case len(visible) == 0:
+		m.setStatus("No todos shown")
+	case n < 1 || n > len(visible):
+		m.setStatus(fmt.Sprintf("No todo %d (%d shown)", n, len(visible)))
+	default:
+		m.SelectedIndex = visible[n-1]
+		m.InvalidateDocumentTree()
+	}
+}
+
+// gotoArg handles ":goto N"
+func (m *Model) gotoArg(arg string) {
+	n, err := strconv.Atoi(strings.TrimSpace(arg))
+	if err != nil {
+		m.setStatus("Usage: goto <number>")
+		return
+	}
+	m.gotoTodo(n)
+}
+
+// startGotoInput opens the prompt for the number of the todo to jump to
+func (m *Model) startGotoInput() {
+	m.GotoInputMode = true
+	m.InputBuffer = ""
+	m.CursorPos = 0
+}
+
+func (m Model) handleGotoInputKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch key := msg.String(); key {
+	case "enter", "ctrl+m":
+		m.GotoInputMode = false
+		if m.InputBuffer != "" {
+			m.gotoArg(m.InputBuffer)
+		}
+		m.InputBuffer = ""
+
+	case "esc":
+		m.GotoInputMode = false
+		m.InputBuffer = ""
+
+	case "backspace", "ctrl+h":
+		if !m.deleteInputBefore() {
+			// Backspace on an empty prompt closes it
+			m.GotoInputMode = false
+		}
+
+	default:
+		// Only allow digits
+		if len(key) == 1 && key >= "0" && key <= "9" {
+			m.insertInput(key)
+		}
+	}
+
+	return m, nil
+}
@@ -0,0 +1,109 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/niklas-heer/tdx/internal/util"
+)
+
+const gotoMarkdown = `# Todos
+
+- [ ] One #work
+- [ ] Two
+- [ ] Three #work
+- [x] Four #work
+- [ ] Five
+- [ ] Six #work
+- [ ] Seven #work
+`
+
+func TestGoto_PipedCommandCountsFilteredTodos(t *testing.T) {
+	m := testModelWithMarkdown(gotoMarkdown)
+	m.FilteredTags = []string{"work"}
+	m.adjustSelectionForFilter()
+
+	m.ProcessPipedInput([]byte(":goto 3\r"))
+
+	// Shown are One, Three, Four, Six, Seven
+	if got := m.FileModel.Todos[m.SelectedIndex].Text; got != "Four #work" {
+		t.Errorf("Selected %q, want the third visible todo %q", got, "Four #work")
+	}
+}
+
+func TestGoto_SkipsHiddenDoneTodos(t *testing.T) {
+	m := testModelWithMarkdown(gotoMarkdown)
+	m.FilterDone = true
+
+	m = typeCommand(t, m, "goto 4")
+
+	if got := m.FileModel.Todos[m.SelectedIndex].Text; got != "Five" {
+		t.Errorf("Selected %q, want %q", got, "Five")
+	}
+}
+
+func TestGoto_PromptShowsNumberAndJumps(t *testing.T) {
+	m := testModelWithMarkdown(gotoMarkdown)
+	m.TermWidth, m.TermHeight = 100, 30
+
+	m = pressKey(t, m, ".")
+	m = pressKey(t, m, "1")
+	m = pressKey(t, m, "x") // Ignored, only digits are typed
+	m = pressKey(t, m, "2")
+	m = pressKeyType(t, m, tea.KeyBackspace)
+	m = pressKey(t, m, "6")
+
+	if !m.GotoInputMode {
+		t.Fatal("Expected the goto prompt to be open")
+	}
+	view := util.StripANSI(m.View())
+	if !strings.Contains(view, "GOTO") || !strings.Contains(view, "16") {
+		t.Errorf("Status bar should show the typed number, got:\n%s", view)
+	}
+
+	m = pressKey(t, m, "1")
+	m = pressKeyType(t, m, tea.KeyBackspace)
+	m = pressKeyType(t, m, tea.KeyBackspace)
+	m = pressKeyType(t, m, tea.KeyEnter)
+
+	if m.GotoInputMode {
+		t.Error("Expected enter to close the prompt")
+	}
+	if got := m.FileModel.Todos[m.SelectedIndex].Text; got != "One #work" {
+		t.Errorf("Selected %q, want %q", got, "One #work")
+	}
+}
+
+func TestGoto_EscCancels(t *testing.T) {
+	m := testModelWithMarkdown(gotoMarkdown)
+
+	m = pressKey(t, m, ".")
+	m = pressKey(t, m, "5")
+	m = pressKeyType(t, m, tea.KeyEsc)
+
+	if m.GotoInputMode || m.SelectedIndex != 0 {
+		t.Errorf("Esc should close the prompt without moving, got mode %v index %d", m.GotoInputMode, m.SelectedIndex)
+	}
+}
+
+func TestGoto_OutOfRange(t *testing.T) {
+	m := testModelWithMarkdown(gotoMarkdown)
+	m.FilteredTags = []string{"work"}
+	m.adjustSelectionForFilter()
+	before := m.SelectedIndex
+
+	m = typeCommand(t, m, "goto 9")
+
+	if m.SelectedIndex != before {
+		t.Errorf("SelectedIndex = %d, want it unchanged at %d", m.SelectedIndex, before)
+	}
+	if m.StatusHint != "No todo 9 (5 shown)" {
+		t.Errorf("StatusHint = %q, want %q", m.StatusHint, "No todo 9 (5 shown)")
+	}
+
+	m = typeCommand(t, m, "goto three")
+	if m.StatusHint != "Usage: goto <number>" {
+		t.Errorf("StatusHint = %q, want the usage", m.StatusHint)
+	}
+}
@@ -0,0 +1,46 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/niklas-heer/tdx/internal/markdown"
+)
+
+func TestInProgress_RenderedWithWarningStyle(t *testing.T) {
+	m := testModelWithMarkdown("- [-] Doing\n- [ ] Open\n")
+	styles := *testStyles()
+	styles.Yellow = func(s string) string { return "<yellow>" + s }
+	m.styles = &styles
+
+	if view := m.View(); !strings.Contains(view, "<yellow>[-] Doing") {
+		t.Errorf("Expected a yellow [-] checkbox:\n%s", view)
+	}
+}
+
+func TestInProgress_SpaceCyclesStates(t *testing.T) {
+	old := markdown.CycleProgress
+	markdown.CycleProgress = true
+	defer func() { markdown.CycleProgress = old }()
+
+	m := testModelWithMarkdown("- [ ] Task\n")
+
+	m = pressKey(t, m, " ")
+	if todo := m.FileModel.Todos[0]; !todo.InProgress || todo.Checked {
+		t.Fatalf("After one toggle: checked=%v inProgress=%v, want in progress", todo.Checked, todo.InProgress)
+	}
+	m = pressKey(t, m, " ")
+	if todo := m.FileModel.Todos[0]; todo.InProgress || !todo.Checked {
+		t.Fatalf("After two toggles: checked=%v inProgress=%v, want done", todo.Checked, todo.InProgress)
+	}
+	m = pressKey(t, m, " ")
+	if todo := m.FileModel.Todos[0]; todo.InProgress || todo.Checked {
+		t.Fatalf("After three toggles: checked=%v inProgress=%v, want open", todo.Checked, todo.InProgress)
+	}
+
+	// Undo goes back one step
+	m = pressKey(t, m, "u")
+	if !m.FileModel.Todos[0].Checked {
+		t.Error("Undo should restore the done state")
+	}
+}
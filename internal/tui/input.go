@@ -0,0 +1,66 @@
+package tui
+
+import (
+	"unicode/utf8"
+)
+
+// CursorPos counts runes, not bytes, so the helpers below never split a
+// multibyte character (emoji, CJK) in the input buffer.
+
+// inputLen returns the length of the input buffer in runes
+func (m Model) inputLen() int {
+	return utf8.RuneCountInString(m.InputBuffer)
+}
+
+// splitInput returns the input before and after the cursor
+func (m Model) splitInput() (before, after string) {
+	runes := []rune(m.InputBuffer)
+	pos := min(max(m.CursorPos, 0), len(runes))
+	return string(runes[:pos]), string(runes[pos:])
+}
+
+// insertInput inserts text at the cursor and moves the cursor past it
+func (m *Model) insertInput(text string) {
+	before, after := m.splitInput()
+	m.InputBuffer = before + text + after
+	m.CursorPos = utf8.RuneCountInString(before + text)
+}
+
+// deleteInputBefore removes the character before the cursor (backspace).
+// It reports whether anything was removed.
+func (m *Model) deleteInputBefore() bool {
+	runes := []rune(m.InputBuffer)
+	pos := min(max(m.CursorPos, 0), len(runes))
+	if pos == 0 {
+		return false
+	}
+	m.InputBuffer = string(runes[:pos-1]) + string(runes[pos:])
+	m.CursorPos = pos - 1
+	return true
+}
+
+// deleteInputAt removes the character under the cursor (delete)
+func (m *Model) deleteInputAt() {
+	runes := []rune(m.InputBuffer)
+	pos := min(max(m.CursorPos, 0), len(runes))
+	if pos < len(runes) {
+		m.InputBuffer = string(runes[:pos]) + string(runes[pos+1:])
+	}
+	m.CursorPos = pos
+}
+
+// moveInputCursor moves the cursor by delta characters, staying within the input
+func (m *Model) moveInputCursor(delta int) {
+	m.CursorPos = min(max(m.CursorPos+delta, 0), m.inputLen())
+}
+
+// cursorToInputEnd puts the cursor after the last character of the input
+func (m *Model) cursorToInputEnd() {
+	m.CursorPos = m.inputLen()
+}
+
+// isTypedChar reports whether the key is a single printable character to insert
+func isTypedChar(key string) bool {
+	r, size := utf8.DecodeRuneInString(key)
+	return size == len(key) && r != utf8.RuneError && r >= ' ' && r != 0x7f
+}
@@ -0,0 +1,122 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/niklas-heer/tdx/internal/util"
+)
+
+// editTodo starts editing the first todo with the cursor at the end
+func editTodo(t *testing.T, content string) Model {
+	t.Helper()
+	m := testModelWithMarkdown(content)
+	m = pressKey(t, m, "e")
+	if !m.EditMode {
+		t.Fatal("Expected edit mode")
+	}
+	return m
+}
+
+func TestEditInput_InsertWideCharacters(t *testing.T) {
+	m := editTodo(t, "# Todos\n\n- [ ] Buy\n")
+
+	for _, key := range []string{" ", "牛", "奶", " ", "🥛"} {
+		m = pressKey(t, m, key)
+	}
+
+	if m.InputBuffer != "Buy 牛奶 🥛" {
+		t.Errorf("InputBuffer = %q, want %q", m.InputBuffer, "Buy 牛奶 🥛")
+	}
+	if m.CursorPos != 8 {
+		t.Errorf("CursorPos = %d, want 8 (runes)", m.CursorPos)
+	}
+}
+
+func TestEditInput_NavigateAndDeleteByRune(t *testing.T) {
+	m := editTodo(t, "# Todos\n\n- [ ] 日本🎌語\n")
+	if m.CursorPos != 4 {
+		t.Fatalf("CursorPos = %d, want 4 after entering edit mode", m.CursorPos)
+	}
+
+	m = pressKeyType(t, m, tea.KeyLeft)
+	m = pressKeyType(t, m, tea.KeyLeft)
+	if before, _ := m.splitInput(); before != "日本" {
+		t.Errorf("Text before cursor = %q, want %q", before, "日本")
+	}
+
+	// Delete removes the whole emoji under the cursor
+	m = pressKeyType(t, m, tea.KeyDelete)
+	if m.InputBuffer != "日本語" {
+		t.Errorf("After delete, InputBuffer = %q, want %q", m.InputBuffer, "日本語")
+	}
+
+	// Backspace removes the whole character before the cursor
+	m = pressKeyType(t, m, tea.KeyBackspace)
+	if m.InputBuffer != "日語" || m.CursorPos != 1 {
+		t.Errorf("After backspace, InputBuffer = %q, CursorPos = %d", m.InputBuffer, m.CursorPos)
+	}
+
+	m = pressKey(t, m, "✓")
+	if m.InputBuffer != "日✓語" || m.CursorPos != 2 {
+		t.Errorf("After insert, InputBuffer = %q, CursorPos = %d", m.InputBuffer, m.CursorPos)
+	}
+
+	m = pressKeyType(t, m, tea.KeyRight)
+	m = pressKeyType(t, m, tea.KeyRight)
+	if m.CursorPos != 3 {
+		t.Errorf("Right past the end, CursorPos = %d, want 3", m.CursorPos)
+	}
+
+	m = pressKeyType(t, m, tea.KeyEnter)
+	if got := m.FileModel.Todos[0].Text; got != "日✓語" {
+		t.Errorf("Saved text = %q, want %q", got, "日✓語")
+	}
+}
+
+func TestEditInput_CursorRenderedBetweenCharacters(t *testing.T) {
+	m := editTodo(t, "# Todos\n\n- [ ] 牛奶🥛\n")
+	m = pressKeyType(t, m, tea.KeyLeft)
+
+	output := util.StripANSI(m.View())
+	if !strings.Contains(output, "牛奶 🥛") {
+		t.Errorf("Expected cursor between 奶 and 🥛:\n%s", output)
+	}
+	if strings.Contains(output, "\uFFFD") {
+		t.Errorf("Output contains a broken character:\n%s", output)
+	}
+}
+
+func TestEditInput_WrappedWideTextFitsWidth(t *testing.T) {
+	m := editTodo(t, "# Todos\n\n- [ ] "+strings.Repeat("漢字", 20)+"\n")
+	m.WordWrap = true
+	m.TermWidth = 40
+
+	for _, line := range strings.Split(util.StripANSI(m.View()), "\n") {
+		if w := util.VisibleWidth(line); w > m.TermWidth {
+			t.Errorf("Line is %d cells wide, want at most %d: %q", w, m.TermWidth, line)
+		}
+	}
+}
+
+func TestEditInput_StaleCursorDoesNotPanic(t *testing.T) {
+	m := editTodo(t, "# Todos\n\n- [ ] é\n")
+	m.CursorPos = 10 // Past the end, e.g. a byte offset
+
+	m = pressKeyType(t, m, tea.KeyBackspace)
+	if m.InputBuffer != "" || m.CursorPos != 0 {
+		t.Errorf("InputBuffer = %q, CursorPos = %d", m.InputBuffer, m.CursorPos)
+	}
+	_ = m.View()
+}
+
+func TestProcessPipedInput_TypesMultibyteCharacters(t *testing.T) {
+	m := testModelWithMarkdown("# Todos\n\n- [ ] Tea\n")
+
+	m.ProcessPipedInput([]byte("n茶 🍵\r"))
+
+	if got := todoTexts(m); len(got) != 2 || got[1] != "茶 🍵" {
+		t.Errorf("Todos = %q, want a new todo %q", got, "茶 🍵")
+	}
+}
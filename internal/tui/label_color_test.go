@@ -0,0 +1,61 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+	"github.com/niklas-heer/tdx/internal/markdown"
+	"github.com/niklas-heer/tdx/internal/util"
+)
+
+func TestLabelStyle_Palette(t *testing.T) {
+	if fg := LabelStyle("red").GetForeground(); fg != lipgloss.Color("1") {
+		t.Errorf("red foreground = %v, want ANSI 1", fg)
+	}
+	for _, color := range markdown.LabelColors {
+		if _, ok := labelColors[color]; !ok {
+			t.Errorf("No terminal color for @%s", color)
+		}
+	}
+}
+
+func TestView_LabelColorStylesLine(t *testing.T) {
+	old := lipgloss.ColorProfile()
+	lipgloss.SetColorProfile(termenv.ANSI)
+	t.Cleanup(func() { lipgloss.SetColorProfile(old) })
+
+	m := testModelWithMarkdown("# Todos\n\n- [ ] Call mom @red\n- [ ] Plain todo\n")
+	view := m.View()
+
+	if !strings.Contains(view, "\x1b[31mCall mom") {
+		t.Errorf("Expected the labelled todo in red:\n%q", view)
+	}
+	if strings.Contains(view, "\x1b[31mPlain") {
+		t.Errorf("Unlabelled todos should keep the default style:\n%q", view)
+	}
+	if strings.Contains(util.StripANSI(view), "@red") {
+		t.Errorf("The @red token should not be shown:\n%s", util.StripANSI(view))
+	}
+}
+
+func TestView_LabelColorShownWhileEditing(t *testing.T) {
+	m := testModelWithMarkdown("# Todos\n\n- [ ] Call mom @red\n")
+	m = pressKey(t, m, "e")
+
+	if !strings.Contains(util.StripANSI(m.View()), "Call mom @red") {
+		t.Error("Expected the @red token in the edit buffer")
+	}
+}
+
+func TestView_DoneStyleWinsOverLabelColor(t *testing.T) {
+	m := testModelWithMarkdown("# Todos\n\n- [x] Called mom @red\n")
+	styles := *testStyles()
+	styles.Magenta = func(s string) string { return "<done>" + s }
+	m.styles = &styles
+
+	if view := m.View(); !strings.Contains(view, "<done>Called mom") {
+		t.Errorf("Expected the done style on a completed labelled todo:\n%s", view)
+	}
+}
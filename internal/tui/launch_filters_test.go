@@ -0,0 +1,102 @@
+package tui
+
+import (
+	"slices"
+	"testing"
+)
+
+const launchFiltersMarkdown = `# Todos
+
+- [ ] Write report #work !p1
+- [x] Call client #work !p2
+- [ ] Buy milk #home !p1
+`
+
+func TestFilters_Add(t *testing.T) {
+	var f Filters
+	for _, flag := range [][2]string{{"--tag", "#work"}, {"--tag", "home"}, {"--tag", "work"}, {"--priority", "1"}, {"--priority", "p2"}} {
+		if err := f.Add(flag[0], flag[1]); err != nil {
+			t.Fatalf("Add(%q, %q) = %v", flag[0], flag[1], err)
+		}
+	}
+	if !slices.Equal(f.Tags, []string{"work", "home"}) {
+		t.Errorf("Tags = %v, want [work home]", f.Tags)
+	}
+	if !slices.Equal(f.Priorities, []int{1, 2}) {
+		t.Errorf("Priorities = %v, want [1 2]", f.Priorities)
+	}
+
+	for _, flag := range [][2]string{{"--priority", "high"}, {"--priority", "0"}, {"--tag", "#"}} {
+		if err := f.Add(flag[0], flag[1]); err == nil {
+			t.Errorf("Add(%q, %q) should fail", flag[0], flag[1])
+		}
+	}
+}
+
+func TestApplyLaunchFilters(t *testing.T) {
+	m := testModelWithMarkdown(launchFiltersMarkdown)
+	filterDone := true
+
+	m.applyLaunchFilters(Filters{Tags: []string{"work"}, Priorities: []int{1}, FilterDone: &filterDone})
+
+	if !slices.Equal(m.FilteredTags, []string{"work"}) || !slices.Equal(m.FilteredPriorities, []int{1}) || !m.FilterDone {
+		t.Fatalf("Filters = tags %v, priorities %v, filter done %v", m.FilteredTags, m.FilteredPriorities, m.FilterDone)
+	}
+	var visible []int
+	for i := range m.FileModel.Todos {
+		if m.isTodoVisible(i) {
+			visible = append(visible, i)
+		}
+	}
+	if !slices.Equal(visible, []int{0}) {
+		t.Errorf("Visible todos = %v, want [0]", visible)
+	}
+}
+
+func TestApplyLaunchFilters_KeepsUnknownTag(t *testing.T) {
+	m := testModelWithMarkdown(launchFiltersMarkdown)
+
+	m.applyLaunchFilters(Filters{Tags: []string{"nope"}})
+
+	if !slices.Equal(m.FilteredTags, []string{"nope"}) {
+		t.Errorf("FilteredTags = %v, want [nope]", m.FilteredTags)
+	}
+	for i := range m.FileModel.Todos {
+		if m.isTodoVisible(i) {
+			t.Errorf("Todo %d is visible, want none for an unknown tag", i)
+		}
+	}
+}
+
+func TestApplyLaunchFilters_EmptyKeepsState(t *testing.T) {
+	m := testModelWithMarkdown(launchFiltersMarkdown)
+	m.FilteredTags = []string{"home"}
+	m.FilterDone = true
+
+	m.applyLaunchFilters(Filters{})
+
+	if !slices.Equal(m.FilteredTags, []string{"home"}) || !m.FilterDone {
+		t.Errorf("Expected filters from the last session to stay, got tags %v, filter done %v", m.FilteredTags, m.FilterDone)
+	}
+}
+
+func TestApplyLaunchFilters_UnknownTagSurvivesRefresh(t *testing.T) {
+	m := testModelWithMarkdown(launchFiltersMarkdown)
+	m.applyLaunchFilters(Filters{Tags: []string{"nope"}})
+
+	// Opening the tag filter refreshes the available tags
+	m = pressKey(t, m, "t")
+	m = pressKey(t, m, "esc")
+	m.RefreshAvailableTags()
+
+	if !slices.Equal(m.FilteredTags, []string{"nope"}) {
+		t.Errorf("FilteredTags = %v, want [nope] kept after refreshing tags", m.FilteredTags)
+	}
+
+	// Restored state from the last session is still pruned
+	m.FilteredTags = []string{"nope", "gone"}
+	m.RefreshAvailableTags()
+	if !slices.Equal(m.FilteredTags, []string{"nope"}) {
+		t.Errorf("FilteredTags = %v, want only the launch tag kept", m.FilteredTags)
+	}
+}
@@ -0,0 +1,88 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/niklas-heer/tdx/internal/markdown"
+)
+
+// largeFileLines is the number of lines above which a file is parsed in the
+// background, with a loading message shown meanwhile
+const largeFileLines = 10000
+
+// largeFileTodos reports whether the file is large enough to load in the
+// background, and roughly how many todos it holds
+func largeFileTodos(filePath string) (int, bool) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return 0, false
+	}
+	content := string(data)
+	if strings.Count(content, "\n") <= largeFileLines {
+		return 0, false
+	}
+	return markdown.CountTodoLines(content), true
+}
+
+// fileLoadedMsg carries the model built in the background
+type fileLoadedMsg struct {
+	model Model
+	err   error
+}
+
+// loadingModel is shown while a large file is parsed. Once the file is loaded
+// it hands over to the real model.
+type loadingModel struct {
+	filePath string
+	todos    int
+	load     func() (Model, error)
+
+	width, height int
+	err           error
+}
+
+func newLoadingModel(filePath string, todos int, load func() (Model, error)) loadingModel {
+	return loadingModel{filePath: filePath, todos: todos, load: load}
+}
+
+// Init starts parsing the file without blocking the program
+func (m loadingModel) Init() tea.Cmd {
+	load := m.load
+	return func() tea.Msg {
+		model, err := load()
+		return fileLoadedMsg{model: model, err: err}
+	}
+}
+
+func (m loadingModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" || msg.String() == "q" || msg.String() == "esc" {
+			return m, tea.Quit
+		}
+	case fileLoadedMsg:
+		if msg.err != nil {
+			m.err = msg.err
+			return m, tea.Quit
+		}
+		// The size arrived before the model existed
+		model := msg.model
+		if m.width > 0 {
+			model.TermWidth, model.TermHeight = m.width, m.height
+		}
+		return model, model.Init()
+	}
+	return m, nil
+}
+
+func (m loadingModel) View() string {
+	if m.err != nil {
+		return ""
+	}
+	return fmt.Sprintf("Large file (%d todos) — loading %s…\n", m.todos, tildePath(m.filePath))
+}
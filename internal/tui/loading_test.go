@@ -0,0 +1,124 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/niklas-heer/tdx/internal/config"
+)
+
+// writeLargeFile writes a todo file with n todos and a note under each, so it has 2n+2 lines
+func writeLargeFile(t *testing.T, n int) string {
+	t.Helper()
+	var b strings.Builder
+	b.WriteString("# Todos\n\n")
+	for i := 0; i < n; i++ {
+		fmt.Fprintf(&b, "- [ ] Task %d #tag%d\n  Note %d\n", i, i%5, i)
+	}
+	path := filepath.Join(t.TempDir(), "large.md")
+	if err := os.WriteFile(path, []byte(b.String()), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLargeFileTodos(t *testing.T) {
+	if _, large := largeFileTodos(writeLargeFile(t, 100)); large {
+		t.Error("A 100 todo file shouldn't count as large")
+	}
+	if _, large := largeFileTodos(filepath.Join(t.TempDir(), "missing.md")); large {
+		t.Error("A missing file shouldn't count as large")
+	}
+
+	todos, large := largeFileTodos(writeLargeFile(t, 6000))
+	if !large || todos != 6000 {
+		t.Errorf("largeFileTodos = %d, %v, want 6000, true", todos, large)
+	}
+}
+
+func TestLoadingModel_InitDoesNotBlock(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	lm := newLoadingModel("/tmp/large.md", 12000, func() (Model, error) {
+		<-release // Parsing takes until the test is done
+		return Model{}, nil
+	})
+
+	done := make(chan tea.Cmd)
+	go func() { done <- lm.Init() }()
+	select {
+	case cmd := <-done:
+		if cmd == nil {
+			t.Fatal("Expected Init to return the loading command")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Init blocked on parsing the file")
+	}
+
+	if view := lm.View(); !strings.Contains(view, "Large file (12000 todos)") || !strings.Contains(view, "loading") {
+		t.Errorf("View = %q, want the loading message", view)
+	}
+}
+
+func TestLoadingModel_SwapsInLoadedModel(t *testing.T) {
+	config.SetConfigDirForTesting(t.TempDir())
+	defer config.ResetConfigDirForTesting()
+
+	path := writeLargeFile(t, 6000)
+	todos, large := largeFileTodos(path)
+	if !large {
+		t.Fatal("Expected the synthetic file to be large")
+	}
+	var lm tea.Model = newLoadingModel(path, todos, func() (Model, error) {
+		return loadModel(path, false, false, -1, -1)
+	})
+
+	lm, _ = lm.Update(tea.WindowSizeMsg{Width: 100, Height: 30})
+	msg := lm.Init()()
+	next, cmd := lm.Update(msg)
+
+	m, ok := next.(Model)
+	if !ok {
+		t.Fatalf("Expected the loaded model, got %T", next)
+	}
+	if len(m.FileModel.Todos) != 6000 {
+		t.Errorf("Loaded %d todos, want 6000", len(m.FileModel.Todos))
+	}
+	if m.TermWidth != 100 || m.TermHeight != 30 {
+		t.Errorf("Size = %dx%d, want the size sent while loading", m.TermWidth, m.TermHeight)
+	}
+	if cmd == nil {
+		t.Error("Expected the loaded model's Init command")
+	}
+}
+
+func TestLoadingModel_LoadError(t *testing.T) {
+	lm := newLoadingModel("/tmp/large.md", 0, func() (Model, error) {
+		return Model{}, errors.New("boom")
+	})
+
+	next, cmd := lm.Update(lm.Init()())
+
+	if got, ok := next.(loadingModel); !ok || got.err == nil {
+		t.Errorf("Expected the loading model to keep the error, got %T", next)
+	}
+	if cmd == nil {
+		t.Error("Expected the program to quit on a load error")
+	}
+}
+
+func TestLoadingModel_QuitWhileLoading(t *testing.T) {
+	lm := newLoadingModel("/tmp/large.md", 0, nil)
+
+	_, cmd := lm.Update(tea.KeyMsg{Type: tea.KeyCtrlC})
+
+	if cmd == nil {
+		t.Error("Expected ctrl+c to quit while loading")
+	}
+}
@@ -1,6 +1,9 @@
 package tui
 
 import (
+	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -17,28 +20,52 @@ type StyleFuncsType struct {
 	Yellow  func(string) string
 	Code    func(string) string
 
-	// Style functions for tags, priorities, and due dates
+	// Style functions for tags, contexts, projects, priorities, due dates, and time tokens
 	Tag            func(string) string
+	Context        func(string) string
+	Project        func(string) string
 	PriorityHigh   func(string) string
 	PriorityMedium func(string) string
 	PriorityLow    func(string) string
+	Priorities     []func(string) string // Configured styles for !p1, !p2, ...; later levels use the last one
 	DueUrgent      func(string) string
 	DueSoon        func(string) string
 	DueFuture      func(string) string
+	Time           func(string) string // est: and spent: time tracking tokens
+}
+
+// priorityStyles returns the styles for each priority level, starting with !p1.
+// Without configured levels these are the high, medium and low styles.
+func (s *StyleFuncsType) priorityStyles() []func(string) string {
+	if len(s.Priorities) > 0 {
+		return s.Priorities
+	}
+	return []func(string) string{s.PriorityHigh, s.PriorityMedium, s.PriorityLow}
 }
 
 // ConfigType holds display configuration
 type ConfigType struct {
+	HistoryFile string // Completion log that checked todos are appended to, "" for none
+
 	Display struct {
 		CheckSymbol  string
 		SelectMarker string
 		MaxVisible   int
+		ShowProgress bool
+		ShowFilename bool
+		StatusHints  string // "full", "minimal" or "none"
+		DoneStyle    string // How completed todos look, one of the DoneStyle* constants
+		ScrollMargin int    // Rows kept between the cursor and the window edges (0 = keep the cursor centered)
+		SectionRule  string // Separator before headings, one of the SectionRule* constants
 	}
 	Defaults struct {
 		WordWrap     bool
 		FilterDone   bool
 		ShowHeadings bool
 		ReadOnly     bool
+
+		ConfirmDeleteParent bool
+		ConfirmQuit         bool
 	}
 }
 
@@ -60,6 +87,7 @@ type Model struct {
 	FilePath            string
 	FileModel           markdown.FileModel
 	SelectedIndex       int
+	scrollTop           int // First list position shown, kept while the scroll margin allows
 	SavedCursorIndex    int // Saved cursor position for move mode cancel
 	InputMode           bool
 	InsertAfterCursor   bool // true = insert after cursor (n), false = append to end (N)
@@ -70,15 +98,24 @@ type Model struct {
 	CommandMode         bool
 	RecentFilesMode     bool
 	MaxVisibleInputMode bool
+	GotoInputMode       bool // Typing the number of the todo to jump to
+	NoteMode            bool // Typing a note for the selected todo
+	ConfirmDeleteMode   bool // Asking whether to delete the subtasks of the selected todo
+	ConfirmQuitMode     bool // Asking whether to quit after esc
+	Unsaved             bool // Changes made in read-only mode that weren't written to the file
 	SearchResults       []int
 	SearchCursor        int
+	SearchHistory       []string // Previous search queries, most recent first
 	InputBuffer         string
 	CursorPos           int
 	NumberBuffer        string
 	History             *markdown.FileModel
 
-	CopyFeedback bool
-	Err          error
+	StatusHint  string // Brief message shown in the status bar, see setStatus
+	statusSeq   int    // Incremented by setStatus so only the latest message is cleared
+	statusOK    bool   // StatusHint reports a success (green) rather than a warning (yellow)
+	loadWarning bool   // StatusHint holds a warning from loading the file, dismissed by any key
+	Err         error
 
 	// Command palette state
 	Commands           []Command
@@ -92,6 +129,9 @@ type Model struct {
 	HideLineNumbers    bool
 	MaxVisibleOverride int
 	ShowHeadings       bool
+	FoldedHeadings     map[int]bool // Positions (in GetHeadings) of headings whose sections are collapsed
+	ShowProgress       bool         // Show completion progress in the status bar
+	StatusHints        string       // What the status bar shows in normal mode: full, minimal or none
 
 	// Track which todos we've locally modified (by text) since last sync
 	LocallyModified map[string]bool // todo text -> true if we toggled it
@@ -101,6 +141,19 @@ type Model struct {
 	FilteredTags    []string // Currently active tag filters
 	AvailableTags   []string // All unique tags from todos
 	TagFilterCursor int      // Cursor position in tag filter list
+	launchTags      []string // Tags from --tag, kept as filters even if no todo has them
+
+	// Context filtering state (@home, @phone)
+	ContextFilterMode   bool     // Whether we're in context filter mode
+	FilteredContexts    []string // Currently active context filters
+	AvailableContexts   []string // All unique contexts from todos
+	ContextFilterCursor int      // Cursor position in context filter list
+
+	// Project filtering state (+website)
+	ProjectFilterMode   bool     // Whether we're in project filter mode
+	FilteredProjects    []string // Currently active project filters
+	AvailableProjects   []string // All unique projects from todos
+	ProjectFilterCursor int      // Cursor position in project filter list
 
 	// Priority filtering state
 	PriorityFilterMode   bool  // Whether we're in priority filter mode
@@ -134,8 +187,35 @@ type Model struct {
 	// Search debouncing
 	searchPending bool // Whether a search update is pending
 
+	// Search history browsing
+	searchHistoryIdx int      // Position in SearchHistory while recalling queries, -1 otherwise
+	newSearches      []string // Queries searched in this session, saved on exit
+
+	// Search scope, set when the search starts
+	searchScope     []int  // Todos a scoped search looks at, nil when it covers all todos
+	searchScopeName string // Describes the scope in the status bar, e.g. "section"
+	searchAll       bool   // ctrl+g widened a scoped search to all todos
+
 	// Vim-style multi-key sequence tracking
-	gPressed bool // Whether 'g' was pressed (for gg sequence)
+	gPressed  bool // Whether 'g' was pressed (for gg sequence)
+	yPressed  bool // Whether 'y' was pressed (for yy sequence)
+	yankCount int  // Count given before the first 'y'
+
+	// Todos copied with yy, pasted with p/P
+	register []yankedTodo
+
+	// Split view: the pane that isn't focused, nil without a split (see split.go)
+	split      *Model
+	splitRight bool // This pane is the right-hand one
+
+	// Text of the todo each pinned todo followed before it was pinned, keyed by the pinned text
+	pinOrigins map[string]string
+
+	// Todos checked since the last write, appended to the completion log once written
+	completed []markdown.Todo
+
+	// Command to run after a palette command handler (e.g. launching the editor)
+	pendingCmd tea.Cmd
 
 	// Document tree for predictable movement and deletion
 	documentTree *DocumentTree
@@ -147,8 +227,11 @@ type Model struct {
 	appVersion string
 }
 
-// ClearCopyFeedbackMsg is sent to clear copy feedback after a delay
-type ClearCopyFeedbackMsg struct{}
+// ClearStatusHintMsg is sent to clear the status hint after a delay. A zero seq
+// clears any message; otherwise only the message set with that sequence number.
+type ClearStatusHintMsg struct {
+	seq int
+}
 
 // SearchDebounceMsg is sent after debounce delay to trigger search update
 type SearchDebounceMsg struct{}
@@ -173,11 +256,16 @@ func New(filePath string, fm *markdown.FileModel, readOnly bool, showHeadings bo
 		LocallyModified:     make(map[string]bool),
 		AvailableTags:       availableTags,
 		FilteredTags:        []string{},
+		AvailableContexts:   markdown.GetAllContexts(fm.Todos),
+		FilteredContexts:    []string{},
+		AvailableProjects:   markdown.GetAllProjects(fm.Todos),
+		FilteredProjects:    []string{},
 		AvailablePriorities: availablePriorities,
 		FilteredPriorities:  []int{},
 		WordWrap:            true,  // Default to true for better UX
 		headingsDirty:       true,  // Force initial cache population
 		searchPending:       false, // No pending search on init
+		searchHistoryIdx:    -1,    // Not browsing search history
 		treeDirty:           true,  // Force initial tree build
 		config:              config,
 		styles:              styles,
@@ -189,6 +277,14 @@ func New(filePath string, fm *markdown.FileModel, readOnly bool, showHeadings bo
 		ThemeSaveFunc:    ThemeSaveFunc,
 	}
 
+	m.StatusHints = StatusHintsFull
+	if config != nil {
+		m.ShowProgress = config.Display.ShowProgress
+		if config.Display.StatusHints != "" {
+			m.StatusHints = config.Display.StatusHints
+		}
+	}
+
 	// Apply metadata settings (including FilterDone) from file
 	if fm.Metadata != nil {
 		if fm.Metadata.FilterDone != nil {
@@ -197,6 +293,13 @@ func New(filePath string, fm *markdown.FileModel, readOnly bool, showHeadings bo
 		if fm.Metadata.WordWrap != nil {
 			m.WordWrap = *fm.Metadata.WordWrap
 		}
+		if fm.Metadata.Theme != "" {
+			m.applyFileTheme(fm.Metadata.Theme)
+		}
+		if len(fm.Metadata.Warnings) > 0 {
+			m.StatusHint = "Frontmatter: " + strings.Join(fm.Metadata.Warnings, ", ") + " (last value used)"
+			m.loadWarning = true
+		}
 	}
 
 	// Position cursor on first visible item if filters are active
@@ -219,6 +322,21 @@ func New(filePath string, fm *markdown.FileModel, readOnly bool, showHeadings bo
 	return m
 }
 
+// applyFileTheme uses the theme named in the file's frontmatter instead of the
+// configured one. Unknown names keep the configured theme and show a warning.
+func (m *Model) applyFileTheme(name string) {
+	if m.ThemeApplyFunc == nil {
+		return
+	}
+	styles := m.ThemeApplyFunc(name)
+	if styles == nil {
+		m.Err = fmt.Errorf("unknown theme %q, using %s", name, m.CurrentThemeName)
+		return
+	}
+	m.styles = styles
+	m.CurrentThemeName = name
+}
+
 // Config returns the model's configuration (for backward compatibility during transition)
 func (m *Model) Config() *ConfigType {
 	if m.config != nil {
@@ -245,15 +363,28 @@ func (m *Model) Version() string {
 
 // Init initializes the TUI
 func (m Model) Init() tea.Cmd {
-	return tea.Batch(
+	cmds := []tea.Cmd{
 		tea.EnableBracketedPaste,
 		watchFileChanges(), // Start watching for file changes
-	)
+	}
+	if m.loadWarning {
+		cmds = append(cmds, tea.Tick(loadWarningDuration, func(t time.Time) tea.Msg {
+			return ClearStatusHintMsg{}
+		}))
+	}
+	return tea.Batch(cmds...)
 }
 
+// loadWarningDuration is how long warnings found while loading the file stay in
+// the status bar, unless a key dismisses them first
+const loadWarningDuration = 5 * time.Second
+
+// fileWatchInterval is how often the file is checked for external changes
+const fileWatchInterval = time.Second
+
 // watchFileChanges returns a command that checks for file changes periodically
 func watchFileChanges() tea.Cmd {
-	return tea.Tick(time.Second, func(t time.Time) tea.Msg {
+	return tea.Tick(fileWatchInterval, func(t time.Time) tea.Msg {
 		return FileChangedMsg{}
 	})
 }
@@ -287,25 +418,35 @@ func (m *Model) InvalidateDocumentTree() {
 	m.treeDirty = true
 }
 
-// RefreshAvailableTags updates AvailableTags from the current todos and cleans up
-// FilteredTags to remove any tags that no longer exist.
+// RefreshAvailableTags updates AvailableTags, AvailableContexts and AvailableProjects
+// from the current todos and removes filters for values that no longer exist.
+// Tags asked for on the command line stay, so they keep showing no todos.
 func (m *Model) RefreshAvailableTags() {
 	m.AvailableTags = markdown.GetAllTags(m.FileModel.Todos)
+	m.AvailableContexts = markdown.GetAllContexts(m.FileModel.Todos)
+	m.AvailableProjects = markdown.GetAllProjects(m.FileModel.Todos)
 
-	// Clean up FilteredTags - remove any tags that no longer exist
-	if len(m.FilteredTags) > 0 {
-		validTags := make([]string, 0, len(m.FilteredTags))
-		tagSet := make(map[string]bool)
-		for _, tag := range m.AvailableTags {
-			tagSet[tag] = true
-		}
-		for _, tag := range m.FilteredTags {
-			if tagSet[tag] {
-				validTags = append(validTags, tag)
-			}
+	m.FilteredTags = keepAvailable(m.FilteredTags, slices.Concat(m.AvailableTags, m.launchTags))
+	m.FilteredContexts = keepAvailable(m.FilteredContexts, m.AvailableContexts)
+	m.FilteredProjects = keepAvailable(m.FilteredProjects, m.AvailableProjects)
+}
+
+// keepAvailable returns the filter values that still appear in available
+func keepAvailable(filtered, available []string) []string {
+	if len(filtered) == 0 {
+		return filtered
+	}
+	set := make(map[string]bool)
+	for _, v := range available {
+		set[v] = true
+	}
+	valid := make([]string, 0, len(filtered))
+	for _, v := range filtered {
+		if set[v] {
+			valid = append(valid, v)
 		}
-		m.FilteredTags = validTags
 	}
+	return valid
 }
 
 // searchDebounceCmd returns a command that triggers search update after a delay
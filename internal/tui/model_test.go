@@ -9,7 +9,7 @@ import (
 // testConfig creates a test configuration
 func testConfig() *ConfigType {
 	cfg := &ConfigType{}
-	cfg.Display.CheckSymbol = "[x]"
+	cfg.Display.CheckSymbol = "x"
 	cfg.Display.SelectMarker = ">"
 	cfg.Display.MaxVisible = 10
 	cfg.Defaults.WordWrap = true
@@ -30,12 +30,15 @@ func testStyles() *StyleFuncsType {
 		Yellow:         identity,
 		Code:           identity,
 		Tag:            identity,
+		Context:        identity,
+		Project:        identity,
 		PriorityHigh:   identity,
 		PriorityMedium: identity,
 		PriorityLow:    identity,
 		DueUrgent:      identity,
 		DueSoon:        identity,
 		DueFuture:      identity,
+		Time:           identity,
 	}
 }
 
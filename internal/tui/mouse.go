@@ -0,0 +1,74 @@
+package tui
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// handleMouse selects the clicked todo, toggles it when its checkbox is clicked,
+// and moves the selection with the scroll wheel
+func (m Model) handleMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
+	if m.split != nil && m.Err == nil && !m.inModalMode() {
+		return m.handleSplitMouse(msg)
+	}
+
+	// Mouse only drives the plain list view, not inputs or overlays
+	if m.Err != nil || m.inModalMode() || len(m.FileModel.Todos) == 0 {
+		return m, nil
+	}
+
+	switch msg.Button {
+	case tea.MouseButtonWheelUp:
+		m.selectUp(1)
+	case tea.MouseButtonWheelDown:
+		m.selectDown(1)
+	case tea.MouseButtonLeft:
+		if msg.Action != tea.MouseActionPress {
+			return m, nil
+		}
+		todoIdx, onCheckbox, ok := m.todoAtPosition(msg.X, msg.Y)
+		if !ok {
+			return m, nil
+		}
+		m.SelectedIndex = todoIdx
+		m.InvalidateDocumentTree()
+		if onCheckbox {
+			m.toggleSelected()
+		}
+	}
+	return m, nil
+}
+
+// inModalMode reports whether an input, picker or overlay currently owns the keyboard
+func (m *Model) inModalMode() bool {
+	return m.InputMode || m.EditMode || m.NoteMode || m.SearchMode || m.CommandMode ||
+		m.MoveMode || m.FilterMode || m.ContextFilterMode || m.ProjectFilterMode ||
+		m.PriorityFilterMode || m.DueFilterMode || m.ThemeMode || m.MaxVisibleInputMode || m.GotoInputMode ||
+		m.HelpMode || m.RecentFilesMode || m.ConfirmQuitMode
+}
+
+// todoAtPosition maps a terminal cell to the todo rendered there and reports
+// whether the cell lies on that todo's checkbox
+func (m Model) todoAtPosition(x, y int) (int, bool, bool) {
+	content, rows := m.layoutMainContent()
+
+	// Without the alt screen a view taller than the terminal scrolls its top
+	// lines off screen, so shift y by the hidden part
+	viewLines := strings.Count(content, "\n") + 1 // + status bar
+	if m.TermHeight > 0 && viewLines > m.TermHeight {
+		y += viewLines - m.TermHeight
+	}
+
+	if y < 0 || y >= len(rows) || rows[y] < 0 {
+		return 0, false, false
+	}
+	todoIdx := rows[y]
+
+	// The checkbox sits on the todo's first line, after the indent, index and arrow
+	firstLine := y == 0 || rows[y-1] != todoIdx
+	checkboxStart := m.FileModel.Todos[todoIdx].Depth*2 + 3 + m.arrowWidth()
+	onCheckbox := firstLine && x >= checkboxStart && x < checkboxStart+m.checkboxWidth()
+
+	return todoIdx, onCheckbox, true
+}
@@ -0,0 +1,167 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/niklas-heer/tdx/internal/markdown"
+)
+
+func click(m Model, x, y int) Model {
+	newModel, _ := m.Update(tea.MouseMsg{X: x, Y: y, Button: tea.MouseButtonLeft, Action: tea.MouseActionPress})
+	return newModel.(Model)
+}
+
+func wheel(m Model, button tea.MouseButton) Model {
+	newModel, _ := m.Update(tea.MouseMsg{Button: button, Action: tea.MouseActionPress})
+	return newModel.(Model)
+}
+
+func TestMouse_ClickTextSelectsTodo(t *testing.T) {
+	m := testModel([]string{"First", "Second", "Third"})
+	m.ReadOnly = true
+
+	m = click(m, 20, 2)
+
+	if m.SelectedIndex != 2 {
+		t.Errorf("SelectedIndex = %d, want 2", m.SelectedIndex)
+	}
+	if m.FileModel.Todos[2].Checked {
+		t.Error("Clicking the text should not toggle the todo")
+	}
+}
+
+func TestMouse_ClickCheckboxToggles(t *testing.T) {
+	m := testModel([]string{"First", "Second", "Third"})
+	m.ReadOnly = true
+
+	// Checkbox is after the 3-wide index and 3-wide arrow
+	m = click(m, 7, 1)
+
+	if m.SelectedIndex != 1 {
+		t.Errorf("SelectedIndex = %d, want 1", m.SelectedIndex)
+	}
+	if !m.FileModel.Todos[1].Checked {
+		t.Error("Clicking the checkbox should toggle the todo")
+	}
+}
+
+func TestMouse_ClickNestedCheckbox(t *testing.T) {
+	m := testModelWithMarkdown("# Todos\n\n- [ ] Parent\n  - [ ] Child\n")
+	m.ReadOnly = true
+
+	// Child is indented by two columns, so its checkbox starts at 8
+	m = click(m, 6, 1)
+	if m.FileModel.Todos[1].Checked {
+		t.Error("Click left of the nested checkbox should not toggle")
+	}
+	m = click(m, 8, 1)
+	if !m.FileModel.Todos[1].Checked {
+		t.Error("Click on the nested checkbox should toggle")
+	}
+}
+
+func TestMouse_ClickSkipsHeadingsAndNotes(t *testing.T) {
+	m := testModelWithMarkdown("# Todos\n\n- [ ] First\n  a note\n\n## Later\n\n- [ ] Second\n")
+	m.ReadOnly = true
+	m.ShowHeadings = true
+
+	// Rows: "# Todos", First, note, "## Later", Second
+	m = click(m, 7, 0)
+	if m.SelectedIndex != 0 || m.FileModel.Todos[0].Checked {
+		t.Errorf("Click on heading changed state: selected=%d", m.SelectedIndex)
+	}
+
+	m = click(m, 7, 2)
+	if m.SelectedIndex != 0 || m.FileModel.Todos[0].Checked {
+		t.Error("Click on a note should select its todo without toggling it")
+	}
+
+	m = click(m, 20, 4)
+	if m.SelectedIndex != 1 {
+		t.Errorf("SelectedIndex = %d, want 1", m.SelectedIndex)
+	}
+}
+
+func TestMouse_ClickUsesScrollWindow(t *testing.T) {
+	todos := make([]string, 20)
+	for i := range todos {
+		todos[i] = "Task"
+	}
+	m := testModel(todos)
+	m.ReadOnly = true
+	m.MaxVisibleOverride = 5
+	m.SelectedIndex = 10
+
+	// Window is todos 8-12 below the "more" indicator on row 0
+	m = click(m, 20, 1)
+	if m.SelectedIndex != 8 {
+		t.Errorf("SelectedIndex = %d, want 8", m.SelectedIndex)
+	}
+}
+
+func TestMouse_ClickRespectsFilters(t *testing.T) {
+	m := testModel([]string{"Done", "Open", "Also open"})
+	m.ReadOnly = true
+	m.FileModel.Todos[0].Checked = true
+	m.FilterDone = true
+
+	// The checked todo is hidden, so row 0 is "Open"
+	m = click(m, 20, 0)
+	if m.SelectedIndex != 1 {
+		t.Errorf("SelectedIndex = %d, want 1", m.SelectedIndex)
+	}
+}
+
+func TestMouse_ClickIgnoredInModalMode(t *testing.T) {
+	m := testModel([]string{"First", "Second"})
+	m.ReadOnly = true
+	m.SearchMode = true
+
+	m = click(m, 7, 1)
+	if m.SelectedIndex != 0 || m.FileModel.Todos[1].Checked {
+		t.Error("Mouse should be ignored while a mode is active")
+	}
+}
+
+func TestMouse_WheelMovesSelection(t *testing.T) {
+	m := testModel([]string{"Done", "Open", "Also open"})
+	m.ReadOnly = true
+	m.FileModel.Todos[1].Checked = true
+	m.FilterDone = true
+
+	m = wheel(m, tea.MouseButtonWheelDown)
+	if m.SelectedIndex != 2 {
+		t.Errorf("Wheel down: SelectedIndex = %d, want 2 (skipping hidden todo)", m.SelectedIndex)
+	}
+
+	m = wheel(m, tea.MouseButtonWheelUp)
+	if m.SelectedIndex != 0 {
+		t.Errorf("Wheel up: SelectedIndex = %d, want 0", m.SelectedIndex)
+	}
+}
+
+func TestMouse_ReadOnlyToggleNotSaved(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "todo.md")
+	content := "# Todos\n\n- [ ] Task\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	fm, err := markdown.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := New(path, fm, true, false, -1, testConfig(), testStyles(), "test")
+
+	m = click(m, 7, 0)
+
+	if !m.FileModel.Todos[0].Checked {
+		t.Error("Expected todo toggled in memory")
+	}
+	data, _ := os.ReadFile(path)
+	if string(data) != content {
+		t.Errorf("Read-only toggle was written to disk:\n%s", data)
+	}
+}
@@ -224,3 +224,152 @@ func TestHandleKey_IndentWithUndo(t *testing.T) {
 		t.Errorf("After undo, depth = %d, want 0", m.FileModel.Todos[1].Depth)
 	}
 }
+
+func TestHandleKey_ToggleSubtree(t *testing.T) {
+	content := `# Todos
+
+- [ ] Parent
+  - [ ] Child 1
+  - [x] Child 2
+- [ ] Sibling
+`
+	m := testModelWithMarkdown(content)
+
+	m = pressKey(t, m, "T")
+
+	for i, todo := range m.FileModel.Todos[:3] {
+		if !todo.Checked {
+			t.Errorf("Todo %d (%s) should be checked after T", i, todo.Text)
+		}
+	}
+	if m.FileModel.Todos[3].Checked {
+		t.Error("Sibling outside the subtree should not be toggled")
+	}
+
+	// A single undo restores all three
+	m = pressKey(t, m, "u")
+	want := []bool{false, false, true, false}
+	for i, todo := range m.FileModel.Todos {
+		if todo.Checked != want[i] {
+			t.Errorf("After undo, todo %d (%s) checked = %v, want %v", i, todo.Text, todo.Checked, want[i])
+		}
+	}
+}
+
+func TestHandleKey_ToggleSubtreeUnchecksAll(t *testing.T) {
+	m := testModelWithMarkdown("- [x] Parent\n  - [x] Child\n    - [ ] Grandchild\n")
+
+	m = pressKey(t, m, "T")
+
+	for i, todo := range m.FileModel.Todos {
+		if todo.Checked {
+			t.Errorf("Todo %d (%s) should be unchecked after T", i, todo.Text)
+		}
+	}
+}
+
+func TestHandleKey_ToggleSubtreeOnLeaf(t *testing.T) {
+	m := testModelWithMarkdown("- [ ] Parent\n  - [ ] Child 1\n  - [ ] Child 2\n")
+	m.SelectedIndex = 1
+
+	m = pressKey(t, m, "T")
+
+	if !m.FileModel.Todos[1].Checked || m.FileModel.Todos[0].Checked || m.FileModel.Todos[2].Checked {
+		t.Errorf("Only Child 1 should be toggled: %+v", m.FileModel.Todos)
+	}
+}
+
+func TestHandleKey_IndentMovesSubtree(t *testing.T) {
+	m := testModelWithMarkdown("- [ ] Task 1\n- [ ] Parent\n  - [ ] Child 1\n  - [ ] Child 2\n")
+	m.SelectedIndex = 1
+
+	m = pressKeyType(t, m, tea.KeyTab)
+
+	for i, want := range []int{0, 1, 2, 2} {
+		if got := m.FileModel.Todos[i].Depth; got != want {
+			t.Errorf("After indent, %q depth = %d, want %d", m.FileModel.Todos[i].Text, got, want)
+		}
+	}
+	if m.FileModel.Todos[2].ParentIndex != 1 || m.FileModel.Todos[3].ParentIndex != 1 {
+		t.Error("Children should stay under their parent")
+	}
+
+	m = pressKeyType(t, m, tea.KeyShiftTab)
+
+	for i, want := range []int{0, 0, 1, 1} {
+		if got := m.FileModel.Todos[i].Depth; got != want {
+			t.Errorf("After outdent, %q depth = %d, want %d", m.FileModel.Todos[i].Text, got, want)
+		}
+	}
+	if m.SelectedIndex != 1 {
+		t.Errorf("SelectedIndex = %d, want 1", m.SelectedIndex)
+	}
+}
+
+func TestHandleKey_IndentWithCount(t *testing.T) {
+	m := testModelWithMarkdown("- [ ] A\n  - [ ] B\n- [ ] C\n  - [ ] C1\n")
+	m.SelectedIndex = 2
+
+	m = pressKey(t, m, "2")
+	m = pressKeyType(t, m, tea.KeyTab)
+
+	// C nests under A, then under B
+	if got := m.FileModel.Todos[2].Depth; got != 2 {
+		t.Errorf("C depth = %d, want 2", got)
+	}
+	if got := m.FileModel.Todos[3].Depth; got != 3 {
+		t.Errorf("C1 depth = %d, want 3", got)
+	}
+
+	// A single undo reverts both levels
+	m = pressKey(t, m, "u")
+	if got := m.FileModel.Todos[2].Depth; got != 0 {
+		t.Errorf("After undo, C depth = %d, want 0", got)
+	}
+}
+
+func TestHandleKey_IndentCountStopsAtValidParent(t *testing.T) {
+	m := testModelWithMarkdown("- [ ] A\n- [ ] B\n")
+	m.SelectedIndex = 1
+
+	m = pressKey(t, m, "5")
+	m = pressKeyType(t, m, tea.KeyTab)
+
+	// Only one level is possible: B can't be deeper than a child of A
+	if got := m.FileModel.Todos[1].Depth; got != 1 {
+		t.Errorf("B depth = %d, want 1", got)
+	}
+	if m.NumberBuffer != "" {
+		t.Errorf("NumberBuffer = %q, want it consumed", m.NumberBuffer)
+	}
+}
+
+func TestHandleKey_OutdentWithCountFollowsTodo(t *testing.T) {
+	m := testModelWithMarkdown("- [ ] A\n  - [ ] B\n    - [ ] C\n    - [ ] D\n  - [ ] E\n")
+	m.SelectedIndex = 2
+
+	m = pressKey(t, m, "3")
+	m = pressKeyType(t, m, tea.KeyShiftTab)
+
+	// C lands after A's subtree at the top level
+	if got := todoTexts(m); strings.Join(got, ",") != "A,B,D,E,C" {
+		t.Fatalf("todos = %v", got)
+	}
+	if m.SelectedIndex != 4 || m.FileModel.Todos[4].Depth != 0 {
+		t.Errorf("SelectedIndex = %d (depth %d), want 4 at depth 0", m.SelectedIndex, m.FileModel.Todos[m.SelectedIndex].Depth)
+	}
+}
+
+func TestHandleKey_IndentFailureKeepsHistory(t *testing.T) {
+	m := testModelWithMarkdown("- [ ] A\n- [ ] B\n")
+	m.SelectedIndex = 1
+	m = pressKeyType(t, m, tea.KeyTab)
+	history := m.History
+
+	m.SelectedIndex = 0
+	m = pressKeyType(t, m, tea.KeyTab)
+
+	if m.History != history {
+		t.Error("A failed indent should not replace the undo snapshot")
+	}
+}
@@ -0,0 +1,97 @@
+package tui
+
+import (
+	"slices"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const newInSectionMarkdown = `# Work
+
+- [ ] Write report
+- [ ] Review PR
+- [ ] Send invoice
+
+# Home
+
+- [ ] Groceries
+- [ ] Laundry
+`
+
+// addTodoWith presses key, types text and confirms it
+func addTodoWith(t *testing.T, m Model, key, text string) Model {
+	t.Helper()
+	m = pressKey(t, m, key)
+	for _, c := range text {
+		m = pressKey(t, m, string(c))
+	}
+	return pressKeyType(t, m, tea.KeyEnter)
+}
+
+func TestNewTodo_AppendsToSectionWithHeadings(t *testing.T) {
+	m := testModelWithMarkdown(newInSectionMarkdown)
+	m.ShowHeadings = true
+	m.SelectedIndex = 1 // Review PR, mid-section
+
+	m = addTodoWith(t, m, "n", "Call client")
+
+	want := []string{"Write report", "Review PR", "Send invoice", "Call client", "Groceries", "Laundry"}
+	if got := todoTexts(m); !slices.Equal(got, want) {
+		t.Errorf("Todos = %v, want %v", got, want)
+	}
+	if m.SelectedIndex != 3 {
+		t.Errorf("SelectedIndex = %d, want 3 (the new todo)", m.SelectedIndex)
+	}
+}
+
+func TestNewTodo_LastSection(t *testing.T) {
+	m := testModelWithMarkdown(newInSectionMarkdown)
+	m.ShowHeadings = true
+	m.SelectedIndex = 3 // Groceries
+
+	m = addTodoWith(t, m, "n", "Water plants")
+
+	want := []string{"Write report", "Review PR", "Send invoice", "Groceries", "Laundry", "Water plants"}
+	if got := todoTexts(m); !slices.Equal(got, want) {
+		t.Errorf("Todos = %v, want %v", got, want)
+	}
+}
+
+func TestNewTodo_AfterCursorWithoutHeadings(t *testing.T) {
+	m := testModelWithMarkdown(newInSectionMarkdown)
+	m.SelectedIndex = 1
+
+	m = addTodoWith(t, m, "n", "Call client")
+
+	want := []string{"Write report", "Review PR", "Call client", "Send invoice", "Groceries", "Laundry"}
+	if got := todoTexts(m); !slices.Equal(got, want) {
+		t.Errorf("Todos = %v, want %v", got, want)
+	}
+}
+
+func TestNewTodo_SkipsHiddenSectionTail(t *testing.T) {
+	m := testModelWithMarkdown("# Work\n\n- [ ] Write report\n- [ ] Review PR\n- [x] Send invoice\n\n# Home\n\n- [ ] Groceries\n")
+	m.ShowHeadings = true
+	m.FilterDone = true
+
+	m = addTodoWith(t, m, "n", "Call client")
+
+	want := []string{"Write report", "Review PR", "Call client", "Send invoice", "Groceries"}
+	if got := todoTexts(m); !slices.Equal(got, want) {
+		t.Errorf("Todos = %v, want %v", got, want)
+	}
+}
+
+func TestNewTodo_CapitalNAppendsToFile(t *testing.T) {
+	m := testModelWithMarkdown(newInSectionMarkdown)
+	m.ShowHeadings = true
+	m.SelectedIndex = 1
+
+	m = addTodoWith(t, m, "N", "Call client")
+
+	want := []string{"Write report", "Review PR", "Send invoice", "Groceries", "Laundry", "Call client"}
+	if got := todoTexts(m); !slices.Equal(got, want) {
+		t.Errorf("Todos = %v, want %v", got, want)
+	}
+}
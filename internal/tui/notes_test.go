@@ -0,0 +1,117 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/niklas-heer/tdx/internal/markdown"
+)
+
+func TestRenderTodoNotes_DimmedAndAligned(t *testing.T) {
+	dim := func(s string) string { return "<" + s + ">" }
+
+	got := RenderTodoNotes([]string{"first", "", "second"}, false, 0, 4, dim)
+
+	expected := "    <first>\n    <second>\n"
+	if got != expected {
+		t.Errorf("Expected %q, got %q", expected, got)
+	}
+}
+
+func TestRenderTodoNotes_Wraps(t *testing.T) {
+	identity := func(s string) string { return s }
+	note := "this note is much too long to fit on a single line of the terminal"
+
+	got := RenderTodoNotes([]string{note}, true, 40, 10, identity)
+
+	lines := strings.Split(strings.TrimSuffix(got, "\n"), "\n")
+	if len(lines) < 2 {
+		t.Fatalf("Expected note to wrap, got %q", got)
+	}
+	for _, line := range lines {
+		if !strings.HasPrefix(line, strings.Repeat(" ", 10)) {
+			t.Errorf("Expected wrapped line to be aligned with todo text, got %q", line)
+		}
+		if len(line) > 40 {
+			t.Errorf("Line exceeds terminal width: %q", line)
+		}
+	}
+}
+
+func TestView_ShowsNotesBelowTodo(t *testing.T) {
+	m := testModelWithMarkdown("# Todos\n\n- [ ] Task\n  remember the milk\n- [ ] Next\n")
+	m.TermWidth = 80
+
+	view := m.View()
+	taskIdx := strings.Index(view, "Task")
+	noteIdx := strings.Index(view, "remember the milk")
+	nextIdx := strings.Index(view, "Next")
+	if taskIdx == -1 || noteIdx == -1 || nextIdx == -1 {
+		t.Fatalf("Expected todo, note and next todo in view:\n%s", view)
+	}
+	if !(taskIdx < noteIdx && noteIdx < nextIdx) {
+		t.Errorf("Expected note between its todo and the next one:\n%s", view)
+	}
+}
+
+func TestNoteMode_AddsNoteToSelectedTodo(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "todo.md")
+	if err := os.WriteFile(path, []byte("# Todos\n\n- [ ] First\n- [ ] Second\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	fm, err := markdown.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := New(path, fm, false, false, -1, testConfig(), testStyles(), "test")
+
+	m = pressKey(t, m, "j")
+	m = pressKey(t, m, "a")
+	if !m.NoteMode {
+		t.Fatal("Expected note mode after pressing a")
+	}
+	for _, c := range "call Bob" {
+		m = pressKey(t, m, string(c))
+	}
+	if !strings.Contains(m.View(), "NOTE") {
+		t.Error("Expected NOTE mode indicator in status bar")
+	}
+	m = pressKeyType(t, m, tea.KeyEnter)
+
+	if m.NoteMode {
+		t.Error("Expected note mode to end after enter")
+	}
+	if notes := m.FileModel.Todos[1].Notes; len(notes) != 1 || notes[0] != "call Bob" {
+		t.Errorf("Expected note on second todo, got %q", notes)
+	}
+
+	data, _ := os.ReadFile(path)
+	expected := "# Todos\n\n- [ ] First\n- [ ] Second\n  call Bob\n"
+	if string(data) != expected {
+		t.Errorf("Expected file:\n%q\nGot:\n%q", expected, string(data))
+	}
+
+	// Undo removes the note again
+	m = pressKey(t, m, "u")
+	if len(m.FileModel.Todos[1].Notes) != 0 {
+		t.Errorf("Expected undo to remove the note, got %q", m.FileModel.Todos[1].Notes)
+	}
+}
+
+func TestNoteMode_EscapeCancels(t *testing.T) {
+	m := testModelWithMarkdown("- [ ] Task\n")
+
+	m = pressKey(t, m, "a")
+	m = pressKey(t, m, "x")
+	m = pressKeyType(t, m, tea.KeyEsc)
+
+	if m.NoteMode {
+		t.Error("Expected note mode to end after escape")
+	}
+	if len(m.FileModel.Todos[0].Notes) != 0 {
+		t.Errorf("Expected no note after cancel, got %q", m.FileModel.Todos[0].Notes)
+	}
+}
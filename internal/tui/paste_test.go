@@ -0,0 +1,104 @@
+package tui
+
+import (
+	"slices"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// paste sends a bracketed paste with the given text
+func paste(t *testing.T, m Model, text string) Model {
+	t.Helper()
+	result, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(text), Paste: true})
+	return result.(Model)
+}
+
+func TestPaste_MultiLineCreatesTodos(t *testing.T) {
+	m := testModelWithMarkdown("- [ ] First\n- [ ] Last\n")
+
+	m = pressKey(t, m, "n")
+	m = paste(t, m, "Buy milk\nCall mom\nWrite report\n")
+
+	want := []string{"First", "Buy milk", "Call mom", "Write report", "Last"}
+	if got := todoTexts(m); !slices.Equal(got, want) {
+		t.Fatalf("todos = %v, want %v", got, want)
+	}
+	if m.InputMode {
+		t.Error("Input mode should end after a multi-line paste")
+	}
+	if m.SelectedIndex != 3 {
+		t.Errorf("SelectedIndex = %d, want 3 (last pasted todo)", m.SelectedIndex)
+	}
+	if m.StatusHint != "Added 3 todos" {
+		t.Errorf("StatusHint = %q", m.StatusHint)
+	}
+}
+
+func TestPaste_KeepsCheckboxesAndSkipsBlankLines(t *testing.T) {
+	m := testModelWithMarkdown("- [ ] Existing\n")
+
+	m = pressKey(t, m, "N")
+	m = paste(t, m, "- [ ] Open task\r\n\r\n- [x] Done task\n  * Bullet task")
+
+	want := []string{"Existing", "Open task", "Done task", "Bullet task"}
+	if got := todoTexts(m); !slices.Equal(got, want) {
+		t.Fatalf("todos = %v, want %v", got, want)
+	}
+	for i, checked := range []bool{false, false, true, false} {
+		if m.FileModel.Todos[i].Checked != checked {
+			t.Errorf("todo %d checked = %v, want %v", i, m.FileModel.Todos[i].Checked, checked)
+		}
+	}
+}
+
+func TestPaste_MultiLineKeepsTypedText(t *testing.T) {
+	m := testModelWithMarkdown("")
+
+	m = pressKey(t, m, "N")
+	m.InputBuffer = "Fix  now"
+	m.CursorPos = 4
+	m = paste(t, m, "login\nlogout")
+
+	want := []string{"Fix login", "logout now"}
+	if got := todoTexts(m); !slices.Equal(got, want) {
+		t.Errorf("todos = %v, want %v", got, want)
+	}
+}
+
+func TestPaste_EditModeTakesFirstLine(t *testing.T) {
+	m := testModelWithMarkdown("- [ ] Task\n")
+
+	m = pressKey(t, m, "e")
+	m = paste(t, m, " one\ntwo")
+
+	if !m.EditMode || m.InputBuffer != "Task one" {
+		t.Errorf("EditMode = %v, InputBuffer = %q; want first line appended", m.EditMode, m.InputBuffer)
+	}
+	if len(m.FileModel.Todos) != 1 {
+		t.Errorf("Expected no new todos, got %d", len(m.FileModel.Todos))
+	}
+}
+
+func TestPaste_SingleLineStaysInInput(t *testing.T) {
+	m := testModelWithMarkdown("- [ ] Task\n")
+
+	m = pressKey(t, m, "n")
+	m = paste(t, m, "Just one line\n")
+
+	if !m.InputMode || m.InputBuffer != "Just one line" {
+		t.Errorf("InputMode = %v, InputBuffer = %q", m.InputMode, m.InputBuffer)
+	}
+}
+
+func TestPaste_MultiLineUndo(t *testing.T) {
+	m := testModelWithMarkdown("- [ ] Task\n")
+
+	m = pressKey(t, m, "n")
+	m = paste(t, m, "A\nB")
+	m = pressKey(t, m, "u")
+
+	if got := todoTexts(m); !slices.Equal(got, []string{"Task"}) {
+		t.Errorf("todos = %v, want paste undone", got)
+	}
+}
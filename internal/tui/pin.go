@@ -0,0 +1,112 @@
+package tui
+
+import (
+	"slices"
+
+	"github.com/niklas-heer/tdx/internal/markdown"
+)
+
+// pinGlyph is shown in front of pinned todos
+const pinGlyph = "★"
+
+// togglePin adds or removes the #pinned tag on the selected todo. Pinning moves
+// the todo (with its nested todos) above the unpinned todos of its section.
+// Unpinning puts it back after the todo it followed when it was pinned, or
+// below the remaining pinned todos if that isn't known.
+func (m *Model) togglePin() {
+	if len(m.FileModel.Todos) == 0 {
+		return
+	}
+	m.saveHistory()
+
+	fm := &m.FileModel
+	idx := m.SelectedIndex
+	todo := fm.Todos[idx]
+	siblings := m.pinSiblings(idx)
+
+	target, after := -1, false
+	if todo.IsPinned() {
+		text := markdown.RemoveTag(todo.Text, markdown.PinnedTag)
+		origin, known := m.pinOrigins[todo.Text]
+		delete(m.pinOrigins, todo.Text)
+		if fm.UpdateTodoItem(idx, text, todo.Checked) != nil {
+			return
+		}
+
+		if known {
+			for _, s := range siblings {
+				if s != idx && !fm.Todos[s].IsPinned() && fm.Todos[s].Text == origin {
+					target, after = s, true
+					break
+				}
+			}
+		}
+		if target < 0 {
+			// Below the last pinned todo, if it comes after this one
+			for _, s := range siblings {
+				if s > idx && fm.Todos[s].IsPinned() {
+					target, after = s, true
+				}
+			}
+		}
+	} else {
+		text := markdown.AddTag(todo.Text, markdown.PinnedTag)
+		if fm.UpdateTodoItem(idx, text, todo.Checked) != nil {
+			return
+		}
+
+		pos := slices.Index(siblings, idx)
+		for _, s := range siblings[:pos] {
+			if !fm.Todos[s].IsPinned() {
+				target = s
+				// Remember the previous sibling so unpinning can restore the order
+				if m.pinOrigins == nil {
+					m.pinOrigins = make(map[string]string)
+				}
+				m.pinOrigins[text] = fm.Todos[siblings[pos-1]].Text
+				break
+			}
+		}
+	}
+
+	if target >= 0 {
+		size := m.subtreeEnd(idx) - idx
+		newIdx := target
+		if after {
+			newIdx = m.subtreeEnd(target)
+		}
+		if newIdx > idx {
+			newIdx -= size
+		}
+		if fm.MoveTodoItemToPosition(idx, target, after) == nil {
+			m.SelectedIndex = newIdx
+		}
+	}
+
+	m.InvalidateHeadingsCache()
+	m.InvalidateDocumentTree()
+	m.RefreshAvailableTags()
+	m.writeIfPersist()
+}
+
+// pinSiblings returns the todos sharing the parent of the todo at idx, within
+// its heading section
+func (m *Model) pinSiblings(idx int) []int {
+	todos := m.FileModel.Todos
+	start, end := 0, len(todos)
+	for _, h := range m.GetHeadings() {
+		if h.BeforeTodoIndex <= idx {
+			start = max(start, h.BeforeTodoIndex)
+		} else {
+			end = min(end, h.BeforeTodoIndex)
+		}
+	}
+
+	var siblings []int
+	for i := start; i < end; i++ {
+		if todos[i].ParentIndex == todos[idx].ParentIndex {
+			siblings = append(siblings, i)
+		}
+	}
+	return siblings
+}
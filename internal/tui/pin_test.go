@@ -0,0 +1,107 @@
+package tui
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestPin_MovesTodoToTop(t *testing.T) {
+	m := testModelWithMarkdown("- [ ] A\n- [ ] B\n- [ ] C\n")
+	m.SelectedIndex = 2
+
+	m = pressKey(t, m, "s")
+
+	if got := todoTexts(m); !slices.Equal(got, []string{"C #pinned", "A", "B"}) {
+		t.Fatalf("todos = %v", got)
+	}
+	if m.SelectedIndex != 0 {
+		t.Errorf("SelectedIndex = %d, want 0 (cursor follows the pinned todo)", m.SelectedIndex)
+	}
+
+	view := m.View()
+	pinned := strings.Index(view, pinGlyph+" C")
+	if pinned < 0 || pinned > strings.Index(view, "A") {
+		t.Errorf("Expected pinned todo rendered first with the pin glyph:\n%s", view)
+	}
+}
+
+func TestPin_UnpinRestoresOrder(t *testing.T) {
+	m := testModelWithMarkdown("- [ ] A\n- [ ] B\n- [ ] C\n")
+	m.SelectedIndex = 2
+
+	m = pressKey(t, m, "s")
+	m = pressKey(t, m, "s")
+
+	if got := todoTexts(m); !slices.Equal(got, []string{"A", "B", "C"}) {
+		t.Fatalf("todos = %v, want original order", got)
+	}
+	if m.SelectedIndex != 2 {
+		t.Errorf("SelectedIndex = %d, want 2", m.SelectedIndex)
+	}
+	if strings.Contains(m.View(), pinGlyph) {
+		t.Error("Unpinned todo should not show the pin glyph")
+	}
+}
+
+func TestPin_NewPinGoesBelowExistingPins(t *testing.T) {
+	m := testModelWithMarkdown("- [ ] A #pinned\n- [ ] B\n- [ ] C\n")
+	m.SelectedIndex = 2
+
+	m = pressKey(t, m, "s")
+
+	if got := todoTexts(m); !slices.Equal(got, []string{"A #pinned", "C #pinned", "B"}) {
+		t.Errorf("todos = %v", got)
+	}
+}
+
+func TestPin_UnpinWithoutOriginGoesBelowPins(t *testing.T) {
+	// Pinned in an earlier session, so the original position is unknown
+	m := testModelWithMarkdown("- [ ] A #pinned\n- [ ] B #pinned\n- [ ] C\n")
+
+	m = pressKey(t, m, "s")
+
+	if got := todoTexts(m); !slices.Equal(got, []string{"B #pinned", "A", "C"}) {
+		t.Errorf("todos = %v", got)
+	}
+	if m.SelectedIndex != 1 {
+		t.Errorf("SelectedIndex = %d, want 1", m.SelectedIndex)
+	}
+}
+
+func TestPin_StaysWithinSectionAndKeepsSubtasks(t *testing.T) {
+	m := testModelWithMarkdown("- [ ] A\n\n## Work\n\n- [ ] B\n- [ ] C\n  - [ ] C1\n")
+	m.SelectedIndex = 2
+
+	m = pressKey(t, m, "s")
+
+	if got := todoTexts(m); !slices.Equal(got, []string{"A", "C #pinned", "C1", "B"}) {
+		t.Errorf("todos = %v", got)
+	}
+	if m.SelectedIndex != 1 {
+		t.Errorf("SelectedIndex = %d, want 1", m.SelectedIndex)
+	}
+}
+
+func TestPin_NestedTodoPinsAmongSiblings(t *testing.T) {
+	m := testModelWithMarkdown("- [ ] Parent\n  - [ ] A\n  - [ ] B\n- [ ] Other\n")
+	m.SelectedIndex = 2
+
+	m = pressKey(t, m, "s")
+
+	if got := todoTexts(m); !slices.Equal(got, []string{"Parent", "B #pinned", "A", "Other"}) {
+		t.Errorf("todos = %v", got)
+	}
+}
+
+func TestPin_Undo(t *testing.T) {
+	m := testModelWithMarkdown("- [ ] A\n- [ ] B\n")
+	m.SelectedIndex = 1
+
+	m = pressKey(t, m, "s")
+	m = pressKey(t, m, "u")
+
+	if got := todoTexts(m); !slices.Equal(got, []string{"A", "B"}) {
+		t.Errorf("todos = %v, want the pin undone", got)
+	}
+}
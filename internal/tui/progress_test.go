@@ -0,0 +1,77 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderProgressBar(t *testing.T) {
+	tests := []struct {
+		done, total int
+		want        string
+	}{
+		{5, 10, "[=====     ] 50% (5/10)"},
+		{0, 0, "[          ] 0% (0/0)"},
+		{3, 3, "[==========] 100% (3/3)"},
+		{1, 3, "[===       ] 33% (1/3)"},
+	}
+	for _, tt := range tests {
+		if got := RenderProgressBar(tt.done, tt.total, progressBarWidth); got != tt.want {
+			t.Errorf("RenderProgressBar(%d, %d) = %q, want %q", tt.done, tt.total, got, tt.want)
+		}
+	}
+}
+
+func TestProgress_StatusBarShowsRatio(t *testing.T) {
+	m := testModelWithMarkdown("- [x] A\n- [ ] B\n- [x] C\n- [ ] D\n")
+
+	if strings.Contains(m.View(), "50% (2/4)") {
+		t.Fatal("Progress should be hidden by default")
+	}
+
+	executeCommand(&m, "toggle-progress")
+	if !strings.Contains(m.View(), "[=====     ] 50% (2/4)") {
+		t.Errorf("Expected progress bar in status bar:\n%s", m.View())
+	}
+
+	// Toggling a todo updates the bar live
+	m = pressKey(t, m, "j")
+	m = pressKey(t, m, " ")
+	if !strings.Contains(m.View(), "75% (3/4)") {
+		t.Errorf("Expected updated progress after toggle:\n%s", m.View())
+	}
+}
+
+func TestProgress_CountsOnlyFilteredTodos(t *testing.T) {
+	m := testModelWithMarkdown("- [x] A #work\n- [ ] B #work\n- [x] C #home\n- [x] D #home\n")
+	m.ShowProgress = true
+	m.FilteredTags = []string{"work"}
+	m.InvalidateDocumentTree()
+
+	if !strings.Contains(m.View(), "50% (1/2) of filtered") {
+		t.Errorf("Expected filtered progress with hint:\n%s", m.View())
+	}
+}
+
+func TestProgress_FilterDoneStillCountsCompleted(t *testing.T) {
+	m := testModelWithMarkdown("- [x] A\n- [ ] B\n")
+	m.ShowProgress = true
+	m.FilterDone = true
+	m.InvalidateDocumentTree()
+
+	view := m.View()
+	if !strings.Contains(view, "50% (1/2)") || strings.Contains(view, "of filtered") {
+		t.Errorf("Hiding completed todos should not change progress:\n%s", view)
+	}
+}
+
+func TestProgress_EnabledFromConfig(t *testing.T) {
+	cfg := testConfig()
+	cfg.Display.ShowProgress = true
+	m := testModelWithMarkdown("- [ ] A\n")
+	m = New(m.FilePath, &m.FileModel, false, false, -1, cfg, testStyles(), "test")
+
+	if !m.ShowProgress {
+		t.Error("ShowProgress should follow the display config")
+	}
+}
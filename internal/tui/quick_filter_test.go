@@ -0,0 +1,94 @@
+package tui
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+// visibleTexts returns the text of each todo that passes the current filters
+func visibleTexts(m Model) []string {
+	var texts []string
+	for i, todo := range m.FileModel.Todos {
+		if m.isTodoVisible(i) {
+			texts = append(texts, todo.Text)
+		}
+	}
+	return texts
+}
+
+func TestQuickFilter_HTogglesFilterDone(t *testing.T) {
+	m := testModelWithMarkdown("- [x] Done\n- [ ] Open\n")
+
+	m = pressKey(t, m, "h")
+	if !m.FilterDone {
+		t.Fatal("FilterDone should be on after 'h'")
+	}
+	if got := visibleTexts(m); !slices.Equal(got, []string{"Open"}) {
+		t.Errorf("visible = %v, want [Open]", got)
+	}
+	if m.SelectedIndex != 1 {
+		t.Errorf("SelectedIndex = %d, want 1 (moved off the hidden todo)", m.SelectedIndex)
+	}
+	if !strings.Contains(m.View(), "FILTERED") {
+		t.Errorf("Expected filter indicator in status bar:\n%s", m.View())
+	}
+
+	m = pressKey(t, m, "h")
+	if m.FilterDone {
+		t.Error("FilterDone should be off after second 'h'")
+	}
+	if got := visibleTexts(m); len(got) != 2 {
+		t.Errorf("visible = %v, want both todos", got)
+	}
+}
+
+func TestQuickFilter_OTogglesOverdue(t *testing.T) {
+	m := testModelWithMarkdown("- [ ] Late @due(2020-01-01)\n- [ ] Someday\n- [ ] Future @due(2999-01-01)\n")
+	m.SelectedIndex = 1
+
+	m = pressKey(t, m, "o")
+	if m.FilteredDueDate != "overdue" {
+		t.Fatalf("FilteredDueDate = %q, want overdue", m.FilteredDueDate)
+	}
+	if got := visibleTexts(m); !slices.Equal(got, []string{"Late @due(2020-01-01)"}) {
+		t.Errorf("visible = %v", got)
+	}
+	if m.SelectedIndex != 0 {
+		t.Errorf("SelectedIndex = %d, want 0", m.SelectedIndex)
+	}
+	if !strings.Contains(m.View(), "overdue") {
+		t.Errorf("Expected due filter indicator in status bar:\n%s", m.View())
+	}
+
+	m = pressKey(t, m, "o")
+	if m.FilteredDueDate != "" {
+		t.Errorf("FilteredDueDate = %q, want cleared", m.FilteredDueDate)
+	}
+}
+
+func TestQuickFilter_OReplacesOtherDueFilter(t *testing.T) {
+	m := testModelWithMarkdown("- [ ] Late @due(2020-01-01)\n")
+	m.FilteredDueDate = "week"
+
+	m = pressKey(t, m, "o")
+	if m.FilteredDueDate != "overdue" {
+		t.Errorf("FilteredDueDate = %q, want overdue", m.FilteredDueDate)
+	}
+}
+
+func TestQuickFilter_CombinesWithTagFilter(t *testing.T) {
+	m := testModelWithMarkdown("- [ ] A #work @due(2020-01-01)\n- [x] B #work @due(2020-01-01)\n- [ ] C #home @due(2020-01-01)\n- [ ] D #work\n")
+	m.FilteredTags = []string{"work"}
+	m.InvalidateDocumentTree()
+
+	m = pressKey(t, m, "o")
+	m = pressKey(t, m, "h")
+
+	if got := visibleTexts(m); !slices.Equal(got, []string{"A #work @due(2020-01-01)"}) {
+		t.Errorf("visible = %v, want only the open overdue #work todo", got)
+	}
+	if len(m.FilteredTags) != 1 {
+		t.Errorf("FilteredTags = %v, want the tag filter kept", m.FilteredTags)
+	}
+}
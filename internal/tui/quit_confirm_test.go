@@ -0,0 +1,102 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/niklas-heer/tdx/internal/util"
+)
+
+// sendKey presses a key and returns the model and whether the command quits
+func sendKey(t *testing.T, m Model, msg tea.KeyMsg) (Model, bool) {
+	t.Helper()
+	result, cmd := m.Update(msg)
+	if cmd == nil {
+		return result.(Model), false
+	}
+	_, quit := cmd().(tea.QuitMsg)
+	return result.(Model), quit
+}
+
+func confirmQuitModel() Model {
+	m := testModelWithMarkdown("- [ ] One\n- [ ] Two\n")
+	m.config.Defaults.ConfirmQuit = true
+	m.TermWidth, m.TermHeight = 80, 20
+	return m
+}
+
+func TestConfirmQuit_EscThenNStays(t *testing.T) {
+	m := confirmQuitModel()
+
+	m, quit := sendKey(t, m, tea.KeyMsg{Type: tea.KeyEsc})
+	if quit || !m.ConfirmQuitMode {
+		t.Fatalf("Expected esc to ask first, quit=%v ConfirmQuitMode=%v", quit, m.ConfirmQuitMode)
+	}
+	if view := util.StripANSI(m.View()); !strings.Contains(view, "Quit? (y/n)") {
+		t.Errorf("Expected the prompt in the status bar:\n%s", view)
+	}
+
+	m, quit = sendKey(t, m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+	if quit || m.ConfirmQuitMode {
+		t.Errorf("Expected n to stay in the app, quit=%v ConfirmQuitMode=%v", quit, m.ConfirmQuitMode)
+	}
+}
+
+func TestConfirmQuit_EscThenYQuits(t *testing.T) {
+	m := confirmQuitModel()
+
+	m, _ = sendKey(t, m, tea.KeyMsg{Type: tea.KeyEsc})
+	_, quit := sendKey(t, m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+
+	if !quit {
+		t.Error("Expected y to quit")
+	}
+}
+
+func TestConfirmQuit_EscTwiceStays(t *testing.T) {
+	m := confirmQuitModel()
+
+	m, _ = sendKey(t, m, tea.KeyMsg{Type: tea.KeyEsc})
+	m, quit := sendKey(t, m, tea.KeyMsg{Type: tea.KeyEsc})
+
+	if quit || m.ConfirmQuitMode {
+		t.Errorf("Expected a second esc to cancel, quit=%v ConfirmQuitMode=%v", quit, m.ConfirmQuitMode)
+	}
+}
+
+func TestConfirmQuit_CtrlCQuitsRightAway(t *testing.T) {
+	m := confirmQuitModel()
+
+	if _, quit := sendKey(t, m, tea.KeyMsg{Type: tea.KeyCtrlC}); !quit {
+		t.Error("Expected ctrl+c to quit without asking")
+	}
+
+	m, _ = sendKey(t, m, tea.KeyMsg{Type: tea.KeyEsc})
+	if _, quit := sendKey(t, m, tea.KeyMsg{Type: tea.KeyCtrlC}); !quit {
+		t.Error("Expected ctrl+c to quit from the prompt")
+	}
+}
+
+func TestConfirmQuit_OffByDefault(t *testing.T) {
+	m := testModelWithMarkdown("- [ ] One\n")
+
+	if _, quit := sendKey(t, m, tea.KeyMsg{Type: tea.KeyEsc}); !quit {
+		t.Error("Expected esc to quit without confirm_quit")
+	}
+}
+
+func TestConfirmQuit_WarnsAboutUnsavedChanges(t *testing.T) {
+	m := confirmQuitModel()
+	m.ReadOnly = true
+
+	m = pressKey(t, m, " ")
+	if !m.Unsaved {
+		t.Fatal("Expected a read-only toggle to leave unsaved changes")
+	}
+	m, _ = sendKey(t, m, tea.KeyMsg{Type: tea.KeyEsc})
+
+	if view := util.StripANSI(m.View()); !strings.Contains(view, "Quit? Unsaved changes will be lost (y/n)") {
+		t.Errorf("Expected the unsaved changes warning:\n%s", view)
+	}
+}
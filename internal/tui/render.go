@@ -2,100 +2,245 @@ package tui
 
 import (
 	"fmt"
+	"net/url"
+	"path/filepath"
 	"regexp"
 	"strings"
 	"time"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/mattn/go-runewidth"
+	"github.com/niklas-heer/tdx/internal/markdown"
 	"github.com/niklas-heer/tdx/internal/util"
 )
 
 // Pre-compiled regexes for inline code rendering (performance optimization)
 var (
 	linkRe     = regexp.MustCompile(`\[([^\]]+)\]\(([^)]+)\)`)
+	imageRe    = regexp.MustCompile(`!\[([^\]]*)\]\(([^)]+)\)`)
+	schemeRe   = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9+.-]*:`)
 	codeRe     = regexp.MustCompile("`([^`]+)`")
 	tagRe      = regexp.MustCompile(`#([a-zA-Z0-9_-]+)`)
+	contextRe  = regexp.MustCompile(`(^|\s)(@[a-zA-Z0-9_-]+)(\(?)`)
+	projectRe  = regexp.MustCompile(`(^|\s)(\+[a-zA-Z0-9_-]+)(\(?)`)
 	priorityRe = regexp.MustCompile(`!p(\d+)`)
 	dueRe      = regexp.MustCompile(`@due\((\d{4}-\d{2}-\d{2})\)`)
+	timeRe     = regexp.MustCompile(`(?i)(^|\s)((?:est|spent):(?:\d+h(?:\d+m)?|\d+m))\b`)
+	relDueRe   = regexp.MustCompile(`(?i)(^|\s)(due:(?:today|tomorrow|mon|tue|wed|thu|fri|sat|sun|\+\d+d))\b`)
+
+	// Emphasis; the content can't start or end with a space, so "2 * 3 * 4" stays as is
+	boldRe   = regexp.MustCompile(`\*\*([^*\s](?:[^*]*[^*\s])?)\*\*`)
+	italicRe = regexp.MustCompile(`\*([^*\s](?:[^*]*[^*\s])?)\*`)
+	strikeRe = regexp.MustCompile(`~~([^~\s](?:[^~]*[^~\s])?)~~`)
+)
+
+// emphasisStyles pairs each emphasis regex with its style, in matching priority order
+var emphasisStyles = []struct {
+	re    *regexp.Regexp
+	style lipgloss.Style
+}{
+	{boldRe, lipgloss.NewStyle().Bold(true)},
+	{strikeRe, lipgloss.NewStyle().Strikethrough(true)},
+	{italicRe, lipgloss.NewStyle().Italic(true)},
+}
+
+// Hyperlinks controls how links are shown: as OSC 8 hyperlinks when true, or as
+// "text (url)" for terminals that don't support them. Set by main.go.
+var Hyperlinks = true
+
+// NoColor is set when colors are turned off with NO_COLOR or --no-color, so
+// the TUI shows no escape sequences at all. Set by main.go.
+var NoColor = false
+
+// labelColors maps markdown.LabelColors to terminal colors. The ANSI colors
+// follow the terminal's palette; orange and pink have no ANSI equivalent.
+var labelColors = map[string]lipgloss.Color{
+	"red":    "1",
+	"orange": "208",
+	"yellow": "3",
+	"green":  "2",
+	"blue":   "4",
+	"purple": "5",
+	"pink":   "213",
+	"gray":   "8",
+}
+
+// LabelStyle returns the text style of todos labelled with the given color
+func LabelStyle(color string) lipgloss.Style {
+	return lipgloss.NewStyle().Foreground(labelColors[color])
+}
+
+// Glyphs shown in front of images and links to local files
+const (
+	imageGlyph      = "🖼"
+	attachmentGlyph = "📎"
 )
 
 // RenderInlineCode renders text with backtick-enclosed code and markdown links highlighted
 func RenderInlineCode(text string, isChecked bool, magentaStyle, cyanStyle, codeStyleFunc func(string) string) string {
+	return RenderInlineCodeIn("", text, isChecked, magentaStyle, cyanStyle, codeStyleFunc)
+}
+
+// RenderInlineCodeIn is RenderInlineCode with relative attachment paths opened
+// from dir (the working directory when empty)
+func RenderInlineCodeIn(dir, text string, isChecked bool, magentaStyle, cyanStyle, codeStyleFunc func(string) string) string {
 	// Use unique markers to preserve links and code blocks during processing
 	type segment struct {
-		text   string
-		isLink bool
-		isCode bool
-		url    string
+		text    string
+		isLink  bool
+		isImage bool
+		isCode  bool
+		url     string
 	}
 
 	var segments []segment
 	remaining := text
 
 	for len(remaining) > 0 {
-		linkMatch := linkRe.FindStringSubmatchIndex(remaining)
-		codeMatch := codeRe.FindStringSubmatchIndex(remaining)
-
-		// Determine which comes first
-		nextLink := -1
-		nextCode := -1
-		if linkMatch != nil {
-			nextLink = linkMatch[0]
-		}
-		if codeMatch != nil {
-			nextCode = codeMatch[0]
+		// Find the earliest link, image or code span; an image starts one byte
+		// before its link, so it wins over the link inside it
+		var match []int
+		kind := ""
+		for _, c := range []struct {
+			re   *regexp.Regexp
+			kind string
+		}{{imageRe, "image"}, {linkRe, "link"}, {codeRe, "code"}} {
+			if loc := c.re.FindStringSubmatchIndex(remaining); loc != nil && (match == nil || loc[0] < match[0]) {
+				match, kind = loc, c.kind
+			}
 		}
 
-		if nextLink == -1 && nextCode == -1 {
+		if match == nil {
 			// No more special elements
 			segments = append(segments, segment{text: remaining})
 			break
 		}
 
-		if nextLink != -1 && (nextCode == -1 || nextLink < nextCode) {
-			// Link comes first
-			if nextLink > 0 {
-				segments = append(segments, segment{text: remaining[:nextLink]})
-			}
-			linkText := remaining[linkMatch[2]:linkMatch[3]]
-			url := remaining[linkMatch[4]:linkMatch[5]]
-			segments = append(segments, segment{text: linkText, isLink: true, url: url})
-			remaining = remaining[linkMatch[1]:]
-		} else {
-			// Code comes first
-			if nextCode > 0 {
-				segments = append(segments, segment{text: remaining[:nextCode]})
-			}
-			code := remaining[codeMatch[2]:codeMatch[3]]
-			segments = append(segments, segment{text: code, isCode: true})
-			remaining = remaining[codeMatch[1]:]
+		if match[0] > 0 {
+			segments = append(segments, segment{text: remaining[:match[0]]})
+		}
+		content := remaining[match[2]:match[3]]
+		switch kind {
+		case "image":
+			segments = append(segments, segment{text: content, isImage: true, url: remaining[match[4]:match[5]]})
+		case "link":
+			segments = append(segments, segment{text: content, isLink: true, url: remaining[match[4]:match[5]]})
+		default:
+			segments = append(segments, segment{text: content, isCode: true})
 		}
+		remaining = remaining[match[1]:]
 	}
 
 	// Build result
 	var result strings.Builder
 	for _, seg := range segments {
-		if seg.isLink {
-			// OSC 8 hyperlink with cyan text
-			result.WriteString(fmt.Sprintf("\x1b]8;;%s\x1b\\%s\x1b]8;;\x1b\\", seg.url, cyanStyle(seg.text)))
+		if seg.isLink || seg.isImage {
+			// Cyan link text; emphasis inside the label is shown unstyled
+			label := stripEmphasis(seg.text)
+			if seg.isImage && label == "" {
+				label = filepath.Base(seg.url)
+			}
+			href := seg.url
+			display := label
+			if seg.isImage || isLocalLink(seg.url) {
+				href = attachmentURL(seg.url, dir)
+				glyph := attachmentGlyph
+				if seg.isImage {
+					glyph = imageGlyph
+				}
+				display = glyph + " " + label
+			}
+
+			if Hyperlinks {
+				// OSC 8 hyperlink
+				result.WriteString(fmt.Sprintf("\x1b]8;;%s\x1b\\%s\x1b]8;;\x1b\\", href, cyanStyle(display)))
+			} else if label == seg.url {
+				result.WriteString(cyanStyle(display))
+			} else {
+				result.WriteString(cyanStyle(display + " (" + seg.url + ")"))
+			}
 		} else if seg.isCode {
 			result.WriteString(codeStyleFunc(" " + seg.text + " "))
 		} else {
 			// Regular text - apply magenta if checked
+			textStyle := func(s string) string { return s }
 			if isChecked {
-				result.WriteString(magentaStyle(seg.text))
-			} else {
-				result.WriteString(seg.text)
+				textStyle = magentaStyle
 			}
+			result.WriteString(renderEmphasis(seg.text, textStyle))
 		}
 	}
 
 	return result.String()
 }
 
-// RenderHelp renders the help screen
-func RenderHelp(version string, cyanStyle, dimStyle func(string) string) string {
+// isLocalLink reports whether a link target is a file rather than a web page
+// or an anchor
+func isLocalLink(target string) bool {
+	if strings.HasPrefix(target, "#") {
+		return false
+	}
+	return !schemeRe.MatchString(target) || strings.HasPrefix(strings.ToLower(target), "file:")
+}
+
+// attachmentURL turns a local link target into an absolute file:// URL so the
+// terminal can open it. Targets with a scheme are returned unchanged.
+func attachmentURL(target, dir string) string {
+	if schemeRe.MatchString(target) {
+		return target
+	}
+	path := target
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(dir, path)
+	}
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	return (&url.URL{Scheme: "file", Path: filepath.ToSlash(path)}).String()
+}
+
+// renderEmphasis styles **bold**, *italic* and ~~strikethrough~~ text without
+// the markers. textStyle is applied to all text, inside emphasis or not.
+func renderEmphasis(text string, textStyle func(string) string) string {
+	if !strings.ContainsAny(text, "*~") {
+		return textStyle(text)
+	}
+
+	var b strings.Builder
+	for len(text) > 0 {
+		// Find the earliest emphasis; on a tie the first in emphasisStyles wins
+		var match []int
+		var style lipgloss.Style
+		for _, e := range emphasisStyles {
+			if loc := e.re.FindStringSubmatchIndex(text); loc != nil && (match == nil || loc[0] < match[0]) {
+				match, style = loc, e.style
+			}
+		}
+		if match == nil {
+			b.WriteString(textStyle(text))
+			break
+		}
+
+		if match[0] > 0 {
+			b.WriteString(textStyle(text[:match[0]]))
+		}
+		b.WriteString(style.Render(textStyle(text[match[2]:match[3]])))
+		text = text[match[1]:]
+	}
+	return b.String()
+}
+
+// stripEmphasis removes emphasis markers, keeping the text between them
+func stripEmphasis(text string) string {
+	for _, e := range emphasisStyles {
+		text = e.re.ReplaceAllString(text, "$1")
+	}
+	return text
+}
+
+// RenderHelp renders the help screen. The sections sit side by side when they fit
+// in width (0 = unknown) and are stacked in a single column otherwise.
+func RenderHelp(version string, width int, cyanStyle, dimStyle func(string) string) string {
 	var b strings.Builder
 
 	title := cyanStyle("tdx") + " " + dimStyle("v"+version)
@@ -118,21 +263,36 @@ func RenderHelp(version string, cyanStyle, dimStyle func(string) string) string
 				{"j", "Down"},
 				{"k", "Up"},
 				{"5j", "Jump 5 down"},
+				{".", "Go to todo #"},
+				{"^d/^u", "Half page"},
+				{"*", "Next same tag"},
+				{"[/]", "Prev/next section"},
 				{"/", "Search"},
 				{"t", "Filter tags"},
-				{"p", "Filter priority"},
+				{"@", "Filter contexts"},
+				{"+", "Filter projects"},
+				{"!", "Filter priority"},
 				{"D", "Filter due date"},
+				{"h", "Hide done"},
+				{"o", "Only overdue"},
+				{"w", "Word wrap"},
 			},
 		},
 		{
 			header: "EDITING",
 			entries: []entry{
 				{"␣", "Toggle"},
+				{"T", "Toggle subtree"},
 				{"n", "New after"},
 				{"N", "New at end"},
 				{"e", "Edit"},
+				{"E", "Edit in $EDITOR"},
+				{"a", "Add note"},
 				{"d", "Delete"},
 				{"c", "Copy"},
+				{"yy", "Yank"},
+				{"p/P", "Paste below/above"},
+				{"s", "Pin to top"},
 				{"m", "Move"},
 				{"Tab", "Indent"},
 				{"S-Tab", "Outdent"},
@@ -143,6 +303,7 @@ func RenderHelp(version string, cyanStyle, dimStyle func(string) string) string
 			entries: []entry{
 				{"u", "Undo"},
 				{"r", "Recent files"},
+				{"^w", "Switch split pane"},
 				{"?", "Help"},
 				{"esc", "Quit"},
 			},
@@ -170,8 +331,11 @@ func RenderHelp(version string, cyanStyle, dimStyle func(string) string) string
 		}
 	}
 
+	footer := dimStyle("  Press ") + cyanStyle("?") + dimStyle(" or ") + cyanStyle("esc") + dimStyle(" to close help")
+
 	// Calculate column widths: key + gap + desc + padding
 	colWidths := make([]int, len(columns))
+	totalWidth := 2 // Left margin
 	for i, col := range columns {
 		// Column width is max of: header width OR (keyWidth + 2 + descWidth)
 		entryWidth := keyWidths[i] + 2 + descWidths[i]
@@ -183,6 +347,31 @@ func RenderHelp(version string, cyanStyle, dimStyle func(string) string) string
 		}
 		// Add padding between columns
 		colWidths[i] += 2
+		totalWidth += colWidths[i]
+	}
+
+	// Narrow terminal: stack the sections in a single column
+	if width > 0 && totalWidth > width {
+		keyWidth := 0
+		for _, kw := range keyWidths {
+			keyWidth = max(keyWidth, kw)
+		}
+		descIndent := strings.Repeat(" ", 2+keyWidth+2)
+
+		for i, col := range columns {
+			if i > 0 {
+				b.WriteString("\n")
+			}
+			b.WriteString("  " + cyanStyle(col.header) + "\n")
+			for _, e := range col.entries {
+				keyPad := keyWidth - displayWidth(e.key)
+				descLines := util.WrapText(e.desc, width-len(descIndent), descIndent)
+				b.WriteString("  " + cyanStyle(e.key) + strings.Repeat(" ", keyPad) + "  " + strings.Join(descLines, "\n") + "\n")
+			}
+		}
+		b.WriteString("\n")
+		b.WriteString(footer)
+		return b.String()
 	}
 
 	// Find max rows
@@ -225,7 +414,7 @@ func RenderHelp(version string, cyanStyle, dimStyle func(string) string) string
 	}
 
 	b.WriteString("\n")
-	b.WriteString(dimStyle("  Press ") + cyanStyle("?") + dimStyle(" or ") + cyanStyle("esc") + dimStyle(" to close help"))
+	b.WriteString(footer)
 
 	return b.String()
 }
@@ -261,6 +450,9 @@ func RenderTodoLine(
 				b.WriteString(line + "\n")
 			}
 		}
+	} else if termWidth > 0 {
+		// Without wrapping, cut overlong lines instead of letting the terminal wrap them
+		b.WriteString(prefix + util.TruncateText(text, termWidth-prefixWidth) + "\n")
 	} else {
 		b.WriteString(prefix + text + "\n")
 	}
@@ -268,6 +460,55 @@ func RenderTodoLine(
 	return b.String()
 }
 
+// RenderTodoNotes renders the note lines of a todo dimmed beneath it, aligned with the todo text
+func RenderTodoNotes(notes []string, wordWrap bool, termWidth int, prefixWidth int, dimStyle func(string) string) string {
+	var b strings.Builder
+	indent := strings.Repeat(" ", prefixWidth)
+
+	for _, note := range notes {
+		if note == "" {
+			continue
+		}
+		lines := []string{note}
+		if wordWrap && termWidth > 0 {
+			lines = util.WrapText(note, termWidth-prefixWidth, "")
+		} else if termWidth > 0 {
+			lines = []string{util.TruncateText(note, termWidth-prefixWidth)}
+		}
+		for _, line := range lines {
+			b.WriteString(indent + dimStyle(line) + "\n")
+		}
+	}
+
+	return b.String()
+}
+
+// progressBarWidth is the number of cells between the brackets of the progress bar
+const progressBarWidth = 10
+
+// RenderProgressBar renders a completion bar like "[=====     ] 50% (5/10)"
+func RenderProgressBar(done, total, width int) string {
+	percent := 0
+	if total > 0 {
+		percent = done * 100 / total
+	}
+	filled := 0
+	if total > 0 {
+		filled = done * width / total
+	}
+	bar := runewidth.FillRight(strings.Repeat("=", filled), width)
+	return fmt.Sprintf("[%s] %d%% (%d/%d)", bar, percent, done, total)
+}
+
+// inputCursor returns the cursor shown in text inputs: a reversed space, or a
+// block when colors are off and a reversed space would show as a plain one
+func inputCursor() string {
+	if NoColor {
+		return "█"
+	}
+	return lipgloss.NewStyle().Reverse(true).Render(" ")
+}
+
 // ModeIndicator creates a styled mode indicator box
 func ModeIndicator(icon, label string) string {
 	// Use double space for wide emojis (like 🏷, 🔍) to align properly
@@ -286,9 +527,45 @@ func ColorizeTags(text string, tagStyle func(string) string) string {
 	})
 }
 
-// ColorizePriorities highlights priority markers (!p1, !p2, etc.) with appropriate colors
-// p1 = high (critical), p2 = medium (high), p3/p4+ = low
-func ColorizePriorities(text string, highStyle, mediumStyle, lowStyle func(string) string) string {
+// ColorizeContexts highlights @contexts with the given style, leaving @due(...) untouched
+func ColorizeContexts(text string, contextStyle func(string) string) string {
+	return colorizeWords(contextRe, text, contextStyle)
+}
+
+// ColorizeProjects highlights +projects with the given style
+func ColorizeProjects(text string, projectStyle func(string) string) string {
+	return colorizeWords(projectRe, text, projectStyle)
+}
+
+// ColorizeTimeTokens highlights est:2h and spent:45m time tracking tokens with the given style
+func ColorizeTimeTokens(text string, timeStyle func(string) string) string {
+	if timeStyle == nil {
+		return text
+	}
+	return timeRe.ReplaceAllStringFunc(text, func(match string) string {
+		submatch := timeRe.FindStringSubmatch(match)
+		return submatch[1] + timeStyle(submatch[2])
+	})
+}
+
+// colorizeWords styles the word captured by re, skipping function-like tokens such as @due(...)
+func colorizeWords(re *regexp.Regexp, text string, style func(string) string) string {
+	return re.ReplaceAllStringFunc(text, func(match string) string {
+		submatch := re.FindStringSubmatch(match)
+		if submatch[3] == "(" {
+			return match
+		}
+		return submatch[1] + style(submatch[2])
+	})
+}
+
+// ColorizePriorities highlights priority markers (!p1, !p2, etc.) with the style of their
+// level: the first style for p1, the second for p2, and so on. Usually these are
+// high (critical), medium (high) and low for p3/p4+.
+func ColorizePriorities(text string, levelStyles ...func(string) string) string {
+	if len(levelStyles) == 0 {
+		return text
+	}
 	return priorityRe.ReplaceAllStringFunc(text, func(match string) string {
 		// Extract priority number
 		submatch := priorityRe.FindStringSubmatch(match)
@@ -299,21 +576,35 @@ func ColorizePriorities(text string, highStyle, mediumStyle, lowStyle func(strin
 		priority := 0
 		_, _ = fmt.Sscanf(submatch[1], "%d", &priority)
 
-		switch priority {
-		case 1:
-			return highStyle(match)
-		case 2:
-			return mediumStyle(match)
-		default:
-			return lowStyle(match)
+		// Levels past the configured ones (and !p0) use the lowest style
+		level := priority - 1
+		if level < 0 || level >= len(levelStyles) {
+			level = len(levelStyles) - 1
 		}
+		return levelStyles[level](match)
 	})
 }
 
-// ColorizeDueDates highlights due date markers (@due(YYYY-MM-DD)) with appropriate colors
-// based on urgency: overdue/today = urgent, soon (3 days) = soon, future = future
+// ColorizeDueDates highlights due date markers (@due(YYYY-MM-DD)) and relative tokens
+// (due:tomorrow) with appropriate colors based on urgency: overdue/today = urgent,
+// soon (3 days) = soon, future = future. Marker dates are shown in markdown.DateFormat.
 func ColorizeDueDates(text string, urgentStyle, soonStyle, futureStyle func(string) string) string {
-	return dueRe.ReplaceAllStringFunc(text, func(match string) string {
+	styleFor := func(dueDate time.Time) func(string) string {
+		today := time.Now().Truncate(24 * time.Hour)
+		dueDay := dueDate.Truncate(24 * time.Hour)
+
+		if dueDay.Before(today) || dueDay.Equal(today) {
+			// Overdue or due today
+			return urgentStyle
+		} else if dueDay.Before(today.AddDate(0, 0, 4)) {
+			// Due soon (within 3 days)
+			return soonStyle
+		}
+		// Future
+		return futureStyle
+	}
+
+	text = dueRe.ReplaceAllStringFunc(text, func(match string) string {
 		// Extract date string
 		submatch := dueRe.FindStringSubmatch(match)
 		if len(submatch) < 2 {
@@ -325,19 +616,21 @@ func ColorizeDueDates(text string, urgentStyle, soonStyle, futureStyle func(stri
 		if err != nil {
 			return match
 		}
+		if markdown.DateFormat != markdown.DateFormatISO {
+			// Only the display changes, the file keeps the ISO date
+			match = "@due(" + markdown.FormatDate(dueDate, markdown.DateFormat) + ")"
+		}
+		return styleFor(dueDate)(match)
+	})
 
-		today := time.Now().Truncate(24 * time.Hour)
-		dueDay := dueDate.Truncate(24 * time.Hour)
-
-		if dueDay.Before(today) || dueDay.Equal(today) {
-			// Overdue or due today
-			return urgentStyle(match)
-		} else if dueDay.Before(today.AddDate(0, 0, 4)) {
-			// Due soon (within 3 days)
-			return soonStyle(match)
-		} else {
-			// Future
-			return futureStyle(match)
+	return relDueRe.ReplaceAllStringFunc(text, func(match string) string {
+		submatch := relDueRe.FindStringSubmatch(match)
+		dueDate := markdown.ExtractDueDate(submatch[2])
+		if dueDate == nil {
+			return match
 		}
+		// Resolved dates are local midnight; compare by calendar day like parsed markers
+		y, mo, d := dueDate.Date()
+		return submatch[1] + styleFor(time.Date(y, mo, d, 0, 0, 0, 0, time.UTC))(submatch[2])
 	})
 }
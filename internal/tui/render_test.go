@@ -1,8 +1,14 @@
 package tui
 
 import (
+	"path/filepath"
 	"strings"
 	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+	"github.com/niklas-heer/tdx/internal/markdown"
+	"github.com/niklas-heer/tdx/internal/util"
 )
 
 // TestRenderInlineCode_Links tests that markdown links are properly rendered
@@ -168,3 +174,331 @@ func TestRenderTodoLine_LinksWithoutWordWrap(t *testing.T) {
 		t.Errorf("Result should not contain markdown syntax, got: %q", result)
 	}
 }
+
+func TestRenderHelp_NarrowTerminalStacksSections(t *testing.T) {
+	identity := func(s string) string { return s }
+
+	help := RenderHelp("1.0.0", 40, identity, identity)
+
+	for _, line := range strings.Split(help, "\n") {
+		if w := runewidth.StringWidth(line); w > 40 {
+			t.Errorf("Line is %d columns wide, exceeds 40: %q", w, line)
+		}
+	}
+
+	// Sections appear one after another
+	nav := strings.Index(help, "NAVIGATION")
+	editing := strings.Index(help, "EDITING")
+	other := strings.Index(help, "OTHER")
+	if nav == -1 || editing == -1 || other == -1 {
+		t.Fatalf("Missing section header:\n%s", help)
+	}
+	if strings.Count(help[:editing], "\n") <= strings.Count(help[:nav], "\n") ||
+		strings.Count(help[:other], "\n") <= strings.Count(help[:editing], "\n") {
+		t.Errorf("Expected sections on separate lines:\n%s", help)
+	}
+	if !strings.Contains(help, "to close help") {
+		t.Errorf("Missing footer:\n%s", help)
+	}
+}
+
+func TestRenderHelp_WideTerminalKeepsColumns(t *testing.T) {
+	identity := func(s string) string { return s }
+
+	for _, width := range []int{0, 120} {
+		help := RenderHelp("1.0.0", width, identity, identity)
+
+		headerLine := ""
+		for _, line := range strings.Split(help, "\n") {
+			if strings.Contains(line, "NAVIGATION") {
+				headerLine = line
+				break
+			}
+		}
+		if !strings.Contains(headerLine, "EDITING") || !strings.Contains(headerLine, "OTHER") {
+			t.Errorf("width %d: expected all section headers on one line, got %q", width, headerLine)
+		}
+	}
+}
+
+func TestView_HelpUsesTerminalWidth(t *testing.T) {
+	m := testModel([]string{"Task"})
+	m.HelpMode = true
+	m.TermWidth = 40
+
+	for _, line := range strings.Split(m.View(), "\n") {
+		if w := runewidth.StringWidth(line); w > 40 {
+			t.Errorf("Help line exceeds terminal width: %q", line)
+		}
+	}
+}
+
+func TestRenderInlineCode_Emphasis(t *testing.T) {
+	identity := func(s string) string { return s }
+	bold := lipgloss.NewStyle().Bold(true)
+	italic := lipgloss.NewStyle().Italic(true)
+	strike := lipgloss.NewStyle().Strikethrough(true)
+
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"Ship **now** please", "Ship " + bold.Render("now") + " please"},
+		{"Read *slowly*", "Read " + italic.Render("slowly")},
+		{"~~Old~~ plan", strike.Render("Old") + " plan"},
+		{"**a** and *b*", bold.Render("a") + " and " + italic.Render("b")},
+		{"2 * 3 * 4", "2 * 3 * 4"},
+		{"Unclosed **bold", "Unclosed **bold"},
+	}
+	for _, tt := range tests {
+		if got := RenderInlineCode(tt.input, false, identity, identity, identity); got != tt.want {
+			t.Errorf("RenderInlineCode(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestRenderInlineCode_EmphasisHidesMarkers(t *testing.T) {
+	identity := func(s string) string { return s }
+
+	result := RenderInlineCode("Fix **x** now", false, identity, identity, identity)
+	if strings.Contains(result, "*") {
+		t.Errorf("Expected no literal asterisks, got %q", result)
+	}
+	if got := runewidth.StringWidth(util.StripANSI(result)); got != len("Fix x now") {
+		t.Errorf("Display width = %d, want %d", got, len("Fix x now"))
+	}
+}
+
+func TestRenderInlineCode_EmphasisCheckedKeepsStyle(t *testing.T) {
+	identity := func(s string) string { return s }
+	magenta := func(s string) string { return "<m>" + s + "</m>" }
+
+	result := RenderInlineCode("Done **x** here", true, magenta, identity, identity)
+	if !strings.Contains(result, "<m>Done </m>") || !strings.Contains(result, "<m>x</m>") || !strings.Contains(result, "<m> here</m>") {
+		t.Errorf("Expected every part of a completed todo in the checked style, got %q", result)
+	}
+	if strings.Contains(result, "**") {
+		t.Errorf("Expected markers removed, got %q", result)
+	}
+}
+
+func TestRenderInlineCode_EmphasisInLinkLabel(t *testing.T) {
+	identity := func(s string) string { return s }
+	cyan := func(s string) string { return "<c>" + s + "</c>" }
+
+	result := RenderInlineCode("See [the **docs**](https://example.com)", false, identity, cyan, identity)
+	if !strings.Contains(result, "<c>the docs</c>") {
+		t.Errorf("Expected link label without markers, got %q", result)
+	}
+}
+
+// withHyperlinks sets the hyperlink mode for the duration of a test
+func withHyperlinks(t *testing.T, enabled bool) {
+	t.Helper()
+	old := Hyperlinks
+	Hyperlinks = enabled
+	t.Cleanup(func() { Hyperlinks = old })
+}
+
+func TestRenderInlineCode_HyperlinksNever(t *testing.T) {
+	withHyperlinks(t, false)
+	cyan := func(s string) string { return "<c>" + s + "</c>" }
+	identity := func(s string) string { return s }
+
+	result := RenderInlineCode("Read [the docs](https://example.com) now", false, identity, cyan, identity)
+	if strings.Contains(result, "\x1b]8") {
+		t.Errorf("Expected no OSC 8 escapes, got %q", result)
+	}
+	if result != "Read <c>the docs (https://example.com)</c> now" {
+		t.Errorf("Expected text (url) in the link style, got %q", result)
+	}
+
+	// A link whose text is its URL isn't repeated
+	result = RenderInlineCode("[https://example.com](https://example.com)", false, identity, cyan, identity)
+	if result != "<c>https://example.com</c>" {
+		t.Errorf("got %q", result)
+	}
+}
+
+func TestRenderInlineCode_HyperlinksAlways(t *testing.T) {
+	withHyperlinks(t, true)
+	identity := func(s string) string { return s }
+
+	result := RenderInlineCode("Read [the docs](https://example.com)", false, identity, identity, identity)
+	if !strings.Contains(result, "\x1b]8;;https://example.com\x1b\\") {
+		t.Errorf("Expected an OSC 8 hyperlink, got %q", result)
+	}
+	if strings.Contains(result, "(https://example.com)") {
+		t.Errorf("URL should not be shown inline, got %q", result)
+	}
+}
+
+func TestRenderInlineCode_ImageShowsGlyph(t *testing.T) {
+	withHyperlinks(t, true)
+	identity := func(s string) string { return s }
+
+	result := RenderInlineCode("See ![diagram](/tmp/arch.png) first", false, identity, identity, identity)
+	visible := util.StripANSI(result)
+	if visible != "See "+imageGlyph+" diagram first" {
+		t.Errorf("Visible text = %q", visible)
+	}
+	if strings.ContainsAny(visible, "![]()") {
+		t.Errorf("Image syntax leaked into %q", visible)
+	}
+	if !strings.Contains(result, "\x1b]8;;file:///tmp/arch.png\x1b\\") {
+		t.Errorf("Expected a file:// hyperlink, got %q", result)
+	}
+}
+
+func TestRenderInlineCode_ImageWithoutAltUsesFileName(t *testing.T) {
+	withHyperlinks(t, false)
+	identity := func(s string) string { return s }
+
+	result := RenderInlineCode("![](shots/bug.png)", false, identity, identity, identity)
+	if result != imageGlyph+" bug.png (shots/bug.png)" {
+		t.Errorf("got %q", result)
+	}
+}
+
+func TestRenderInlineCode_AttachmentLinks(t *testing.T) {
+	withHyperlinks(t, true)
+	identity := func(s string) string { return s }
+	dir := t.TempDir()
+
+	result := RenderInlineCodeIn(dir, "Sign [contract](docs/contract.pdf)", false, identity, identity, identity)
+	if util.StripANSI(result) != "Sign "+attachmentGlyph+" contract" {
+		t.Errorf("Visible text = %q", util.StripANSI(result))
+	}
+	want := "file://" + filepath.ToSlash(filepath.Join(dir, "docs", "contract.pdf"))
+	if !strings.Contains(result, "\x1b]8;;"+want+"\x1b\\") {
+		t.Errorf("Expected a hyperlink to %s, got %q", want, result)
+	}
+
+	// Web links and anchors are not attachments
+	for _, text := range []string{"[site](https://example.com)", "[up](#top)"} {
+		if got := RenderInlineCodeIn(dir, text, false, identity, identity, identity); strings.Contains(got, attachmentGlyph) {
+			t.Errorf("%s: unexpected attachment glyph in %q", text, got)
+		}
+	}
+}
+
+func TestRenderInlineCode_ImageInCodeSpanIsCode(t *testing.T) {
+	identity := func(s string) string { return s }
+
+	result := RenderInlineCode("Use `![alt](src)` syntax", false, identity, identity, identity)
+	if strings.Contains(result, imageGlyph) || !strings.Contains(result, "![alt](src)") {
+		t.Errorf("got %q", result)
+	}
+}
+
+func TestColorizePriorities_OutOfRangeUsesLowest(t *testing.T) {
+	tag := func(name string) func(string) string {
+		return func(s string) string { return name + "(" + s + ")" }
+	}
+	high, medium, low := tag("high"), tag("medium"), tag("low")
+
+	if got := ColorizePriorities("Later !p5", high, medium, low); got != "Later low(!p5)" {
+		t.Errorf("!p5 with three levels = %q, want the lowest style", got)
+	}
+	if got := ColorizePriorities("Now !p1", high, medium, low); got != "Now high(!p1)" {
+		t.Errorf("!p1 = %q, want the high style", got)
+	}
+	if got := ColorizePriorities("Odd !p0", high, medium, low); got != "Odd low(!p0)" {
+		t.Errorf("!p0 = %q, want the lowest style", got)
+	}
+	if got := ColorizePriorities("No styles !p1"); got != "No styles !p1" {
+		t.Errorf("Without styles = %q, want the text unchanged", got)
+	}
+}
+
+func TestColorizePriorities_ConfiguredLevels(t *testing.T) {
+	var levels []func(string) string
+	for _, name := range []string{"l1", "l2", "l3", "l4", "l5"} {
+		levels = append(levels, func(s string) string { return name + "(" + s + ")" })
+	}
+
+	if got := ColorizePriorities("Task !p5", levels...); got != "Task l5(!p5)" {
+		t.Errorf("!p5 = %q, want the fifth level style", got)
+	}
+	if got := ColorizePriorities("Task !p4", levels...); got != "Task l4(!p4)" {
+		t.Errorf("!p4 = %q, want the fourth level style", got)
+	}
+	if got := ColorizePriorities("Task !p9", levels...); got != "Task l5(!p9)" {
+		t.Errorf("!p9 = %q, want the last level style", got)
+	}
+}
+
+func TestPriorityStyles_ViewWithHighLevel(t *testing.T) {
+	m := testModelWithMarkdown("- [ ] Urgent !p1\n- [ ] Someday !p5\n")
+	if len(m.AvailablePriorities) != 2 || m.AvailablePriorities[1] != 5 {
+		t.Fatalf("AvailablePriorities = %v, want [1 5]", m.AvailablePriorities)
+	}
+
+	styles := *testStyles()
+	styles.Priorities = []func(string) string{
+		func(s string) string { return "<1>" + s },
+		func(s string) string { return "<2>" + s },
+	}
+	m.styles = &styles
+
+	view := m.View()
+	if !strings.Contains(view, "<1>!p1") || !strings.Contains(view, "<2>!p5") {
+		t.Errorf("Expected configured level styles in view:\n%s", view)
+	}
+
+	// The priority filter overlay lists the p5 level too
+	m = pressKey(t, m, "!")
+	if view := m.View(); !strings.Contains(view, "!p5") {
+		t.Errorf("Expected p5 in the priority filter:\n%s", view)
+	}
+}
+
+func TestColorizeDueDates_RelativeTokens(t *testing.T) {
+	urgent := func(s string) string { return "U(" + s + ")" }
+	soon := func(s string) string { return "S(" + s + ")" }
+	future := func(s string) string { return "F(" + s + ")" }
+
+	tests := map[string]string{
+		"Pay rent due:today":    "Pay rent U(due:today)",
+		"Call mom due:tomorrow": "Call mom S(due:tomorrow)",
+		"Taxes due:+30d #home":  "Taxes F(due:+30d) #home",
+		"Not overdue:tomorrow":  "Not overdue:tomorrow",
+		"Unknown due:someday":   "Unknown due:someday",
+	}
+	for text, want := range tests {
+		if got := ColorizeDueDates(text, urgent, soon, future); got != want {
+			t.Errorf("ColorizeDueDates(%q) = %q, want %q", text, got, want)
+		}
+	}
+}
+
+func TestColorizeDueDates_DateFormat(t *testing.T) {
+	old := markdown.DateFormat
+	markdown.DateFormat = markdown.DateFormatUS
+	t.Cleanup(func() { markdown.DateFormat = old })
+	style := func(s string) string { return "<" + s + ">" }
+
+	got := ColorizeDueDates("Pay rent @due(2020-01-15) due:today", style, style, style)
+	if got != "Pay rent <@due(01/15/2020)> <due:today>" {
+		t.Errorf("got %q", got)
+	}
+}
+
+func TestInputCursor_NoColor(t *testing.T) {
+	old := NoColor
+	t.Cleanup(func() { NoColor = old })
+
+	NoColor = false
+	if got := util.StripANSI(inputCursor()); got != " " {
+		t.Errorf("inputCursor() = %q, want a reversed space", got)
+	}
+
+	NoColor = true
+	m := testModelWithMarkdown("- [ ] Task\n")
+	for _, key := range []string{":", "s", "o"} {
+		m = pressKey(t, m, key)
+	}
+	if view := m.View(); !strings.Contains(view, ":so█") {
+		t.Errorf("Expected a block cursor in the command line:\n%s", view)
+	}
+}
@@ -0,0 +1,85 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/niklas-heer/tdx/internal/markdown"
+)
+
+// renameTagArgs handles ":rename-tag old new"
+func (m *Model) renameTagArgs(arg string) {
+	fields := strings.Fields(arg)
+	if len(fields) != 2 {
+		m.setStatus("Usage: rename-tag <old> <new>")
+		return
+	}
+	oldTag, newTag := strings.TrimPrefix(fields[0], "#"), strings.TrimPrefix(fields[1], "#")
+	if !markdown.IsValidTagName(newTag) {
+		m.setStatus("Invalid tag #" + newTag + ": use letters, digits, - and _")
+		return
+	}
+
+	count := m.rewriteTags(oldTag, func(text string) string {
+		return markdown.RenameTag(text, oldTag, newTag)
+	})
+	if count > 0 {
+		m.setStatus(fmt.Sprintf("Renamed #%s to #%s on %s", oldTag, newTag, pluralTodos(count)))
+	}
+}
+
+// removeTagArgs handles ":remove-tag name"
+func (m *Model) removeTagArgs(arg string) {
+	fields := strings.Fields(arg)
+	if len(fields) != 1 {
+		m.setStatus("Usage: remove-tag <name>")
+		return
+	}
+	tag := strings.TrimPrefix(fields[0], "#")
+
+	count := m.rewriteTags(tag, func(text string) string {
+		return markdown.RemoveTag(text, tag)
+	})
+	if count > 0 {
+		m.setStatus(fmt.Sprintf("Removed #%s from %s", tag, pluralTodos(count)))
+	}
+}
+
+// rewriteTags applies rewrite to the text of every todo tagged #tag, as a
+// single undo step, and returns how many todos changed
+func (m *Model) rewriteTags(tag string, rewrite func(string) string) int {
+	if m.ReadOnly {
+		m.setStatus("Read-only mode")
+		return 0
+	}
+
+	changed := make(map[int]string)
+	for i, todo := range m.FileModel.Todos {
+		if text := rewrite(todo.Text); text != todo.Text {
+			changed[i] = text
+		}
+	}
+	if len(changed) == 0 {
+		m.setStatus("No todos tagged #" + tag)
+		return 0
+	}
+
+	m.saveHistory()
+	for i, text := range changed {
+		_ = m.FileModel.UpdateTodoItem(i, text, m.FileModel.Todos[i].Checked)
+	}
+
+	m.InvalidateDocumentTree()
+	m.RefreshAvailableTags()
+	m.writeIfPersist()
+	m.adjustSelectionForFilter()
+	return len(changed)
+}
+
+// pluralTodos returns "1 todo" or "n todos"
+func pluralTodos(n int) string {
+	if n == 1 {
+		return "1 todo"
+	}
+	return fmt.Sprintf("%d todos", n)
+}
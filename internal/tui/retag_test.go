@@ -0,0 +1,107 @@
+package tui
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/niklas-heer/tdx/internal/markdown"
+)
+
+const retagMarkdown = `# Todos
+
+- [ ] Write report #work
+- [x] Call client #Work #urgent
+- [ ] Plan sprint #work #home
+- [ ] Buy milk #home
+`
+
+func TestRenameTag_RewritesEveryTodo(t *testing.T) {
+	m := testModelWithMarkdown(retagMarkdown)
+
+	m = typeCommand(t, m, "rename-tag work job")
+
+	want := []string{"Write report #job", "Call client #job #urgent", "Plan sprint #job #home", "Buy milk #home"}
+	if got := todoTexts(m); !slices.Equal(got, want) {
+		t.Errorf("Todos = %q, want %q", got, want)
+	}
+	if !m.FileModel.Todos[1].Checked {
+		t.Error("Renaming a tag should keep the todo checked")
+	}
+	if got := markdown.GetAllTags(m.FileModel.Todos); !slices.Equal(got, []string{"home", "job", "urgent"}) {
+		t.Errorf("GetAllTags = %v, want [home job urgent]", got)
+	}
+	if !slices.Equal(m.AvailableTags, []string{"home", "job", "urgent"}) {
+		t.Errorf("AvailableTags = %v, want [home job urgent]", m.AvailableTags)
+	}
+	if m.StatusHint != "Renamed #work to #job on 3 todos" {
+		t.Errorf("StatusHint = %q", m.StatusHint)
+	}
+}
+
+func TestRenameTag_SingleUndoStep(t *testing.T) {
+	m := testModelWithMarkdown(retagMarkdown)
+	before := todoTexts(m)
+
+	m = typeCommand(t, m, "rename-tag #work #job")
+	m = pressKey(t, m, "u")
+
+	if got := todoTexts(m); !slices.Equal(got, before) {
+		t.Errorf("After one undo, todos = %q, want %q", got, before)
+	}
+}
+
+func TestRenameTag_RejectsInvalidTag(t *testing.T) {
+	m := testModelWithMarkdown(retagMarkdown)
+	before := todoTexts(m)
+
+	for _, input := range []string{"rename-tag work two words", "rename-tag work bad!", "rename-tag work"} {
+		m = typeCommand(t, m, input)
+		if got := todoTexts(m); !slices.Equal(got, before) {
+			t.Errorf("%q changed the todos: %q", input, got)
+		}
+		if m.StatusHint == "" {
+			t.Errorf("%q should explain why nothing happened", input)
+		}
+	}
+}
+
+func TestRenameTag_UnknownTag(t *testing.T) {
+	m := testModelWithMarkdown(retagMarkdown)
+
+	m = typeCommand(t, m, "rename-tag nope job")
+
+	if m.StatusHint != "No todos tagged #nope" {
+		t.Errorf("StatusHint = %q, want %q", m.StatusHint, "No todos tagged #nope")
+	}
+	if m.History != nil {
+		t.Error("Expected no undo step when nothing changed")
+	}
+}
+
+func TestRemoveTag_RemovesFromEveryTodo(t *testing.T) {
+	m := testModelWithMarkdown(retagMarkdown)
+
+	m = typeCommand(t, m, "remove-tag home")
+
+	want := []string{"Write report #work", "Call client #Work #urgent", "Plan sprint #work", "Buy milk"}
+	if got := todoTexts(m); !slices.Equal(got, want) {
+		t.Errorf("Todos = %q, want %q", got, want)
+	}
+	if slices.Contains(m.AvailableTags, "home") {
+		t.Errorf("AvailableTags = %v, want no home", m.AvailableTags)
+	}
+	if m.StatusHint != "Removed #home from 2 todos" {
+		t.Errorf("StatusHint = %q", m.StatusHint)
+	}
+}
+
+func TestRemoveTag_DropsStaleFilter(t *testing.T) {
+	m := testModelWithMarkdown(retagMarkdown)
+	m = typeCommand(t, m, "tag urgent")
+
+	m = typeCommand(t, m, "remove-tag urgent")
+
+	if len(m.FilteredTags) != 0 {
+		t.Errorf("FilteredTags = %v, want the removed tag's filter cleared", m.FilteredTags)
+	}
+}
@@ -0,0 +1,134 @@
+package tui
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/niklas-heer/tdx/internal/util"
+)
+
+var (
+	moreAboveRe = regexp.MustCompile(`▲ (\d+) more`)
+	moreBelowRe = regexp.MustCompile(`▼ (\d+) more`)
+	shownTaskRe = regexp.MustCompile(`\] (Task \d+)`)
+)
+
+// filteredListModel returns 20 todos where every even one is done, with
+// filter-done on and a window of 4, so 10 todos are listed
+func filteredListModel(t *testing.T) Model {
+	t.Helper()
+	var b strings.Builder
+	for i := 1; i <= 20; i++ {
+		check := " "
+		if i%2 == 0 {
+			check = "x"
+		}
+		fmt.Fprintf(&b, "- [%s] Task %d\n", check, i)
+	}
+	m := testModelWithMarkdown(b.String())
+	m.FilterDone = true
+	m.MaxVisibleOverride = 4
+	return m
+}
+
+// scrollIndicators returns the counts of the ▲/▼ indicators (0 when not shown)
+// and the todos drawn in between
+func scrollIndicators(t *testing.T, m Model) (above, below int, shown []string) {
+	t.Helper()
+	view := util.StripANSI(m.View())
+	if match := moreAboveRe.FindStringSubmatch(view); match != nil {
+		above, _ = strconv.Atoi(match[1])
+	}
+	if match := moreBelowRe.FindStringSubmatch(view); match != nil {
+		below, _ = strconv.Atoi(match[1])
+	}
+	for _, match := range shownTaskRe.FindAllStringSubmatch(view, -1) {
+		shown = append(shown, match[1])
+	}
+	return above, below, shown
+}
+
+// assertIndicatorCounts checks the indicators against the listed todos before
+// and after the ones drawn
+func assertIndicatorCounts(t *testing.T, m Model, wantAbove, wantBelow int) {
+	t.Helper()
+	above, below, shown := scrollIndicators(t, m)
+
+	listed := m.listTodos()
+	first := -1
+	for i, idx := range listed {
+		if len(shown) > 0 && m.FileModel.Todos[idx].Text == shown[0] {
+			first = i
+			break
+		}
+	}
+	if first < 0 {
+		t.Fatalf("Drawn todos %v aren't in the list", shown)
+	}
+
+	if above != wantAbove || above != first {
+		t.Errorf("▲ %d more, want %d (%d listed todos above the window)", above, wantAbove, first)
+	}
+	if hidden := len(listed) - first - len(shown); below != wantBelow || below != hidden {
+		t.Errorf("▼ %d more, want %d (%d listed todos below the window)", below, wantBelow, hidden)
+	}
+}
+
+func TestScrollIndicators_FilterDoneMidList(t *testing.T) {
+	m := filteredListModel(t)
+
+	// Move to the 6th open todo (Task 11)
+	for range 5 {
+		m = pressKey(t, m, "j")
+	}
+	if got := m.FileModel.Todos[m.SelectedIndex].Text; got != "Task 11" {
+		t.Fatalf("Selected %q, want Task 11", got)
+	}
+
+	// Window of 4 centered on position 5: positions 3-6 (Task 7 to Task 13)
+	assertIndicatorCounts(t, m, 3, 3)
+
+	_, _, shown := scrollIndicators(t, m)
+	for _, text := range shown {
+		n, _ := strconv.Atoi(strings.TrimPrefix(text, "Task "))
+		if n%2 == 0 {
+			t.Errorf("Completed %s drawn with filter-done on", text)
+		}
+	}
+}
+
+func TestScrollIndicators_FilterDoneAtEdges(t *testing.T) {
+	m := filteredListModel(t)
+	assertIndicatorCounts(t, m, 0, 6)
+
+	m = pressKey(t, m, "G")
+	assertIndicatorCounts(t, m, 6, 0)
+}
+
+func TestScrollIndicators_InsertAfterCursorMidList(t *testing.T) {
+	m := filteredListModel(t)
+	for range 5 {
+		m = pressKey(t, m, "j")
+	}
+
+	// The input line takes a row, leaving 3 todos around the cursor (Task 9 to Task 13)
+	m = pressKey(t, m, "n")
+	if !m.InputMode || !m.InsertAfterCursor {
+		t.Fatal("Expected insert-after-cursor input mode")
+	}
+	assertIndicatorCounts(t, m, 4, 3)
+}
+
+func TestScrollIndicators_AppendAtEnd(t *testing.T) {
+	m := filteredListModel(t)
+	for range 5 {
+		m = pressKey(t, m, "j")
+	}
+
+	// Appending shows the last 3 todos above the input line
+	m = pressKey(t, m, "N")
+	assertIndicatorCounts(t, m, 7, 0)
+}
@@ -0,0 +1,110 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestScrollWindowStart(t *testing.T) {
+	tests := []struct {
+		name                                  string
+		prevStart, pos, total, window, margin int
+		want                                  int
+	}{
+		{"cursor inside margin keeps window", 0, 5, 30, 10, 3, 0},
+		{"cursor at lower margin keeps window", 0, 6, 30, 10, 3, 0},
+		{"cursor past lower margin scrolls one row", 0, 7, 30, 10, 3, 1},
+		{"jump down scrolls just enough", 0, 20, 30, 10, 3, 14},
+		{"cursor at upper margin keeps window", 10, 13, 30, 10, 3, 10},
+		{"cursor past upper margin scrolls up", 10, 12, 30, 10, 3, 9},
+		{"top of list", 5, 1, 30, 10, 3, 0},
+		{"bottom of list", 14, 29, 30, 10, 3, 20},
+		{"margin larger than half the window", 0, 8, 30, 10, 20, 3},
+		{"no margin keeps a start that shows the cursor", 3, 8, 30, 10, 0, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := scrollWindowStart(tt.prevStart, tt.pos, tt.total, tt.window, tt.margin)
+			if got != tt.want {
+				t.Errorf("scrollWindowStart(%d, %d, %d, %d, %d) = %d, want %d",
+					tt.prevStart, tt.pos, tt.total, tt.window, tt.margin, got, tt.want)
+			}
+		})
+	}
+}
+
+// longListModel returns a model with n todos, a window of 10 and the given scroll margin
+func longListModel(n, margin int) Model {
+	var b strings.Builder
+	for i := 1; i <= n; i++ {
+		fmt.Fprintf(&b, "- [ ] Task %d\n", i)
+	}
+	m := testModelWithMarkdown(b.String())
+	cfg := *m.Config()
+	cfg.Display.ScrollMargin = margin
+	m.config = &cfg
+	m.MaxVisibleOverride = 10
+	return m
+}
+
+// windowStart returns the first list position currently shown
+func windowStart(m Model) int {
+	return m.listWindowStart(m.listTodos(), m.listWindowSize())
+}
+
+func TestScrollMargin_ScrollsWhenCursorReachesMargin(t *testing.T) {
+	m := longListModel(30, 3)
+
+	// Window 0-9: the cursor moves freely until row 6
+	for i := 1; i <= 6; i++ {
+		m = pressKey(t, m, "j")
+		if got := windowStart(m); got != 0 {
+			t.Fatalf("cursor %d: window start = %d, want 0", m.SelectedIndex, got)
+		}
+	}
+
+	// From then on each step scrolls by one row
+	for i := 7; i <= 12; i++ {
+		m = pressKey(t, m, "j")
+		if got, want := windowStart(m), i-6; got != want {
+			t.Fatalf("cursor %d: window start = %d, want %d", m.SelectedIndex, got, want)
+		}
+	}
+
+	// Moving back up keeps the window until the upper margin is reached
+	start := windowStart(m)
+	for m.SelectedIndex > start+3 {
+		m = pressKey(t, m, "k")
+		if got := windowStart(m); got != start {
+			t.Fatalf("cursor %d: window start = %d, want %d", m.SelectedIndex, got, start)
+		}
+	}
+	m = pressKey(t, m, "k")
+	if got := windowStart(m); got != start-1 {
+		t.Errorf("cursor %d: window start = %d, want %d", m.SelectedIndex, got, start-1)
+	}
+}
+
+func TestScrollMargin_EndOfList(t *testing.T) {
+	m := longListModel(30, 3)
+	m = pressKey(t, m, "G")
+
+	if got := windowStart(m); got != 20 {
+		t.Errorf("window start = %d, want 20 (last 10 todos)", got)
+	}
+	if view := m.View(); !strings.Contains(view, "▲ 20 more") || !strings.Contains(view, "Task 30") {
+		t.Errorf("Expected the end of the list:\n%s", view)
+	}
+}
+
+func TestScrollMargin_ZeroCentersCursor(t *testing.T) {
+	m := longListModel(30, 0)
+	for range 7 {
+		m = pressKey(t, m, "j")
+	}
+
+	if got := windowStart(m); got != 2 {
+		t.Errorf("window start = %d, want 2 (cursor 7 centered)", got)
+	}
+}
@@ -0,0 +1,128 @@
+package tui
+
+import (
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/niklas-heer/tdx/internal/markdown"
+	"github.com/niklas-heer/tdx/internal/util"
+)
+
+// searchPriorityRe matches priority terms in a search query: !p1, !1 or p1
+var searchPriorityRe = regexp.MustCompile(`^(?:!p?|p)(\d+)$`)
+
+// searchQuery is a parsed search input. Terms starting with # match tags, priority
+// terms match the todo's priority and the remaining words are matched against the text.
+// A todo has to match every part that is present.
+type searchQuery struct {
+	text       string   // Folded free text
+	tags       []string // Folded canonical tag terms without the leading #
+	priorities []int
+}
+
+// parseSearchQuery splits the search input into text, tag and priority terms
+func parseSearchQuery(input string) searchQuery {
+	var q searchQuery
+	var words []string
+	for _, word := range strings.Fields(input) {
+		if tag, ok := strings.CutPrefix(word, "#"); ok {
+			if tag != "" {
+				q.tags = append(q.tags, util.FoldText(markdown.CanonicalTag(tag)))
+			}
+			continue
+		}
+		if match := searchPriorityRe.FindStringSubmatch(strings.ToLower(word)); match != nil {
+			if priority, err := strconv.Atoi(match[1]); err == nil {
+				q.priorities = append(q.priorities, priority)
+				continue
+			}
+		}
+		words = append(words, word)
+	}
+	q.text = util.FoldText(strings.Join(words, " "))
+	return q
+}
+
+// score returns how well the todo matches the query, 0 if it doesn't
+func (q searchQuery) score(todo markdown.Todo) int {
+	score := 0
+
+	if q.text != "" {
+		score = util.FuzzyScore(q.text, util.FoldText(todo.Text))
+		if score == 0 {
+			return 0
+		}
+	}
+
+	for _, term := range q.tags {
+		best := 0
+		for _, tag := range todo.Tags {
+			best = max(best, util.FuzzyScore(term, util.FoldText(tag)))
+		}
+		if best == 0 {
+			return 0
+		}
+		score += best
+	}
+
+	for _, priority := range q.priorities {
+		if todo.Priority != priority {
+			return 0
+		}
+	}
+
+	return max(score, 1)
+}
+
+// searchScopeTodos returns the todos a search started now is scoped to: those
+// passing the active filters, within the selected todo's section when headings
+// are shown. A search started from a folded section isn't limited to it, so it
+// can jump into other folded sections. The scope is nil when it would hold
+// every todo.
+func (m *Model) searchScopeTodos() ([]int, string) {
+	inSection := m.ShowHeadings && len(m.GetHeadings()) > 0 && !m.isTodoFolded(m.SelectedIndex)
+	filtered := m.hasActiveFilters()
+	if !inSection && !filtered {
+		return nil, ""
+	}
+
+	section := m.sectionHeading(m.SelectedIndex)
+	var scope []int
+	for i := range m.FileModel.Todos {
+		if inSection && m.sectionHeading(i) != section {
+			continue
+		}
+		if filtered && !m.passesFilters(i) {
+			continue
+		}
+		scope = append(scope, i)
+	}
+	if len(scope) == len(m.FileModel.Todos) {
+		return nil, ""
+	}
+
+	switch {
+	case inSection && filtered:
+		return scope, "filtered section"
+	case inSection:
+		return scope, "section"
+	default:
+		return scope, "filtered"
+	}
+}
+
+// searchCandidates returns the todos the search looks at: the scope, unless
+// ctrl+g widened the search to all todos
+func (m *Model) searchCandidates() []int {
+	if m.searchScope != nil && !m.searchAll {
+		// The file may have changed on disk since the search started
+		return slices.DeleteFunc(slices.Clone(m.searchScope), func(i int) bool { return i >= len(m.FileModel.Todos) })
+	}
+	all := make([]int, len(m.FileModel.Todos))
+	for i := range all {
+		all[i] = i
+	}
+	return all
+}
@@ -0,0 +1,95 @@
+package tui
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// searchAndConfirm runs a search for query and confirms it with enter
+func searchAndConfirm(t *testing.T, m Model, query string) Model {
+	t.Helper()
+	m = pressKey(t, m, "/")
+	for _, r := range query {
+		m = pressKey(t, m, string(r))
+	}
+	return pressKeyType(t, m, tea.KeyEnter)
+}
+
+func TestSearchHistory_UpRecallsMostRecent(t *testing.T) {
+	m := testModel([]string{"alpha task", "beta task", "gamma task"})
+	m = searchAndConfirm(t, m, "alpha")
+	m = searchAndConfirm(t, m, "beta")
+
+	m = pressKey(t, m, "/")
+	m = pressKeyType(t, m, tea.KeyUp)
+	if m.InputBuffer != "beta" {
+		t.Fatalf("After up, InputBuffer = %q, want beta", m.InputBuffer)
+	}
+	if len(m.SearchResults) != 1 || m.SearchResults[0] != 1 {
+		t.Errorf("Recalled query should update results, got %v", m.SearchResults)
+	}
+
+	m = pressKeyType(t, m, tea.KeyUp)
+	if m.InputBuffer != "alpha" {
+		t.Errorf("After second up, InputBuffer = %q, want alpha", m.InputBuffer)
+	}
+
+	// Up at the oldest entry stays there
+	m = pressKeyType(t, m, tea.KeyUp)
+	if m.InputBuffer != "alpha" {
+		t.Errorf("Up past the oldest entry, InputBuffer = %q, want alpha", m.InputBuffer)
+	}
+
+	m = pressKeyType(t, m, tea.KeyDown)
+	if m.InputBuffer != "beta" {
+		t.Errorf("After down, InputBuffer = %q, want beta", m.InputBuffer)
+	}
+	m = pressKeyType(t, m, tea.KeyDown)
+	if m.InputBuffer != "" {
+		t.Errorf("Down past the newest entry should clear the input, got %q", m.InputBuffer)
+	}
+}
+
+func TestSearchHistory_ArrowsMoveResultsAfterTyping(t *testing.T) {
+	m := testModel([]string{"task one", "task two"})
+	m = searchAndConfirm(t, m, "one")
+
+	m = pressKey(t, m, "/")
+	m = pressKey(t, m, "t")
+	m.updateSearchResults()
+	m = pressKeyType(t, m, tea.KeyDown)
+
+	if m.InputBuffer != "t" {
+		t.Errorf("Typed query should stay, got %q", m.InputBuffer)
+	}
+	if m.SearchCursor != 1 {
+		t.Errorf("Down should move through results, SearchCursor = %d", m.SearchCursor)
+	}
+}
+
+func TestSearchHistory_DuplicatesMoveToFront(t *testing.T) {
+	m := testModel([]string{"alpha", "beta"})
+	m = searchAndConfirm(t, m, "alpha")
+	m = searchAndConfirm(t, m, "beta")
+	m = searchAndConfirm(t, m, "alpha")
+
+	if len(m.SearchHistory) != 2 || m.SearchHistory[0] != "alpha" {
+		t.Errorf("SearchHistory = %v, want [alpha beta]", m.SearchHistory)
+	}
+}
+
+func TestSearchHistory_PersistsAcrossSessions(t *testing.T) {
+	path := viewStateFixture(t, "# Todos\n\n- [ ] alpha task\n- [ ] beta task\n")
+
+	RunPiped(path, []byte("/alpha\r/beta\r"), false)
+
+	m := testModel([]string{"alpha task", "beta task"})
+	m.loadSearchHistory()
+	m = pressKey(t, m, "/")
+	m = pressKeyType(t, m, tea.KeyUp)
+
+	if m.InputBuffer != "beta" {
+		t.Errorf("Expected most recent query from the last session, got %q", m.InputBuffer)
+	}
+}
@@ -0,0 +1,99 @@
+package tui
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/niklas-heer/tdx/internal/util"
+)
+
+const scopeMarkdown = "# Work\n\n- [ ] Task report #work\n- [ ] Task review #work\n\n# Home\n\n- [ ] Task laundry #home\n- [ ] Task dishes\n"
+
+// searchResultTexts returns the texts of the current search results
+func searchResultTexts(m Model) []string {
+	var texts []string
+	for _, idx := range m.SearchResults {
+		texts = append(texts, m.FileModel.Todos[idx].Text)
+	}
+	slices.Sort(texts)
+	return texts
+}
+
+func TestSearchScope_TagFilter(t *testing.T) {
+	m := testModelWithMarkdown(scopeMarkdown)
+	m.FilteredTags = []string{"work"}
+
+	m.ProcessPipedInput([]byte("/task"))
+
+	want := []string{"Task report #work", "Task review #work"}
+	if got := searchResultTexts(m); !slices.Equal(got, want) {
+		t.Errorf("Scoped results = %q, want %q", got, want)
+	}
+	for _, idx := range m.SearchResults {
+		if !m.FileModel.Todos[idx].HasTag("work") {
+			t.Errorf("Result %q doesn't carry #work", m.FileModel.Todos[idx].Text)
+		}
+	}
+	if bar := util.StripANSI(m.renderStatusBar()); !strings.Contains(bar, "[filtered]") || !strings.Contains(bar, "ctrl+g scope") {
+		t.Errorf("Expected the scope in the status bar: %s", bar)
+	}
+}
+
+func TestSearchScope_ToggleAll(t *testing.T) {
+	m := testModelWithMarkdown(scopeMarkdown)
+	m.FilteredTags = []string{"work"}
+	m.ProcessPipedInput([]byte("/task"))
+
+	m = pressKeyType(t, m, tea.KeyCtrlG)
+	if len(m.SearchResults) != 4 {
+		t.Errorf("All results = %q, want every todo", searchResultTexts(m))
+	}
+	if bar := util.StripANSI(m.renderStatusBar()); !strings.Contains(bar, "[all]") {
+		t.Errorf("Expected [all] in the status bar: %s", bar)
+	}
+
+	m = pressKeyType(t, m, tea.KeyCtrlG)
+	if len(m.SearchResults) != 2 {
+		t.Errorf("Scoped again = %q, want the #work todos", searchResultTexts(m))
+	}
+}
+
+func TestSearchScope_Section(t *testing.T) {
+	m := testModelWithMarkdown(scopeMarkdown)
+	m.ShowHeadings = true
+	m.SelectedIndex = 2 // Task laundry, under Home
+
+	m.ProcessPipedInput([]byte("/task"))
+
+	want := []string{"Task dishes", "Task laundry #home"}
+	if got := searchResultTexts(m); !slices.Equal(got, want) {
+		t.Errorf("Section results = %q, want %q", got, want)
+	}
+	if bar := util.StripANSI(m.renderStatusBar()); !strings.Contains(bar, "[section]") {
+		t.Errorf("Expected [section] in the status bar: %s", bar)
+	}
+
+	m.ProcessPipedInput([]byte("\r"))
+	if m.SearchMode || m.sectionHeading(m.SelectedIndex) != 1 {
+		t.Errorf("Expected a todo of the Home section to be selected, got %d", m.SelectedIndex)
+	}
+}
+
+func TestSearchScope_NothingToScope(t *testing.T) {
+	m := testModelWithMarkdown(scopeMarkdown)
+
+	m.ProcessPipedInput([]byte("/task"))
+	if len(m.SearchResults) != 4 {
+		t.Errorf("Results = %q, want every todo", searchResultTexts(m))
+	}
+
+	m = pressKeyType(t, m, tea.KeyCtrlG)
+	if len(m.SearchResults) != 4 {
+		t.Errorf("ctrl+g without a scope changed the results: %q", searchResultTexts(m))
+	}
+	if bar := util.StripANSI(m.renderStatusBar()); strings.Contains(bar, "ctrl+g") || strings.Contains(bar, "[all]") {
+		t.Errorf("Expected no scope in the status bar: %s", bar)
+	}
+}
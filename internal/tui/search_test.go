@@ -0,0 +1,134 @@
+package tui
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func searchFor(t *testing.T, todos []string, query string) []int {
+	t.Helper()
+	m := testModelWithMarkdown("- [ ] " + strings.Join(todos, "\n- [ ] ") + "\n")
+	m.InputBuffer = query
+	m.updateSearchResults()
+	return m.SearchResults
+}
+
+var searchTodos = []string{
+	"Fix login redirect #auth !p1",
+	"Write onboarding docs #docs !p2",
+	"Refresh login tokens #Auth #backend !p2",
+	"Plan work week #planning",
+}
+
+func TestSearch_TagPrefixMatchesTagsOnly(t *testing.T) {
+	got := searchFor(t, searchTodos, "#auth")
+	slices.Sort(got)
+	if !slices.Equal(got, []int{0, 2}) {
+		t.Errorf("#auth = %v, want [0 2]", got)
+	}
+
+	// "work" appears in "Plan work week" but no todo has a #work tag
+	if got := searchFor(t, searchTodos, "#work"); len(got) != 0 {
+		t.Errorf("#work = %v, want no results", got)
+	}
+}
+
+func TestSearch_PriorityTerms(t *testing.T) {
+	for _, query := range []string{"p1", "!p1", "!1", "P1"} {
+		if got := searchFor(t, searchTodos, query); !slices.Equal(got, []int{0}) {
+			t.Errorf("%s = %v, want [0]", query, got)
+		}
+	}
+
+	got := searchFor(t, searchTodos, "p2")
+	slices.Sort(got)
+	if !slices.Equal(got, []int{1, 2}) {
+		t.Errorf("p2 = %v, want [1 2]", got)
+	}
+}
+
+func TestSearch_CombinedQueryRequiresAllParts(t *testing.T) {
+	got := searchFor(t, searchTodos, "login #auth")
+	slices.Sort(got)
+	if !slices.Equal(got, []int{0, 2}) {
+		t.Errorf("login #auth = %v, want [0 2]", got)
+	}
+
+	if got := searchFor(t, searchTodos, "login #auth p2"); !slices.Equal(got, []int{2}) {
+		t.Errorf("login #auth p2 = %v, want [2]", got)
+	}
+
+	if got := searchFor(t, searchTodos, "docs #auth"); len(got) != 0 {
+		t.Errorf("docs #auth = %v, want no results", got)
+	}
+}
+
+func TestSearch_PlainTextUnchanged(t *testing.T) {
+	if got := searchFor(t, searchTodos, "onboarding"); !slices.Equal(got, []int{1}) {
+		t.Errorf("onboarding = %v, want [1]", got)
+	}
+}
+
+func TestParseSearchQuery(t *testing.T) {
+	q := parseSearchQuery("Login #Auth !p2 #")
+
+	if q.text != "login" {
+		t.Errorf("text = %q, want login", q.text)
+	}
+	if !slices.Equal(q.tags, []string{"auth"}) {
+		t.Errorf("tags = %v, want [auth]", q.tags)
+	}
+	if !slices.Equal(q.priorities, []int{2}) {
+		t.Errorf("priorities = %v, want [2]", q.priorities)
+	}
+}
+
+func TestSearch_EqualScoresKeepFileOrder(t *testing.T) {
+	todos := []string{"Buy milk", "Review notes", "Buy milk", "milk", "Buy milk", "Oat milk order", "Buy milk"}
+
+	got := searchFor(t, todos, "milk")
+
+	m := testModelWithMarkdown("- [ ] " + strings.Join(todos, "\n- [ ] ") + "\n")
+	query := parseSearchQuery("milk")
+	for i := 1; i < len(got); i++ {
+		prev, cur := query.score(m.FileModel.Todos[got[i-1]]), query.score(m.FileModel.Todos[got[i]])
+		if cur > prev || (cur == prev && got[i] < got[i-1]) {
+			t.Fatalf("Results %v not ordered by score, then file order (scores %d, %d at %d)", got, prev, cur, i)
+		}
+	}
+
+	var buyMilk []int
+	for _, idx := range got {
+		if todos[idx] == "Buy milk" {
+			buyMilk = append(buyMilk, idx)
+		}
+	}
+	if !slices.Equal(buyMilk, []int{0, 2, 4, 6}) {
+		t.Errorf("Identical todos ranked %v, want file order [0 2 4 6]", buyMilk)
+	}
+}
+
+func TestRankMatches_Stable(t *testing.T) {
+	matches := []scoredMatch{{0, 5}, {1, 9}, {2, 5}, {3, 9}, {4, 1}, {5, 5}}
+
+	if got := rankMatches(matches); !slices.Equal(got, []int{1, 3, 0, 2, 5, 4}) {
+		t.Errorf("rankMatches = %v, want [1 3 0 2 5 4]", got)
+	}
+}
+
+func TestCommandPalette_EqualScoresKeepListOrder(t *testing.T) {
+	m := testModelWithMarkdown("- [ ] Task\n")
+	m.InputBuffer = "sort"
+	m.updateFilteredCommands()
+
+	var names []string
+	for _, idx := range m.FilteredCmds {
+		if strings.HasPrefix(m.Commands[idx].Name, "sort-") {
+			names = append(names, m.Commands[idx].Name)
+		}
+	}
+	if !slices.Equal(names, []string{"sort-done", "sort-due", "sort-priority"}) {
+		t.Errorf("sort commands ranked %v, want the palette order", names)
+	}
+}
@@ -0,0 +1,83 @@
+package tui
+
+import "testing"
+
+const sectionJumpMarkdown = "## Alpha\n\n- [ ] A1\n- [ ] A2\n\n## Beta\n\n- [x] B1\n- [ ] B2\n\n## Gamma\n\n- [ ] C1\n"
+
+func TestSectionJump_NextLandsOnFirstTodoOfEachSection(t *testing.T) {
+	m := testModelWithMarkdown(sectionJumpMarkdown)
+	m.ShowHeadings = true
+
+	m = pressKey(t, m, "]")
+	if m.SelectedIndex != 2 {
+		t.Fatalf("After ']', SelectedIndex = %d, want 2 (B1)", m.SelectedIndex)
+	}
+	m = pressKey(t, m, "]")
+	if m.SelectedIndex != 4 {
+		t.Fatalf("After second ']', SelectedIndex = %d, want 4 (C1)", m.SelectedIndex)
+	}
+
+	// No section after the last one
+	m = pressKey(t, m, "]")
+	if m.SelectedIndex != 4 {
+		t.Errorf("After ']' in last section, SelectedIndex = %d, want 4", m.SelectedIndex)
+	}
+}
+
+func TestSectionJump_PrevFromMiddleOfSection(t *testing.T) {
+	m := testModelWithMarkdown(sectionJumpMarkdown)
+	m.ShowHeadings = true
+	m.SelectedIndex = 3 // B2
+
+	m = pressKey(t, m, "[")
+	if m.SelectedIndex != 0 {
+		t.Errorf("After '[', SelectedIndex = %d, want 0 (A1)", m.SelectedIndex)
+	}
+}
+
+func TestSectionJump_LandsOnFirstVisibleTodo(t *testing.T) {
+	m := testModelWithMarkdown(sectionJumpMarkdown)
+	m.ShowHeadings = true
+	m.FilterDone = true
+
+	m = pressKey(t, m, "]")
+	if m.SelectedIndex != 3 {
+		t.Errorf("After ']' with done hidden, SelectedIndex = %d, want 3 (B2)", m.SelectedIndex)
+	}
+}
+
+func TestSectionJump_SkipsHiddenSections(t *testing.T) {
+	m := testModelWithMarkdown("## Alpha\n\n- [ ] A1\n\n## Beta\n\n- [x] B1\n\n## Gamma\n\n- [ ] C1\n")
+	m.ShowHeadings = true
+	m.FilterDone = true
+
+	m = pressKey(t, m, "]")
+	if m.SelectedIndex != 2 {
+		t.Errorf("After ']', SelectedIndex = %d, want 2 (C1, Beta is fully hidden)", m.SelectedIndex)
+	}
+	m = pressKey(t, m, "[")
+	if m.SelectedIndex != 0 {
+		t.Errorf("After '[', SelectedIndex = %d, want 0 (A1)", m.SelectedIndex)
+	}
+}
+
+func TestSectionJump_WithCount(t *testing.T) {
+	m := testModelWithMarkdown(sectionJumpMarkdown)
+	m.ShowHeadings = true
+
+	m = pressKey(t, m, "2")
+	m = pressKey(t, m, "]")
+	if m.SelectedIndex != 4 {
+		t.Errorf("After '2]', SelectedIndex = %d, want 4 (C1)", m.SelectedIndex)
+	}
+}
+
+func TestSectionJump_IgnoredWithoutHeadings(t *testing.T) {
+	m := testModelWithMarkdown(sectionJumpMarkdown)
+	m.ShowHeadings = false
+
+	m = pressKey(t, m, "]")
+	if m.SelectedIndex != 0 {
+		t.Errorf("SelectedIndex = %d, want 0 when headings are hidden", m.SelectedIndex)
+	}
+}
@@ -0,0 +1,87 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/niklas-heer/tdx/internal/util"
+)
+
+// sectionRuleModel shows foldMarkdown with headings and the given section rule
+func sectionRuleModel(rule string) Model {
+	m := testModelWithMarkdown("# Plan\n\n" + foldMarkdown)
+	m.ShowHeadings = true
+	m.TermWidth = 50
+	cfg := *m.Config()
+	cfg.Display.SectionRule = rule
+	m.config = &cfg
+	return m
+}
+
+// lineAbove returns the view line directly above the one containing text
+func lineAbove(t *testing.T, view, text string) string {
+	t.Helper()
+	lines := strings.Split(util.StripANSI(view), "\n")
+	for i, line := range lines {
+		if strings.Contains(line, text) {
+			if i == 0 {
+				return ""
+			}
+			return lines[i-1]
+		}
+	}
+	t.Fatalf("%q not in view:\n%s", text, view)
+	return ""
+}
+
+func TestSectionRule_LineBetweenSections(t *testing.T) {
+	m := sectionRuleModel(SectionRuleLine)
+	view := m.View()
+
+	rule := "      " + strings.Repeat("─", 44)
+	for _, heading := range []string{"## Home", "## Later"} {
+		if got := lineAbove(t, view, heading); got != rule {
+			t.Errorf("Line above %s = %q, want a rule as wide as the terminal", heading, got)
+		}
+	}
+	if got := lineAbove(t, view, "## Work"); strings.Contains(got, "─") {
+		t.Errorf("No rule expected between headings without todos, got %q", got)
+	}
+	if strings.Contains(lineAbove(t, view, "# Plan"), "─") || strings.Count(view, "─") != 2*44 {
+		t.Errorf("Expected exactly two rules and none above the first heading:\n%s", view)
+	}
+}
+
+func TestSectionRule_Blank(t *testing.T) {
+	m := sectionRuleModel(SectionRuleBlank)
+	view := m.View()
+
+	for _, heading := range []string{"## Home", "## Later"} {
+		if got := lineAbove(t, view, heading); got != "" {
+			t.Errorf("Line above %s = %q, want blank", heading, got)
+		}
+	}
+	if strings.Contains(view, "─") {
+		t.Errorf("Expected blank separators only:\n%s", view)
+	}
+}
+
+func TestSectionRule_NoneByDefault(t *testing.T) {
+	m := sectionRuleModel(SectionRuleNone)
+	view := m.View()
+
+	if got := lineAbove(t, view, "## Home"); !strings.Contains(got, "Review PR") {
+		t.Errorf("Line above ## Home = %q, want the previous todo", got)
+	}
+}
+
+func TestSectionRule_FoldedSections(t *testing.T) {
+	m := sectionRuleModel(SectionRuleLine)
+	executeCommand(&m, "fold-all")
+	view := m.View()
+
+	// Folded sections have no todos between them, so there are no rules
+	if strings.Contains(view, "─") {
+		t.Errorf("Expected no rules between folded headings:\n%s", view)
+	}
+}
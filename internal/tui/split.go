@@ -0,0 +1,193 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+	"github.com/niklas-heer/tdx/internal/markdown"
+	"github.com/niklas-heer/tdx/internal/util"
+)
+
+// splitSeparator is drawn between the two panes of a split view
+const splitSeparator = " │ "
+
+// openSplit shows the file at path next to the current one. The current pane
+// stays focused; ctrl+w moves to the new one.
+func (m *Model) openSplit(arg string) {
+	path, err := resolveSplitPath(arg)
+	if err != nil {
+		m.setStatus("Can't open " + arg + ": " + err.Error())
+		return
+	}
+	if util.CanonicalPath(path) == util.CanonicalPath(m.FilePath) {
+		m.setStatus(tildePath(path) + " is already open")
+		return
+	}
+
+	fm, err := markdown.ReadFile(path)
+	if err != nil {
+		m.setStatus("Can't open " + tildePath(path) + ": " + err.Error())
+		return
+	}
+
+	other := New(path, fm, m.ReadOnly, m.ShowHeadings, m.MaxVisibleOverride, m.config, m.styles, m.appVersion)
+	other.TermWidth, other.TermHeight = m.TermWidth, m.TermHeight
+	other.splitRight = !m.splitRight
+	m.split = &other
+	m.setStatus("Split with " + tildePath(path) + " (ctrl+w switches)")
+}
+
+// closeSplit closes the pane that isn't focused
+func (m *Model) closeSplit() {
+	if m.split == nil {
+		m.setStatus("No split to close")
+		return
+	}
+	m.split.saveRecentState()
+	m.split = nil
+	m.splitRight = false
+}
+
+// switchPane focuses the other pane of a split view. Each pane keeps its own
+// file, selection, filters and undo history; the terminal size, theme, search
+// history and yank register are shared.
+func (m *Model) switchPane() {
+	if m.split == nil {
+		return
+	}
+	current := *m
+	next := *m.split
+	current.split = nil
+	next.split = &current
+
+	next.TermWidth, next.TermHeight = current.TermWidth, current.TermHeight
+	next.styles = current.styles
+	next.CurrentThemeName = current.CurrentThemeName
+	next.SearchHistory = current.SearchHistory
+	next.newSearches = current.newSearches
+	next.register = current.register
+
+	*m = next
+	// The pane may have been changed on disk while it wasn't focused
+	m.reloadIfChanged()
+}
+
+// resolveSplitPath turns the argument of :split into an absolute path
+func resolveSplitPath(arg string) (string, error) {
+	path := strings.TrimSpace(arg)
+	if rest, ok := strings.CutPrefix(path, "~/"); ok {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		path = filepath.Join(home, rest)
+	}
+	return filepath.Abs(path)
+}
+
+// splitPaneWidth returns the width of each pane, or 0 when the terminal size is unknown
+func (m Model) splitPaneWidth() int {
+	if m.TermWidth <= 0 {
+		return 0
+	}
+	return max((m.TermWidth-lipgloss.Width(splitSeparator))/2, 1)
+}
+
+// pane returns the model of one pane sized to its column. The header line with
+// the file name takes one row.
+func (m Model) pane(width int) Model {
+	m.split = nil
+	if width > 0 {
+		m.TermWidth = width
+	}
+	if m.TermHeight > 1 {
+		m.TermHeight--
+	}
+	return m
+}
+
+// renderPane renders a pane's header and todo list as lines
+func (m Model) renderPane(width int, focused bool) []string {
+	styles := m.Styles()
+	name := tildePath(m.FilePath)
+	// Shorten long paths from the left so the file name stays visible
+	if w := runewidth.StringWidth(name); width > 0 && w > width {
+		name = runewidth.TruncateLeft(name, w-width+1, "…")
+	}
+	header := styles.Dim(name)
+	if focused {
+		header = styles.Cyan(name)
+	}
+	content := strings.TrimSuffix(m.pane(width).renderMainContent(), "\n")
+	return append([]string{header}, strings.Split(content, "\n")...)
+}
+
+// renderSplitContent renders both panes of a split view side by side
+func (m Model) renderSplitContent() string {
+	width := m.splitPaneWidth()
+	left := m.renderPane(width, true)
+	right := m.split.renderPane(width, false)
+	if m.splitRight {
+		left, right = right, left
+	}
+
+	// Without a known size the left pane is as wide as its longest line
+	if width == 0 {
+		for _, line := range left {
+			width = max(width, lipgloss.Width(line))
+		}
+	}
+	cell := lipgloss.NewStyle().MaxWidth(width)
+
+	var b strings.Builder
+	for i := 0; i < max(len(left), len(right)); i++ {
+		l, r := "", ""
+		if i < len(left) {
+			l = cell.Render(left[i])
+		}
+		if i < len(right) {
+			r = cell.Render(right[i])
+		}
+		b.WriteString(l + strings.Repeat(" ", max(width-lipgloss.Width(l), 0)))
+		b.WriteString(m.Styles().Dim(splitSeparator))
+		b.WriteString(r)
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// handleSplitMouse focuses the clicked pane and hands the event to it, with
+// the position made relative to the pane
+func (m Model) handleSplitMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
+	width := m.splitPaneWidth()
+	if width == 0 {
+		return m, nil
+	}
+	onRight := msg.X >= width+lipgloss.Width(splitSeparator)
+	if !onRight && msg.X >= width {
+		return m, nil // On the separator
+	}
+	if onRight != m.splitRight {
+		// Only a click or the wheel moves the focus, not the pointer passing by
+		if msg.Action != tea.MouseActionPress {
+			return m, nil
+		}
+		m.switchPane()
+	}
+	if onRight {
+		msg.X -= width + lipgloss.Width(splitSeparator)
+	}
+	msg.Y-- // Below the header
+
+	termWidth, termHeight := m.TermWidth, m.TermHeight
+	other := m.split
+	result, cmd := m.pane(width).handleMouse(msg)
+	next := result.(Model)
+	next.split = other
+	next.TermWidth, next.TermHeight = termWidth, termHeight
+	return next, cmd
+}
@@ -0,0 +1,207 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/niklas-heer/tdx/internal/config"
+	"github.com/niklas-heer/tdx/internal/markdown"
+	"github.com/niklas-heer/tdx/internal/util"
+)
+
+const (
+	splitWork     = "# Work\n\n- [ ] Write report #urgent\n- [ ] Review PR\n"
+	splitPersonal = "# Personal\n\n- [ ] Buy milk\n- [ ] Call mom #urgent\n"
+)
+
+// splitModel opens work.md and splits it with personal.md, both in a temp dir
+func splitModel(t *testing.T) (m Model, workPath, personalPath string) {
+	t.Helper()
+	config.SetConfigDirForTesting(t.TempDir())
+	t.Cleanup(config.ResetConfigDirForTesting)
+
+	dir := t.TempDir()
+	workPath = filepath.Join(dir, "work.md")
+	personalPath = filepath.Join(dir, "personal.md")
+	for path, content := range map[string]string{workPath: splitWork, personalPath: splitPersonal} {
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	fm, err := markdown.ReadFile(workPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m = New(workPath, fm, false, false, -1, testConfig(), testStyles(), "test")
+	m.TermWidth, m.TermHeight = 100, 20
+	m = typeCommand(t, m, "split "+personalPath)
+	if m.split == nil {
+		t.Fatalf("Expected a split view, status %q", m.StatusHint)
+	}
+	return m, workPath, personalPath
+}
+
+func readSplitFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestSplit_ShowsBothFiles(t *testing.T) {
+	m, _, _ := splitModel(t)
+
+	view := util.StripANSI(m.View())
+	for _, text := range []string{"work.md", "personal.md", "Write report", "Review PR", "Buy milk", "Call mom"} {
+		if !strings.Contains(view, text) {
+			t.Errorf("Expected %q in the split view:\n%s", text, view)
+		}
+	}
+
+	// Both lists share rows, side by side
+	for _, line := range strings.Split(view, "\n") {
+		if strings.Contains(line, "Write report") {
+			if !strings.Contains(line, "Buy milk") {
+				t.Errorf("Expected the first todos of both files on one row, got %q", line)
+			}
+			if idx := strings.Index(line, "Buy milk"); idx < 40 {
+				t.Errorf("Expected the second file in the right column, got %q", line)
+			}
+		}
+	}
+}
+
+func TestSplit_EditsTargetFocusedPane(t *testing.T) {
+	m, workPath, personalPath := splitModel(t)
+
+	m = pressKey(t, m, " ")
+	if !strings.Contains(readSplitFile(t, workPath), "- [x] Write report") {
+		t.Errorf("Expected the focused file to be toggled:\n%s", readSplitFile(t, workPath))
+	}
+	if got := readSplitFile(t, personalPath); got != splitPersonal {
+		t.Errorf("The other file shouldn't change, got:\n%s", got)
+	}
+
+	m = pressKeyType(t, m, tea.KeyCtrlW)
+	if m.FilePath != personalPath {
+		t.Fatalf("Expected ctrl+w to focus %s, got %s", personalPath, m.FilePath)
+	}
+	m = pressKey(t, m, "j")
+	m = pressKey(t, m, "d")
+
+	if got := readSplitFile(t, personalPath); strings.Contains(got, "Call mom") || !strings.Contains(got, "Buy milk") {
+		t.Errorf("Expected the todo deleted from the focused file, got:\n%s", got)
+	}
+	if got := readSplitFile(t, workPath); !strings.Contains(got, "Review PR") {
+		t.Errorf("The other file shouldn't change, got:\n%s", got)
+	}
+}
+
+func TestSplit_PanesKeepSelectionAndFilters(t *testing.T) {
+	m, workPath, _ := splitModel(t)
+
+	m = pressKey(t, m, "j")
+	m = pressKeyType(t, m, tea.KeyCtrlW)
+	m = typeCommand(t, m, "tag urgent")
+	if m.SelectedIndex != 1 {
+		t.Errorf("Expected the filter to select Call mom, got index %d", m.SelectedIndex)
+	}
+
+	m = pressKeyType(t, m, tea.KeyCtrlW)
+	if m.FilePath != workPath {
+		t.Fatalf("Expected ctrl+w to focus %s again, got %s", workPath, m.FilePath)
+	}
+	if m.SelectedIndex != 1 {
+		t.Errorf("Expected the work pane to keep its selection, got %d", m.SelectedIndex)
+	}
+	if len(m.FilteredTags) != 0 {
+		t.Errorf("Expected the work pane to have no filter, got %v", m.FilteredTags)
+	}
+	if len(m.split.FilteredTags) != 1 {
+		t.Errorf("Expected the personal pane to keep its filter, got %v", m.split.FilteredTags)
+	}
+}
+
+func TestSplit_UndoStaysInPane(t *testing.T) {
+	m, workPath, personalPath := splitModel(t)
+
+	m = pressKey(t, m, " ")
+	m = pressKeyType(t, m, tea.KeyCtrlW)
+	m = pressKey(t, m, "u")
+
+	if !strings.Contains(readSplitFile(t, workPath), "- [x] Write report") {
+		t.Error("Undo in the other pane shouldn't revert the work file")
+	}
+	if got := readSplitFile(t, personalPath); got != splitPersonal {
+		t.Errorf("Expected the personal file unchanged, got:\n%s", got)
+	}
+}
+
+func TestSplit_ClickFocusesPane(t *testing.T) {
+	m, _, personalPath := splitModel(t)
+
+	// Row 0 is the header; the first todos are on the next row
+	view := util.StripANSI(m.View())
+	lines := strings.Split(view, "\n")
+	y := -1
+	for i, line := range lines {
+		if strings.Contains(line, "Call mom") {
+			y = i
+		}
+	}
+	if y < 0 {
+		t.Fatalf("Call mom not shown:\n%s", view)
+	}
+	x := strings.Index(lines[y], "Call mom")
+
+	result, _ := m.Update(tea.MouseMsg{X: x, Y: y, Button: tea.MouseButtonLeft, Action: tea.MouseActionPress})
+	m = result.(Model)
+
+	if m.FilePath != personalPath || m.FileModel.Todos[m.SelectedIndex].Text != "Call mom #urgent" {
+		t.Errorf("Expected the click to focus Call mom in %s, got %q in %s", personalPath, m.FileModel.Todos[m.SelectedIndex].Text, m.FilePath)
+	}
+}
+
+func TestSplit_Unsplit(t *testing.T) {
+	m, workPath, _ := splitModel(t)
+
+	m = typeCommand(t, m, "unsplit")
+
+	if m.split != nil || m.FilePath != workPath {
+		t.Errorf("Expected only %s left open", workPath)
+	}
+	if view := util.StripANSI(m.View()); strings.Contains(view, "Buy milk") {
+		t.Errorf("Expected the other file gone from the view:\n%s", view)
+	}
+}
+
+func TestSplit_TabIndents(t *testing.T) {
+	m, workPath, _ := splitModel(t)
+
+	m = pressKey(t, m, "j")
+	m = pressKeyType(t, m, tea.KeyTab)
+	if m.FilePath != workPath || m.FileModel.Todos[1].Depth != 1 {
+		t.Fatal("Expected tab to indent in the focused pane of a split view")
+	}
+	m = pressKeyType(t, m, tea.KeyShiftTab)
+	if m.FileModel.Todos[1].Depth != 0 {
+		t.Error("Expected shift+tab to outdent again")
+	}
+}
+
+func TestSplit_SameFile(t *testing.T) {
+	m, workPath, _ := splitModel(t)
+	m = typeCommand(t, m, "unsplit")
+
+	m = typeCommand(t, m, "split "+workPath)
+
+	if m.split != nil {
+		t.Error("Expected no split with the file that is already open")
+	}
+}
@@ -0,0 +1,71 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/niklas-heer/tdx/internal/markdown"
+)
+
+func TestStatusHints_FullShowsHelp(t *testing.T) {
+	m := testModelWithMarkdown("- [ ] Task\n")
+
+	if m.StatusHints != StatusHintsFull {
+		t.Fatalf("StatusHints = %q, want full by default", m.StatusHints)
+	}
+	if bar := m.renderStatusBar(); !strings.Contains(bar, "help") || !strings.Contains(bar, "cmd") {
+		t.Errorf("Expected help hints in full mode: %q", bar)
+	}
+}
+
+func TestStatusHints_MinimalShowsOnlyIndicators(t *testing.T) {
+	m := testModelWithMarkdown("- [ ] Task\n")
+	m.StatusHints = StatusHintsMinimal
+	m.FilterDone = true
+
+	bar := m.renderStatusBar()
+	if strings.Contains(bar, "help") {
+		t.Errorf("Help hints should be hidden in minimal mode: %q", bar)
+	}
+	if !strings.Contains(bar, "FILTERED") {
+		t.Errorf("Expected filter indicator in minimal mode: %q", bar)
+	}
+}
+
+func TestStatusHints_NoneShowsNothing(t *testing.T) {
+	m := testModelWithMarkdown("- [ ] Task\n")
+	m.StatusHints = StatusHintsNone
+	m.FilterDone = true
+
+	if bar := m.renderStatusBar(); bar != "" {
+		t.Errorf("Expected an empty status bar in none mode: %q", bar)
+	}
+
+	// Modes still show their indicator
+	m.SearchMode = true
+	if bar := m.renderStatusBar(); !strings.Contains(bar, "SEARCH") {
+		t.Errorf("Expected the search mode indicator: %q", bar)
+	}
+}
+
+func TestStatusHints_ToggleCycles(t *testing.T) {
+	m := testModelWithMarkdown("- [ ] Task\n")
+
+	for _, want := range []string{StatusHintsMinimal, StatusHintsNone, StatusHintsFull} {
+		executeCommand(&m, "toggle-hints")
+		m.StatusHint = ""
+		if m.StatusHints != want {
+			t.Errorf("StatusHints = %q, want %q", m.StatusHints, want)
+		}
+	}
+}
+
+func TestStatusHints_FromConfig(t *testing.T) {
+	config := testConfig()
+	config.Display.StatusHints = StatusHintsNone
+	m := New("/tmp/test.md", markdown.ParseMarkdown("- [ ] Task\n"), false, false, -1, config, testStyles(), "test")
+
+	if m.StatusHints != StatusHintsNone {
+		t.Errorf("StatusHints = %q, want none from config", m.StatusHints)
+	}
+}
@@ -0,0 +1,98 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestStatus_DeleteShowsUndoHint(t *testing.T) {
+	m := testModelWithMarkdown("# Todos\n\n- [ ] buy milk\n- [ ] call mom\n")
+
+	result, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("d")})
+	m = result.(Model)
+
+	if m.StatusHint != "Deleted: buy milk — u to undo" {
+		t.Errorf("StatusHint = %q", m.StatusHint)
+	}
+	if cmd == nil {
+		t.Error("Expected a command to clear the status later")
+	}
+	if view := m.renderStatusBar(); !strings.Contains(view, "Deleted: buy milk") {
+		t.Errorf("Expected the status in the status bar:\n%s", view)
+	}
+
+	result, _ = m.Update(ClearStatusHintMsg{seq: m.statusSeq})
+	m = result.(Model)
+	if m.StatusHint != "" {
+		t.Errorf("StatusHint should be cleared, got %q", m.StatusHint)
+	}
+}
+
+func TestStatus_DeleteCountShowsTotal(t *testing.T) {
+	m := testModelWithMarkdown("# Todos\n\n- [ ] One\n- [ ] Two\n- [ ] Three\n")
+
+	m = pressKey(t, m, "2")
+	m = pressKey(t, m, "d")
+
+	if m.StatusHint != "Deleted 2 todos — u to undo" {
+		t.Errorf("StatusHint = %q", m.StatusHint)
+	}
+}
+
+func TestStatus_StaleClearKeepsNewerMessage(t *testing.T) {
+	m := testModelWithMarkdown("# Todos\n\n- [ ] Task\n")
+
+	m.setStatus("First")
+	first := m.statusSeq
+	m.setStatus("Second")
+
+	result, _ := m.Update(ClearStatusHintMsg{seq: first})
+	m = result.(Model)
+	if m.StatusHint != "Second" {
+		t.Errorf("Clearing the first message removed the second, StatusHint = %q", m.StatusHint)
+	}
+
+	result, _ = m.Update(ClearStatusHintMsg{seq: m.statusSeq})
+	m = result.(Model)
+	if m.StatusHint != "" {
+		t.Errorf("StatusHint = %q, want cleared", m.StatusHint)
+	}
+}
+
+func TestStatus_MoveShowsHint(t *testing.T) {
+	m := testModelWithMarkdown("# Todos\n\n- [ ] One\n- [ ] Two\n")
+
+	m = pressKey(t, m, "m")
+	m = pressKey(t, m, "j")
+	m = pressKeyType(t, m, tea.KeyEnter)
+
+	if m.StatusHint != "Moved: One — u to undo" {
+		t.Errorf("StatusHint = %q", m.StatusHint)
+	}
+
+	// Confirming without moving says nothing
+	m = testModelWithMarkdown("# Todos\n\n- [ ] One\n- [ ] Two\n")
+	m = pressKey(t, m, "m")
+	m = pressKeyType(t, m, tea.KeyEnter)
+	if m.StatusHint != "" {
+		t.Errorf("StatusHint = %q, want none", m.StatusHint)
+	}
+}
+
+func TestStatus_SuccessIsGreen(t *testing.T) {
+	m := testModelWithMarkdown("# Todos\n\n- [ ] buy milk\n")
+	m.styles.Green = func(s string) string { return "<green>" + s }
+	m.styles.Yellow = func(s string) string { return "<yellow>" + s }
+
+	m.setStatusOK("Yanked 1 todo")
+	if view := m.renderStatusBar(); !strings.Contains(view, "<green>Yanked 1 todo") {
+		t.Errorf("Expected a success message in green:\n%s", view)
+	}
+
+	m.setStatus("No tag on this todo")
+	if view := m.renderStatusBar(); !strings.Contains(view, "<yellow>No tag on this todo") {
+		t.Errorf("Expected a warning in yellow:\n%s", view)
+	}
+}
@@ -0,0 +1,76 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTagJump_JumpsToNextSharedTagAndWraps(t *testing.T) {
+	m := testModelWithMarkdown("- [ ] Crash on start #bug\n- [ ] Write docs #docs\n- [ ] Wrong color #bug #ui\n")
+
+	m = pressKey(t, m, "*")
+	if m.SelectedIndex != 2 {
+		t.Fatalf("After '*', SelectedIndex = %d, want 2", m.SelectedIndex)
+	}
+
+	// First tag of todo 2 is #bug as well, so the jump wraps back to todo 0
+	m = pressKey(t, m, "*")
+	if m.SelectedIndex != 0 {
+		t.Errorf("After second '*', SelectedIndex = %d, want 0 (wrap)", m.SelectedIndex)
+	}
+}
+
+func TestTagJump_UsesFirstTag(t *testing.T) {
+	m := testModelWithMarkdown("- [ ] A #ui #bug\n- [ ] B #bug\n- [ ] C #ui\n")
+
+	m = pressKey(t, m, "*")
+	if m.SelectedIndex != 2 {
+		t.Errorf("SelectedIndex = %d, want 2 (next #ui)", m.SelectedIndex)
+	}
+}
+
+func TestTagJump_SkipsHiddenTodos(t *testing.T) {
+	m := testModelWithMarkdown("- [ ] A #bug\n- [x] B #bug\n- [ ] C #bug\n")
+	m.FilterDone = true
+
+	m = pressKey(t, m, "*")
+	if m.SelectedIndex != 2 {
+		t.Errorf("SelectedIndex = %d, want 2 (skipping completed todo)", m.SelectedIndex)
+	}
+
+	// Navigation afterwards continues from the new position
+	m = pressKey(t, m, "k")
+	if m.SelectedIndex != 0 {
+		t.Errorf("After 'k', SelectedIndex = %d, want 0", m.SelectedIndex)
+	}
+}
+
+func TestTagJump_NoTagShowsHint(t *testing.T) {
+	m := testModelWithMarkdown("- [ ] Untagged\n- [ ] Other #bug\n")
+
+	m = pressKey(t, m, "*")
+	if m.SelectedIndex != 0 {
+		t.Errorf("SelectedIndex = %d, want 0", m.SelectedIndex)
+	}
+	if !strings.Contains(m.View(), "No tag on this todo") {
+		t.Errorf("Expected hint in status bar:\n%s", m.View())
+	}
+
+	result, _ := m.Update(ClearStatusHintMsg{})
+	m = result.(Model)
+	if m.StatusHint != "" {
+		t.Errorf("StatusHint should be cleared, got %q", m.StatusHint)
+	}
+}
+
+func TestTagJump_NoOtherTaggedTodoShowsHint(t *testing.T) {
+	m := testModelWithMarkdown("- [ ] Only #bug\n- [ ] Other #docs\n")
+
+	m = pressKey(t, m, "*")
+	if m.SelectedIndex != 0 {
+		t.Errorf("SelectedIndex = %d, want 0", m.SelectedIndex)
+	}
+	if m.StatusHint != "No other todo tagged #bug" {
+		t.Errorf("StatusHint = %q", m.StatusHint)
+	}
+}
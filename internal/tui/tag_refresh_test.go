@@ -1,6 +1,7 @@
 package tui
 
 import (
+	"slices"
 	"testing"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -197,3 +198,23 @@ func TestEnterTagFilterModeRefreshesAvailableTags(t *testing.T) {
 		t.Errorf("AvailableTags = %v, want [newtag] - entering filter mode should refresh available tags", m.AvailableTags)
 	}
 }
+
+func TestTagFilter_NormalizedCaseIsOneTag(t *testing.T) {
+	oldCase := markdown.NormalizeTagCase
+	markdown.NormalizeTagCase = true
+	t.Cleanup(func() { markdown.NormalizeTagCase = oldCase })
+
+	m := testModelWithMarkdown("- [ ] A #Work\n- [ ] B #work\n- [ ] C #home\n")
+
+	if !slices.Equal(m.AvailableTags, []string{"home", "work"}) {
+		t.Fatalf("AvailableTags = %v, want [home work]", m.AvailableTags)
+	}
+
+	m.FilteredTags = []string{"work"}
+	m.InvalidateDocumentTree()
+	for i, want := range []bool{true, true, false} {
+		if got := m.isTodoVisible(i); got != want {
+			t.Errorf("todo %d visible = %v, want %v", i, got, want)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package tui
+
+import (
+	"time"
+
+	"github.com/niklas-heer/tdx/internal/markdown"
+)
+
+// timeLogIncrement is the time :log-time adds to the selected todo
+const timeLogIncrement = 15 * time.Minute
+
+// timeTotals sums the estimated and spent time of the visible todos.
+// ok is false when none of them carry a time token.
+func (m *Model) timeTotals() (estimate, spent time.Duration, ok bool) {
+	var visible []markdown.Todo
+	for i, todo := range m.FileModel.Todos {
+		if m.isTodoVisible(i) {
+			visible = append(visible, todo)
+		}
+	}
+	estimate, spent = markdown.SumTime(visible)
+	return estimate, spent, estimate > 0 || spent > 0
+}
+
+// logTime adds d to the spent: token of the selected todo
+func (m *Model) logTime(d time.Duration) {
+	if len(m.FileModel.Todos) == 0 {
+		return
+	}
+	m.saveHistory()
+
+	todo := m.FileModel.Todos[m.SelectedIndex]
+	text := markdown.AddSpent(todo.Text, d)
+	if m.FileModel.UpdateTodoItem(m.SelectedIndex, text, todo.Checked) != nil {
+		return
+	}
+	m.setStatus("Spent " + markdown.FormatDuration(m.FileModel.Todos[m.SelectedIndex].Spent))
+
+	m.InvalidateDocumentTree()
+	m.writeIfPersist()
+}
@@ -0,0 +1,60 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTimeTotals_StatusBar(t *testing.T) {
+	m := testModelWithMarkdown("- [ ] Write proposal est:3h spent:1h15m #work\n- [ ] Review est:45m #work\n- [ ] Shop est:2h #home\n")
+
+	if bar := m.renderStatusBar(); !strings.Contains(bar, "est 5h45m / spent 1h15m") {
+		t.Errorf("Expected time totals in status bar:\n%s", bar)
+	}
+
+	// Only visible todos count
+	m.FilteredTags = []string{"work"}
+	if bar := m.renderStatusBar(); !strings.Contains(bar, "est 3h45m / spent 1h15m") {
+		t.Errorf("Expected filtered time totals in status bar:\n%s", bar)
+	}
+}
+
+func TestTimeTotals_HiddenWithoutTokens(t *testing.T) {
+	m := testModelWithMarkdown("- [ ] Shop est:banana\n")
+
+	if bar := m.renderStatusBar(); strings.Contains(bar, "spent") {
+		t.Errorf("Expected no time totals:\n%s", bar)
+	}
+}
+
+func TestLogTime(t *testing.T) {
+	m := testModelWithMarkdown("- [ ] Write proposal est:2h\n- [ ] Review spent:50m\n")
+
+	executeCommand(&m, "log-time")
+	m.SelectedIndex = 1
+	executeCommand(&m, "log-time")
+
+	if got := todoTexts(m); got[0] != "Write proposal est:2h spent:15m" || got[1] != "Review spent:1h5m" {
+		t.Errorf("Todos = %q", got)
+	}
+	if m.StatusHint != "Spent 1h5m" {
+		t.Errorf("StatusHint = %q", m.StatusHint)
+	}
+
+	m = pressKey(t, m, "u")
+	if got := todoTexts(m); got[1] != "Review spent:50m" {
+		t.Errorf("Undo should restore the token, got %q", got[1])
+	}
+}
+
+func TestColorizeTimeTokens(t *testing.T) {
+	style := func(s string) string { return "<" + s + ">" }
+
+	got := ColorizeTimeTokens("Write est:2h spent:1h30m forest:2h est:banana", style)
+	if got != "Write <est:2h> <spent:1h30m> forest:2h est:banana" {
+		t.Errorf("ColorizeTimeTokens = %q", got)
+	}
+	if got := ColorizeTimeTokens("Write est:2h", nil); got != "Write est:2h" {
+		t.Errorf("nil style should leave the text unchanged, got %q", got)
+	}
+}
@@ -4,9 +4,13 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"regexp"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/niklas-heer/tdx/internal/config"
@@ -20,26 +24,39 @@ var (
 	Styles    any
 )
 
-// FileChangedMsg is sent when the file changes on disk
+// FileChangedMsg is sent periodically to check the file for changes on disk
 type FileChangedMsg struct{}
 
 // Update handles all TUI updates
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	result, cmd := m.update(msg)
+	if next, ok := result.(Model); ok {
+		next.syncScroll()
+		if next.pendingCmd != nil {
+			// Commands queued by handlers, e.g. clearing a status message
+			cmd = tea.Batch(cmd, next.pendingCmd)
+			next.pendingCmd = nil
+		}
+		result = next
+	}
+	return result, cmd
+}
+
+func (m Model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.WindowSizeMsg:
 		m.TermWidth = msg.Width
 		m.TermHeight = msg.Height
 		return m, nil
-	case ClearCopyFeedbackMsg:
-		m.CopyFeedback = false
+	case ClearStatusHintMsg:
+		if msg.seq == 0 || msg.seq == m.statusSeq {
+			m.StatusHint = ""
+			m.loadWarning = false
+		}
 		return m, nil
 	case FileChangedMsg:
-		// File changed on disk - try to auto-reload
-		return m, m.checkAndReloadFile()
-	case reloadedMsg:
-		// Successfully reloaded from disk
-		m = msg.model
-		m.InvalidateHeadingsCache()  // Invalidate cache on reload
+		// Periodic check - pick up changes made on disk
+		m.reloadIfChanged()
 		return m, watchFileChanges() // Continue watching
 	case SearchDebounceMsg:
 		// Debounced search update
@@ -48,6 +65,11 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.searchPending = false
 		}
 		return m, nil
+	case editorFinishedMsg:
+		m.applyExternalEdit(msg)
+		return m, nil
+	case tea.MouseMsg:
+		return m.handleMouse(msg)
 	case CommandDebounceMsg:
 		// Debounced command filter update
 		if m.CommandMode && m.searchPending {
@@ -56,19 +78,18 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 	case tea.KeyMsg:
-		// Handle EOF from piped input
-		if msg.Type == tea.KeyCtrlD {
-			return m, tea.Quit
-		}
 		// Handle bracketed paste (cmd+v on macOS)
-		if msg.Paste && (m.InputMode || m.EditMode) {
-			text := string(msg.Runes)
-			// Take only first line
+		if msg.Paste && (m.InputMode || m.EditMode || m.NoteMode) {
+			text := strings.TrimRight(string(msg.Runes), "\r\n")
+			// New todos take one todo per line, edits and notes only the first line
+			if m.InputMode && strings.Contains(text, "\n") {
+				m.addPastedTodos(text)
+				return m, nil
+			}
 			if idx := strings.Index(text, "\n"); idx != -1 {
 				text = text[:idx]
 			}
-			m.InputBuffer = m.InputBuffer[:m.CursorPos] + text + m.InputBuffer[m.CursorPos:]
-			m.CursorPos += len(text)
+			m.insertInput(text)
 			return m, nil
 		}
 		return m.handleKey(msg)
@@ -85,8 +106,14 @@ func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m, nil
 	}
 
-	// Handle input/edit mode
-	if m.InputMode || m.EditMode {
+	// Warnings from loading the file also go away with the first key
+	if m.loadWarning {
+		m.loadWarning = false
+		m.StatusHint = ""
+	}
+
+	// Handle input/edit/note mode
+	if m.InputMode || m.EditMode || m.NoteMode {
 		return m.handleInputKey(msg)
 	}
 
@@ -95,6 +122,11 @@ func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m.handleMaxVisibleInputKey(msg)
 	}
 
+	// Handle the goto prompt
+	if m.GotoInputMode {
+		return m.handleGotoInputKey(msg)
+	}
+
 	// Handle search mode
 	if m.SearchMode {
 		return m.handleSearchKey(msg)
@@ -105,6 +137,16 @@ func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m.handleFilterKey(msg)
 	}
 
+	// Handle context filter mode
+	if m.ContextFilterMode {
+		return m.handleContextFilterKey(msg)
+	}
+
+	// Handle project filter mode
+	if m.ProjectFilterMode {
+		return m.handleProjectFilterKey(msg)
+	}
+
 	// Handle priority filter mode
 	if m.PriorityFilterMode {
 		return m.handlePriorityFilterKey(msg)
@@ -130,6 +172,16 @@ func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m.handleMoveKey(msg)
 	}
 
+	// Handle delete confirmation for todos with subtasks
+	if m.ConfirmDeleteMode {
+		return m.handleConfirmDeleteKey(key)
+	}
+
+	// Handle quit confirmation
+	if m.ConfirmQuitMode {
+		return m.handleConfirmQuitKey(key)
+	}
+
 	// Handle help mode
 	if m.HelpMode {
 		if key == "?" || key == "esc" {
@@ -160,56 +212,52 @@ func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	if key != "g" && key != "G" {
 		m.gPressed = false
 	}
+	if key != "y" {
+		m.yPressed = false
+	}
+
+	if m.blockedByFold(key) {
+		return m, nil
+	}
 
 	switch key {
-	case "esc", "ctrl+c":
+	case "esc":
+		if m.config != nil && m.config.Defaults.ConfirmQuit {
+			m.ConfirmQuitMode = true
+			return m, nil
+		}
+		return m, tea.Quit
+
+	case "ctrl+c":
 		return m, tea.Quit
 
 	case "j", "down":
-		if m.hasActiveFilters() || m.ShowHeadings {
-			// Use document tree for filtered navigation
-			tree := m.GetDocumentTree()
-			tree.NavigateDown(count)
-			if selectedNode := tree.GetSelectedNode(); selectedNode != nil && selectedNode.Type == DocNodeTodo {
-				m.SelectedIndex = selectedNode.TodoIndex
-			}
-		} else {
-			m.SelectedIndex = util.Min(m.SelectedIndex+count, len(m.FileModel.Todos)-1)
-			if m.SelectedIndex < 0 {
-				m.SelectedIndex = 0
-			}
-		}
+		m.selectDown(count)
 
 	case "k", "up":
-		if m.hasActiveFilters() || m.ShowHeadings {
-			// Use document tree for filtered navigation
-			tree := m.GetDocumentTree()
-			tree.NavigateUp(count)
-			if selectedNode := tree.GetSelectedNode(); selectedNode != nil && selectedNode.Type == DocNodeTodo {
-				m.SelectedIndex = selectedNode.TodoIndex
-			}
-		} else {
-			m.SelectedIndex = util.Max(m.SelectedIndex-count, 0)
-		}
+		m.selectUp(count)
+
+	case "ctrl+d":
+		// Half-page down (vim-style)
+		m.selectDown(count * m.halfPage())
+
+	case "ctrl+u":
+		// Half-page up (vim-style)
+		m.selectUp(count * m.halfPage())
 
 	case " ", "enter":
-		if len(m.FileModel.Todos) > 0 {
-			m.saveHistory()
-			todo := m.FileModel.Todos[m.SelectedIndex]
-			_ = m.FileModel.UpdateTodoItem(m.SelectedIndex, todo.Text, !todo.Checked)
-			// Mark this todo as locally modified
-			m.LocallyModified[todo.Text] = true
-			m.writeIfPersist()
-			// Adjust selection if item is now hidden by any filter
-			if !m.isTodoVisible(m.SelectedIndex) {
-				m.SelectedIndex = m.findBestVisibleSelection(m.SelectedIndex)
-				m.InvalidateDocumentTree()
-			}
-		}
+		m.toggleCount(count)
+
+	case "T":
+		m.toggleSubtree()
 
 	case "n":
-		// Insert new todo after cursor position (like vim's 'o')
+		// Insert new todo after cursor position (like vim's 'o'), or at the
+		// end of the cursor's section when headings are shown
 		m.saveHistory()
+		if m.ShowHeadings && len(m.FileModel.Todos) > 0 {
+			m.SelectedIndex = m.sectionTail(m.SelectedIndex)
+		}
 		m.InputMode = true
 		m.InsertAfterCursor = true
 		m.InputBuffer = ""
@@ -224,28 +272,58 @@ func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.CursorPos = 0
 
 	case "e":
+		m.startEdit()
+
+	case "E":
+		// Edit the selected todo in $EDITOR
+		return m, m.openExternalEditor()
+
+	case "a":
+		// Append a note line to the selected todo
 		if len(m.FileModel.Todos) > 0 {
 			m.saveHistory()
-			m.EditMode = true
-			m.InputBuffer = m.FileModel.Todos[m.SelectedIndex].Text
-			m.CursorPos = len(m.InputBuffer)
+			m.NoteMode = true
+			m.InputBuffer = ""
+			m.CursorPos = 0
 		}
 
 	case "d":
-		if len(m.FileModel.Todos) > 0 {
+		if count > 1 {
+			m.deleteCount(count)
+		} else if len(m.FileModel.Todos) > 0 {
+			if m.config != nil && m.config.Defaults.ConfirmDeleteParent && m.subtaskCount(m.SelectedIndex) > 0 {
+				m.ConfirmDeleteMode = true
+				break
+			}
 			m.saveHistory()
 			m.deleteCurrent()
 		}
 
 	case "c":
 		if len(m.FileModel.Todos) > 0 {
-			util.CopyToClipboard(m.FileModel.Todos[m.SelectedIndex].Text)
-			m.CopyFeedback = true
-			return m, tea.Tick(1500*time.Millisecond, func(t time.Time) tea.Msg {
-				return ClearCopyFeedbackMsg{}
-			})
+			if err := util.CopyToClipboard(m.FileModel.Todos[m.SelectedIndex].Text); err != nil {
+				m.setStatus("Copy failed (" + err.Error() + ")")
+			} else {
+				m.setStatusOK("✓ Copied to clipboard!")
+			}
 		}
 
+	case "]":
+		// Jump to the first todo under the next heading
+		m.jumpSection(count)
+
+	case "[":
+		// Jump to the first todo under the previous heading
+		m.jumpSection(-count)
+
+	case "*":
+		// Jump to the next todo sharing the current todo's first tag (vim-style)
+		m.jumpToNextTagged()
+		return m, nil
+
+	case "s":
+		m.togglePin()
+
 	case "m":
 		if len(m.FileModel.Todos) > 0 {
 			m.saveHistory()
@@ -255,8 +333,7 @@ func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 	case "u":
 		if m.History != nil {
-			m.FileModel = *m.History
-			m.History = nil
+			m.restoreHistory()
 			m.InvalidateDocumentTree()
 			m.writeIfPersist()
 			if m.SelectedIndex >= len(m.FileModel.Todos) {
@@ -277,7 +354,7 @@ func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case "r":
 		// Load and display recent files
 		if recentFiles, err := config.LoadRecentFiles(); err == nil {
-			recentFiles.SortByScore()
+			recentFiles.SortBy(config.RecentSort)
 			m.RecentFiles = recentFiles.Files
 			m.RecentFilesCursor = 0
 			m.RecentFilesSearch = ""
@@ -286,15 +363,14 @@ func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 	case "/":
 		if len(m.FileModel.Todos) > 0 {
+			// The scope is taken from the list as it is before searching
+			m.searchScope, m.searchScopeName = m.searchScopeTodos()
+			m.searchAll = false
 			m.SearchMode = true
 			m.InputBuffer = ""
 			m.CursorPos = 0
-			m.SearchCursor = 0
-			// Initialize with all todos
-			m.SearchResults = nil
-			for i := range m.FileModel.Todos {
-				m.SearchResults = append(m.SearchResults, i)
-			}
+			m.searchHistoryIdx = -1
+			m.updateSearchResults()
 		}
 
 	case "t":
@@ -304,16 +380,60 @@ func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.FilterMode = true
 		m.TagFilterCursor = 0
 
-	case "p":
+	case "@":
+		// Filter by todo.txt style @contexts
+		m.RefreshAvailableTags()
+		m.ContextFilterMode = true
+		m.ContextFilterCursor = 0
+
+	case "+":
+		// Filter by todo.txt style +projects
+		m.RefreshAvailableTags()
+		m.ProjectFilterMode = true
+		m.ProjectFilterCursor = 0
+
+	case "y":
+		// First y press - wait for second y
+		if m.yPressed {
+			m.yPressed = false
+			m.yankTodos(m.yankCount)
+			return m, nil
+		}
+		m.yPressed = true
+		m.yankCount = count
+		return m, nil
+
+	case "!":
 		// Always allow entering priority filter mode - show helpful message if no priorities
 		m.PriorityFilterMode = true
 		m.PriorityFilterCursor = 0
 
+	case "p":
+		m.pasteTodos(false)
+
+	case "P":
+		m.pasteTodos(true)
+
+	case "h":
+		// Quick toggle for hiding completed todos
+		m.toggleFilterDone()
+
+	case "o":
+		// Quick toggle for showing only overdue todos
+		m.toggleDueFilter("overdue")
+
+	case "w":
+		m.WordWrap = !m.WordWrap
+
 	case "D":
 		// Enter due date filter mode (capital D to not conflict with delete)
 		m.DueFilterMode = true
 		m.DueFilterCursor = 0
 
+	case ".":
+		// Jump to a todo by its number
+		m.startGotoInput()
+
 	case "G":
 		// Go to bottom (vim-style)
 		if m.hasActiveFilters() || m.ShowHeadings {
@@ -357,26 +477,19 @@ func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.FilteredCmds = append(m.FilteredCmds, i)
 		}
 
+	case "ctrl+w":
+		m.switchPane()
+
 	case "tab":
-		// Indent: make current todo a child of its previous sibling
+		// Indent: make current todo (with its subtasks) a child of its previous sibling
 		if len(m.FileModel.Todos) > 0 && !m.ReadOnly {
-			m.saveHistory()
-			if err := m.FileModel.IndentTodoItem(m.SelectedIndex); err == nil {
-				m.InvalidateDocumentTree()
-				m.writeIfPersist()
-			}
-			// Silently ignore errors (e.g., can't indent first item)
+			m.indentSelected(count)
 		}
 
 	case "shift+tab":
-		// Outdent: move current todo up one level in hierarchy
+		// Outdent: move current todo (with its subtasks) up one level in hierarchy
 		if len(m.FileModel.Todos) > 0 && !m.ReadOnly {
-			m.saveHistory()
-			if err := m.FileModel.OutdentTodoItem(m.SelectedIndex); err == nil {
-				m.InvalidateDocumentTree()
-				m.writeIfPersist()
-			}
-			// Silently ignore errors (e.g., can't outdent top-level item)
+			m.outdentSelected(count)
 		}
 	}
 
@@ -402,56 +515,53 @@ func (m Model) handleInputKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				m.writeIfPersist()
 			}
 			m.EditMode = false
+		} else if m.NoteMode {
+			if m.InputBuffer != "" {
+				_ = m.FileModel.AddTodoNote(m.SelectedIndex, m.InputBuffer)
+				m.writeIfPersist()
+			}
+			m.NoteMode = false
+			m.InputBuffer = ""
+			m.CursorPos = 0
 		}
 
 	case "esc":
 		m.InputMode = false
 		m.EditMode = false
+		m.NoteMode = false
 		if m.History != nil {
-			m.FileModel = *m.History
-			m.History = nil
+			m.restoreHistory()
 		}
 
 	case "backspace", "ctrl+h":
-		if m.CursorPos > 0 {
-			m.InputBuffer = m.InputBuffer[:m.CursorPos-1] + m.InputBuffer[m.CursorPos:]
-			m.CursorPos--
-		}
+		m.deleteInputBefore()
 
 	case "delete":
-		if m.CursorPos < len(m.InputBuffer) {
-			m.InputBuffer = m.InputBuffer[:m.CursorPos] + m.InputBuffer[m.CursorPos+1:]
-		}
+		m.deleteInputAt()
 
 	case "left":
-		if m.CursorPos > 0 {
-			m.CursorPos--
-		}
+		m.moveInputCursor(-1)
 
 	case "right":
-		if m.CursorPos < len(m.InputBuffer) {
-			m.CursorPos++
-		}
+		m.moveInputCursor(1)
 
 	case "home", "ctrl+a":
 		m.CursorPos = 0
 
 	case "end", "ctrl+e":
-		m.CursorPos = len(m.InputBuffer)
+		m.cursorToInputEnd()
 
 	case "ctrl+v", "ctrl+shift+v", "ctrl+y":
 		// Paste from clipboard (ctrl+y is more reliable in terminals)
 		text := util.PasteFromClipboard()
 		if text != "" {
-			m.InputBuffer = m.InputBuffer[:m.CursorPos] + text + m.InputBuffer[m.CursorPos:]
-			m.CursorPos += len(text)
+			m.insertInput(text)
 		}
 
 	default:
 		// Insert character
-		if len(key) == 1 {
-			m.InputBuffer = m.InputBuffer[:m.CursorPos] + key + m.InputBuffer[m.CursorPos:]
-			m.CursorPos++
+		if isTypedChar(key) {
+			m.insertInput(key)
 		}
 	}
 
@@ -477,37 +587,27 @@ func (m Model) handleMaxVisibleInputKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.InputBuffer = ""
 
 	case "backspace", "ctrl+h":
-		if m.CursorPos > 0 {
-			m.InputBuffer = m.InputBuffer[:m.CursorPos-1] + m.InputBuffer[m.CursorPos:]
-			m.CursorPos--
-		}
+		m.deleteInputBefore()
 
 	case "delete":
-		if m.CursorPos < len(m.InputBuffer) {
-			m.InputBuffer = m.InputBuffer[:m.CursorPos] + m.InputBuffer[m.CursorPos+1:]
-		}
+		m.deleteInputAt()
 
 	case "left":
-		if m.CursorPos > 0 {
-			m.CursorPos--
-		}
+		m.moveInputCursor(-1)
 
 	case "right":
-		if m.CursorPos < len(m.InputBuffer) {
-			m.CursorPos++
-		}
+		m.moveInputCursor(1)
 
 	case "home", "ctrl+a":
 		m.CursorPos = 0
 
 	case "end", "ctrl+e":
-		m.CursorPos = len(m.InputBuffer)
+		m.cursorToInputEnd()
 
 	default:
 		// Only allow digits
 		if len(key) == 1 && key >= "0" && key <= "9" {
-			m.InputBuffer = m.InputBuffer[:m.CursorPos] + key + m.InputBuffer[m.CursorPos:]
-			m.CursorPos++
+			m.insertInput(key)
 		}
 	}
 
@@ -548,10 +648,23 @@ func (m Model) handleMoveKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				}
 			}
 		} else {
-			// No filters: simple insertion move
-			if m.SelectedIndex < len(m.FileModel.Todos)-1 {
-				if err := m.FileModel.MoveTodoItem(m.SelectedIndex, m.SelectedIndex+1); err == nil {
-					m.SelectedIndex++
+			// No filters: move past the next todo, skipping the selected todo's
+			// own nested todos, which move along with it
+			todos := m.FileModel.Todos
+			next := m.SelectedIndex + 1
+			for next < len(todos) && todos[next].Depth > todos[m.SelectedIndex].Depth {
+				next++
+			}
+			if next < len(todos) {
+				lineNo := todos[m.SelectedIndex].LineNo
+				if err := m.FileModel.MoveTodoItem(m.SelectedIndex, next); err == nil {
+					// Moving keeps the todo's position in the source, which identifies it
+					for i, todo := range m.FileModel.Todos {
+						if todo.LineNo == lineNo {
+							m.SelectedIndex = i
+							break
+						}
+					}
 				}
 			}
 		}
@@ -597,11 +710,13 @@ func (m Model) handleMoveKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case "enter":
 		m.writeIfPersist()
 		m.MoveMode = false
+		if m.SelectedIndex != m.SavedCursorIndex {
+			m.setStatus("Moved: " + m.FileModel.Todos[m.SelectedIndex].Text + " — u to undo")
+		}
 
 	case "esc":
 		if m.History != nil {
-			m.FileModel = *m.History
-			m.History = nil
+			m.restoreHistory()
 			m.InvalidateDocumentTree()
 			m.InvalidateHeadingsCache()
 		}
@@ -621,7 +736,9 @@ func (m Model) handleSearchKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		// Select current search result
 		if len(m.SearchResults) > 0 && m.SearchCursor < len(m.SearchResults) {
 			m.SelectedIndex = m.SearchResults[m.SearchCursor]
+			m.unfoldSection(m.SelectedIndex)
 		}
+		m.recordSearch(m.InputBuffer)
 		m.SearchMode = false
 		m.InputBuffer = ""
 		m.SearchResults = nil
@@ -633,22 +750,44 @@ func (m Model) handleSearchKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.SearchResults = nil
 		m.searchPending = false
 
-	case "down", "ctrl+n", "ctrl+j":
+	case "up":
+		// Recall older queries while the input is empty or already recalled
+		if m.InputBuffer == "" || m.searchHistoryIdx >= 0 {
+			m.recallSearch(m.searchHistoryIdx + 1)
+		} else if m.SearchCursor > 0 {
+			m.SearchCursor--
+		}
+
+	case "down":
+		if m.searchHistoryIdx >= 0 {
+			m.recallSearch(m.searchHistoryIdx - 1)
+		} else if len(m.SearchResults) > 0 && m.SearchCursor < len(m.SearchResults)-1 {
+			m.SearchCursor++
+		}
+
+	case "ctrl+g":
+		// Switch between the scoped search and all todos
+		if m.searchScope != nil {
+			m.searchAll = !m.searchAll
+			m.searchPending = false
+			m.updateSearchResults()
+		}
+
+	case "ctrl+n", "ctrl+j":
 		// Move down in search results
 		if len(m.SearchResults) > 0 && m.SearchCursor < len(m.SearchResults)-1 {
 			m.SearchCursor++
 		}
 
-	case "up", "ctrl+p", "ctrl+k":
+	case "ctrl+p", "ctrl+k":
 		// Move up in search results
 		if m.SearchCursor > 0 {
 			m.SearchCursor--
 		}
 
 	case "backspace", "ctrl+h":
-		if m.CursorPos > 0 {
-			m.InputBuffer = m.InputBuffer[:m.CursorPos-1] + m.InputBuffer[m.CursorPos:]
-			m.CursorPos--
+		if m.deleteInputBefore() {
+			m.searchHistoryIdx = -1
 			// Debounce search update
 			m.searchPending = true
 			return m, searchDebounceCmd()
@@ -656,9 +795,9 @@ func (m Model) handleSearchKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 	default:
 		// Insert character
-		if len(key) == 1 {
-			m.InputBuffer = m.InputBuffer[:m.CursorPos] + key + m.InputBuffer[m.CursorPos:]
-			m.CursorPos++
+		if isTypedChar(key) {
+			m.insertInput(key)
+			m.searchHistoryIdx = -1
 			// Debounce search update
 			m.searchPending = true
 			return m, searchDebounceCmd()
@@ -668,6 +807,59 @@ func (m Model) handleSearchKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// recallSearch puts the search history entry at idx into the input.
+// Going past the newest entry clears the input again.
+func (m *Model) recallSearch(idx int) {
+	if idx >= len(m.SearchHistory) {
+		return
+	}
+	m.searchHistoryIdx = idx
+	m.InputBuffer = ""
+	if idx >= 0 {
+		m.InputBuffer = m.SearchHistory[idx]
+	}
+	m.cursorToInputEnd()
+	m.searchPending = false
+	m.updateSearchResults()
+}
+
+// recordSearch adds a confirmed query to the history
+func (m *Model) recordSearch(query string) {
+	if strings.TrimSpace(query) == "" {
+		return
+	}
+	history := config.SearchHistory{Queries: slices.Clone(m.SearchHistory)}
+	history.Add(query)
+	m.SearchHistory = history.Queries
+	m.newSearches = append(m.newSearches, query)
+}
+
+// loadSearchHistory reads the queries of previous sessions
+func (m *Model) loadSearchHistory() {
+	if history, err := config.LoadSearchHistory(); err == nil {
+		m.SearchHistory = history.Queries
+	}
+}
+
+// saveSearchHistory persists the queries searched in this session
+func (m *Model) saveSearchHistory() {
+	if len(m.newSearches) > 0 {
+		_ = config.AddSearchQueries(m.newSearches...)
+	}
+}
+
+// toggleTagFilter adds the tag to the filter, or removes it if it is already there
+func (m *Model) toggleTagFilter(tag string) {
+	if i := slices.Index(m.FilteredTags, tag); i >= 0 {
+		m.FilteredTags = slices.Delete(m.FilteredTags, i, i+1)
+	} else {
+		m.FilteredTags = append(m.FilteredTags, tag)
+	}
+
+	// Filter change affects document tree
+	m.InvalidateDocumentTree()
+}
+
 func (m Model) handleFilterKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	key := msg.String()
 
@@ -675,26 +867,7 @@ func (m Model) handleFilterKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case "enter", " ":
 		// Toggle tag filter
 		if len(m.AvailableTags) > 0 && m.TagFilterCursor < len(m.AvailableTags) {
-			selectedTag := m.AvailableTags[m.TagFilterCursor]
-
-			// Check if tag is already in filter
-			found := false
-			for i, tag := range m.FilteredTags {
-				if tag == selectedTag {
-					// Remove tag from filter
-					m.FilteredTags = append(m.FilteredTags[:i], m.FilteredTags[i+1:]...)
-					found = true
-					break
-				}
-			}
-
-			if !found {
-				// Add tag to filter
-				m.FilteredTags = append(m.FilteredTags, selectedTag)
-			}
-
-			// Filter change affects document tree
-			m.InvalidateDocumentTree()
+			m.toggleTagFilter(m.AvailableTags[m.TagFilterCursor])
 
 			// Close filter mode after selection
 			m.FilterMode = false
@@ -724,6 +897,63 @@ func (m Model) handleFilterKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+func (m Model) handleContextFilterKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	m.FilteredContexts, m.ContextFilterCursor, m.ContextFilterMode =
+		m.updateWordFilter(msg.String(), m.AvailableContexts, m.FilteredContexts, m.ContextFilterCursor)
+	return m, nil
+}
+
+func (m Model) handleProjectFilterKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	m.FilteredProjects, m.ProjectFilterCursor, m.ProjectFilterMode =
+		m.updateWordFilter(msg.String(), m.AvailableProjects, m.FilteredProjects, m.ProjectFilterCursor)
+	return m, nil
+}
+
+// updateWordFilter handles a key in the context or project filter overlay.
+// It returns the new active filters, cursor position and whether the overlay stays open.
+func (m *Model) updateWordFilter(key string, available, filtered []string, cursor int) ([]string, int, bool) {
+	switch key {
+	case "enter", " ":
+		// Toggle the selected value and close the overlay
+		if len(available) > 0 && cursor < len(available) {
+			selected := available[cursor]
+			found := false
+			for i, v := range filtered {
+				if v == selected {
+					filtered = append(filtered[:i], filtered[i+1:]...)
+					found = true
+					break
+				}
+			}
+			if !found {
+				filtered = append(filtered, selected)
+			}
+			m.InvalidateDocumentTree()
+			return filtered, cursor, false
+		}
+
+	case "esc":
+		return filtered, cursor, false
+
+	case "c":
+		// Clear all filters
+		m.InvalidateDocumentTree()
+		return []string{}, cursor, true
+
+	case "down", "ctrl+n", "ctrl+j", "j":
+		if cursor < len(available)-1 {
+			cursor++
+		}
+
+	case "up", "ctrl+p", "ctrl+k", "k":
+		if cursor > 0 {
+			cursor--
+		}
+	}
+
+	return filtered, cursor, true
+}
+
 func (m Model) handlePriorityFilterKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	key := msg.String()
 
@@ -884,10 +1114,21 @@ func (m Model) handleCommandKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 	switch key {
 	case "enter":
-		// Execute current command
-		if len(m.FilteredCmds) > 0 && m.CommandCursor < len(m.FilteredCmds) {
-			cmdIdx := m.FilteredCmds[m.CommandCursor]
-			m.Commands[cmdIdx].Handler(&m)
+		// Execute the command, passing on any text typed after its name
+		if m.searchPending {
+			// Enter came before the debounced filter update
+			m.updateFilteredCommands()
+		}
+		name, arg := splitCommandInput(m.InputBuffer)
+		if cmd := m.commandToRun(name, arg); cmd != nil {
+			switch {
+			case arg == "":
+				cmd.Handler(&m)
+			case cmd.ArgHandler != nil:
+				cmd.ArgHandler(&m, arg)
+			default:
+				m.setStatus(cmd.Name + " takes no argument")
+			}
 		}
 		m.CommandMode = false
 		m.searchPending = false
@@ -901,8 +1142,12 @@ func (m Model) handleCommandKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		// Tab completes to the selected command name
 		if len(m.FilteredCmds) > 0 && m.CommandCursor < len(m.FilteredCmds) {
 			cmdIdx := m.FilteredCmds[m.CommandCursor]
+			_, arg := splitCommandInput(m.InputBuffer)
 			m.InputBuffer = m.Commands[cmdIdx].Name
-			m.CursorPos = len(m.InputBuffer)
+			if arg != "" {
+				m.InputBuffer += " " + arg
+			}
+			m.cursorToInputEnd()
 			m.updateFilteredCommands()
 		}
 
@@ -925,9 +1170,7 @@ func (m Model) handleCommandKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 
 	case "backspace", "ctrl+h":
-		if m.CursorPos > 0 {
-			m.InputBuffer = m.InputBuffer[:m.CursorPos-1] + m.InputBuffer[m.CursorPos:]
-			m.CursorPos--
+		if m.deleteInputBefore() {
 			// Debounce command filter update
 			m.searchPending = true
 			return m, commandDebounceCmd()
@@ -935,9 +1178,8 @@ func (m Model) handleCommandKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 	default:
 		// Insert character
-		if len(key) == 1 {
-			m.InputBuffer = m.InputBuffer[:m.CursorPos] + key + m.InputBuffer[m.CursorPos:]
-			m.CursorPos++
+		if isTypedChar(key) {
+			m.insertInput(key)
 			// Debounce command filter update
 			m.searchPending = true
 			return m, commandDebounceCmd()
@@ -949,53 +1191,386 @@ func (m Model) handleCommandKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 // Helper functions
 
-func (m *Model) saveHistory() {
-	m.History = m.FileModel.Clone()
+// selectDown moves the selection down by count visible todos
+func (m *Model) selectDown(count int) {
+	if m.hasActiveFilters() || m.ShowHeadings {
+		// Use document tree for filtered navigation
+		tree := m.GetDocumentTree()
+		tree.NavigateDown(count)
+		if selectedNode := tree.GetSelectedNode(); selectedNode != nil && selectedNode.Type == DocNodeTodo {
+			m.SelectedIndex = selectedNode.TodoIndex
+		}
+	} else {
+		m.SelectedIndex = util.Min(m.SelectedIndex+count, len(m.FileModel.Todos)-1)
+		if m.SelectedIndex < 0 {
+			m.SelectedIndex = 0
+		}
+	}
 }
 
-func (m *Model) addNewTodo() {
-	if m.InsertAfterCursor && len(m.FileModel.Todos) > 0 {
-		// Insert after current cursor position
-		newIndex := m.FileModel.InsertTodoItemAfter(m.SelectedIndex, m.InputBuffer, false)
-		m.SelectedIndex = newIndex
+// selectUp moves the selection up by count visible todos
+func (m *Model) selectUp(count int) {
+	if m.hasActiveFilters() || m.ShowHeadings {
+		// Use document tree for filtered navigation
+		tree := m.GetDocumentTree()
+		tree.NavigateUp(count)
+		if selectedNode := tree.GetSelectedNode(); selectedNode != nil && selectedNode.Type == DocNodeTodo {
+			m.SelectedIndex = selectedNode.TodoIndex
+		}
 	} else {
-		// Append to end of file (also used when list is empty)
-		m.FileModel.AddTodoItem(m.InputBuffer, false)
-		m.SelectedIndex = len(m.FileModel.Todos) - 1
+		m.SelectedIndex = util.Max(m.SelectedIndex-count, 0)
 	}
-	m.InvalidateHeadingsCache() // New todo may affect heading positions
-	m.InvalidateDocumentTree()  // New todo affects document tree
-	m.RefreshAvailableTags()    // New todo may introduce new tags
-	m.writeIfPersist()
 }
 
-// findBestVisibleSelection finds the best visible todo to select when the item at
-// hiddenIdx becomes hidden (e.g., toggled to done with filter-done active).
-// It considers subtask relationships:
-// 1. Next sibling at same depth with same parent
-// 2. Previous sibling if no next sibling
-// 3. Parent if no siblings remain
-// 4. Fall back to next/previous visible item
-// Returns the index to select (no adjustment needed since nothing is removed)
-func (m *Model) findBestVisibleSelection(hiddenIdx int) int {
-	if len(m.FileModel.Todos) == 0 {
-		return 0
+// halfPage returns the number of todos a half-page scroll moves by
+func (m *Model) halfPage() int {
+	page := m.effectiveMaxVisible()
+	if page == 0 {
+		// Unlimited window without terminal size: use the visible list
+		page = len(m.getVisibleTodos())
 	}
+	return util.Max(page/2, 1)
+}
 
-	todos := m.FileModel.Todos
-	hiddenTodo := todos[hiddenIdx]
-	hiddenDepth := hiddenTodo.Depth
-	hiddenParent := hiddenTodo.ParentIndex
+// jumpToNextTagged selects the next visible todo that shares the first tag of
+// the selected todo, wrapping around at the end of the list
+func (m *Model) jumpToNextTagged() {
+	if len(m.FileModel.Todos) == 0 {
+		return
+	}
+	todo := m.FileModel.Todos[m.SelectedIndex]
+	if len(todo.Tags) == 0 {
+		m.setStatus("No tag on this todo")
+		return
+	}
 
-	// Look for next sibling (same parent, same depth, comes after hiddenIdx)
-	for i := hiddenIdx + 1; i < len(todos); i++ {
-		todo := todos[i]
-		// If we hit a shallower depth, we've left the subtree
-		if todo.Depth < hiddenDepth {
-			break
+	tag := todo.Tags[0]
+	total := len(m.FileModel.Todos)
+	for offset := 1; offset < total; offset++ {
+		idx := (m.SelectedIndex + offset) % total
+		if m.isTodoVisible(idx) && m.FileModel.Todos[idx].HasTag(tag) {
+			m.SelectedIndex = idx
+			m.InvalidateDocumentTree()
+			return
 		}
-		// Found a sibling at same depth with same parent
-		if todo.Depth == hiddenDepth && todo.ParentIndex == hiddenParent {
+	}
+	m.setStatus("No other todo tagged #" + tag)
+}
+
+// jumpSection moves the selection to the first visible todo of the section count
+// headings below (count > 0) or above (count < 0) the current one. Sections
+// whose todos are all hidden are skipped. Only active when headings are shown.
+func (m *Model) jumpSection(count int) {
+	if !m.ShowHeadings {
+		return
+	}
+
+	// Section starts, from the todo indices the headings come before
+	starts := []int{0}
+	for _, h := range m.GetHeadings() {
+		if h.BeforeTodoIndex > starts[len(starts)-1] && h.BeforeTodoIndex < len(m.FileModel.Todos) {
+			starts = append(starts, h.BeforeTodoIndex)
+		}
+	}
+
+	// firstVisible returns the first visible todo of section i, or -1
+	firstVisible := func(i int) int {
+		end := len(m.FileModel.Todos)
+		if i+1 < len(starts) {
+			end = starts[i+1]
+		}
+		for idx := starts[i]; idx < end; idx++ {
+			if m.isTodoVisible(idx) {
+				return idx
+			}
+		}
+		return -1
+	}
+
+	current := 0
+	for i, start := range starts {
+		if start <= m.SelectedIndex {
+			current = i
+		}
+	}
+
+	step := 1
+	if count < 0 {
+		step, count = -1, -count
+	}
+	target := -1
+	for i := current + step; i >= 0 && i < len(starts) && count > 0; i += step {
+		if idx := firstVisible(i); idx >= 0 {
+			target = idx
+			count--
+		}
+	}
+	if target >= 0 {
+		m.SelectedIndex = target
+		m.InvalidateDocumentTree()
+	}
+}
+
+// statusDuration is how long a status message stays in the status bar
+const statusDuration = 2 * time.Second
+
+// setStatus shows a transient message in the status bar. It is cleared after
+// statusDuration, unless a newer message replaced it by then.
+func (m *Model) setStatus(msg string) {
+	m.showStatus(msg, false)
+}
+
+// setStatusOK shows a transient success message in the status bar, in green
+func (m *Model) setStatusOK(msg string) {
+	m.showStatus(msg, true)
+}
+
+// showStatus shows msg in the status bar until statusDuration has passed
+func (m *Model) showStatus(msg string, ok bool) {
+	m.StatusHint = msg
+	m.statusOK = ok
+	m.statusSeq++
+	seq := m.statusSeq
+	m.pendingCmd = tea.Batch(m.pendingCmd, tea.Tick(statusDuration, func(t time.Time) tea.Msg {
+		return ClearStatusHintMsg{seq: seq}
+	}))
+}
+
+// toggleSelected flips the checkbox of the selected todo
+func (m *Model) toggleSelected() {
+	m.toggleCount(1)
+}
+
+// toggleCount flips the checkboxes of count visible todos starting at the selection,
+// as a single undo step
+func (m *Model) toggleCount(count int) {
+	indices := m.visibleFromSelection(count)
+	if len(indices) == 0 {
+		return
+	}
+	m.saveHistory()
+	for _, idx := range indices {
+		todo := m.FileModel.Todos[idx]
+		_ = m.FileModel.ToggleTodoItem(idx)
+		m.noteCompleted(idx)
+		// Mark this todo as locally modified
+		m.LocallyModified[todo.Text] = true
+	}
+	m.writeIfPersist()
+	// Adjust selection if item is now hidden by any filter
+	if !m.isTodoVisible(m.SelectedIndex) {
+		m.SelectedIndex = m.findBestVisibleSelection(m.SelectedIndex)
+		m.InvalidateDocumentTree()
+	}
+}
+
+// visibleFromSelection returns up to count visible todos, starting with the selected one
+func (m *Model) visibleFromSelection(count int) []int {
+	if m.SelectedIndex < 0 || m.SelectedIndex >= len(m.FileModel.Todos) {
+		return nil
+	}
+	visible := m.getVisibleTodos()
+	for i, idx := range visible {
+		if idx == m.SelectedIndex {
+			return visible[i:min(i+max(count, 1), len(visible))]
+		}
+	}
+	return []int{m.SelectedIndex}
+}
+
+// toggleSubtree toggles the selected todo and sets all nested todos to its new state
+func (m *Model) toggleSubtree() {
+	if len(m.FileModel.Todos) == 0 {
+		return
+	}
+	m.saveHistory()
+	checked := !m.FileModel.Todos[m.SelectedIndex].Checked
+	for i := m.SelectedIndex; i < m.subtreeEnd(m.SelectedIndex); i++ {
+		todo := m.FileModel.Todos[i]
+		if todo.Checked != checked {
+			_ = m.FileModel.UpdateTodoItem(i, todo.Text, checked)
+			m.noteCompleted(i)
+		}
+		m.LocallyModified[todo.Text] = true
+	}
+	m.InvalidateDocumentTree()
+	m.writeIfPersist()
+	if !m.isTodoVisible(m.SelectedIndex) {
+		m.SelectedIndex = m.findBestVisibleSelection(m.SelectedIndex)
+		m.InvalidateDocumentTree()
+	}
+}
+
+// subtreeEnd returns the index just past the last todo nested under the todo at idx.
+// Nested todos directly follow their parent with a greater depth.
+func (m *Model) subtreeEnd(idx int) int {
+	todos := m.FileModel.Todos
+	end := idx + 1
+	for end < len(todos) && todos[end].Depth > todos[idx].Depth {
+		end++
+	}
+	return end
+}
+
+// indentSelected indents the selected todo and its subtasks by up to count levels.
+// Each level needs a previous sibling to nest under, so it stops early (silently,
+// like vim) once there is none.
+func (m *Model) indentSelected(count int) {
+	snapshot := m.FileModel.Clone()
+	levels := 0
+	for ; levels < max(count, 1); levels++ {
+		if m.FileModel.IndentTodoItem(m.SelectedIndex) != nil {
+			break
+		}
+	}
+	if levels == 0 {
+		return
+	}
+
+	m.History = snapshot
+	m.InvalidateDocumentTree()
+	m.writeIfPersist()
+}
+
+// outdentSelected outdents the selected todo and its subtasks by up to count
+// levels, stopping at the top level. Each level places the todo after its
+// former parent's subtasks, and the selection follows it.
+func (m *Model) outdentSelected(count int) {
+	snapshot := m.FileModel.Clone()
+	levels := 0
+	for ; levels < max(count, 1); levels++ {
+		idx := m.SelectedIndex
+		newIdx := idx
+		if parent := m.FileModel.Todos[idx].ParentIndex; parent >= 0 {
+			newIdx = m.subtreeEnd(parent) - (m.subtreeEnd(idx) - idx)
+		}
+		if m.FileModel.OutdentTodoItem(idx) != nil {
+			break
+		}
+		m.SelectedIndex = newIdx
+	}
+	if levels == 0 {
+		return
+	}
+
+	m.History = snapshot
+	m.InvalidateDocumentTree()
+	m.writeIfPersist()
+}
+
+func (m *Model) saveHistory() {
+	m.History = m.FileModel.Clone()
+}
+
+// restoreHistory brings back the snapshot taken by saveHistory. The modification
+// time of the current state is kept, as the file on disk hasn't changed since.
+func (m *Model) restoreHistory() {
+	modTime := m.FileModel.ModTime
+	m.FileModel = *m.History
+	m.FileModel.ModTime = modTime
+	m.History = nil
+}
+
+func (m *Model) addNewTodo() {
+	if m.InsertAfterCursor && len(m.FileModel.Todos) > 0 {
+		// Insert after current cursor position
+		newIndex := m.FileModel.InsertTodoItemAfter(m.SelectedIndex, m.InputBuffer, false)
+		m.SelectedIndex = newIndex
+	} else {
+		// Append to end of file (also used when list is empty)
+		m.FileModel.AddTodoItem(m.InputBuffer, false)
+		m.SelectedIndex = len(m.FileModel.Todos) - 1
+	}
+	m.InvalidateHeadingsCache() // New todo may affect heading positions
+	m.InvalidateDocumentTree()  // New todo affects document tree
+	m.RefreshAvailableTags()    // New todo may introduce new tags
+	m.writeIfPersist()
+}
+
+// sectionTail returns the last visible todo of the heading section containing idx
+func (m *Model) sectionTail(idx int) int {
+	_, end := markdown.SectionRange(m.FileModel.Todos, m.GetHeadings(), idx)
+	for i := end - 1; i > idx; i-- {
+		if m.isTodoVisible(i) {
+			return i
+		}
+	}
+	return idx
+}
+
+// pastedTodoRegex matches a list bullet and checkbox at the start of a pasted line
+var pastedTodoRegex = regexp.MustCompile(`^(?:[-*+]\s+)?(?:\[([ xX])\]\s+)?`)
+
+// addPastedTodos adds a todo for each non-empty line of a multi-line paste,
+// where the typed todo would have gone. Text typed around the cursor is kept
+// on the first and last line. A leading "- [ ]" or "- [x]" sets the checked state.
+func (m *Model) addPastedTodos(text string) {
+	lines := strings.Split(text, "\n")
+	before, after := m.splitInput()
+	lines[0] = before + lines[0]
+	lines[len(lines)-1] += after
+
+	added := 0
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		match := pastedTodoRegex.FindStringSubmatch(line)
+		checked := strings.EqualFold(match[1], "x")
+		line = line[len(match[0]):]
+		if line == "" {
+			continue
+		}
+
+		if m.InsertAfterCursor && len(m.FileModel.Todos) > 0 {
+			m.SelectedIndex = m.FileModel.InsertTodoItemAfter(m.SelectedIndex, line, checked)
+		} else {
+			m.FileModel.AddTodoItem(line, checked)
+			m.SelectedIndex = len(m.FileModel.Todos) - 1
+		}
+		added++
+	}
+
+	m.InputMode = false
+	m.InputBuffer = ""
+	m.CursorPos = 0
+	m.InvalidateHeadingsCache()
+	m.InvalidateDocumentTree()
+	m.RefreshAvailableTags()
+	m.writeIfPersist()
+
+	if added == 1 {
+		m.setStatus("Added 1 todo")
+		return
+	}
+	m.setStatus(fmt.Sprintf("Added %d todos", added))
+}
+
+// findBestVisibleSelection finds the best visible todo to select when the item at
+// hiddenIdx becomes hidden (e.g., toggled to done with filter-done active).
+// It considers subtask relationships:
+// 1. Next sibling at same depth with same parent
+// 2. Previous sibling if no next sibling
+// 3. Parent if no siblings remain
+// 4. Fall back to next/previous visible item
+// Returns the index to select (no adjustment needed since nothing is removed)
+func (m *Model) findBestVisibleSelection(hiddenIdx int) int {
+	if len(m.FileModel.Todos) == 0 {
+		return 0
+	}
+
+	todos := m.FileModel.Todos
+	hiddenTodo := todos[hiddenIdx]
+	hiddenDepth := hiddenTodo.Depth
+	hiddenParent := hiddenTodo.ParentIndex
+
+	// Look for next sibling (same parent, same depth, comes after hiddenIdx)
+	for i := hiddenIdx + 1; i < len(todos); i++ {
+		todo := todos[i]
+		// If we hit a shallower depth, we've left the subtree
+		if todo.Depth < hiddenDepth {
+			break
+		}
+		// Found a sibling at same depth with same parent
+		if todo.Depth == hiddenDepth && todo.ParentIndex == hiddenParent {
 			if m.isTodoVisible(i) {
 				return i
 			}
@@ -1136,7 +1711,9 @@ func (m *Model) deleteCurrent() {
 	}
 
 	// Perform the deletion
+	deletedText := m.FileModel.Todos[deletedIdx].Text
 	_ = m.FileModel.DeleteTodoItem(deletedIdx)
+	m.setStatus("Deleted: " + deletedText + " — u to undo")
 	m.InvalidateHeadingsCache()
 	m.InvalidateDocumentTree()
 	m.RefreshAvailableTags() // Delete may remove tags
@@ -1155,47 +1732,191 @@ func (m *Model) deleteCurrent() {
 	m.writeIfPersist()
 }
 
+// deleteCount deletes count visible todos starting at the selection, as a single
+// undo step. Like a single delete, nested todos that aren't deleted move up a level.
+func (m *Model) deleteCount(count int) {
+	indices := m.visibleFromSelection(count)
+	if len(indices) == 0 {
+		return
+	}
+	m.saveHistory()
+
+	// Select the next visible todo after the deleted ones, or the one before them
+	newSelection := 0
+	if next := m.nextVisibleAfter(indices[len(indices)-1]); next >= 0 {
+		newSelection = next - len(indices)
+	} else if prev := m.prevVisibleBefore(indices[0]); prev >= 0 {
+		newSelection = prev
+	}
+
+	if len(indices) == 1 {
+		m.setStatus("Deleted: " + m.FileModel.Todos[indices[0]].Text + " — u to undo")
+	} else {
+		m.setStatus(fmt.Sprintf("Deleted %d todos — u to undo", len(indices)))
+	}
+	for i := len(indices) - 1; i >= 0; i-- {
+		_ = m.FileModel.DeleteTodoItem(indices[i])
+	}
+
+	m.SelectedIndex = newSelection
+	m.InvalidateHeadingsCache()
+	m.InvalidateDocumentTree()
+	m.RefreshAvailableTags() // Delete may remove tags
+	m.writeIfPersist()
+}
+
+// nextVisibleAfter returns the first visible todo after idx, or -1
+func (m *Model) nextVisibleAfter(idx int) int {
+	for i := idx + 1; i < len(m.FileModel.Todos); i++ {
+		if m.isTodoVisible(i) {
+			return i
+		}
+	}
+	return -1
+}
+
+// prevVisibleBefore returns the last visible todo before idx, or -1
+func (m *Model) prevVisibleBefore(idx int) int {
+	for i := idx - 1; i >= 0; i-- {
+		if m.isTodoVisible(i) {
+			return i
+		}
+	}
+	return -1
+}
+
+// handleConfirmDeleteKey answers the "Delete N subtasks too?" prompt:
+// y deletes the whole subtree, n promotes the subtasks one level, esc cancels
+func (m Model) handleConfirmDeleteKey(key string) (tea.Model, tea.Cmd) {
+	switch key {
+	case "y", "Y":
+		m.saveHistory()
+		m.deleteSubtree()
+	case "n", "N":
+		m.saveHistory()
+		m.deleteCurrent()
+	case "esc", "ctrl+c":
+	default:
+		return m, nil
+	}
+	m.ConfirmDeleteMode = false
+	return m, nil
+}
+
+// handleConfirmQuitKey answers the "Quit? (y/n)" prompt: y (or ctrl+c) quits,
+// n or esc stays in the app
+func (m Model) handleConfirmQuitKey(key string) (tea.Model, tea.Cmd) {
+	switch key {
+	case "y", "Y", "ctrl+c":
+		return m, tea.Quit
+	case "n", "N", "esc":
+		m.ConfirmQuitMode = false
+	}
+	return m, nil
+}
+
+// subtaskCount returns the number of todos nested under the todo at idx
+func (m *Model) subtaskCount(idx int) int {
+	return m.subtreeEnd(idx) - idx - 1
+}
+
+// deleteSubtree deletes the selected todo together with all of its nested todos
+func (m *Model) deleteSubtree() {
+	if len(m.FileModel.Todos) == 0 {
+		return
+	}
+
+	start, end := m.SelectedIndex, m.subtreeEnd(m.SelectedIndex)
+	removed := end - start
+
+	// Prefer the next visible todo after the subtree, then the previous one
+	newSelection := 0
+	if next := m.nextVisibleAfter(end - 1); next >= 0 {
+		newSelection = next - removed
+	} else if prev := m.prevVisibleBefore(start); prev >= 0 {
+		newSelection = prev
+	}
+
+	// Delete from the bottom up so every todo is a leaf when it's removed
+	for i := end - 1; i >= start; i-- {
+		_ = m.FileModel.DeleteTodoItem(i)
+	}
+
+	m.SelectedIndex = newSelection
+	m.InvalidateHeadingsCache()
+	m.InvalidateDocumentTree()
+	m.RefreshAvailableTags() // Delete may remove tags
+	m.writeIfPersist()
+}
+
 func (m *Model) updateSearchResults() {
 	m.SearchResults = nil
 	m.SearchCursor = 0
 
+	candidates := m.searchCandidates()
 	if m.InputBuffer == "" {
-		// Show all todos when query is empty
-		for i := range m.FileModel.Todos {
-			m.SearchResults = append(m.SearchResults, i)
-		}
+		// Show every candidate when query is empty
+		m.SearchResults = append(m.SearchResults, candidates...)
 		return
 	}
 
-	query := strings.ToLower(m.InputBuffer)
+	// Accents and case are ignored, so "cafe" finds "Café"
+	query := parseSearchQuery(m.InputBuffer)
 
 	// Collect matches with scores
-	type match struct {
-		index int
-		score int
-	}
-	var matches []match
+	var matches []scoredMatch
 
-	for i, todo := range m.FileModel.Todos {
-		text := strings.ToLower(todo.Text)
-		score := util.FuzzyScore(query, text)
+	for _, i := range candidates {
+		score := query.score(m.FileModel.Todos[i])
 		if score > 0 {
-			matches = append(matches, match{i, score})
+			matches = append(matches, scoredMatch{i, score})
 		}
 	}
 
-	// Sort by score descending
-	for i := 0; i < len(matches)-1; i++ {
-		for j := i + 1; j < len(matches); j++ {
-			if matches[j].score > matches[i].score {
-				matches[i], matches[j] = matches[j], matches[i]
+	m.SearchResults = rankMatches(matches)
+}
+
+// scoredMatch is a search or command palette match and its score
+type scoredMatch struct {
+	index int
+	score int
+}
+
+// rankMatches returns the indices of the matches by descending score. Matches
+// with equal scores keep their order, so they stay in file or list order.
+func rankMatches(matches []scoredMatch) []int {
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].score > matches[j].score
+	})
+	indices := make([]int, len(matches))
+	for i, match := range matches {
+		indices[i] = match.index
+	}
+	return indices
+}
+
+// splitCommandInput splits the palette input into the command name and the
+// argument typed after it
+func splitCommandInput(input string) (name, arg string) {
+	name, arg, _ = strings.Cut(strings.TrimSpace(input), " ")
+	return name, strings.TrimSpace(arg)
+}
+
+// commandToRun returns the command highlighted in the palette. With an argument
+// the command named exactly name wins, so a typed ":add milk" adds "milk"
+// whichever command is highlighted.
+func (m *Model) commandToRun(name, arg string) *Command {
+	if arg != "" {
+		for i := range m.Commands {
+			if m.Commands[i].Name == name {
+				return &m.Commands[i]
 			}
 		}
 	}
-
-	for _, match := range matches {
-		m.SearchResults = append(m.SearchResults, match.index)
+	if len(m.FilteredCmds) > 0 && m.CommandCursor < len(m.FilteredCmds) {
+		return &m.Commands[m.FilteredCmds[m.CommandCursor]]
 	}
+	return nil
 }
 
 func (m *Model) updateFilteredCommands() {
@@ -1210,39 +1931,28 @@ func (m *Model) updateFilteredCommands() {
 		return
 	}
 
-	query := strings.ToLower(m.InputBuffer)
+	// Arguments after the command name don't take part in matching
+	name, _ := splitCommandInput(m.InputBuffer)
+	query := strings.ToLower(name)
 
 	// Collect matches with scores
-	type match struct {
-		index int
-		score int
-	}
-	var matches []match
+	var matches []scoredMatch
 
 	for i, cmd := range m.Commands {
 		text := strings.ToLower(cmd.Name)
 		score := util.FuzzyScore(query, text)
 		if score > 0 {
-			matches = append(matches, match{i, score})
+			matches = append(matches, scoredMatch{i, score})
 		}
 	}
 
-	// Sort by score descending
-	for i := 0; i < len(matches)-1; i++ {
-		for j := i + 1; j < len(matches); j++ {
-			if matches[j].score > matches[i].score {
-				matches[i], matches[j] = matches[j], matches[i]
-			}
-		}
-	}
-
-	for _, match := range matches {
-		m.FilteredCmds = append(m.FilteredCmds, match.index)
-	}
+	m.FilteredCmds = rankMatches(matches)
 }
 
 func (m *Model) writeIfPersist() {
-	if !m.ReadOnly {
+	if m.ReadOnly {
+		m.Unsaved = true
+	} else {
 		// Check for external modifications first
 		modified, err := m.FileModel.CheckFileModified()
 		if err != nil {
@@ -1259,6 +1969,7 @@ func (m *Model) writeIfPersist() {
 				} else {
 					// Clear locally modified tracking after successful write
 					m.LocallyModified = make(map[string]bool)
+					m.logCompleted()
 				}
 				return
 			}
@@ -1273,6 +1984,7 @@ func (m *Model) writeIfPersist() {
 		} else {
 			// Clear locally modified tracking after successful write
 			m.LocallyModified = make(map[string]bool)
+			m.logCompleted()
 		}
 	}
 }
@@ -1330,38 +2042,121 @@ func (m *Model) trySmartReload() bool {
 	// Clear locally modified tracking after successful merge
 	m.LocallyModified = make(map[string]bool)
 
+	var selectedText string
+	if m.SelectedIndex >= 0 && m.SelectedIndex < len(m.FileModel.Todos) {
+		selectedText = m.FileModel.Todos[m.SelectedIndex].Text
+	}
 	m.FileModel = *resultFM
+	m.SelectedIndex = m.relocateSelection(selectedText, m.SelectedIndex)
 	return true
 }
 
-// checkAndReloadFile checks if the file changed and reloads if safe
-func (m Model) checkAndReloadFile() tea.Cmd {
-	if m.ReadOnly {
-		return watchFileChanges() // Continue watching
+// relocateSelection returns the index of the todo with the given text nearest
+// to oldIdx, so the cursor stays on the same todo when others were added or
+// removed. Without such a todo the index nearest to oldIdx is used.
+func (m *Model) relocateSelection(text string, oldIdx int) int {
+	todos := m.FileModel.Todos
+	best := -1
+	for i, todo := range todos {
+		if todo.Text == text && (best < 0 || util.Abs(i-oldIdx) < util.Abs(best-oldIdx)) {
+			best = i
+		}
+	}
+	if best >= 0 {
+		return best
+	}
+	return max(0, min(oldIdx, len(todos)-1))
+}
+
+// reloadIfChanged merges external changes into the model when the file changed on disk.
+// While the user is typing or moving a todo the reload is deferred to a later check,
+// so the file is picked up once they're done.
+func (m *Model) reloadIfChanged() {
+	if m.ReadOnly || m.isEditing() {
+		return
 	}
 
-	// Check if file was modified externally
 	modified, err := m.FileModel.CheckFileModified()
 	if err != nil || !modified {
-		return watchFileChanges() // Continue watching
+		return
 	}
 
-	// File changed - try smart reload
-	if m.trySmartReload() {
-		// Successfully auto-reloaded, the model is updated
-		return func() tea.Msg {
-			// Return updated model through a custom message
-			return reloadedMsg{model: m}
-		}
+	// Can't auto-merge: the conflict is shown on the next write attempt
+	if !m.trySmartReload() {
+		return
+	}
+
+	if m.SelectedIndex >= len(m.FileModel.Todos) {
+		m.SelectedIndex = util.Max(0, len(m.FileModel.Todos)-1)
 	}
+	m.InvalidateHeadingsCache()
+	m.InvalidateDocumentTree()
+	m.RefreshAvailableTags()
+	m.adjustSelectionForFilter()
+}
+
+// startEdit enters edit mode on the selected todo
+func (m *Model) startEdit() {
+	if len(m.FileModel.Todos) == 0 {
+		return
+	}
+	m.saveHistory()
+	m.EditMode = true
+	m.InputBuffer = m.FileModel.Todos[m.SelectedIndex].Text
+	m.cursorToInputEnd()
+}
+
+// startEditAt selects the todo at idx and enters edit mode on it.
+// Filters that would hide the todo are cleared first.
+func (m *Model) startEditAt(idx int) {
+	if idx < 0 || idx >= len(m.FileModel.Todos) {
+		return
+	}
+	if !m.isTodoVisible(idx) {
+		m.clearFilters()
+	}
+	m.SelectedIndex = idx
+	m.InvalidateDocumentTree()
+	m.startEdit()
+}
+
+// clearFilters removes every visibility filter
+func (m *Model) clearFilters() {
+	m.FilterDone = false
+	m.FilteredTags = []string{}
+	m.FilteredContexts = []string{}
+	m.FilteredProjects = []string{}
+	m.FilteredPriorities = []int{}
+	m.FilteredDueDate = ""
+}
+
+// toggleFilterDone hides or shows completed todos
+func (m *Model) toggleFilterDone() {
+	m.FilterDone = !m.FilterDone
+	// Invalidate document tree since visibility changed
+	m.InvalidateDocumentTree()
+	// Adjust selection if current item is now hidden
+	if m.FilterDone {
+		m.adjustSelectionForFilter()
+	}
+}
 
-	// Can't auto-merge, will show error on next write attempt
-	return watchFileChanges() // Continue watching
+// toggleDueFilter switches the due date filter to filter, or clears it if it is already active
+func (m *Model) toggleDueFilter(filter string) {
+	if m.FilteredDueDate == filter {
+		m.FilteredDueDate = ""
+	} else {
+		m.FilteredDueDate = filter
+	}
+	m.InvalidateDocumentTree()
+	if m.FilteredDueDate != "" {
+		m.adjustSelectionForFilter()
+	}
 }
 
-// reloadedMsg carries the updated model after successful reload
-type reloadedMsg struct {
-	model Model
+// isEditing returns true while the user is entering text, moving a todo or confirming a delete
+func (m *Model) isEditing() bool {
+	return m.InputMode || m.EditMode || m.NoteMode || m.MoveMode || m.ConfirmDeleteMode
 }
 
 // isTodoVisible returns true if the todo at the given index is visible given current filters
@@ -1369,6 +2164,17 @@ func (m *Model) isTodoVisible(idx int) bool {
 	if idx < 0 || idx >= len(m.FileModel.Todos) {
 		return false
 	}
+
+	// Hidden in a collapsed heading section
+	if m.isTodoFolded(idx) {
+		return false
+	}
+
+	return m.passesFilters(idx)
+}
+
+// passesFilters reports whether the todo at idx matches every active filter
+func (m *Model) passesFilters(idx int) bool {
 	todo := m.FileModel.Todos[idx]
 
 	// Hidden by filter-done
@@ -1381,6 +2187,16 @@ func (m *Model) isTodoVisible(idx int) bool {
 		return false
 	}
 
+	// Hidden by context filters
+	if len(m.FilteredContexts) > 0 && !todo.HasAnyContext(m.FilteredContexts) {
+		return false
+	}
+
+	// Hidden by project filters
+	if len(m.FilteredProjects) > 0 && !todo.HasAnyProject(m.FilteredProjects) {
+		return false
+	}
+
 	// Hidden by priority filters
 	if len(m.FilteredPriorities) > 0 && !todo.HasAnyPriority(m.FilteredPriorities) {
 		return false
@@ -1396,7 +2212,8 @@ func (m *Model) isTodoVisible(idx int) bool {
 
 // hasActiveFilters returns true if any visibility filter is active
 func (m *Model) hasActiveFilters() bool {
-	return m.FilterDone || len(m.FilteredTags) > 0 || len(m.FilteredPriorities) > 0 || m.FilteredDueDate != ""
+	return m.FilterDone || len(m.FilteredTags) > 0 || len(m.FilteredContexts) > 0 || len(m.FilteredProjects) > 0 ||
+		len(m.FilteredPriorities) > 0 || m.FilteredDueDate != ""
 }
 
 func (m *Model) getVisibleTodos() []int {
@@ -1464,15 +2281,28 @@ func (m *Model) ProcessPipedInput(input []byte) {
 	for i := 0; i < len(input); i++ {
 		b := input[i]
 		msg := byteToKeyMsg(b)
+		if b >= utf8.RuneSelf {
+			// Multibyte UTF-8 character, typed as a single key
+			r, size := utf8.DecodeRune(input[i:])
+			if r != utf8.RuneError {
+				msg = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}}
+				i += size - 1
+			}
+		}
 
 		// Skip empty messages (non-printable bytes)
 		if msg.Type == 0 && len(msg.Runes) == 0 {
 			continue
 		}
 
+		// Ctrl+D marks the end of piped input
+		if msg.Type == tea.KeyCtrlD {
+			return
+		}
+
 		// Check for quit in normal mode (q or esc without other modes active)
-		if !m.InputMode && !m.EditMode && !m.SearchMode && !m.CommandMode &&
-			!m.MoveMode && !m.FilterMode && !m.MaxVisibleInputMode && !m.HelpMode && !m.RecentFilesMode {
+		if !m.InputMode && !m.EditMode && !m.NoteMode && !m.SearchMode && !m.CommandMode &&
+			!m.MoveMode && !m.FilterMode && !m.ContextFilterMode && !m.ProjectFilterMode && !m.MaxVisibleInputMode && !m.GotoInputMode && !m.HelpMode && !m.RecentFilesMode {
 			if b == 'q' || b == 27 {
 				return
 			}
@@ -1550,8 +2380,8 @@ func (m Model) handleRecentFilesInput(key string) (tea.Model, tea.Cmd) {
 		if m.RecentFilesCursor < len(filteredFiles) {
 			selectedFile := filteredFiles[m.RecentFilesCursor]
 
-			// Save current file's cursor position before switching
-			_ = config.SaveRecentFile(m.FilePath, m.SelectedIndex)
+			// Save current file's cursor position and filters before switching
+			m.saveRecentState()
 
 			// Load the new file
 			fm, err := markdown.ReadFile(selectedFile.Path)
@@ -1568,8 +2398,10 @@ func (m Model) handleRecentFilesInput(key string) (tea.Model, tea.Cmd) {
 			m.RecentFilesMode = false
 			m.RecentFilesSearch = ""
 
-			// Try to restore cursor position from recent files
+			// Try to restore filters and cursor position from recent files
+			m.RefreshAvailableTags()
 			if recentFiles, err := config.LoadRecentFiles(); err == nil {
+				m.applyViewState(recentFiles.GetViewState(selectedFile.Path))
 				if savedPos := recentFiles.GetCursorPosition(selectedFile.Path); savedPos >= 0 && savedPos < len(m.FileModel.Todos) {
 					m.SelectedIndex = savedPos
 				} else {
@@ -1635,8 +2467,9 @@ func RunPiped(filePath string, input []byte, readOnly bool) string {
 	// Note: FilterDone and WordWrap are now applied in New() from metadata
 	// This ensures cursor positioning happens after filters are applied
 
-	// Try to restore cursor position from recent files (if file content hasn't changed)
+	// Restore filters from the last session, then the cursor position (if file content hasn't changed)
 	if recentFiles, err := config.LoadRecentFiles(); err == nil {
+		m.applyViewState(recentFiles.GetViewState(filePath))
 		if savedPos := recentFiles.GetCursorPosition(filePath); savedPos >= 0 && savedPos < len(m.FileModel.Todos) {
 			m.SelectedIndex = savedPos
 			// Invalidate tree to ensure correct positioning
@@ -1644,21 +2477,77 @@ func RunPiped(filePath string, input []byte, readOnly bool) string {
 		}
 	}
 
+	m.loadSearchHistory()
+
 	m.ProcessPipedInput(input)
+	if m.statusSeq > 0 && !m.loadWarning {
+		// Transient messages would have timed out by the time the output is read
+		m.StatusHint = ""
+	}
 	output := m.View()
 
-	// Save cursor position to recent files when exiting
-	_ = config.SaveRecentFile(filePath, m.SelectedIndex)
+	// Save cursor position and filters to recent files when exiting
+	m.saveRecentState()
+	m.saveSearchHistory()
 
 	return output
 }
 
 // Run starts the TUI with Bubbletea
 func Run(filePath string, readOnly bool, showHeadings bool, maxVisible int) {
+	if err := run(filePath, readOnly, showHeadings, maxVisible, -1); err != nil {
+		fmt.Printf("Error: %v\n", err)
+	}
+}
+
+// RunEdit starts the TUI in edit mode on the todo at index (0-based)
+func RunEdit(filePath string, index int, readOnly bool, showHeadings bool, maxVisible int) error {
+	return run(filePath, readOnly, showHeadings, maxVisible, index)
+}
+
+// run starts the TUI, entering edit mode on the todo at editIndex unless it is negative
+func run(filePath string, readOnly bool, showHeadings bool, maxVisible int, editIndex int) error {
+	load := func() (Model, error) {
+		return loadModel(filePath, readOnly, showHeadings, maxVisible, editIndex)
+	}
+
+	stat, _ := os.Stdin.Stat()
+	tty := (stat.Mode() & os.ModeCharDevice) != 0
+	if tty && editIndex < 0 {
+		// Large files are parsed in the background so the terminal doesn't hang
+		if todos, large := largeFileTodos(filePath); large {
+			return runProgram(newLoadingModel(filePath, todos, load))
+		}
+	}
+
+	m, err := load()
+	if err != nil {
+		return err
+	}
+
+	if !tty {
+		// Piped input - process directly without Bubbletea event loop
+		input, _ := io.ReadAll(os.Stdin)
+		m.ProcessPipedInput(input)
+		fmt.Print(m.View())
+		// Save cursor position and filters to recent files
+		m.saveRecentState()
+		m.saveSearchHistory()
+		return nil
+	}
+	return runProgram(m)
+}
+
+// loadModel reads the file and sets up the model with the config, frontmatter
+// and last session applied, entering edit mode on the todo at editIndex unless
+// it is negative
+func loadModel(filePath string, readOnly bool, showHeadings bool, maxVisible int, editIndex int) (Model, error) {
 	fm, err := markdown.ReadFile(filePath)
 	if err != nil {
-		fmt.Printf("Error: %v\n", err)
-		return
+		return Model{}, err
+	}
+	if editIndex >= len(fm.Todos) {
+		return Model{}, fmt.Errorf("invalid index %d", editIndex+1)
 	}
 
 	// Config defaults are now set via tui.Config from main.go (loaded from config.toml)
@@ -1695,38 +2584,44 @@ func Run(filePath string, readOnly bool, showHeadings bool, maxVisible int) {
 		}
 	}
 
-	// Try to restore cursor position from recent files (if file content hasn't changed)
+	// Restore filters from the last session, then the cursor position (if file content hasn't changed)
 	if recentFiles, err := config.LoadRecentFiles(); err == nil {
+		m.applyViewState(recentFiles.GetViewState(filePath))
 		if savedPos := recentFiles.GetCursorPosition(filePath); savedPos >= 0 && savedPos < len(m.FileModel.Todos) {
 			m.SelectedIndex = savedPos
 			// Invalidate tree to ensure correct positioning
 			m.InvalidateDocumentTree()
 		}
 	}
+	m.applyLaunchFilters(LaunchFilters)
 
-	// Check if we have a TTY
-	stat, _ := os.Stdin.Stat()
-	if (stat.Mode() & os.ModeCharDevice) == 0 {
-		// Piped input - process directly without Bubbletea event loop
-		input, _ := io.ReadAll(os.Stdin)
-		m.ProcessPipedInput(input)
-		fmt.Print(m.View())
-		// Save cursor position to recent files
-		_ = config.SaveRecentFile(filePath, m.SelectedIndex)
-		return
+	m.loadSearchHistory()
+
+	if editIndex >= 0 {
+		m.startEditAt(editIndex)
 	}
+	return m, nil
+}
 
-	// Normal TTY - use Bubbletea (no alt screen to keep context visible)
-	p := tea.NewProgram(m)
+// runProgram runs the Bubbletea program and remembers the view state on exit
+func runProgram(model tea.Model) error {
+	// No alt screen to keep context visible
+	p := tea.NewProgram(model, tea.WithMouseCellMotion())
 	finalModel, err := p.Run()
 	if err != nil {
-		fmt.Printf("Error: %v\n", err)
-		return
+		return err
 	}
 
-	// Save cursor position to recent files when exiting
-	if m, ok := finalModel.(Model); ok {
-		// Save with current cursor position
-		_ = config.SaveRecentFile(filePath, m.SelectedIndex)
+	switch m := finalModel.(type) {
+	case Model:
+		// Save cursor position and filters to recent files when exiting
+		m.saveRecentState()
+		if m.split != nil {
+			m.split.saveRecentState()
+		}
+		m.saveSearchHistory()
+	case loadingModel:
+		return m.err
 	}
+	return nil
 }
@@ -2,6 +2,7 @@ package tui
 
 import (
 	"errors"
+	"fmt"
 	"testing"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -88,6 +89,111 @@ func TestHandleKey_NavigationWithCount(t *testing.T) {
 	}
 }
 
+func longTodoList(n int) []string {
+	todos := make([]string, n)
+	for i := range todos {
+		todos[i] = fmt.Sprintf("Task %d", i+1)
+	}
+	return todos
+}
+
+func TestHandleKey_HalfPageScroll(t *testing.T) {
+	m := testModel(longTodoList(30))
+	m.MaxVisibleOverride = 10
+
+	m = pressKeyType(t, m, tea.KeyCtrlD)
+	if m.SelectedIndex != 5 {
+		t.Errorf("After ctrl+d, SelectedIndex = %d, want 5", m.SelectedIndex)
+	}
+
+	m = pressKeyType(t, m, tea.KeyCtrlD)
+	m = pressKeyType(t, m, tea.KeyCtrlU)
+	if m.SelectedIndex != 5 {
+		t.Errorf("After ctrl+d ctrl+u, SelectedIndex = %d, want 5", m.SelectedIndex)
+	}
+}
+
+func TestHandleKey_HalfPageScrollUsesTerminalHeight(t *testing.T) {
+	m := testModel(longTodoList(60))
+	m.MaxVisibleOverride = 0
+	m.TermHeight = 24 // 20 visible todos
+
+	m = pressKeyType(t, m, tea.KeyCtrlD)
+	if m.SelectedIndex != 10 {
+		t.Errorf("After ctrl+d, SelectedIndex = %d, want 10", m.SelectedIndex)
+	}
+}
+
+func TestUpdate_WindowSizeSetsAutoMaxVisible(t *testing.T) {
+	m := testModel(longTodoList(60))
+	m.MaxVisibleOverride = 0
+
+	result, _ := m.Update(tea.WindowSizeMsg{Width: 80, Height: 30})
+	m = result.(Model)
+
+	if m.TermWidth != 80 || m.TermHeight != 30 {
+		t.Fatalf("TermWidth/TermHeight = %d/%d, want 80/30", m.TermWidth, m.TermHeight)
+	}
+	if got := m.effectiveMaxVisible(); got != 26 {
+		t.Errorf("effectiveMaxVisible() = %d, want 26 (height minus status bar and spacing)", got)
+	}
+	if _, rows := m.layoutMainContent(); countTodoRows(rows) != 26 {
+		t.Errorf("Rendered %d todos, want 26", countTodoRows(rows))
+	}
+}
+
+// countTodoRows counts the distinct todos drawn in a layout
+func countTodoRows(rows []int) int {
+	seen := make(map[int]bool)
+	for _, idx := range rows {
+		if idx >= 0 {
+			seen[idx] = true
+		}
+	}
+	return len(seen)
+}
+
+func TestHandleKey_HalfPageScrollClamps(t *testing.T) {
+	m := testModel(longTodoList(12))
+	m.MaxVisibleOverride = 10
+	m.SelectedIndex = 9
+
+	m = pressKeyType(t, m, tea.KeyCtrlD)
+	if m.SelectedIndex != 11 {
+		t.Errorf("ctrl+d past the end: SelectedIndex = %d, want 11", m.SelectedIndex)
+	}
+
+	m.SelectedIndex = 2
+	m = pressKeyType(t, m, tea.KeyCtrlU)
+	if m.SelectedIndex != 0 {
+		t.Errorf("ctrl+u past the start: SelectedIndex = %d, want 0", m.SelectedIndex)
+	}
+}
+
+func TestHandleKey_HalfPageScrollRespectsFilters(t *testing.T) {
+	m := testModel(longTodoList(20))
+	m.MaxVisibleOverride = 6
+	for i := range m.FileModel.Todos {
+		m.FileModel.Todos[i].Checked = i%2 == 1
+	}
+	m.FilterDone = true
+
+	// Three visible todos down from Task 1: Task 3, 5, 7
+	m = pressKeyType(t, m, tea.KeyCtrlD)
+	if m.SelectedIndex != 6 {
+		t.Errorf("After ctrl+d with filter, SelectedIndex = %d, want 6", m.SelectedIndex)
+	}
+}
+
+func TestHandleKey_CtrlDDoesNotQuit(t *testing.T) {
+	m := testModel(longTodoList(4))
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlD})
+	if cmd != nil {
+		t.Error("ctrl+d should scroll, not quit, in interactive mode")
+	}
+}
+
 func TestHandleKey_EnterInputMode(t *testing.T) {
 	m := testModel([]string{"Task 1"})
 
@@ -448,6 +554,26 @@ func TestUpdateSearchResults_WithQuery(t *testing.T) {
 	}
 }
 
+func TestUpdateSearchResults_IgnoresDiacritics(t *testing.T) {
+	m := testModel([]string{"Coffee run", "Lunch at Café Luna", "Cake"})
+	m.InputBuffer = "cafe"
+
+	m.updateSearchResults()
+
+	if len(m.SearchResults) == 0 || m.SearchResults[0] != 1 {
+		t.Errorf("Expected 'Café' todo ranked first, got %v", m.SearchResults)
+	}
+}
+
+func TestHighlightMatches_Diacritics(t *testing.T) {
+	highlight := func(s string) string { return "[" + s + "]" }
+
+	got := HighlightMatches("Lunch at Café", "cafe", highlight)
+	if got != "Lunch at [C][a][f][é]" {
+		t.Errorf("HighlightMatches = %q", got)
+	}
+}
+
 func TestUpdateFilteredCommands_EmptyQuery(t *testing.T) {
 	m := testModel([]string{"Task 1"})
 	m.InputBuffer = ""
@@ -522,6 +648,16 @@ func TestProcessPipedInput_QuitEarly(t *testing.T) {
 	}
 }
 
+func TestProcessPipedInput_CtrlDEndsInput(t *testing.T) {
+	m := testModel([]string{"Task 1", "Task 2", "Task 3"})
+
+	m.ProcessPipedInput([]byte("j\x04j")) // Move down, EOF, (ignored)
+
+	if m.SelectedIndex != 1 {
+		t.Errorf("After 'j^Dj', SelectedIndex = %d, want 1 (ctrl+d should stop)", m.SelectedIndex)
+	}
+}
+
 func TestProcessPipedInput_CommandExecution(t *testing.T) {
 	fm := &markdown.FileModel{
 		Todos: []markdown.Todo{
@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
 	"github.com/niklas-heer/tdx/internal/config"
 	"github.com/niklas-heer/tdx/internal/markdown"
 	"github.com/niklas-heer/tdx/internal/util"
@@ -17,11 +18,14 @@ import (
 func (m Model) View() string {
 	styles := m.Styles()
 	if m.HelpMode {
-		return RenderHelp(m.Version(), styles.Cyan, styles.Dim)
+		return RenderHelp(m.Version(), m.TermWidth, styles.Cyan, styles.Dim)
 	}
 
 	// Render main content and status bar
 	mainContent := m.renderMainContent()
+	if m.split != nil {
+		mainContent = m.renderSplitContent()
+	}
 	statusBar := m.renderStatusBar()
 
 	// Combine main content and status bar
@@ -58,6 +62,27 @@ func (m Model) View() string {
 		return overlay.Composite(overlayContent, background, overlay.Left, overlay.Bottom, 0, -1)
 	}
 
+	if m.ContextFilterMode || m.ProjectFilterMode {
+		// Ensure there's space for overlay positioning
+		contentLines := strings.Count(mainContent, "\n")
+		minLines := 10 // Minimum lines to ensure overlay positioning works well
+		if contentLines < minLines {
+			for i := contentLines; i < minLines; i++ {
+				background += "\n"
+			}
+		}
+
+		styles := m.Styles()
+		var overlayContent string
+		if m.ContextFilterMode {
+			overlayContent = m.renderWordFilterOverlayCompact("@", "contexts", m.AvailableContexts, m.FilteredContexts, m.ContextFilterCursor, styles.Context)
+		} else {
+			overlayContent = m.renderWordFilterOverlayCompact("+", "projects", m.AvailableProjects, m.FilteredProjects, m.ProjectFilterCursor, styles.Project)
+		}
+		// Position overlay just above status bar
+		return overlay.Composite(overlayContent, background, overlay.Left, overlay.Bottom, 0, -1)
+	}
+
 	if m.PriorityFilterMode {
 		// Ensure there's space for overlay positioning
 		contentLines := strings.Count(mainContent, "\n")
@@ -123,19 +148,38 @@ func (m Model) View() string {
 
 // renderMainContent renders the main todo list (without status bar)
 func (m Model) renderMainContent() string {
-	var b strings.Builder
-	styles := m.Styles()
-	config := m.Config()
+	content, _ := m.layoutMainContent()
+	return content
+}
 
-	if len(m.FileModel.Todos) == 0 && !m.InputMode {
-		b.WriteString(styles.Dim("No todos. Press 'n' to create one."))
-		b.WriteString("\n")
+// effectiveMaxVisible returns how many todos fit in the list window (0 = unlimited)
+func (m Model) effectiveMaxVisible() int {
+	// Use maxVisibleOverride if set (>= 0), otherwise use config
+	configMaxVisible := m.Config().Display.MaxVisible
+	if m.MaxVisibleOverride >= 0 {
+		configMaxVisible = m.MaxVisibleOverride
 	}
 
-	// Determine which todos to display
-	var todosToShow []int
+	// If max_visible is 0 (unlimited) but we have terminal height info,
+	// auto-calculate a reasonable limit based on terminal size
+	// Reserve lines for: status bar (1), empty line (1), potential headings, scroll indicators
+	if configMaxVisible == 0 && m.TermHeight > 0 {
+		// Reserve ~4 lines for UI chrome (status bar, spacing, etc.)
+		autoMaxVisible := m.TermHeight - 4
+		if autoMaxVisible < 5 {
+			autoMaxVisible = 5 // Minimum reasonable visible items
+		}
+		return autoMaxVisible
+	}
+	return configMaxVisible
+}
+
+// listTodos returns the todos shown in the list: the search results while
+// searching, otherwise the todos that pass the active filters
+func (m Model) listTodos() []int {
+	var todos []int
 	if m.SearchMode {
-		todosToShow = m.SearchResults
+		todos = m.SearchResults
 	} else {
 		for i := range m.FileModel.Todos {
 			todo := m.FileModel.Todos[i]
@@ -145,11 +189,24 @@ func (m Model) renderMainContent() string {
 				continue
 			}
 
+			// Skip todos in collapsed heading sections
+			if m.isTodoFolded(i) {
+				continue
+			}
+
 			// Apply tag filtering if active
 			if len(m.FilteredTags) > 0 && !todo.HasAnyTag(m.FilteredTags) {
 				continue
 			}
 
+			// Apply context and project filtering if active
+			if len(m.FilteredContexts) > 0 && !todo.HasAnyContext(m.FilteredContexts) {
+				continue
+			}
+			if len(m.FilteredProjects) > 0 && !todo.HasAnyProject(m.FilteredProjects) {
+				continue
+			}
+
 			// Apply priority filtering if active
 			if len(m.FilteredPriorities) > 0 && !todo.HasAnyPriority(m.FilteredPriorities) {
 				continue
@@ -160,88 +217,138 @@ func (m Model) renderMainContent() string {
 				continue
 			}
 
-			todosToShow = append(todosToShow, i)
+			todos = append(todos, i)
 		}
 	}
+	return todos
+}
 
-	// Apply max_visible limit with scrolling
-	startIdx := 0
-	totalCount := len(todosToShow)
-	hasMoreAbove := false
-	hasMoreBelow := false
-
-	// When in input mode, reserve one slot for the new task input
-	// Use maxVisibleOverride if set (>= 0), otherwise use config
-	configMaxVisible := config.Display.MaxVisible
-	if m.MaxVisibleOverride >= 0 {
-		configMaxVisible = m.MaxVisibleOverride
+// listWindowSize returns how many todos fit in the list window (0 = unlimited).
+// In input mode one slot is reserved for the new task input.
+func (m Model) listWindowSize() int {
+	size := m.effectiveMaxVisible()
+	if m.InputMode && size > 0 {
+		size--
 	}
+	return size
+}
 
-	// If max_visible is 0 (unlimited) but we have terminal height info,
-	// auto-calculate a reasonable limit based on terminal size
-	// Reserve lines for: status bar (1), empty line (1), potential headings, scroll indicators
-	autoMaxVisible := 0
-	if configMaxVisible == 0 && m.TermHeight > 0 {
-		// Reserve ~4 lines for UI chrome (status bar, spacing, etc.)
-		autoMaxVisible = m.TermHeight - 4
-		if autoMaxVisible < 5 {
-			autoMaxVisible = 5 // Minimum reasonable visible items
+// arrowWidth returns the width of the column holding the select marker, which
+// is padded with a space on each side
+func (m Model) arrowWidth() int {
+	return max(runewidth.StringWidth(m.Config().Display.SelectMarker)+2, 3)
+}
+
+// checkboxWidth returns the width of the checkbox column, wide enough for the
+// configured check symbol and the in-progress character
+func (m Model) checkboxWidth() int {
+	symbol := max(runewidth.StringWidth(m.Config().Display.CheckSymbol), runewidth.StringWidth(markdown.ProgressChar), 1)
+	return symbol + 2
+}
+
+// padCell returns the spaces that fill plain text up to width cells
+func padCell(plain string, width int) string {
+	return strings.Repeat(" ", max(width-runewidth.StringWidth(plain), 0))
+}
+
+// listCursorPos returns the position of the cursor within todos
+func (m Model) listCursorPos(todos []int) int {
+	if m.SearchMode {
+		return m.SearchCursor
+	}
+	if m.InputMode && !m.InsertAfterCursor {
+		// When appending new task at end, scroll to show last items before the input
+		return len(todos) - 1
+	}
+	for i, idx := range todos {
+		if idx == m.SelectedIndex {
+			return i
 		}
 	}
+	return 0
+}
 
-	effectiveMaxVisible := configMaxVisible
-	if effectiveMaxVisible == 0 && autoMaxVisible > 0 {
-		effectiveMaxVisible = autoMaxVisible
+// listWindowStart returns the first position of todos shown in a window of the
+// given size. The window is centered on the cursor, or with a scroll margin
+// only moves when the cursor gets closer than the margin to one of its edges.
+func (m Model) listWindowStart(todos []int, window int) int {
+	pos := m.listCursorPos(todos)
+	if margin := m.Config().Display.ScrollMargin; margin > 0 {
+		return scrollWindowStart(m.scrollTop, pos, len(todos), window, margin)
 	}
-	if m.InputMode && effectiveMaxVisible > 0 {
-		effectiveMaxVisible = effectiveMaxVisible - 1
+	return scrollWindowStart(pos-window/2, pos, len(todos), window, 0)
+}
+
+// scrollWindowStart moves a window of the given size starting at prevStart as
+// little as possible so that pos is at least margin rows from both edges, and
+// keeps it within the total number of positions
+func scrollWindowStart(prevStart, pos, total, window, margin int) int {
+	margin = min(margin, (window-1)/2)
+	start := min(prevStart, pos-margin)
+	start = max(start, pos+margin-window+1)
+	return max(0, min(start, total-window))
+}
+
+// syncScroll remembers where the list window starts so the scroll margin can
+// keep it in place while the cursor moves within it
+func (m *Model) syncScroll() {
+	if m.Config().Display.ScrollMargin <= 0 {
+		return
+	}
+	window := m.listWindowSize()
+	todos := m.listTodos()
+	if window <= 0 || len(todos) <= window {
+		m.scrollTop = 0
+		return
 	}
+	m.scrollTop = m.listWindowStart(todos, window)
+}
 
-	if effectiveMaxVisible > 0 && len(todosToShow) > effectiveMaxVisible {
-		// Calculate visible window centered on selection
-		var currentPos int
-		if m.SearchMode {
-			currentPos = m.SearchCursor
-		} else if m.InputMode && !m.InsertAfterCursor {
-			// When appending new task at end, scroll to show last items before the input
-			currentPos = totalCount - 1
-		} else {
-			// Find position of selectedIndex in todosToShow
-			for i, idx := range todosToShow {
-				if idx == m.SelectedIndex {
-					currentPos = i
-					break
-				}
-			}
-		}
+// layoutMainContent renders the main todo list and reports, for every output line,
+// the index of the todo drawn on it (-1 for headings, indicators and blank lines)
+func (m Model) layoutMainContent() (string, []int) {
+	var b strings.Builder
+	styles := m.Styles()
+	config := m.Config()
 
-		// Center the window on current position
-		halfWindow := effectiveMaxVisible / 2
-		startIdx = currentPos - halfWindow
-		if startIdx < 0 {
-			startIdx = 0
-		}
-		endIdx := startIdx + effectiveMaxVisible
-		if endIdx > totalCount {
-			endIdx = totalCount
-			startIdx = endIdx - effectiveMaxVisible
-			if startIdx < 0 {
-				startIdx = 0
-			}
+	// rows maps each line written so far to a todo index
+	var rows []int
+	claimRows := func(todoIdx int) {
+		for n := strings.Count(b.String(), "\n"); len(rows) < n; {
+			rows = append(rows, todoIdx)
 		}
+	}
 
-		hasMoreAbove = startIdx > 0
-		hasMoreBelow = endIdx < totalCount || m.InputMode
+	if len(m.FileModel.Todos) == 0 && !m.InputMode {
+		b.WriteString(styles.Dim("No todos. Press 'n' to create one."))
+		b.WriteString("\n")
+	}
+
+	// Determine which todos to display
+	todosToShow := m.listTodos()
+
+	// Apply max_visible limit with scrolling
+	startIdx := 0
+	totalCount := len(todosToShow)
+
+	effectiveMaxVisible := m.listWindowSize()
+	scrolling := effectiveMaxVisible > 0 && totalCount > effectiveMaxVisible
+
+	if scrolling {
+		startIdx = m.listWindowStart(todosToShow, effectiveMaxVisible)
+		endIdx := min(startIdx+effectiveMaxVisible, totalCount)
 		todosToShow = todosToShow[startIdx:endIdx]
-	} else if m.InputMode && effectiveMaxVisible > 0 && len(todosToShow) == effectiveMaxVisible {
-		// Edge case: exactly at the limit when adding new task
-		hasMoreBelow = true
 	}
 
+	// The indicators count the listed todos outside the window, so todos hidden
+	// by filters or folds aren't included
+	moreAbove := startIdx
+	moreBelow := totalCount - startIdx - len(todosToShow)
+
 	// Show indicator for items above (when scrolling is active)
-	if effectiveMaxVisible > 0 && totalCount > effectiveMaxVisible {
-		if hasMoreAbove {
-			b.WriteString(fmt.Sprintf("      %s\n", styles.Dim(fmt.Sprintf("▲ %d more", startIdx))))
+	if scrolling {
+		if moreAbove > 0 {
+			b.WriteString(fmt.Sprintf("      %s\n", styles.Dim(fmt.Sprintf("▲ %d more", moreAbove))))
 		} else {
 			b.WriteString("\n")
 		}
@@ -265,22 +372,33 @@ func (m Model) renderMainContent() string {
 	// Track the last displayed todo index to show headings in between
 	lastDisplayedTodoIdx := -1
 
+	// A section rule goes before a heading that follows a todo, not before the
+	// first heading or between headings without todos in between
+	todoAbove := false
+	writeHeading := func(i int) {
+		if todoAbove {
+			b.WriteString(m.sectionRule())
+		}
+		b.WriteString(m.renderHeadingLine(allHeadings, i))
+		todoAbove = false
+	}
+
 	for displayIdx, todoIdx := range todosToShow {
 		todo := m.FileModel.Todos[todoIdx]
 
 		// Show headings that fall between last displayed todo and current todo
 		if m.ShowHeadings {
-			for _, heading := range allHeadings {
+			for i, heading := range allHeadings {
 				// Show heading if it appears after the last displayed todo
 				// and before or at the current todo
 				if heading.BeforeTodoIndex > lastDisplayedTodoIdx && heading.BeforeTodoIndex <= todoIdx {
-					// Render heading with appropriate formatting
-					headingText := strings.Repeat("#", heading.Level) + " " + heading.Text
-					b.WriteString(fmt.Sprintf("      %s\n", styles.Cyan(headingText)))
+					writeHeading(i)
 				}
 			}
 		}
+		todoAbove = true
 
+		claimRows(-1)
 		lastDisplayedTodoIdx = todoIdx
 
 		var isSelected bool
@@ -316,47 +434,82 @@ func (m Model) renderMainContent() string {
 		}
 
 		// Arrow - don't show on existing items when in input mode (arrow goes on input line)
-		arrow := "   "
+		// The marker and check symbol may be wider than one cell, so the
+		// columns are padded to keep the text aligned
+		arrowWidth, checkboxWidth := m.arrowWidth(), m.checkboxWidth()
+		arrow := strings.Repeat(" ", arrowWidth)
 		if isSelected && !m.InputMode {
-			arrow = styles.Cyan(" " + config.Display.SelectMarker + " ")
+			marker := " " + config.Display.SelectMarker + " "
+			arrow = styles.Cyan(marker) + padCell(marker, arrowWidth)
 		}
 		// In insert-after-cursor mode, don't show arrow on the item we're inserting after
 		if m.InputMode && m.InsertAfterCursor && isSelected {
-			arrow = "   "
+			arrow = strings.Repeat(" ", arrowWidth)
 		}
 
 		// Checkbox
 		var checkbox string
 		if todo.Checked {
-			checkbox = styles.Magenta("[" + config.Display.CheckSymbol + "]")
+			box := "[" + config.Display.CheckSymbol + "]"
+			checkbox = styles.Magenta(box) + padCell(box, checkboxWidth)
+		} else if todo.InProgress {
+			box := "[" + markdown.ProgressChar + "]"
+			checkbox = styles.Yellow(box) + padCell(box, checkboxWidth)
 		} else {
-			checkbox = styles.Dim("[ ]")
+			checkbox = styles.Dim("[ ]") + padCell("[ ]", checkboxWidth)
 		}
 
 		// Move indicator (check before building prefix)
 		if m.MoveMode && isSelected {
-			arrow = styles.Yellow(" ≡ ")
+			arrow = styles.Yellow(" ≡ ") + padCell(" ≡ ", arrowWidth)
 		}
 
 		// Build the line prefix (needed early for edit mode wrapping)
 		// Add indentation based on nesting depth (2 spaces per level)
 		indent := strings.Repeat("  ", todo.Depth)
 		prefix := fmt.Sprintf("%s%s%s%s ", indent, styles.Dim(indexStr), arrow, checkbox)
-		prefixWidth := (todo.Depth * 2) + 3 + 3 + 3 + 1 // indent + index(3) + arrow(3) + checkbox(3) + space(1)
+		prefixWidth := (todo.Depth * 2) + 3 + arrowWidth + checkboxWidth + 1 // indent + index(3) + arrow + checkbox + space(1)
 
 		// Text with inline code rendering and tag colorization
 		var text string
 		plainText := todo.Text
+		// The @color label styles the text instead of being shown
+		displayText := todo.Text
+		if todo.Color != "" {
+			displayText = markdown.RemoveColor(todo.Text)
+		}
 
 		if m.SearchMode && m.InputBuffer != "" {
 			// Highlight matches during search
-			text = HighlightMatches(todo.Text, m.InputBuffer, styles.Green)
+			// Tag and priority terms aren't highlighted, only the free text
+			text = HighlightMatches(displayText, parseSearchQuery(m.InputBuffer).text, styles.Green)
 		} else {
-			text = RenderInlineCode(todo.Text, todo.Checked, styles.Magenta, styles.Cyan, styles.Code)
+			textStyle, styled := m.doneTextStyle(), todo.Checked
+			if todo.Color != "" && !todo.Checked {
+				label := LabelStyle(todo.Color)
+				textStyle = func(s string) string { return label.Render(s) }
+				styled = true
+			}
+			text = RenderInlineCodeIn(filepath.Dir(m.FilePath), displayText, styled, textStyle, styles.Cyan, styles.Code)
 			// Colorize tags, priorities, and due dates
 			text = ColorizeTags(text, styles.Tag)
-			text = ColorizePriorities(text, styles.PriorityHigh, styles.PriorityMedium, styles.PriorityLow)
-			text = ColorizeDueDates(text, styles.DueUrgent, styles.DueSoon, styles.DueFuture)
+			text = ColorizeContexts(text, styles.Context)
+			text = ColorizeProjects(text, styles.Project)
+			text = ColorizePriorities(text, styles.priorityStyles()...)
+			if todo.DueDate != nil {
+				// The date parsed with the todo tells whether there is a marker to color
+				text = ColorizeDueDates(text, styles.DueUrgent, styles.DueSoon, styles.DueFuture)
+			}
+			text = ColorizeTimeTokens(text, styles.Time)
+			if todo.Checked && m.Config().Display.DoneStyle == DoneStyleHiddenText {
+				text = ""
+			}
+		}
+		if todo.IsPinned() {
+			text = styles.Yellow(pinGlyph) + " " + text
+		}
+		if done, total := m.childProgress(todoIdx); total > 0 {
+			text += " " + styles.Dim(fmt.Sprintf("[%d/%d]", done, total))
 		}
 
 		// Show edit cursor if in edit mode on this item
@@ -365,9 +518,8 @@ func (m Model) renderMainContent() string {
 
 			// If wrapping is enabled, insert cursor and wrap the text
 			if m.WordWrap && m.TermWidth > 0 {
-				before := m.InputBuffer[:m.CursorPos]
-				after := m.InputBuffer[m.CursorPos:]
-				cursor := lipgloss.NewStyle().Reverse(true).Render(" ")
+				before, after := m.splitInput()
+				cursor := inputCursor()
 				textWithCursor := before + cursor + after
 
 				// Wrap text with cursor included
@@ -383,22 +535,36 @@ func (m Model) renderMainContent() string {
 						b.WriteString(line + "\n")
 					}
 				}
+				b.WriteString(RenderTodoNotes(todo.Notes, m.WordWrap, m.TermWidth, prefixWidth, styles.Dim))
+				claimRows(todoIdx)
 				continue // Skip normal rendering
 			} else {
 				// No wrapping - simple cursor insertion
-				before := m.InputBuffer[:m.CursorPos]
-				after := m.InputBuffer[m.CursorPos:]
-				text = before + lipgloss.NewStyle().Reverse(true).Render(" ") + after
+				before, after := m.splitInput()
+				text = before + inputCursor() + after
 			}
 		}
 
-		// Render the todo line
+		// Render the todo line; the line being edited isn't truncated so the cursor stays visible
+		lineWidth := m.TermWidth
+		if m.EditMode && isSelected && !m.SearchMode {
+			lineWidth = 0
+		}
 		b.WriteString(RenderTodoLine(
 			prefix, text, plainText,
 			m.SearchMode, m.InputBuffer, todo.Checked,
-			m.WordWrap, m.TermWidth, prefixWidth,
+			m.WordWrap, lineWidth, prefixWidth,
 			styles.Magenta, styles.Cyan, styles.Code, styles.Dim,
 		))
+		b.WriteString(RenderTodoNotes(todo.Notes, m.WordWrap, m.TermWidth, prefixWidth, styles.Dim))
+		claimRows(todoIdx)
+
+		// Show the note being typed beneath the selected todo's notes
+		if m.NoteMode && isSelected {
+			before, after := m.splitInput()
+			cursor := inputCursor()
+			b.WriteString(strings.Repeat(" ", prefixWidth) + before + cursor + after + "\n")
+		}
 
 		// If in input mode with insert-after-cursor, show input line after selected item
 		if m.InputMode && m.InsertAfterCursor && isSelected {
@@ -406,6 +572,18 @@ func (m Model) renderMainContent() string {
 		}
 	}
 
+	// Collapsed sections after the last shown todo still show their headings
+	foldedShown := false
+	if m.ShowHeadings && len(m.FoldedHeadings) > 0 && !m.SearchMode {
+		for i, heading := range allHeadings {
+			if heading.BeforeTodoIndex > lastDisplayedTodoIdx {
+				writeHeading(i)
+				foldedShown = true
+			}
+		}
+		claimRows(-1)
+	}
+
 	// Input mode at end - show new task at end when not inserting after cursor
 	// Also handles the case when inserting after cursor but there are no todos
 	if m.InputMode && (!m.InsertAfterCursor || len(m.FileModel.Todos) == 0) {
@@ -413,9 +591,9 @@ func (m Model) renderMainContent() string {
 	}
 
 	// Show indicator for items below (when scrolling is active)
-	if effectiveMaxVisible > 0 && totalCount > effectiveMaxVisible {
-		if hasMoreBelow && !m.InputMode {
-			b.WriteString(fmt.Sprintf("      %s\n", styles.Dim(fmt.Sprintf("▼ %d more", totalCount-startIdx-len(todosToShow)))))
+	if scrolling {
+		if moreBelow > 0 {
+			b.WriteString(fmt.Sprintf("      %s\n", styles.Dim(fmt.Sprintf("▼ %d more", moreBelow))))
 		} else {
 			b.WriteString("\n")
 		}
@@ -428,7 +606,7 @@ func (m Model) renderMainContent() string {
 	}
 
 	// Show message when filters result in no visible todos
-	if !m.SearchMode && !m.InputMode && len(m.FileModel.Todos) > 0 && len(todosToShow) == 0 {
+	if !m.SearchMode && !m.InputMode && len(m.FileModel.Todos) > 0 && len(todosToShow) == 0 && !foldedShown {
 		b.WriteString(styles.Dim("  No todos match current filters."))
 		b.WriteString("\n")
 		// Build hint about which filters are active
@@ -439,6 +617,12 @@ func (m Model) renderMainContent() string {
 		if len(m.FilteredTags) > 0 {
 			activeFilters = append(activeFilters, fmt.Sprintf("tags: #%s", strings.Join(m.FilteredTags, " #")))
 		}
+		if len(m.FilteredContexts) > 0 {
+			activeFilters = append(activeFilters, fmt.Sprintf("contexts: @%s", strings.Join(m.FilteredContexts, " @")))
+		}
+		if len(m.FilteredProjects) > 0 {
+			activeFilters = append(activeFilters, fmt.Sprintf("projects: +%s", strings.Join(m.FilteredProjects, " +")))
+		}
 		if len(m.FilteredPriorities) > 0 {
 			var pStrs []string
 			for _, p := range m.FilteredPriorities {
@@ -458,25 +642,27 @@ func (m Model) renderMainContent() string {
 	}
 
 	b.WriteString("\n")
+	claimRows(-1)
 
-	return b.String()
+	return b.String(), rows
 }
 
 // renderInputLine renders the new task input line with word wrap support
 func (m Model) renderInputLine(styles *StyleFuncsType, config *ConfigType) string {
 	var b strings.Builder
 
-	arrow := styles.Cyan(" " + config.Display.SelectMarker + " ")
-	checkbox := styles.Dim("[ ]")
+	arrowWidth, checkboxWidth := m.arrowWidth(), m.checkboxWidth()
+	marker := " " + config.Display.SelectMarker + " "
+	arrow := styles.Cyan(marker) + padCell(marker, arrowWidth)
+	checkbox := styles.Dim("[ ]") + padCell("[ ]", checkboxWidth)
 	indexStr := styles.Dim("  0")
 
 	// Build prefix
 	prefix := fmt.Sprintf("%s%s%s ", indexStr, arrow, checkbox)
-	prefixWidth := 3 + 3 + 3 + 1 // index(3) + arrow(3) + checkbox(3) + space(1)
+	prefixWidth := 3 + arrowWidth + checkboxWidth + 1 // index(3) + arrow + checkbox + space(1)
 
-	before := m.InputBuffer[:m.CursorPos]
-	after := m.InputBuffer[m.CursorPos:]
-	cursor := lipgloss.NewStyle().Reverse(true).Render(" ")
+	before, after := m.splitInput()
+	cursor := inputCursor()
 
 	// Apply word wrap if enabled
 	if m.WordWrap && m.TermWidth > 0 {
@@ -502,6 +688,54 @@ func (m Model) renderInputLine(styles *StyleFuncsType, config *ConfigType) strin
 	return b.String()
 }
 
+// Status bar modes for normal mode, cycled with :toggle-hints
+const (
+	StatusHintsFull    = "full"    // Indicators and key hints
+	StatusHintsMinimal = "minimal" // Indicators only
+	StatusHintsNone    = "none"    // Nothing
+)
+
+// nextStatusHints returns the status bar mode after mode: full, minimal, none, full
+func nextStatusHints(mode string) string {
+	switch mode {
+	case StatusHintsFull:
+		return StatusHintsMinimal
+	case StatusHintsMinimal:
+		return StatusHintsNone
+	default:
+		return StatusHintsFull
+	}
+}
+
+// Looks for completed todos, chosen with [display] done_style
+const (
+	DoneStyleMagenta       = "magenta"       // Text in the magenta style (default)
+	DoneStyleDim           = "dim"           // Dimmed text
+	DoneStyleStrikethrough = "strikethrough" // Struck-through text
+	DoneStyleHiddenText    = "hidden-text"   // Only the checkbox
+)
+
+// Separators drawn before headings when they are shown, chosen with [display] section_rule
+const (
+	SectionRuleNone  = "none"  // Headings follow the todos directly (default)
+	SectionRuleLine  = "line"  // A thin rule across the terminal
+	SectionRuleBlank = "blank" // An empty line
+)
+
+// doneTextStyle returns the style for the text of completed todos
+func (m Model) doneTextStyle() func(string) string {
+	styles := m.Styles()
+	switch m.Config().Display.DoneStyle {
+	case DoneStyleDim:
+		return styles.Dim
+	case DoneStyleStrikethrough:
+		strike := lipgloss.NewStyle().Strikethrough(true)
+		return func(s string) string { return strike.Render(s) }
+	default:
+		return styles.Magenta
+	}
+}
+
 // renderStatusBar renders the status bar at the bottom
 func (m Model) renderStatusBar() string {
 	var b strings.Builder
@@ -539,6 +773,14 @@ func (m Model) renderStatusBar() string {
 		b.WriteString(ModeIndicator("🏷", "TAGS"))
 		b.WriteString("  ")
 		b.WriteString(styles.Dim("Select tags to filter todos"))
+	} else if m.ContextFilterMode {
+		b.WriteString(ModeIndicator("@", "CONTEXTS"))
+		b.WriteString("  ")
+		b.WriteString(styles.Dim("Select contexts to filter todos"))
+	} else if m.ProjectFilterMode {
+		b.WriteString(ModeIndicator("+", "PROJECTS"))
+		b.WriteString("  ")
+		b.WriteString(styles.Dim("Select projects to filter todos"))
 	} else if m.PriorityFilterMode {
 		b.WriteString(ModeIndicator("⚡", "PRIORITY"))
 		b.WriteString("  ")
@@ -550,19 +792,34 @@ func (m Model) renderStatusBar() string {
 	} else if m.SearchMode {
 		b.WriteString(ModeIndicator("🔍", "SEARCH"))
 		b.WriteString("  ")
-		before := m.InputBuffer[:m.CursorPos]
-		after := m.InputBuffer[m.CursorPos:]
-		cursor := lipgloss.NewStyle().Reverse(true).Render(" ")
+		if m.searchScope != nil {
+			scope := m.searchScopeName
+			if m.searchAll {
+				scope = "all"
+			}
+			b.WriteString(styles.Cyan("["+scope+"]") + " ")
+		}
+		before, after := m.splitInput()
+		cursor := inputCursor()
 		b.WriteString(before + cursor + after)
 		b.WriteString(styles.Dim("  ↑/↓ navigate  enter select  esc cancel"))
+		if m.searchScope != nil {
+			b.WriteString(styles.Dim("  ctrl+g scope"))
+		}
 	} else if m.MaxVisibleInputMode {
 		b.WriteString(ModeIndicator("⊙", "SET MAX"))
 		b.WriteString("  ")
-		before := m.InputBuffer[:m.CursorPos]
-		after := m.InputBuffer[m.CursorPos:]
-		cursor := lipgloss.NewStyle().Reverse(true).Render(" ")
+		before, after := m.splitInput()
+		cursor := inputCursor()
 		b.WriteString(before + cursor + after)
 		b.WriteString(styles.Dim("  enter confirm  esc cancel"))
+	} else if m.GotoInputMode {
+		b.WriteString(ModeIndicator("→", "GOTO"))
+		b.WriteString("  ")
+		before, after := m.splitInput()
+		cursor := inputCursor()
+		b.WriteString(before + cursor + after)
+		b.WriteString(styles.Dim(fmt.Sprintf("  todo 1-%d  enter jump  esc cancel", len(m.getVisibleTodos()))))
 	} else if m.InputMode {
 		b.WriteString(ModeIndicator("✎", "NEW"))
 		b.WriteString("  ")
@@ -571,13 +828,29 @@ func (m Model) renderStatusBar() string {
 		b.WriteString(ModeIndicator("✎", "EDIT"))
 		b.WriteString("  ")
 		b.WriteString(styles.Dim("enter confirm  esc cancel"))
+	} else if m.NoteMode {
+		b.WriteString(ModeIndicator("✎", "NOTE"))
+		b.WriteString("  ")
+		b.WriteString(styles.Dim("enter add note  esc cancel"))
+	} else if m.ConfirmDeleteMode {
+		b.WriteString(styles.Yellow(fmt.Sprintf("Delete %d subtasks too? (y/n)", m.subtaskCount(m.SelectedIndex))))
+		b.WriteString(styles.Dim("  esc cancel"))
+	} else if m.ConfirmQuitMode {
+		if m.ReadOnly && m.Unsaved {
+			b.WriteString(styles.Yellow("Quit? Unsaved changes will be lost (y/n)"))
+			b.WriteString(styles.Dim("  :save keeps them"))
+		} else {
+			b.WriteString(styles.Yellow("Quit? (y/n)"))
+		}
 	} else if m.MoveMode {
 		b.WriteString(ModeIndicator("≡", "MOVE"))
 		b.WriteString("  ")
 		b.WriteString(styles.Dim("j/k move  enter confirm  esc cancel"))
-	} else if m.CopyFeedback {
-		b.WriteString(styles.Green("✓ Copied to clipboard!"))
-	} else {
+	} else if m.StatusHint != "" && m.statusOK {
+		b.WriteString(styles.Green(m.StatusHint))
+	} else if m.StatusHint != "" {
+		b.WriteString(styles.Yellow(m.StatusHint))
+	} else if m.StatusHints != StatusHintsNone {
 		// Normal status bar with mode indicators and help
 		var indicators []string
 		if m.ReadOnly {
@@ -590,6 +863,12 @@ func (m Model) renderStatusBar() string {
 			tagList := strings.Join(m.FilteredTags, " #")
 			indicators = append(indicators, fmt.Sprintf("🏷  #%s", tagList))
 		}
+		if len(m.FilteredContexts) > 0 {
+			indicators = append(indicators, "@"+strings.Join(m.FilteredContexts, " @"))
+		}
+		if len(m.FilteredProjects) > 0 {
+			indicators = append(indicators, "+"+strings.Join(m.FilteredProjects, " +"))
+		}
 		if len(m.FilteredPriorities) > 0 {
 			var priorityStrs []string
 			for _, p := range m.FilteredPriorities {
@@ -609,6 +888,18 @@ func (m Model) renderStatusBar() string {
 		if m.MaxVisibleOverride >= 0 {
 			indicators = append(indicators, fmt.Sprintf("⊙ MAX:%d", m.MaxVisibleOverride))
 		}
+		if estimate, spent, ok := m.timeTotals(); ok {
+			indicators = append(indicators, fmt.Sprintf("est %s / spent %s",
+				markdown.FormatDuration(estimate), markdown.FormatDuration(spent)))
+		}
+		if m.ShowProgress {
+			done, total, filtered := m.progressCounts()
+			progress := RenderProgressBar(done, total, progressBarWidth)
+			if filtered {
+				progress += " of filtered"
+			}
+			indicators = append(indicators, progress)
+		}
 
 		// Show indicator block with background if any indicators are active
 		if len(indicators) > 0 {
@@ -622,27 +913,115 @@ func (m Model) renderStatusBar() string {
 		}
 
 		// Help hints
-		var helpParts []string
-		helpParts = append(helpParts, styles.Cyan("?")+styles.Dim(" help"))
-		helpParts = append(helpParts, styles.Cyan(":")+styles.Dim(" cmd"))
-		helpParts = append(helpParts, styles.Cyan("n")+styles.Dim(" new"))
-		helpParts = append(helpParts, styles.Cyan("␣")+styles.Dim(" toggle"))
-		helpParts = append(helpParts, styles.Cyan("esc")+styles.Dim(" quit"))
-		b.WriteString(strings.Join(helpParts, "  "))
+		if m.StatusHints != StatusHintsMinimal {
+			var helpParts []string
+			helpParts = append(helpParts, styles.Cyan("?")+styles.Dim(" help"))
+			helpParts = append(helpParts, styles.Cyan(":")+styles.Dim(" cmd"))
+			helpParts = append(helpParts, styles.Cyan("n")+styles.Dim(" new"))
+			helpParts = append(helpParts, styles.Cyan("␣")+styles.Dim(" toggle"))
+			helpParts = append(helpParts, styles.Cyan("esc")+styles.Dim(" quit"))
+			b.WriteString(strings.Join(helpParts, "  "))
+		}
+
+		if m.config != nil && m.config.Display.ShowFilename {
+			b.WriteString(m.renderFileInfo(lipgloss.Width(b.String())))
+		}
 	}
 
 	return b.String()
 }
 
+// renderFileInfo returns the file path and todo count, right-aligned after
+// usedWidth columns. The path is shortened from the left when space is short.
+func (m Model) renderFileInfo(usedWidth int) string {
+	total := len(m.FileModel.Todos)
+	count := fmt.Sprintf("%d todos", total)
+	if total == 1 {
+		count = "1 todo"
+	}
+	if m.hasActiveFilters() {
+		count = fmt.Sprintf("%d/%d shown", len(m.getVisibleTodos()), total)
+	}
+
+	path := tildePath(m.FilePath)
+	if m.TermWidth <= 0 {
+		return "  " + m.Styles().Dim(path+" · "+count)
+	}
+
+	// Keep two columns of gap; the path needs three more for the separator
+	available := m.TermWidth - usedWidth - 2 - runewidth.StringWidth(count)
+	if available < 0 {
+		return ""
+	}
+	info := count
+	if pathWidth := available - 3; pathWidth >= minFileInfoPathWidth {
+		if width := runewidth.StringWidth(path); width > pathWidth {
+			path = runewidth.TruncateLeft(path, width-pathWidth+1, "…")
+		}
+		info = path + " · " + count
+	}
+
+	padding := m.TermWidth - usedWidth - runewidth.StringWidth(info)
+	return strings.Repeat(" ", padding) + m.Styles().Dim(info)
+}
+
+// minFileInfoPathWidth is the narrowest space worth showing the file path in
+const minFileInfoPathWidth = 8
+
+// tildePath shows paths under the home directory relative to ~
+func tildePath(path string) string {
+	if home, err := os.UserHomeDir(); err == nil {
+		if rel, err := filepath.Rel(home, path); err == nil && !strings.HasPrefix(rel, "..") {
+			return "~/" + rel
+		}
+	}
+	return path
+}
+
+// progressCounts returns the checked and total todo counts for the progress bar.
+// With tag, context, project, priority or due filters active only matching todos
+// are counted; filter-done and folded sections are ignored so completed and
+// collapsed todos still count.
+func (m Model) progressCounts() (done, total int, filtered bool) {
+	m.FilterDone = false
+	m.FoldedHeadings = nil
+	filtered = m.hasActiveFilters()
+	for i, todo := range m.FileModel.Todos {
+		if filtered && !m.isTodoVisible(i) {
+			continue
+		}
+		total++
+		if todo.Checked {
+			done++
+		}
+	}
+	return done, total, filtered
+}
+
+// childProgress returns how many of the direct subtasks of the todo at idx are
+// checked, and how many there are. Leaf todos have a total of 0.
+func (m Model) childProgress(idx int) (done, total int) {
+	todos := m.FileModel.Todos
+	for i := idx + 1; i < len(todos) && todos[i].Depth > todos[idx].Depth; i++ {
+		if todos[i].ParentIndex != idx {
+			continue
+		}
+		total++
+		if todos[i].Checked {
+			done++
+		}
+	}
+	return done, total
+}
+
 // renderCommandOverlayCompact renders a compact modal command palette
 func (m Model) renderCommandOverlayCompact() string {
 	var b strings.Builder
 	styles := m.Styles()
 
 	// Command input with cursor
-	before := m.InputBuffer[:m.CursorPos]
-	after := m.InputBuffer[m.CursorPos:]
-	cursor := lipgloss.NewStyle().Reverse(true).Render(" ")
+	before, after := m.splitInput()
+	cursor := inputCursor()
 	b.WriteString(styles.Cyan(":") + before + cursor + after)
 	b.WriteString("\n")
 
@@ -940,12 +1319,7 @@ func (m Model) renderRecentFilesOverlay() string {
 			isSelected := (i == m.RecentFilesCursor)
 
 			// Get display path (show ~ for home directory)
-			displayPath := file.Path
-			if home, err := os.UserHomeDir(); err == nil {
-				if rel, err := filepath.Rel(home, file.Path); err == nil && !strings.HasPrefix(rel, "..") {
-					displayPath = "~/" + rel
-				}
-			}
+			displayPath := tildePath(file.Path)
 
 			// Truncate long paths
 			if len(displayPath) > 60 {
@@ -1078,6 +1452,70 @@ func (m Model) renderFilterOverlayCompact() string {
 	return overlayStyle.Render(content)
 }
 
+// renderWordFilterOverlayCompact renders a compact modal selector for @contexts or +projects
+func (m Model) renderWordFilterOverlayCompact(sigil, noun string, available, filtered []string, cursor int, wordStyle func(string) string) string {
+	var b strings.Builder
+	styles := m.Styles()
+
+	if len(available) == 0 {
+		b.WriteString(styles.Dim(fmt.Sprintf("No %s available.", noun)))
+		b.WriteString("\n\n")
+		b.WriteString(styles.Dim(fmt.Sprintf("Add e.g. %sname to a todo.", sigil)))
+		b.WriteString("\n\n")
+		b.WriteString(styles.Dim("esc close"))
+	} else {
+		// Display values vertically in compact modal
+		maxItems := 8
+		displayCount := len(available)
+		if displayCount > maxItems {
+			displayCount = maxItems
+		}
+
+		for i := 0; i < displayCount; i++ {
+			value := available[i]
+			isActive := false
+			for _, active := range filtered {
+				if active == value {
+					isActive = true
+					break
+				}
+			}
+
+			marker := "  "
+			if i == cursor {
+				marker = styles.Cyan("→ ")
+			}
+
+			checkbox := styles.Dim("[ ] ")
+			if isActive {
+				checkbox = styles.Green("[✓] ")
+			}
+
+			b.WriteString(marker + checkbox + wordStyle(sigil+value))
+			b.WriteString("\n")
+		}
+
+		b.WriteString("\n")
+		b.WriteString(styles.Dim("space toggle  c clear  esc done"))
+	}
+
+	overlayStyle := lipgloss.NewStyle().
+		BorderStyle(lipgloss.Border{
+			Top:         "─",
+			Bottom:      "─",
+			Left:        "│",
+			Right:       "│",
+			TopLeft:     "┌",
+			TopRight:    "┐",
+			BottomLeft:  "└",
+			BottomRight: "┘",
+		}).
+		BorderForeground(lipgloss.Color("#7aa2f7")).
+		Padding(0, 1)
+
+	return overlayStyle.Render(b.String())
+}
+
 // renderPriorityFilterOverlayCompact renders a compact modal priority filter selector
 func (m Model) renderPriorityFilterOverlayCompact() string {
 	var b strings.Builder
@@ -1129,7 +1567,7 @@ func (m Model) renderPriorityFilterOverlayCompact() string {
 
 			// Color the priority marker based on level
 			priorityText := fmt.Sprintf("!p%d", priority)
-			priorityText = ColorizePriorities(priorityText, styles.PriorityHigh, styles.PriorityMedium, styles.PriorityLow)
+			priorityText = ColorizePriorities(priorityText, styles.priorityStyles()...)
 			b.WriteString(marker + checkbox + priorityText)
 			b.WriteString("\n")
 		}
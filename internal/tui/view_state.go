@@ -0,0 +1,100 @@
+package tui
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/niklas-heer/tdx/internal/config"
+	"github.com/niklas-heer/tdx/internal/markdown"
+)
+
+// Filters are filters requested on the command line (--tag, --priority,
+// --done) for the TUI to start with
+type Filters struct {
+	Tags       []string
+	Priorities []int
+	FilterDone *bool // nil keeps the configured setting
+}
+
+// LaunchFilters are applied when the TUI starts, over the config, frontmatter
+// and the filters restored from the last session
+var LaunchFilters Filters
+
+// Add records the value of a --tag or --priority flag
+func (f *Filters) Add(flag, value string) error {
+	switch flag {
+	case "--tag":
+		tag := markdown.CanonicalTag(strings.TrimPrefix(value, "#"))
+		if tag == "" {
+			return fmt.Errorf("--tag requires a value")
+		}
+		if !slices.Contains(f.Tags, tag) {
+			f.Tags = append(f.Tags, tag)
+		}
+	case "--priority":
+		p, err := strconv.Atoi(strings.TrimLeft(value, "!pP"))
+		if err != nil || p < 1 {
+			return fmt.Errorf("invalid priority %q", value)
+		}
+		if !slices.Contains(f.Priorities, p) {
+			f.Priorities = append(f.Priorities, p)
+		}
+	default:
+		return fmt.Errorf("unknown filter flag %s", flag)
+	}
+	return nil
+}
+
+// viewState captures the filters that are restored when the file is reopened
+func (m *Model) viewState() config.ViewState {
+	return config.ViewState{
+		FilteredTags:       m.FilteredTags,
+		FilterDone:         m.FilterDone,
+		MaxVisibleOverride: m.MaxVisibleOverride,
+	}
+}
+
+// applyViewState restores saved filters, dropping tags no longer present in the file.
+// Explicit settings from CLI flags or frontmatter are kept.
+func (m *Model) applyViewState(state *config.ViewState) {
+	if state == nil {
+		return
+	}
+
+	if m.FileModel.Metadata == nil || m.FileModel.Metadata.FilterDone == nil {
+		m.FilterDone = state.FilterDone
+	}
+	m.FilteredTags = keepAvailable(state.FilteredTags, m.AvailableTags)
+	if m.MaxVisibleOverride < 0 {
+		m.MaxVisibleOverride = state.MaxVisibleOverride
+	}
+
+	m.InvalidateDocumentTree()
+	m.adjustSelectionForFilter()
+}
+
+// applyLaunchFilters replaces the active filters with the ones given on the
+// command line. Tags that don't appear in the file are kept, also when the
+// available tags are refreshed, so the list shows no todos rather than every todo.
+func (m *Model) applyLaunchFilters(f Filters) {
+	if len(f.Tags) > 0 {
+		m.FilteredTags = slices.Clone(f.Tags)
+		m.launchTags = slices.Clone(f.Tags)
+	}
+	if len(f.Priorities) > 0 {
+		m.FilteredPriorities = slices.Clone(f.Priorities)
+	}
+	if f.FilterDone != nil {
+		m.FilterDone = *f.FilterDone
+	}
+
+	m.InvalidateDocumentTree()
+	m.adjustSelectionForFilter()
+}
+
+// saveRecentState records the cursor position and view state of the current file
+func (m *Model) saveRecentState() {
+	_ = config.SaveRecentFileWithState(m.FilePath, m.SelectedIndex, m.viewState())
+}
@@ -0,0 +1,105 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/niklas-heer/tdx/internal/config"
+	"github.com/niklas-heer/tdx/internal/markdown"
+)
+
+// viewStateFixture writes content to a temp file and isolates the recent files list and globals
+func viewStateFixture(t *testing.T, content string) string {
+	t.Helper()
+	dir := t.TempDir()
+	config.SetConfigDirForTesting(dir)
+	t.Cleanup(config.ResetConfigDirForTesting)
+
+	// RunPiped reads the injected globals
+	oldConfig, oldStyles := Config, StyleFuncs
+	Config, StyleFuncs = testConfig(), testStyles()
+	t.Cleanup(func() { Config, StyleFuncs = oldConfig, oldStyles })
+
+	path := filepath.Join(dir, "todo.md")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestViewState_RestoredOnReopen(t *testing.T) {
+	path := viewStateFixture(t, "# Todos\n\n- [ ] Write report #work\n- [x] Done thing #work\n- [ ] Groceries #home\n")
+
+	fm, err := markdown.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := New(path, fm, false, false, -1, testConfig(), testStyles(), "test")
+	m.FilteredTags = []string{"work"}
+	m.FilterDone = true
+	m.MaxVisibleOverride = 4
+	m.saveRecentState()
+
+	output := RunPiped(path, nil, false)
+
+	if !strings.Contains(output, "#work") || !strings.Contains(output, "FILTERED") || !strings.Contains(output, "MAX:4") {
+		t.Errorf("Expected restored filters in status bar:\n%s", output)
+	}
+	if strings.Contains(output, "Groceries") || strings.Contains(output, "Done thing") {
+		t.Errorf("Expected filtered todos to stay hidden:\n%s", output)
+	}
+}
+
+func TestViewState_StaleTagsDropped(t *testing.T) {
+	path := viewStateFixture(t, "# Todos\n\n- [ ] Task #work\n")
+
+	_ = config.SaveRecentFileWithState(path, 0, config.ViewState{
+		FilteredTags:       []string{"gone", "work"},
+		MaxVisibleOverride: -1,
+	})
+
+	recent, err := config.LoadRecentFiles()
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := testModelWithMarkdown("# Todos\n\n- [ ] Task #work\n")
+	m.applyViewState(recent.GetViewState(path))
+
+	if len(m.FilteredTags) != 1 || m.FilteredTags[0] != "work" {
+		t.Errorf("FilteredTags = %v, want [work]", m.FilteredTags)
+	}
+	if m.MaxVisibleOverride != -1 {
+		t.Errorf("MaxVisibleOverride = %d, want -1", m.MaxVisibleOverride)
+	}
+}
+
+func TestViewState_ExplicitSettingsWin(t *testing.T) {
+	path := viewStateFixture(t, "---\nfilter-done: false\n---\n# Todos\n\n- [x] Done\n- [ ] Open\n")
+	fm, err := markdown.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := New(path, fm, false, false, 3, testConfig(), testStyles(), "test") // e.g. --max-visible 3
+
+	m.applyViewState(&config.ViewState{FilterDone: true, MaxVisibleOverride: 10})
+
+	if m.FilterDone {
+		t.Error("Frontmatter filter-done should take precedence over the saved state")
+	}
+	if m.MaxVisibleOverride != 3 {
+		t.Errorf("MaxVisibleOverride = %d, want 3", m.MaxVisibleOverride)
+	}
+}
+
+func TestViewState_NilKeepsModel(t *testing.T) {
+	m := testModelWithMarkdown("# Todos\n\n- [ ] Task #work\n")
+	m.FilteredTags = []string{"work"}
+
+	m.applyViewState(nil)
+
+	if len(m.FilteredTags) != 1 {
+		t.Errorf("FilteredTags = %v, want unchanged", m.FilteredTags)
+	}
+}
@@ -0,0 +1,106 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mattn/go-runewidth"
+	"github.com/niklas-heer/tdx/internal/util"
+)
+
+// withMarkers returns a model for content using the given select marker and
+// check symbol, wrapped at width
+func withMarkers(content, marker, check string, width int) Model {
+	m := testModelWithMarkdown(content)
+	cfg := *m.Config()
+	cfg.Display.SelectMarker = marker
+	cfg.Display.CheckSymbol = check
+	m.config = &cfg
+	m.WordWrap = true
+	m.TermWidth = width
+	return m
+}
+
+// textColumn returns the display column where text starts on the line containing it
+func textColumn(t *testing.T, view, text string) int {
+	t.Helper()
+	for _, line := range strings.Split(util.StripANSI(view), "\n") {
+		if i := strings.Index(line, text); i >= 0 {
+			return runewidth.StringWidth(line[:i])
+		}
+	}
+	t.Fatalf("%q not found in view:\n%s", text, util.StripANSI(view))
+	return 0
+}
+
+// leadingSpaces returns the number of spaces a line starts with
+func leadingSpaces(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " "))
+}
+
+func TestWideMarker_WrappedLinesAlignUnderText(t *testing.T) {
+	m := withMarkers("- [ ] Alpha bravo charlie delta echo foxtrot golf hotel india\n- [x] Juliet kilo lima mike november oscar papa quebec romeo\n", "👉", "✅", 40)
+
+	view := m.View()
+	col := textColumn(t, view, "Alpha")
+	if want := 3 + 4 + 4 + 1; col != want {
+		t.Errorf("Text starts at column %d, want %d", col, want)
+	}
+
+	lines := strings.Split(util.StripANSI(view), "\n")
+	continued := 0
+	for i, line := range lines {
+		if i == 0 || strings.TrimSpace(line) == "" || strings.Contains(line, "[") || strings.Contains(line, "WRAP") {
+			continue
+		}
+		continued++
+		if got := leadingSpaces(line); got != col {
+			t.Errorf("Continuation line %q is indented %d, want %d", line, got, col)
+		}
+	}
+	if continued == 0 {
+		t.Fatalf("Expected wrapped lines:\n%s", util.StripANSI(view))
+	}
+
+	// The unselected, checked todo lines up with the selected one
+	if got := textColumn(t, view, "Juliet"); got != col {
+		t.Errorf("Second todo text starts at column %d, want %d", got, col)
+	}
+}
+
+func TestWideMarker_NarrowMarkersKeepLayout(t *testing.T) {
+	m := withMarkers("- [ ] First\n- [x] Second\n", ">", "✓", 40)
+
+	view := m.View()
+	if got := textColumn(t, view, "First"); got != 10 {
+		t.Errorf("Text starts at column %d, want 10", got)
+	}
+	if got := textColumn(t, view, "Second"); got != 10 {
+		t.Errorf("Second todo text starts at column %d, want 10", got)
+	}
+}
+
+func TestWideMarker_InputLineAligns(t *testing.T) {
+	m := withMarkers("- [ ] First\n", "👉", "✅", 40)
+	m = pressKey(t, m, "N")
+	for _, r := range "new" {
+		m = pressKey(t, m, string(r))
+	}
+
+	view := m.View()
+	if got, want := textColumn(t, view, "new"), textColumn(t, view, "First"); got != want {
+		t.Errorf("Input text starts at column %d, todo text at %d", got, want)
+	}
+}
+
+func TestWideMarker_CheckboxClick(t *testing.T) {
+	m := withMarkers("- [ ] First\n", "👉", "✅", 40)
+
+	// Index (3) and arrow (4) come before the checkbox
+	if _, onCheckbox, ok := m.todoAtPosition(7, 0); !ok || !onCheckbox {
+		t.Error("Expected a click at column 7 to hit the checkbox")
+	}
+	if _, onCheckbox, _ := m.todoAtPosition(6, 0); onCheckbox {
+		t.Error("Column 6 is the arrow, not the checkbox")
+	}
+}
@@ -0,0 +1,82 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/niklas-heer/tdx/internal/util"
+)
+
+const overlongTodo = "This todo is much longer than the terminal is wide and would otherwise spill over"
+
+func TestWrapToggle_Key(t *testing.T) {
+	m := testModelWithMarkdown("- [ ] Short\n")
+	m.WordWrap = true
+
+	m = pressKey(t, m, "w")
+	if m.WordWrap {
+		t.Fatal("w should turn word wrap off")
+	}
+	if strings.Contains(m.renderStatusBar(), "WRAP") {
+		t.Error("WRAP indicator should disappear right away")
+	}
+
+	m = pressKey(t, m, "w")
+	if !m.WordWrap {
+		t.Fatal("w should turn word wrap back on")
+	}
+	if !strings.Contains(m.renderStatusBar(), "↩ WRAP") {
+		t.Error("WRAP indicator should show right away")
+	}
+}
+
+func TestWrapToggle_Command(t *testing.T) {
+	m := testModelWithMarkdown("- [ ] Short\n")
+	m.WordWrap = false
+
+	executeCommand(&m, "toggle-wrap")
+	if !m.WordWrap {
+		t.Error("toggle-wrap should turn word wrap on")
+	}
+}
+
+func TestWrapOff_TruncatesWithEllipsis(t *testing.T) {
+	m := testModelWithMarkdown("- [ ] " + overlongTodo + "\n- [ ] Short\n")
+	m.TermWidth = 40
+	m.WordWrap = false
+
+	view := m.View()
+	if strings.Contains(view, overlongTodo) {
+		t.Errorf("Overlong todo should be truncated:\n%s", view)
+	}
+	for _, line := range strings.Split(view, "\n") {
+		if strings.Contains(line, "This todo") {
+			if !strings.HasSuffix(line, "…") {
+				t.Errorf("Truncated line should end with an ellipsis: %q", line)
+			}
+			if w := util.VisibleWidth(line); w > m.TermWidth {
+				t.Errorf("Truncated line is %d wide, want at most %d", w, m.TermWidth)
+			}
+		}
+	}
+	if !strings.Contains(view, "Short") {
+		t.Error("Short todos should be untouched")
+	}
+
+	// With wrap on the whole text is shown across lines
+	m.WordWrap = true
+	if view := m.View(); strings.Contains(view, "…") || !strings.Contains(view, "spill over") {
+		t.Errorf("Wrapped view should show the full text:\n%s", view)
+	}
+}
+
+func TestWrapOff_EditLineNotTruncated(t *testing.T) {
+	m := testModelWithMarkdown("- [ ] " + overlongTodo + "\n")
+	m.TermWidth = 40
+	m.WordWrap = false
+
+	m = pressKey(t, m, "e")
+	if view := m.View(); !strings.Contains(view, "spill over") {
+		t.Errorf("The line being edited should not be truncated:\n%s", view)
+	}
+}
@@ -0,0 +1,82 @@
+package tui
+
+import (
+	"fmt"
+)
+
+// yankedTodo is a todo copied with yy, keeping what's needed to recreate it
+type yankedTodo struct {
+	Text    string
+	Checked bool
+	Depth   int
+}
+
+// yankTodos copies count visible todos starting at the selection into the register
+func (m *Model) yankTodos(count int) {
+	indices := m.visibleFromSelection(count)
+	if len(indices) == 0 {
+		return
+	}
+
+	m.register = m.register[:0]
+	for _, idx := range indices {
+		todo := m.FileModel.Todos[idx]
+		m.register = append(m.register, yankedTodo{Text: todo.Text, Checked: todo.Checked, Depth: todo.Depth})
+	}
+
+	if len(m.register) == 1 {
+		m.setStatusOK("Yanked 1 todo")
+		return
+	}
+	m.setStatusOK(fmt.Sprintf("Yanked %d todos", len(m.register)))
+}
+
+// pasteTodos inserts the yanked todos below the selected todo and its nested
+// todos, or directly above it. Nesting relative to the first yanked todo is kept.
+func (m *Model) pasteTodos(above bool) {
+	if len(m.register) == 0 {
+		return
+	}
+	m.saveHistory()
+
+	fm := &m.FileModel
+	baseDepth := 0
+	var first int
+	if len(fm.Todos) == 0 {
+		fm.AddTodoItem(m.register[0].Text, m.register[0].Checked)
+		first = 0
+	} else {
+		anchor := m.SelectedIndex
+		baseDepth = fm.Todos[anchor].Depth
+		// Inserting after the anchor places the todo as its sibling, past its nested todos
+		first = m.subtreeEnd(anchor)
+		fm.InsertTodoItemAfter(anchor, m.register[0].Text, m.register[0].Checked)
+		if above {
+			_ = fm.MoveTodoItemToPosition(first, anchor, false)
+			first = anchor
+		}
+	}
+
+	prev := first
+	for _, yanked := range m.register[1:] {
+		idx := fm.InsertTodoItemAfter(prev, yanked.Text, yanked.Checked)
+		target := max(baseDepth+yanked.Depth-m.register[0].Depth, baseDepth)
+		for fm.Todos[idx].Depth < target {
+			if fm.IndentTodoItem(idx) != nil {
+				break
+			}
+		}
+		for fm.Todos[idx].Depth > target {
+			if fm.OutdentTodoItem(idx) != nil {
+				break
+			}
+		}
+		prev = idx
+	}
+
+	m.SelectedIndex = first
+	m.InvalidateHeadingsCache()
+	m.InvalidateDocumentTree()
+	m.RefreshAvailableTags()
+	m.writeIfPersist()
+}
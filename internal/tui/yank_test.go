@@ -0,0 +1,143 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/niklas-heer/tdx/internal/markdown"
+)
+
+func todoTexts(m Model) []string {
+	var texts []string
+	for _, todo := range m.FileModel.Todos {
+		texts = append(texts, todo.Text)
+	}
+	return texts
+}
+
+func TestYank_PasteBelowPreservesState(t *testing.T) {
+	m := testModelWithMarkdown("- [x] Done task\n- [ ] Second\n- [ ] Third\n")
+
+	m = pressKey(t, m, "y")
+	m = pressKey(t, m, "y")
+	if len(m.register) != 1 {
+		t.Fatalf("register = %v, want 1 todo", m.register)
+	}
+	m = pressKey(t, m, "j")
+	m = pressKey(t, m, "j")
+	m = pressKey(t, m, "p")
+
+	got := strings.Join(todoTexts(m), ",")
+	if got != "Done task,Second,Third,Done task" {
+		t.Fatalf("Todos = %s", got)
+	}
+	if !m.FileModel.Todos[3].Checked {
+		t.Error("Pasted todo should keep its checked state")
+	}
+	if m.SelectedIndex != 3 {
+		t.Errorf("SelectedIndex = %d, want 3 (pasted todo)", m.SelectedIndex)
+	}
+	if m.PriorityFilterMode {
+		t.Error("p should paste, not open the priority filter")
+	}
+}
+
+func TestYank_PasteAbove(t *testing.T) {
+	m := testModelWithMarkdown("- [ ] First\n- [ ] Second\n- [ ] Third\n")
+
+	m = pressKey(t, m, "G")
+	m = pressKey(t, m, "y")
+	m = pressKey(t, m, "y")
+	m = pressKey(t, m, "g")
+	m = pressKey(t, m, "g")
+	m = pressKey(t, m, "P")
+
+	if got := strings.Join(todoTexts(m), ","); got != "Third,First,Second,Third" {
+		t.Errorf("Todos = %s", got)
+	}
+	if m.SelectedIndex != 0 {
+		t.Errorf("SelectedIndex = %d, want 0", m.SelectedIndex)
+	}
+}
+
+func TestYank_CountYanksConsecutiveTodosWithNesting(t *testing.T) {
+	m := testModelWithMarkdown("- [ ] Parent\n  - [x] Child\n- [ ] Other\n")
+
+	m = pressKey(t, m, "2")
+	m = pressKey(t, m, "y")
+	m = pressKey(t, m, "y")
+	if len(m.register) != 2 {
+		t.Fatalf("register = %v, want 2 todos", m.register)
+	}
+	m = pressKey(t, m, "G")
+	m = pressKey(t, m, "p")
+
+	if got := strings.Join(todoTexts(m), ","); got != "Parent,Child,Other,Parent,Child" {
+		t.Fatalf("Todos = %s", got)
+	}
+	if m.FileModel.Todos[3].Depth != 0 || m.FileModel.Todos[4].Depth != 1 {
+		t.Errorf("Depths = %d, %d, want 0, 1", m.FileModel.Todos[3].Depth, m.FileModel.Todos[4].Depth)
+	}
+	if !m.FileModel.Todos[4].Checked {
+		t.Error("Pasted child should keep its checked state")
+	}
+	want := "- [ ] Parent\n  - [x] Child\n- [ ] Other\n- [ ] Parent\n  - [x] Child\n"
+	if got := markdown.SerializeMarkdown(&m.FileModel); !strings.Contains(got, want) {
+		t.Errorf("Serialized:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestYank_PasteBelowSkipsNestedTodos(t *testing.T) {
+	m := testModelWithMarkdown("- [ ] Copy me\n- [ ] Parent\n  - [ ] Child\n")
+
+	m = pressKey(t, m, "y")
+	m = pressKey(t, m, "y")
+	m = pressKey(t, m, "j")
+	m = pressKey(t, m, "p")
+
+	if got := strings.Join(todoTexts(m), ","); got != "Copy me,Parent,Child,Copy me" {
+		t.Fatalf("Todos = %s", got)
+	}
+	if m.FileModel.Todos[3].Depth != 0 {
+		t.Errorf("Pasted todo depth = %d, want 0", m.FileModel.Todos[3].Depth)
+	}
+}
+
+func TestYank_PriorityFilterMovedToBang(t *testing.T) {
+	m := testModelWithMarkdown("- [ ] Task !p1\n")
+
+	m = pressKey(t, m, "p")
+	if m.PriorityFilterMode {
+		t.Error("p should paste, not open the priority filter")
+	}
+
+	m = pressKey(t, m, "!")
+	if !m.PriorityFilterMode {
+		t.Error("! should open the priority filter")
+	}
+}
+
+func TestYank_SingleYDoesNothing(t *testing.T) {
+	m := testModelWithMarkdown("- [ ] First\n- [ ] Second\n")
+
+	m = pressKey(t, m, "y")
+	m = pressKey(t, m, "j")
+	m = pressKey(t, m, "y")
+
+	if len(m.register) != 0 {
+		t.Errorf("register = %v, want empty", m.register)
+	}
+}
+
+func TestYank_PasteCanBeUndone(t *testing.T) {
+	m := testModelWithMarkdown("- [ ] First\n- [ ] Second\n")
+
+	m = pressKey(t, m, "y")
+	m = pressKey(t, m, "y")
+	m = pressKey(t, m, "p")
+	m = pressKey(t, m, "u")
+
+	if got := strings.Join(todoTexts(m), ","); got != "First,Second" {
+		t.Errorf("Todos after undo = %s", got)
+	}
+}
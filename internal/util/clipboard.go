@@ -1,16 +1,41 @@
 package util
 
 import (
+	"errors"
+	"fmt"
 	"os/exec"
 	"strings"
 )
 
-// CopyToClipboard copies text to the system clipboard
-func CopyToClipboard(text string) {
-	// Use pbcopy on macOS
-	cmd := exec.Command("pbcopy")
-	cmd.Stdin = strings.NewReader(text)
-	_ = cmd.Run() // Ignore error - clipboard may not be available
+// ErrNoClipboard is returned when none of the clipboard tools is installed
+var ErrNoClipboard = errors.New("no clipboard tool")
+
+// clipboardTools are the commands that copy their stdin to the clipboard, in
+// the order they are tried
+var clipboardTools = [][]string{
+	{"pbcopy"},                           // macOS
+	{"wl-copy"},                          // Wayland
+	{"xclip", "-selection", "clipboard"}, // X11
+	{"xsel", "--clipboard", "--input"},   // X11
+	{"clip.exe"},                         // Windows and WSL
+}
+
+// CopyToClipboard copies text to the system clipboard with the first clipboard
+// tool found on the PATH
+func CopyToClipboard(text string) error {
+	for _, tool := range clipboardTools {
+		path, err := exec.LookPath(tool[0])
+		if err != nil {
+			continue
+		}
+		cmd := exec.Command(path, tool[1:]...)
+		cmd.Stdin = strings.NewReader(text)
+		if err := cmd.Run(); err != nil {
+			return fmt.Errorf("%s: %w", tool[0], err)
+		}
+		return nil
+	}
+	return ErrNoClipboard
 }
 
 // PasteFromClipboard retrieves text from the system clipboard
@@ -0,0 +1,46 @@
+package util
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// withClipboardTools points CopyToClipboard at the given commands for one test
+func withClipboardTools(t *testing.T, tools ...[]string) {
+	t.Helper()
+	saved := clipboardTools
+	clipboardTools = tools
+	t.Cleanup(func() { clipboardTools = saved })
+}
+
+func TestCopyToClipboard_NoTool(t *testing.T) {
+	withClipboardTools(t, []string{"tdx-no-such-clipboard-tool"})
+
+	if err := CopyToClipboard("text"); !errors.Is(err, ErrNoClipboard) {
+		t.Errorf("CopyToClipboard = %v, want ErrNoClipboard", err)
+	}
+}
+
+func TestCopyToClipboard_ToolFails(t *testing.T) {
+	withClipboardTools(t, []string{"sh", "-c", "exit 1"})
+
+	err := CopyToClipboard("text")
+	if err == nil || errors.Is(err, ErrNoClipboard) || !strings.HasPrefix(err.Error(), "sh: ") {
+		t.Errorf("CopyToClipboard = %v, want the failing command's error", err)
+	}
+}
+
+func TestCopyToClipboard_UsesFirstInstalledTool(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "clipboard")
+	withClipboardTools(t, []string{"tdx-no-such-clipboard-tool"}, []string{"sh", "-c", "cat > " + out})
+
+	if err := CopyToClipboard("Buy milk"); err != nil {
+		t.Fatalf("CopyToClipboard: %v", err)
+	}
+	if got, _ := os.ReadFile(out); string(got) != "Buy milk" {
+		t.Errorf("Copied %q, want %q", got, "Buy milk")
+	}
+}
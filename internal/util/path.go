@@ -0,0 +1,16 @@
+package util
+
+import "path/filepath"
+
+// CanonicalPath returns the absolute path of a file with symlinks resolved, so
+// one file has one path however it was opened. A file that doesn't exist yet
+// keeps its absolute path.
+func CanonicalPath(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		path = resolved
+	}
+	return path
+}
@@ -0,0 +1,30 @@
+package util
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCanonicalPath(t *testing.T) {
+	dir, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	realPath := filepath.Join(dir, "todo.md")
+	linkPath := filepath.Join(dir, "link.md")
+	if err := os.WriteFile(realPath, []byte("- [ ] Task\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(realPath, linkPath); err != nil {
+		t.Skipf("Cannot create symlinks: %v", err)
+	}
+
+	if got := CanonicalPath(linkPath); got != realPath {
+		t.Errorf("CanonicalPath(link) = %q, want %q", got, realPath)
+	}
+	missing := filepath.Join(dir, "new.md")
+	if got := CanonicalPath(missing); got != missing {
+		t.Errorf("CanonicalPath(%q) = %q, want it unchanged", missing, got)
+	}
+}
@@ -0,0 +1,24 @@
+package util
+
+import "strings"
+
+// SupportsHyperlinks guesses from the environment whether the terminal shows
+// OSC 8 hyperlinks. Pass os.Getenv; tests pass a map lookup instead.
+func SupportsHyperlinks(getenv func(string) string) bool {
+	switch getenv("TERM_PROGRAM") {
+	case "iTerm.app", "WezTerm", "vscode", "ghostty", "Hyper":
+		return true
+	case "Apple_Terminal":
+		return false
+	}
+	if getenv("WT_SESSION") != "" || getenv("KITTY_WINDOW_ID") != "" || getenv("VTE_VERSION") != "" {
+		return true
+	}
+
+	term := getenv("TERM")
+	switch {
+	case term == "", term == "dumb", term == "linux", strings.HasPrefix(term, "vt"):
+		return false
+	}
+	return true
+}
@@ -0,0 +1,26 @@
+package util
+
+import "testing"
+
+func TestSupportsHyperlinks(t *testing.T) {
+	tests := []struct {
+		name string
+		env  map[string]string
+		want bool
+	}{
+		{"xterm", map[string]string{"TERM": "xterm-256color"}, true},
+		{"iTerm", map[string]string{"TERM_PROGRAM": "iTerm.app", "TERM": "xterm-256color"}, true},
+		{"Windows Terminal", map[string]string{"WT_SESSION": "abc"}, true},
+		{"Apple Terminal", map[string]string{"TERM_PROGRAM": "Apple_Terminal", "TERM": "xterm-256color"}, false},
+		{"dumb", map[string]string{"TERM": "dumb"}, false},
+		{"linux console", map[string]string{"TERM": "linux"}, false},
+		{"vt100", map[string]string{"TERM": "vt100"}, false},
+		{"no TERM", map[string]string{}, false},
+	}
+	for _, tt := range tests {
+		getenv := func(key string) string { return tt.env[key] }
+		if got := SupportsHyperlinks(getenv); got != tt.want {
+			t.Errorf("%s: SupportsHyperlinks = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
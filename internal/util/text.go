@@ -3,9 +3,11 @@ package util
 import (
 	"regexp"
 	"strings"
+	"unicode"
 	"unicode/utf8"
 
 	"github.com/mattn/go-runewidth"
+	"golang.org/x/text/unicode/norm"
 )
 
 // ANSI escape code regex (matches CSI sequences and OSC 8 hyperlinks)
@@ -52,6 +54,57 @@ func FuzzyScore(query, text string) int {
 	return 0
 }
 
+// FoldText lowercases text and strips diacritics so that "Café" and "cafe" compare equal.
+// Fold the query and each text once per search and pass the results to FuzzyScore.
+func FoldText(text string) string {
+	if isASCII(text) {
+		return strings.ToLower(text)
+	}
+	folded, _ := FoldTextOffsets(text)
+	return folded
+}
+
+// isASCII reports whether text contains only ASCII characters
+func isASCII(text string) bool {
+	for i := 0; i < len(text); i++ {
+		if text[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
+
+// FoldTextOffsets folds text like FoldText and also returns, for every byte of the
+// folded text, the byte offset of the rune in text it came from
+func FoldTextOffsets(text string) (string, []int) {
+	var b strings.Builder
+	b.Grow(len(text))
+	offsets := make([]int, 0, len(text))
+
+	for i, r := range text {
+		start := b.Len()
+		if r < utf8.RuneSelf {
+			// ASCII fast path
+			if 'A' <= r && r <= 'Z' {
+				r += 'a' - 'A'
+			}
+			b.WriteByte(byte(r))
+		} else {
+			// Decompose (é -> e + combining accent) and drop the combining marks
+			for _, d := range norm.NFD.String(string(r)) {
+				if !unicode.Is(unicode.Mn, d) {
+					b.WriteRune(unicode.ToLower(d))
+				}
+			}
+		}
+		for range b.Len() - start {
+			offsets = append(offsets, i)
+		}
+	}
+
+	return b.String(), offsets
+}
+
 // StripANSI removes ANSI escape codes from text
 func StripANSI(text string) string {
 	return ansiRe.ReplaceAllString(text, "")
@@ -63,6 +116,42 @@ func VisibleWidth(text string) int {
 	return runewidth.StringWidth(stripped)
 }
 
+// TruncateText shortens text to maxWidth visible cells, ending it with an ellipsis.
+// ANSI escape codes are kept, so styles and hyperlinks are still closed.
+func TruncateText(text string, maxWidth int) string {
+	if maxWidth <= 0 || VisibleWidth(text) <= maxWidth {
+		return text
+	}
+
+	var b strings.Builder
+	width, truncated := 0, false
+	writeVisible := func(s string) {
+		for _, r := range s {
+			if truncated {
+				return
+			}
+			w := runewidth.RuneWidth(r)
+			if width+w > maxWidth-1 {
+				b.WriteString("…")
+				truncated = true
+				return
+			}
+			width += w
+			b.WriteRune(r)
+		}
+	}
+
+	last := 0
+	for _, loc := range ansiRe.FindAllStringIndex(text, -1) {
+		writeVisible(text[last:loc[0]])
+		b.WriteString(text[loc[0]:loc[1]])
+		last = loc[1]
+	}
+	writeVisible(text[last:])
+
+	return b.String()
+}
+
 // WrapText wraps text to fit within maxWidth, returning multiple lines
 // indent is the string to prepend to continuation lines
 // NOTE: This function is ANSI-aware - it calculates visual width correctly
@@ -30,6 +30,57 @@ func TestFuzzyScore_EmptyQuery(t *testing.T) {
 	}
 }
 
+func TestFoldText(t *testing.T) {
+	tests := map[string]string{
+		"Café":         "cafe",
+		"NAÏVE Résumé": "naive resume",
+		"Ångström":     "angstrom",
+		"plain ascii":  "plain ascii",
+		"Straße":       "straße",
+	}
+	for input, want := range tests {
+		if got := FoldText(input); got != want {
+			t.Errorf("FoldText(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestFoldTextOffsets(t *testing.T) {
+	folded, offsets := FoldTextOffsets("xé!")
+	if folded != "xe!" {
+		t.Fatalf("folded = %q, want %q", folded, "xe!")
+	}
+	// é is two bytes in the original, so "!" comes from offset 3
+	want := []int{0, 1, 3}
+	for i, off := range want {
+		if offsets[i] != off {
+			t.Errorf("offsets = %v, want %v", offsets, want)
+			break
+		}
+	}
+}
+
+func TestFuzzyScore_IgnoresDiacritics(t *testing.T) {
+	if score := FuzzyScore(FoldText("cafe"), FoldText("Meet at Café Central")); score < 1000 {
+		t.Errorf("cafe should match Café as a substring, got %d", score)
+	}
+	if score := FuzzyScore(FoldText("résumé"), FoldText("Update RESUME")); score < 1000 {
+		t.Errorf("Accented query should match unaccented text, got %d", score)
+	}
+}
+
+func TestFuzzyScore_ExactSubstringRanksHighest(t *testing.T) {
+	query := FoldText("cafe")
+	exact := FuzzyScore(query, FoldText("Café menu"))
+	fuzzy := FuzzyScore(query, FoldText("call a friend, eat"))
+	if fuzzy <= 0 {
+		t.Fatalf("Expected a fuzzy match, got %d", fuzzy)
+	}
+	if exact <= fuzzy {
+		t.Errorf("Exact substring (%d) should outrank fuzzy match (%d)", exact, fuzzy)
+	}
+}
+
 func TestWrapText_NoWrapNeeded(t *testing.T) {
 	lines := WrapText("short", 100, "  ")
 	if len(lines) != 1 || lines[0] != "short" {
@@ -44,6 +95,32 @@ func TestWrapText_NeedsWrap(t *testing.T) {
 	}
 }
 
+func TestTruncateText(t *testing.T) {
+	if got := TruncateText("short", 10); got != "short" {
+		t.Errorf("Short text should be unchanged, got %q", got)
+	}
+	if got := TruncateText("exactly ten", 11); got != "exactly ten" {
+		t.Errorf("Text that fits exactly should be unchanged, got %q", got)
+	}
+	if got := TruncateText("this is a long text", 10); got != "this is a…" {
+		t.Errorf("TruncateText = %q, want %q", got, "this is a…")
+	}
+	if got := TruncateText("日本語のテキスト", 7); got != "日本語…" {
+		t.Errorf("Wide runes: TruncateText = %q, want %q", got, "日本語…")
+	}
+}
+
+func TestTruncateText_KeepsEscapeCodes(t *testing.T) {
+	styled := "\x1b[31mred text\x1b[0m and more words"
+	got := TruncateText(styled, 8)
+	if got != "\x1b[31mred tex…\x1b[0m" {
+		t.Errorf("TruncateText = %q", got)
+	}
+	if VisibleWidth(got) != 8 {
+		t.Errorf("VisibleWidth = %d, want 8", VisibleWidth(got))
+	}
+}
+
 func TestMinMax(t *testing.T) {
 	if Min(1, 2) != 1 {
 		t.Error("Min(1, 2) should be 1")
@@ -94,6 +171,20 @@ func BenchmarkFuzzyScore_LongQuery(b *testing.B) {
 	}
 }
 
+func BenchmarkFoldText_ASCII(b *testing.B) {
+	text := "Fix critical bug in the payment service #urgent"
+	for i := 0; i < b.N; i++ {
+		FoldText(text)
+	}
+}
+
+func BenchmarkFoldText_Accented(b *testing.B) {
+	text := "Réserver une table au café près de l'hôtel"
+	for i := 0; i < b.N; i++ {
+		FoldText(text)
+	}
+}
+
 func BenchmarkWrapText_Short(b *testing.B) {
 	text := "Short text"
 	b.ResetTimer()